@@ -1,12 +1,33 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"log/slog"
+	"net"
 	"net/http"
 	"os"
+	"path/filepath"
+	"roadmap-visualizer/internal/apierror"
+	"roadmap-visualizer/internal/auth"
+	"roadmap-visualizer/internal/compression"
+	"roadmap-visualizer/internal/graphapi"
+	"roadmap-visualizer/internal/grpcapi"
+	"roadmap-visualizer/internal/grpcapi/roadmapv1"
 	"roadmap-visualizer/internal/handlers"
+	"roadmap-visualizer/internal/jira"
+	"roadmap-visualizer/internal/models"
+	"roadmap-visualizer/internal/requestlog"
+	"roadmap-visualizer/internal/servicelines"
 	"roadmap-visualizer/internal/storage"
+	"roadmap-visualizer/internal/webhooks"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
 )
 
 func main() {
@@ -21,19 +42,225 @@ func main() {
 		dataDir = "./data"
 	}
 
+	storageDriver := os.Getenv("STORAGE_DRIVER")
+	if storageDriver == "" {
+		storageDriver = "file"
+	}
+	if envBool("MEMORY_ONLY", false) {
+		storageDriver = "memory"
+	}
+
+	// Item types must be set before any roadmap is loaded and validated
+	if itemTypes := os.Getenv("ITEM_TYPES"); itemTypes != "" {
+		types, err := models.ParseItemTypes(itemTypes)
+		if err != nil {
+			log.Fatalf("Invalid ITEM_TYPES: %v", err)
+		}
+		models.ItemTypes = types
+	}
+
+	// Minimum buffer between external dependencies and the items waiting on
+	// them, reported by dependency validation
+	if leadTimes := os.Getenv("DEPENDENCY_LEAD_TIMES"); leadTimes != "" {
+		policy, err := models.ParseLeadTimes(leadTimes)
+		if err != nil {
+			log.Fatalf("Invalid DEPENDENCY_LEAD_TIMES: %v", err)
+		}
+		models.LeadTimes = policy
+	}
+
 	// Initialize storage
-	fileStorage, err := storage.NewFileStorage(dataDir)
+	var store storage.Storage
+	var err error
+	switch storageDriver {
+	case "file":
+		store, err = storage.NewFileStorage(dataDir)
+	case "memory":
+		store = storage.NewMemoryStorage()
+	case "sqlite":
+		sqlitePath := os.Getenv("SQLITE_PATH")
+		if sqlitePath == "" {
+			sqlitePath = filepath.Join(dataDir, "roadmaps.db")
+		}
+		store, err = storage.NewSQLiteStorage(sqlitePath)
+	case "postgres":
+		store, err = storage.NewPostgresStorage(storage.PostgresConfig{
+			URL:             os.Getenv("DATABASE_URL"),
+			MaxOpenConns:    envInt("DB_MAX_OPEN_CONNS", 10),
+			MaxIdleConns:    envInt("DB_MAX_IDLE_CONNS", 5),
+			ConnMaxLifetime: envDuration("DB_CONN_MAX_LIFETIME", 30*time.Minute),
+		})
+	case "s3":
+		store, err = storage.NewS3Storage(storage.S3Config{
+			Endpoint:        os.Getenv("S3_ENDPOINT"),
+			Region:          os.Getenv("S3_REGION"),
+			Bucket:          os.Getenv("S3_BUCKET"),
+			Prefix:          os.Getenv("S3_PREFIX"),
+			AccessKeyID:     os.Getenv("S3_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("S3_SECRET_ACCESS_KEY"),
+			UseSSL:          envBool("S3_USE_SSL", true),
+			PathStyle:       envBool("S3_PATH_STYLE", false),
+		})
+	default:
+		log.Fatalf("Unknown STORAGE_DRIVER: %s (must be file, memory, sqlite, postgres, or s3)", storageDriver)
+	}
 	if err != nil {
 		log.Fatalf("Failed to initialize storage: %v", err)
 	}
 
+	// Register YAML files dropped into the data directory outside the API
+	if reindexer, ok := store.(storage.Reindexer); ok {
+		result, err := reindexer.Reindex()
+		if err != nil {
+			log.Fatalf("Failed to index data directory: %v", err)
+		}
+		logReindex(result)
+	}
+
+	// Keep a reverse index of external dependencies so dependents are found
+	// without loading every roadmap. The SQL drivers keep it in the database;
+	// elsewhere it is in memory, and S3, which other replicas may write to, is
+	// reindexed now and then since their changes don't pass through here.
+	dependents, err := storage.NewDependentsIndex(store)
+	if err != nil {
+		log.Fatalf("Failed to index dependencies: %v", err)
+	}
+	dependentsMaxAge := time.Duration(0)
+	if storageDriver == "s3" {
+		dependentsMaxAge = 30 * time.Second
+	}
+	dependents.MaxAge = envDuration("DEPENDENTS_MAX_AGE", dependentsMaxAge)
+	store = dependents
+
+	// Webhooks hear about every change made through the API or on disk
+	webhooksFile := os.Getenv("WEBHOOKS_FILE")
+	if webhooksFile == "" && storageDriver != "memory" {
+		webhooksFile = filepath.Join(dataDir, "webhooks.json")
+	}
+	dispatcher, err := webhooks.NewDispatcher(webhooks.Config{
+		Path:           webhooksFile,
+		MaxAttempts:    envInt("WEBHOOK_MAX_ATTEMPTS", 5),
+		InitialBackoff: envDuration("WEBHOOK_BACKOFF", time.Second),
+		Timeout:        envDuration("WEBHOOK_TIMEOUT", 10*time.Second),
+	})
+	if err != nil {
+		log.Fatalf("Failed to load webhooks: %v", err)
+	}
+	notifier := webhooks.NewNotifier(store, dispatcher)
+
+	// Pick up roadmap files changed on disk while running
+	if fileStore, ok := dependents.Storage.(*storage.FileStorage); ok && envBool("WATCH_DATA_DIR", true) {
+		_, err := fileStore.Watch(envDuration("WATCH_DEBOUNCE", time.Second), func(result *storage.ReindexResult, err error) {
+			if err != nil {
+				log.Printf("Failed to sync data directory: %v", err)
+				return
+			}
+			logReindex(result)
+			dependents.Reindexed(result)
+			notifier.Reindexed(result)
+		})
+		if err != nil {
+			log.Fatalf("Failed to watch data directory: %v", err)
+		}
+	}
+
+	// Handlers write through the notifier so every change is published
+	store = notifier
+
+	// Registered service lines; in strict mode roadmaps must use one of them
+	serviceLinesFile := os.Getenv("SERVICE_LINES_FILE")
+	if serviceLinesFile == "" && storageDriver != "memory" {
+		serviceLinesFile = filepath.Join(dataDir, "service_lines.json")
+	}
+	serviceLines, err := servicelines.NewRegistry(serviceLinesFile)
+	if err != nil {
+		log.Fatalf("Failed to load service lines: %v", err)
+	}
+	if envBool("STRICT_SERVICE_LINES", false) {
+		store = servicelines.NewStrict(store, serviceLines)
+	}
+
+	// Soft delete keeps deleted roadmaps in the trash until the retention window passes
+	softDelete := envBool("SOFT_DELETE", false)
+	trashRetention := envDuration("TRASH_RETENTION", 30*24*time.Hour)
+	if softDelete {
+		go purgeTrashPeriodically(store, trashRetention, time.Hour)
+	}
+
+	// Import from Jira when a site is configured
+	var jiraClient *jira.Client
+	if jiraURL := os.Getenv("JIRA_URL"); jiraURL != "" {
+		jiraAPIVersion := envInt("JIRA_API_VERSION", 3)
+		if jiraAPIVersion != 2 && jiraAPIVersion != 3 {
+			log.Fatalf("Invalid JIRA_API_VERSION: %d (must be 2 or 3)", jiraAPIVersion)
+		}
+		jiraClient = jira.New(jira.Config{
+			BaseURL:     jiraURL,
+			User:        os.Getenv("JIRA_USER"),
+			Token:       os.Getenv("JIRA_API_TOKEN"),
+			SprintField: os.Getenv("JIRA_SPRINT_FIELD"),
+			APIVersion:  jiraAPIVersion,
+			MaxIssues:   envInt("JIRA_MAX_ISSUES", 1000),
+			Timeout:     envDuration("JIRA_TIMEOUT", 30*time.Second),
+		})
+		log.Printf("Jira import enabled (site: %s)", jiraURL)
+	}
+
 	// Initialize handlers
-	roadmapHandler := handlers.NewRoadmapHandler(fileStorage)
+	roadmapHandler := handlers.NewRoadmapHandler(store, handlers.Config{
+		SoftDelete:     softDelete,
+		RequireIfMatch: envBool("REQUIRE_IF_MATCH", true),
+		MaxUploadBytes: int64(envInt("MAX_UPLOAD_BYTES", 10<<20)),
+		Dependents:     dependents,
+		Jira:           jiraClient,
+	})
+	adminHandler := handlers.NewAdminHandler(store)
+	searchHandler := handlers.NewSearchHandler(store)
+	openAPIHandler, err := handlers.NewOpenAPIHandler()
+	if err != nil {
+		log.Fatalf("Failed to build OpenAPI document: %v", err)
+	}
+	graphAPI, err := graphapi.New(store)
+	if err != nil {
+		log.Fatalf("Failed to build GraphQL schema: %v", err)
+	}
+	graphQLHandler := handlers.NewGraphQLHandler(graphAPI)
+	webhookHandler := handlers.NewWebhookHandler(dispatcher)
+	serviceLineHandler := handlers.NewServiceLineHandler(store, serviceLines)
 
-	// Set up routes
-	http.HandleFunc("/api/roadmaps", roadmapHandler.HandleRoadmaps)
-	http.HandleFunc("/api/roadmaps/", roadmapHandler.HandleRoadmaps)
-	http.HandleFunc("/api/dependencies/", roadmapHandler.HandleDependencies)
+	// With OIDC configured, API requests must carry a bearer token and the
+	// caller is recorded as the author of their changes
+	var authenticator *auth.Authenticator
+	if issuer := os.Getenv("OIDC_ISSUER_URL"); issuer != "" {
+		authenticator, err = auth.New(context.Background(), auth.Config{
+			IssuerURL: issuer,
+			Audience:  os.Getenv("OIDC_AUDIENCE"),
+		})
+		if err != nil {
+			log.Fatalf("Failed to set up authentication: %v", err)
+		}
+		log.Printf("Authentication enabled (issuer: %s)", issuer)
+	}
+
+	// Set up routes. The REST API is served under /api/v1; the unversioned
+	// paths stay as deprecated aliases until LEGACY_API is turned off.
+	legacy := handlers.LegacyConfig{Enabled: envBool("LEGACY_API", true)}
+	if sunset := os.Getenv("LEGACY_API_SUNSET"); sunset != "" {
+		legacy.Sunset, err = time.Parse("2006-01-02", sunset)
+		if err != nil {
+			log.Fatalf("Invalid LEGACY_API_SUNSET: %v", err)
+		}
+	}
+	api := &handlers.API{
+		Roadmaps:     roadmapHandler,
+		Admin:        adminHandler,
+		Search:       searchHandler,
+		Webhooks:     webhookHandler,
+		OpenAPI:      openAPIHandler,
+		ServiceLines: serviceLineHandler,
+	}
+	api.Register(http.DefaultServeMux, legacy)
+	http.HandleFunc("/graphql", graphQLHandler.HandleGraphQL)
 
 	// Health check endpoints
 	http.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
@@ -59,16 +286,135 @@ func main() {
 			http.ServeFile(w, r, "web/templates/view.html")
 		} else if r.URL.Path == "/compare" {
 			http.ServeFile(w, r, "web/templates/compare.html")
+		} else if strings.HasPrefix(r.URL.Path, "/api/") {
+			apierror.Write(w, r, http.StatusNotFound, "Not found")
 		} else {
 			http.NotFound(w, r)
 		}
 	})
 
+	// The gRPC API listens on its own port and is off unless GRPC_PORT is set
+	if grpcPort := os.Getenv("GRPC_PORT"); grpcPort != "" {
+		var opts []grpc.ServerOption
+		if authenticator != nil {
+			unary, stream := grpcapi.AuthInterceptors(authenticator)
+			opts = append(opts, grpc.UnaryInterceptor(unary), grpc.StreamInterceptor(stream))
+		}
+		grpcServer := grpc.NewServer(opts...)
+		roadmapv1.RegisterRoadmapServiceServer(grpcServer, grpcapi.NewServer(store, grpcapi.Config{
+			SoftDelete:    softDelete,
+			WatchInterval: envDuration("GRPC_WATCH_INTERVAL", 2*time.Second),
+			Dependents:    dependents,
+		}))
+		reflection.Register(grpcServer)
+
+		listener, err := net.Listen("tcp", fmt.Sprintf(":%s", grpcPort))
+		if err != nil {
+			log.Fatalf("Failed to listen for gRPC: %v", err)
+		}
+		log.Printf("Starting gRPC server on :%s", grpcPort)
+		go func() {
+			if err := grpcServer.Serve(listener); err != nil {
+				log.Fatalf("gRPC server failed: %v", err)
+			}
+		}()
+	}
+
+	var handler http.Handler = http.DefaultServeMux
+	if authenticator != nil {
+		handler = authenticator.Require(handler, "/api/", "/graphql")
+	}
+	handler = requestlog.Middleware(handler, accessLogger())
+	if envBool("COMPRESS_RESPONSES", true) {
+		handler = compression.Middleware(handler, compression.DefaultMinSize)
+	}
+
 	// Start server
 	addr := fmt.Sprintf(":%s", port)
 	log.Printf("Starting server on %s", addr)
+	log.Printf("Storage driver: %s", storageDriver)
 	log.Printf("Data directory: %s", dataDir)
-	if err := http.ListenAndServe(addr, nil); err != nil {
+	if err := http.ListenAndServe(addr, handler); err != nil {
 		log.Fatalf("Server failed: %v", err)
 	}
 }
+
+// logReindex logs the changes and skipped files from a data directory reindex
+func logReindex(result *storage.ReindexResult) {
+	if result.Changed() {
+		log.Printf("Synced data directory: %d added, %d updated, %d removed",
+			len(result.Added), len(result.Updated), len(result.Removed))
+	}
+	for _, e := range result.Errors {
+		log.Printf("Skipping %s: %s", e.File, e.Error)
+	}
+}
+
+// purgeTrashPeriodically permanently removes trashed roadmaps older than retention
+func purgeTrashPeriodically(store storage.Storage, retention, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := storage.PurgeTrash(store, time.Now().Add(-retention))
+		if err != nil {
+			log.Printf("Failed to purge trash: %v", err)
+		} else if purged > 0 {
+			log.Printf("Purged %d roadmap(s) from trash", purged)
+		}
+		<-ticker.C
+	}
+}
+
+// accessLogger returns the logger for request logs, in the format set by
+// LOG_FORMAT (text or json)
+func accessLogger() *slog.Logger {
+	switch format := os.Getenv("LOG_FORMAT"); format {
+	case "", "text":
+		return slog.New(slog.NewTextHandler(os.Stderr, nil))
+	case "json":
+		return slog.New(slog.NewJSONHandler(os.Stderr, nil))
+	default:
+		log.Fatalf("Invalid LOG_FORMAT: %s (must be text or json)", format)
+		return nil
+	}
+}
+
+// envInt reads an integer environment variable, falling back to def
+func envInt(name string, def int) int {
+	value := os.Getenv(name)
+	if value == "" {
+		return def
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		log.Fatalf("Invalid %s: %v", name, err)
+	}
+	return n
+}
+
+// envBool reads a boolean environment variable, falling back to def
+func envBool(name string, def bool) bool {
+	value := os.Getenv(name)
+	if value == "" {
+		return def
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Fatalf("Invalid %s: %v", name, err)
+	}
+	return b
+}
+
+// envDuration reads a duration environment variable (e.g. "30m"), falling back to def
+func envDuration(name string, def time.Duration) time.Duration {
+	value := os.Getenv(name)
+	if value == "" {
+		return def
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		log.Fatalf("Invalid %s: %v", name, err)
+	}
+	return d
+}
@@ -0,0 +1,57 @@
+// Package apierror writes the JSON error envelope returned by every HTTP API
+// endpoint:
+//
+//	{"code": "not_found", "message": "Roadmap not found", "request_id": "..."}
+//
+// Code is a stable, machine-readable name derived from the HTTP status;
+// Message is meant for people and may change between releases. Details, when
+// present, carries endpoint-specific data about the failure.
+package apierror
+
+import (
+	"encoding/json"
+	"net/http"
+	"roadmap-visualizer/internal/requestlog"
+	"strings"
+)
+
+// Error is the body of an error response
+type Error struct {
+	Code    string      `json:"code"`
+	Message string      `json:"message"`
+	Details interface{} `json:"details,omitempty"`
+	// RequestID matches the X-Request-ID header and the access log line
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// Write sends an error response with the given status and message
+func Write(w http.ResponseWriter, r *http.Request, status int, message string) {
+	WriteDetails(w, r, status, message, nil)
+}
+
+// WriteDetails sends an error response that also carries details, which
+// must encode to JSON
+func WriteDetails(w http.ResponseWriter, r *http.Request, status int, message string, details interface{}) {
+	h := w.Header()
+	h.Del("Content-Length")
+	h.Set("Content-Type", "application/json")
+	h.Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(Error{
+		Code:      Code(status),
+		Message:   message,
+		Details:   details,
+		RequestID: requestlog.ID(r.Context()),
+	})
+}
+
+// Code returns the error code for an HTTP status: its status text in
+// snake_case, e.g. "not_found" or "precondition_failed"
+func Code(status int) string {
+	text := http.StatusText(status)
+	if text == "" {
+		return "error"
+	}
+	text = strings.ToLower(strings.ReplaceAll(text, "-", " "))
+	return strings.Join(strings.Fields(text), "_")
+}
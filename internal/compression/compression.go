@@ -0,0 +1,245 @@
+// Package compression gzip- or deflate-encodes HTTP responses for clients
+// that accept it, negotiated through Accept-Encoding.
+package compression
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultMinSize is the smallest body worth compressing; below it the
+// encoding overhead outweighs the savings
+const DefaultMinSize = 1024
+
+// Content encodings in order of preference
+const (
+	encodingGzip    = "gzip"
+	encodingDeflate = "deflate"
+)
+
+// compressibleTypes are the media types that are compressed. Archives and
+// images are already compressed and are passed through.
+var compressibleTypes = []string{
+	"text/",
+	"application/json",
+	"application/javascript",
+	"application/x-yaml",
+	"application/yaml",
+	"application/xml",
+	"image/svg+xml",
+}
+
+var gzipWriters = sync.Pool{New: func() interface{} { return gzip.NewWriter(io.Discard) }}
+
+var zlibWriters = sync.Pool{New: func() interface{} { return zlib.NewWriter(io.Discard) }}
+
+// Middleware compresses responses of at least minSize bytes with a
+// compressible content type. Range requests, HEAD requests, and responses
+// that already set Content-Encoding are left alone. ETags are passed
+// through unchanged: they name a roadmap revision, which is the same however
+// the body is encoded, and If-Match relies on them matching exactly.
+func Middleware(next http.Handler, minSize int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+
+		encoding := negotiate(r.Header.Get("Accept-Encoding"))
+		if encoding == "" || r.Method == http.MethodHead || r.Header.Get("Range") != "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		cw := &compressWriter{ResponseWriter: w, encoding: encoding, minSize: minSize, status: http.StatusOK}
+		defer cw.Close()
+		next.ServeHTTP(cw, r)
+	})
+}
+
+// negotiate picks the preferred encoding the client accepts, or "" for none
+func negotiate(acceptEncoding string) string {
+	best, bestQ := "", 0.0
+	wildcard := -1.0
+	seen := make(map[string]bool)
+
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+
+		switch name {
+		case "*":
+			wildcard = q
+		case encodingGzip, encodingDeflate:
+			seen[name] = true
+			if q > bestQ || (q == bestQ && name == encodingGzip) {
+				best, bestQ = name, q
+			}
+		}
+	}
+
+	// "*" covers encodings that weren't listed by name
+	if !seen[encodingGzip] && wildcard > 0 && wildcard >= bestQ {
+		return encodingGzip
+	}
+	if bestQ <= 0 {
+		return ""
+	}
+	return best
+}
+
+func compressible(contentType string) bool {
+	contentType = strings.ToLower(contentType)
+	for _, prefix := range compressibleTypes {
+		if strings.HasPrefix(contentType, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// compressWriter buffers the start of a response until it knows whether the
+// body is large enough to compress, then either streams it through an
+// encoder or passes it through untouched
+type compressWriter struct {
+	http.ResponseWriter
+	encoding string
+	minSize  int
+
+	status  int
+	buf     []byte
+	decided bool
+	encoder io.WriteCloser
+}
+
+func (cw *compressWriter) WriteHeader(status int) {
+	if cw.decided {
+		return
+	}
+	cw.status = status
+	// Responses without a body, and partial content, are never encoded
+	if status < 200 || status == http.StatusNoContent || status == http.StatusNotModified || status == http.StatusPartialContent {
+		cw.decide(false)
+	}
+}
+
+func (cw *compressWriter) Write(p []byte) (int, error) {
+	if !cw.decided {
+		cw.buf = append(cw.buf, p...)
+		if len(cw.buf) < cw.minSize {
+			return len(p), nil
+		}
+		if err := cw.decide(true); err != nil {
+			return 0, err
+		}
+		return len(p), nil
+	}
+
+	if cw.encoder != nil {
+		return cw.encoder.Write(p)
+	}
+	return cw.ResponseWriter.Write(p)
+}
+
+// decide sends the headers, choosing to compress if the body may be
+// compressed and is large enough, and writes out anything buffered
+func (cw *compressWriter) decide(largeEnough bool) error {
+	cw.decided = true
+	header := cw.Header()
+
+	if header.Get("Content-Type") == "" && len(cw.buf) > 0 {
+		header.Set("Content-Type", http.DetectContentType(cw.buf))
+	}
+	if largeEnough && header.Get("Content-Encoding") == "" && compressible(header.Get("Content-Type")) {
+		header.Del("Content-Length")
+		header.Set("Content-Encoding", cw.encoding)
+		cw.encoder = cw.newEncoder()
+	}
+
+	cw.ResponseWriter.WriteHeader(cw.status)
+
+	buffered := cw.buf
+	cw.buf = nil
+	if len(buffered) == 0 {
+		return nil
+	}
+	var err error
+	if cw.encoder != nil {
+		_, err = cw.encoder.Write(buffered)
+	} else {
+		_, err = cw.ResponseWriter.Write(buffered)
+	}
+	return err
+}
+
+func (cw *compressWriter) newEncoder() io.WriteCloser {
+	if cw.encoding == encodingDeflate {
+		zw := zlibWriters.Get().(*zlib.Writer)
+		zw.Reset(cw.ResponseWriter)
+		return &pooledWriter{WriteCloser: zw, release: func() { zlibWriters.Put(zw) }}
+	}
+	gw := gzipWriters.Get().(*gzip.Writer)
+	gw.Reset(cw.ResponseWriter)
+	return &pooledWriter{WriteCloser: gw, release: func() { gzipWriters.Put(gw) }}
+}
+
+// Flush sends what has been written so far, compressing it if the content
+// type allows, so streamed responses aren't held back by the size threshold
+func (cw *compressWriter) Flush() {
+	if !cw.decided {
+		cw.decide(true)
+	}
+	if flusher, ok := cw.encoder.(interface{ Flush() error }); ok {
+		flusher.Flush()
+	}
+	if flusher, ok := cw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Close finishes the response, sending small bodies uncompressed
+func (cw *compressWriter) Close() error {
+	if !cw.decided {
+		if err := cw.decide(false); err != nil {
+			return err
+		}
+	}
+	if cw.encoder != nil {
+		return cw.encoder.Close()
+	}
+	return nil
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController
+func (cw *compressWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
+
+// pooledWriter returns its encoder to a pool once closed
+type pooledWriter struct {
+	io.WriteCloser
+	release func()
+}
+
+func (pw *pooledWriter) Flush() error {
+	if flusher, ok := pw.WriteCloser.(interface{ Flush() error }); ok {
+		return flusher.Flush()
+	}
+	return nil
+}
+
+func (pw *pooledWriter) Close() error {
+	err := pw.WriteCloser.Close()
+	pw.release()
+	return err
+}
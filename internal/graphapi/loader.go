@@ -0,0 +1,63 @@
+package graphapi
+
+import (
+	"context"
+	"fmt"
+	"roadmap-visualizer/internal/models"
+	"roadmap-visualizer/internal/storage"
+	"sync"
+)
+
+// loader caches every stored roadmap for the duration of one query, so nested
+// fields such as dependents and dependency targets don't hit storage again
+type loader struct {
+	store storage.Storage
+
+	once   sync.Once
+	err    error
+	all    []*models.StoredRoadmap
+	byID   map[string]*models.StoredRoadmap
+	byName map[string]*models.StoredRoadmap
+}
+
+type loaderKey struct{}
+
+// withLoader attaches a fresh loader to the context of a query
+func withLoader(ctx context.Context, store storage.Storage) context.Context {
+	return context.WithValue(ctx, loaderKey{}, &loader{store: store})
+}
+
+// loaderFrom returns the loader of the current query
+func loaderFrom(ctx context.Context) (*loader, error) {
+	l, ok := ctx.Value(loaderKey{}).(*loader)
+	if !ok {
+		return nil, fmt.Errorf("query context has no loader")
+	}
+	return l, l.load()
+}
+
+// load reads every roadmap once
+func (l *loader) load() error {
+	l.once.Do(func() {
+		l.all, l.err = l.store.List(storage.ListFilter{})
+		if l.err != nil {
+			return
+		}
+		l.byID = make(map[string]*models.StoredRoadmap, len(l.all))
+		l.byName = make(map[string]*models.StoredRoadmap, len(l.all))
+		for _, rm := range l.all {
+			l.byID[rm.ID] = rm
+			l.byName[rm.Roadmap.Name] = rm
+		}
+	})
+	return l.err
+}
+
+// target resolves the roadmap an external dependency points at, preferring
+// roadmap_id over the roadmap name as dependency validation does
+func (l *loader) target(dep models.ExternalDependency) *models.StoredRoadmap {
+	if dep.RoadmapID != "" {
+		return l.byID[dep.RoadmapID]
+	}
+	return l.byName[dep.RoadmapName]
+}
@@ -0,0 +1,944 @@
+// Package graphapi exposes roadmaps, items, and external dependencies as a
+// GraphQL graph, so a client can fetch a roadmap together with its dependents
+// and dependency status in one request.
+package graphapi
+
+import (
+	"context"
+	"fmt"
+	"roadmap-visualizer/internal/models"
+	"roadmap-visualizer/internal/storage"
+	"time"
+
+	"github.com/graphql-go/graphql"
+)
+
+// item is an item together with the roadmap that holds it
+type item struct {
+	roadmap *models.StoredRoadmap
+	item    *models.RoadmapItem
+}
+
+// externalDependency is a dependency together with the item that declares it
+type externalDependency struct {
+	from item
+	dep  models.ExternalDependency
+}
+
+// API executes GraphQL queries against a storage backend
+type API struct {
+	schema graphql.Schema
+	store  storage.Storage
+}
+
+// New builds the GraphQL schema for a storage backend
+func New(store storage.Storage) (*API, error) {
+	schema, err := newSchema(store)
+	if err != nil {
+		return nil, err
+	}
+	return &API{schema: schema, store: store}, nil
+}
+
+// Execute runs a query. Roadmaps are read from storage at most once per query.
+func (a *API) Execute(ctx context.Context, query, operationName string, variables map[string]interface{}) *graphql.Result {
+	return graphql.Do(graphql.Params{
+		Schema:         a.schema,
+		RequestString:  query,
+		OperationName:  operationName,
+		VariableValues: variables,
+		Context:        withLoader(ctx, a.store),
+	})
+}
+
+var statusEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name:        "Status",
+	Description: "Status of a roadmap item",
+	Values: graphql.EnumValueConfigMap{
+		"PLANNED":     {Value: models.StatusPlanned},
+		"IN_PROGRESS": {Value: models.StatusInProgress},
+		"COMPLETED":   {Value: models.StatusCompleted},
+		"BLOCKED":     {Value: models.StatusBlocked},
+	},
+})
+
+var confidenceEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name:        "Confidence",
+	Description: "How firmly a roadmap item is planned",
+	Values: graphql.EnumValueConfigMap{
+		"COMMITTED":   {Value: models.ConfidenceCommitted},
+		"LIKELY":      {Value: models.ConfidenceLikely},
+		"EXPLORATORY": {Value: models.ConfidenceExploratory},
+	},
+})
+
+var responsibilityEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name:        "Responsibility",
+	Description: "A stakeholder's part in a roadmap, as in a RACI matrix",
+	Values: graphql.EnumValueConfigMap{
+		"RESPONSIBLE": {Value: models.ResponsibilityResponsible},
+		"ACCOUNTABLE": {Value: models.ResponsibilityAccountable},
+		"CONSULTED":   {Value: models.ResponsibilityConsulted},
+		"INFORMED":    {Value: models.ResponsibilityInformed},
+	},
+})
+
+var healthEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name:        "Health",
+	Description: "How a roadmap or item is doing against its dates",
+	Values: graphql.EnumValueConfigMap{
+		"ON_TRACK": {Value: models.HealthOnTrack},
+		"AT_RISK":  {Value: models.HealthAtRisk},
+		"LATE":     {Value: models.HealthLate},
+	},
+})
+
+var sortEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name: "RoadmapSort",
+	Values: graphql.EnumValueConfigMap{
+		"NAME":         {Value: storage.SortByName},
+		"CREATED_AT":   {Value: storage.SortByCreatedAt},
+		"UPDATED_AT":   {Value: storage.SortByUpdatedAt},
+		"SERVICE_LINE": {Value: storage.SortByServiceLine},
+	},
+})
+
+var orderEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name: "SortOrder",
+	Values: graphql.EnumValueConfigMap{
+		"ASC":  {Value: "asc"},
+		"DESC": {Value: "desc"},
+	},
+})
+
+// nonNullList is [T!]!
+func nonNullList(t graphql.Type) graphql.Type {
+	return graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(t)))
+}
+
+func newSchema(store storage.Storage) (graphql.Schema, error) {
+	var roadmapType, itemType, dependencyType *graphql.Object
+
+	milestoneType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Milestone",
+		Description: "A key date on a roadmap",
+		Fields: graphql.Fields{
+			"id":          milestoneField(graphql.NewNonNull(graphql.String), func(m *models.Milestone) interface{} { return m.ID }),
+			"name":        milestoneField(graphql.NewNonNull(graphql.String), func(m *models.Milestone) interface{} { return m.Name }),
+			"date":        milestoneField(graphql.NewNonNull(graphql.String), func(m *models.Milestone) interface{} { return m.Date }),
+			"description": milestoneField(graphql.String, func(m *models.Milestone) interface{} { return m.Description }),
+		},
+	})
+
+	objectiveType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Objective",
+		Description: "A goal, such as an OKR, that items contribute to",
+		Fields: graphql.Fields{
+			"id":          objectiveField(graphql.NewNonNull(graphql.String), func(o *models.Objective) interface{} { return o.ID }),
+			"name":        objectiveField(graphql.NewNonNull(graphql.String), func(o *models.Objective) interface{} { return o.Name }),
+			"description": objectiveField(graphql.String, func(o *models.Objective) interface{} { return o.Description }),
+			"keyResults": objectiveField(nonNullList(graphql.String), func(o *models.Objective) interface{} {
+				if o.KeyResults == nil {
+					return []string{}
+				}
+				return o.KeyResults
+			}),
+		},
+	})
+
+	contactType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Contact",
+		Description: "An owner of a roadmap and where to reach them",
+		Fields: graphql.Fields{
+			"name":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"email": &graphql.Field{Type: graphql.String},
+			"slack": &graphql.Field{Type: graphql.String},
+		},
+	})
+
+	stakeholderType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Stakeholder",
+		Description: "Someone with a part in a roadmap beyond its owner",
+		Fields: graphql.Fields{
+			"name":           &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"role":           &graphql.Field{Type: graphql.String},
+			"responsibility": &graphql.Field{Type: graphql.NewNonNull(responsibilityEnum)},
+		},
+	})
+
+	teamPeriodType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "TeamPeriod",
+		Description: "A team's capacity for a quarter, month, or year",
+		Fields: graphql.Fields{
+			"period":   &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"capacity": &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+		},
+	})
+
+	teamType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Team",
+		Description: "A team items allocate people from, with its monthly capacity in full-time people",
+		Fields: graphql.Fields{
+			"name":     &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"capacity": &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+			"periods": &graphql.Field{Type: nonNullList(teamPeriodType), Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				if periods := p.Source.(models.Team).Periods; periods != nil {
+					return periods, nil
+				}
+				return []models.TeamPeriod{}, nil
+			}},
+		},
+	})
+
+	allocationType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Allocation",
+		Description: "Full-time people an item takes from a team while it runs",
+		Fields: graphql.Fields{
+			"team":   &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"people": &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+		},
+	})
+
+	deliverableType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Deliverable",
+		Description: "An entry in an item's checklist",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"done": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+			"due": &graphql.Field{Type: graphql.String, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				if due := p.Source.(models.Deliverable).Due; due != "" {
+					return due, nil
+				}
+				return nil, nil
+			}},
+		},
+	})
+
+	linkType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Link",
+		Description: "A page related to an item, such as a design doc or an epic",
+		Fields: graphql.Fields{
+			"title": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"url":   &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		},
+	})
+
+	statusChangeType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "StatusChange",
+		Description: "A status change made through the API; from is null for the status an item was added with",
+		Fields: graphql.Fields{
+			"from": &graphql.Field{Type: statusEnum, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				if change := p.Source.(models.StatusChange); change.From != "" {
+					return change.From, nil
+				}
+				return nil, nil
+			}},
+			"to":     &graphql.Field{Type: graphql.NewNonNull(statusEnum)},
+			"at":     &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"author": &graphql.Field{Type: graphql.String},
+		},
+	})
+
+	baselineType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Baseline",
+		Description: "The start and end an item was first stored with",
+		Fields: graphql.Fields{
+			"start": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"end":   &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		},
+	})
+
+	recurrenceType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Recurrence",
+		Description: "How often an item repeats; either count or until is set",
+		Fields: graphql.Fields{
+			"every": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"count": &graphql.Field{Type: graphql.Int, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				if rec := p.Source.(*models.Recurrence); rec.Count > 0 {
+					return rec.Count, nil
+				}
+				return nil, nil
+			}},
+			"until": &graphql.Field{Type: graphql.String, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				if rec := p.Source.(*models.Recurrence); rec.Until != "" {
+					return rec.Until, nil
+				}
+				return nil, nil
+			}},
+		},
+	})
+
+	roadmapType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Roadmap",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":          roadmapField(graphql.NewNonNull(graphql.ID), func(rm *models.StoredRoadmap) interface{} { return rm.ID }),
+				"name":        roadmapField(graphql.NewNonNull(graphql.String), func(rm *models.StoredRoadmap) interface{} { return rm.Roadmap.Name }),
+				"serviceLine": roadmapField(graphql.NewNonNull(graphql.String), func(rm *models.StoredRoadmap) interface{} { return rm.Roadmap.ServiceLine }),
+				"owner":       roadmapField(graphql.String, func(rm *models.StoredRoadmap) interface{} { return rm.Roadmap.Owner }),
+				"portfolio":   roadmapField(graphql.String, func(rm *models.StoredRoadmap) interface{} { return rm.Roadmap.Portfolio }),
+				"category":    roadmapField(graphql.String, func(rm *models.StoredRoadmap) interface{} { return rm.Roadmap.Category }),
+				"currency":    roadmapField(graphql.String, func(rm *models.StoredRoadmap) interface{} { return rm.Roadmap.Currency }),
+				"notes":       roadmapField(graphql.String, func(rm *models.StoredRoadmap) interface{} { return rm.Roadmap.Notes }),
+				"fileName":    roadmapField(graphql.String, func(rm *models.StoredRoadmap) interface{} { return rm.FileName }),
+				"createdAt":   roadmapField(graphql.NewNonNull(graphql.DateTime), func(rm *models.StoredRoadmap) interface{} { return rm.CreatedAt }),
+				"updatedAt":   roadmapField(graphql.NewNonNull(graphql.DateTime), func(rm *models.StoredRoadmap) interface{} { return rm.UpdatedAt }),
+				"revision":    roadmapField(graphql.NewNonNull(graphql.Int), func(rm *models.StoredRoadmap) interface{} { return rm.CurrentRevision() }),
+				"createdBy":   roadmapField(graphql.String, func(rm *models.StoredRoadmap) interface{} { return rm.CreatedBy }),
+				"updatedBy":   roadmapField(graphql.String, func(rm *models.StoredRoadmap) interface{} { return rm.UpdatedBy }),
+				"archived":    roadmapField(graphql.NewNonNull(graphql.Boolean), func(rm *models.StoredRoadmap) interface{} { return rm.Roadmap.Archived }),
+				"completion":  roadmapField(graphql.NewNonNull(graphql.Int), func(rm *models.StoredRoadmap) interface{} { return rm.Roadmap.Completion() }),
+				"health":      roadmapField(graphql.NewNonNull(healthEnum), func(rm *models.StoredRoadmap) interface{} { return rm.Roadmap.Health(time.Now()) }),
+				"tags": roadmapField(nonNullList(graphql.String), func(rm *models.StoredRoadmap) interface{} {
+					if rm.Roadmap.Tags == nil {
+						return []string{}
+					}
+					return rm.Roadmap.Tags
+				}),
+				"items": &graphql.Field{
+					Type:        nonNullList(itemType),
+					Description: "Items of the roadmap, optionally only those with a status, confidence, or type; archived items are left out unless includeArchived is set",
+					Args: graphql.FieldConfigArgument{
+						"status":          {Type: statusEnum},
+						"confidence":      {Type: confidenceEnum},
+						"type":            {Type: graphql.String},
+						"includeArchived": {Type: graphql.Boolean, DefaultValue: false},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						rm := p.Source.(*models.StoredRoadmap)
+						status, _ := p.Args["status"].(models.RoadmapStatus)
+						confidence, _ := p.Args["confidence"].(models.Confidence)
+						itemType, _ := p.Args["type"].(string)
+						archived, _ := p.Args["includeArchived"].(bool)
+						items := []item{}
+						for i := range rm.Roadmap.Items {
+							if (archived || !rm.Roadmap.Items[i].Archived) &&
+								(status == "" || rm.Roadmap.Items[i].Status == status) &&
+								(confidence == "" || rm.Roadmap.Items[i].Confidence == confidence) &&
+								(itemType == "" || rm.Roadmap.Items[i].Type == itemType) {
+								items = append(items, item{roadmap: rm, item: &rm.Roadmap.Items[i]})
+							}
+						}
+						return items, nil
+					},
+				},
+				"milestones": &graphql.Field{
+					Type: nonNullList(milestoneType),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						rm := p.Source.(*models.StoredRoadmap)
+						milestones := make([]*models.Milestone, len(rm.Roadmap.Milestones))
+						for i := range rm.Roadmap.Milestones {
+							milestones[i] = &rm.Roadmap.Milestones[i]
+						}
+						return milestones, nil
+					},
+				},
+				"objectives": &graphql.Field{
+					Type: nonNullList(objectiveType),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						rm := p.Source.(*models.StoredRoadmap)
+						objectives := make([]*models.Objective, len(rm.Roadmap.Objectives))
+						for i := range rm.Roadmap.Objectives {
+							objectives[i] = &rm.Roadmap.Objectives[i]
+						}
+						return objectives, nil
+					},
+				},
+				"owners": roadmapField(nonNullList(contactType), func(rm *models.StoredRoadmap) interface{} {
+					if rm.Roadmap.Owners == nil {
+						return []models.Contact{}
+					}
+					return rm.Roadmap.Owners
+				}),
+				"stakeholders": roadmapField(nonNullList(stakeholderType), func(rm *models.StoredRoadmap) interface{} {
+					if rm.Roadmap.Stakeholders == nil {
+						return []models.Stakeholder{}
+					}
+					return rm.Roadmap.Stakeholders
+				}),
+				"teams": roadmapField(nonNullList(teamType), func(rm *models.StoredRoadmap) interface{} {
+					if rm.Roadmap.Teams == nil {
+						return []models.Team{}
+					}
+					return rm.Roadmap.Teams
+				}),
+				"item": &graphql.Field{
+					Type: itemType,
+					Args: graphql.FieldConfigArgument{
+						"id": {Type: graphql.NewNonNull(graphql.String)},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						rm := p.Source.(*models.StoredRoadmap)
+						if it, ok := findItem(rm, p.Args["id"].(string)); ok {
+							return it, nil
+						}
+						return nil, nil
+					},
+				},
+				"externalDependencies": &graphql.Field{
+					Type:        nonNullList(dependencyType),
+					Description: "External dependencies declared by items of this roadmap",
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return dependenciesOf(p.Source.(*models.StoredRoadmap)), nil
+					},
+				},
+				"dependents": &graphql.Field{
+					Type:        nonNullList(dependencyType),
+					Description: "External dependencies in other roadmaps that point at this roadmap",
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						l, err := loaderFrom(p.Context)
+						if err != nil {
+							return nil, err
+						}
+						rm := p.Source.(*models.StoredRoadmap)
+						dependents := []externalDependency{}
+						for _, other := range l.all {
+							if other.ID == rm.ID {
+								continue
+							}
+							for _, d := range dependenciesOf(other) {
+								if target := l.target(d.dep); target != nil && target.ID == rm.ID {
+									dependents = append(dependents, d)
+								}
+							}
+						}
+						return dependents, nil
+					},
+				},
+			}
+		}),
+	})
+
+	itemType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Item",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":          itemField(graphql.NewNonNull(graphql.String), func(it item) interface{} { return it.item.ID }),
+				"name":        itemField(graphql.NewNonNull(graphql.String), func(it item) interface{} { return it.item.Name }),
+				"start":       itemField(graphql.NewNonNull(graphql.String), func(it item) interface{} { return it.item.Start }),
+				"end":         itemField(graphql.NewNonNull(graphql.String), func(it item) interface{} { return it.item.End }),
+				"status":      itemField(graphql.NewNonNull(statusEnum), func(it item) interface{} { return it.item.Status }),
+				"description": itemField(graphql.String, func(it item) interface{} { return it.item.Description }),
+				"notes":       itemField(graphql.String, func(it item) interface{} { return it.item.Notes }),
+				"owner":       itemField(graphql.String, func(it item) interface{} { return it.item.Owner }),
+				"team":        itemField(graphql.String, func(it item) interface{} { return it.item.Team }),
+				"risk":        itemField(graphql.String, func(it item) interface{} { return it.item.Risk }),
+				"riskNotes":   itemField(graphql.String, func(it item) interface{} { return it.item.RiskNotes }),
+				"health": itemField(graphql.NewNonNull(healthEnum), func(it item) interface{} {
+					return it.roadmap.Roadmap.ItemHealth(time.Now())[it.item.ID]
+				}),
+				"confidence": itemField(confidenceEnum, func(it item) interface{} {
+					if it.item.Confidence == "" {
+						return nil
+					}
+					return it.item.Confidence
+				}),
+				"type":     itemField(graphql.String, func(it item) interface{} { return it.item.Type }),
+				"archived": itemField(graphql.NewNonNull(graphql.Boolean), func(it item) interface{} { return it.item.Archived }),
+				"color":    itemField(graphql.String, func(it item) interface{} { return it.item.Color }),
+				"icon":     itemField(graphql.String, func(it item) interface{} { return it.item.Icon }),
+				"progress": itemField(graphql.NewNonNull(graphql.Int), func(it item) interface{} { return it.item.EffectiveProgress() }),
+				"roadmap":  itemField(graphql.NewNonNull(roadmapType), func(it item) interface{} { return it.roadmap }),
+				"tags": itemField(nonNullList(graphql.String), func(it item) interface{} {
+					if it.item.Tags == nil {
+						return []string{}
+					}
+					return it.item.Tags
+				}),
+				"customField": &graphql.Field{
+					Type:        graphql.String,
+					Description: "The value of one of the item's custom fields, if set",
+					Args: graphql.FieldConfigArgument{
+						"key": {Type: graphql.NewNonNull(graphql.String)},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						key, _ := p.Args["key"].(string)
+						if value, ok := p.Source.(item).item.Custom[key]; ok {
+							return value, nil
+						}
+						return nil, nil
+					},
+				},
+				"links": itemField(nonNullList(linkType), func(it item) interface{} {
+					if it.item.Links == nil {
+						return []models.Link{}
+					}
+					return it.item.Links
+				}),
+				"budget": itemField(graphql.Float, func(it item) interface{} {
+					if it.item.Budget == nil {
+						return nil
+					}
+					return *it.item.Budget
+				}),
+				"actualCost": itemField(graphql.Float, func(it item) interface{} {
+					if it.item.ActualCost == nil {
+						return nil
+					}
+					return *it.item.ActualCost
+				}),
+				"effortWeeks": itemField(graphql.Float, func(it item) interface{} {
+					if it.item.Effort == nil {
+						return nil
+					}
+					return it.item.Effort.PersonWeeks()
+				}),
+				"statusChangedAt": itemField(graphql.DateTime, func(it item) interface{} {
+					if it.item.StatusChangedAt == nil {
+						return nil
+					}
+					return *it.item.StatusChangedAt
+				}),
+				"statusHistory": itemField(nonNullList(statusChangeType), func(it item) interface{} {
+					if it.item.StatusHistory == nil {
+						return []models.StatusChange{}
+					}
+					return it.item.StatusHistory
+				}),
+				"cycleTimeDays": itemField(graphql.Float, func(it item) interface{} {
+					cycle, ok := it.item.CycleTime()
+					if !ok {
+						return nil
+					}
+					return cycle.Hours() / 24
+				}),
+				"deliverables": itemField(nonNullList(deliverableType), func(it item) interface{} {
+					if it.item.Deliverables == nil {
+						return []models.Deliverable{}
+					}
+					return it.item.Deliverables
+				}),
+				"deliverablesDone": itemField(graphql.NewNonNull(graphql.Int), func(it item) interface{} { return it.item.CountDeliverables().Done }),
+				"allocations": itemField(nonNullList(allocationType), func(it item) interface{} {
+					if it.item.Allocations == nil {
+						return []models.Allocation{}
+					}
+					return it.item.Allocations
+				}),
+				"baseline": itemField(baselineType, func(it item) interface{} {
+					if it.item.Baseline == nil {
+						return nil
+					}
+					return it.item.Baseline
+				}),
+				"recurrence": itemField(recurrenceType, func(it item) interface{} {
+					if it.item.Recurrence == nil {
+						return nil
+					}
+					return it.item.Recurrence
+				}),
+				"recurrenceOf": &graphql.Field{
+					Type:        itemType,
+					Description: "The recurring item this item is an occurrence of, if any",
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						it := p.Source.(item)
+						if source, ok := findItem(it.roadmap, it.item.RecurrenceOf); ok && it.item.RecurrenceOf != "" {
+							return source, nil
+						}
+						return nil, nil
+					},
+				},
+				"milestone": &graphql.Field{
+					Type:        milestoneType,
+					Description: "The milestone the item delivers, if any",
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						it := p.Source.(item)
+						milestones := it.roadmap.Roadmap.Milestones
+						for i := range milestones {
+							if it.item.Milestone != "" && milestones[i].ID == it.item.Milestone {
+								return &milestones[i], nil
+							}
+						}
+						return nil, nil
+					},
+				},
+				"objective": &graphql.Field{
+					Type:        objectiveType,
+					Description: "The objective the item contributes to, if any",
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						it := p.Source.(item)
+						objectives := it.roadmap.Roadmap.Objectives
+						for i := range objectives {
+							if it.item.Objective != "" && objectives[i].ID == it.item.Objective {
+								return &objectives[i], nil
+							}
+						}
+						return nil, nil
+					},
+				},
+				"dependencies": &graphql.Field{
+					Type:        nonNullList(itemType),
+					Description: "Items in the same roadmap this item depends on",
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						it := p.Source.(item)
+						deps := []item{}
+						for _, id := range it.item.Dependencies {
+							if dep, ok := findItem(it.roadmap, id); ok {
+								deps = append(deps, dep)
+							}
+						}
+						return deps, nil
+					},
+				},
+				"externalDependencies": &graphql.Field{
+					Type: nonNullList(dependencyType),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						it := p.Source.(item)
+						deps := make([]externalDependency, len(it.item.ExternalDependencies))
+						for i, dep := range it.item.ExternalDependencies {
+							deps[i] = externalDependency{from: it, dep: dep}
+						}
+						return deps, nil
+					},
+				},
+				"dependents": &graphql.Field{
+					Type:        nonNullList(itemType),
+					Description: "Items in any roadmap that depend on this item",
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						l, err := loaderFrom(p.Context)
+						if err != nil {
+							return nil, err
+						}
+						it := p.Source.(item)
+						dependents := []item{}
+						for _, rm := range l.all {
+							for i := range rm.Roadmap.Items {
+								candidate := &rm.Roadmap.Items[i]
+								if dependsOn(l, rm, candidate, it) {
+									dependents = append(dependents, item{roadmap: rm, item: candidate})
+								}
+							}
+						}
+						return dependents, nil
+					},
+				},
+			}
+		}),
+	})
+
+	dependencyType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "ExternalDependency",
+		Description: "A dependency of an item on an item or milestone of another roadmap, or on its completion",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"roadmapName": dependencyField(graphql.String, func(d externalDependency) interface{} { return d.dep.RoadmapName }),
+				"roadmapId":   dependencyField(graphql.String, func(d externalDependency) interface{} { return d.dep.RoadmapID }),
+				"itemId":      dependencyField(graphql.NewNonNull(graphql.String), func(d externalDependency) interface{} { return d.dep.ItemID }),
+				"itemName":    dependencyField(graphql.String, func(d externalDependency) interface{} { return d.dep.ItemName }),
+				"milestone":   dependencyField(graphql.String, func(d externalDependency) interface{} { return d.dep.Milestone }),
+				"completion":  dependencyField(graphql.NewNonNull(graphql.Boolean), func(d externalDependency) interface{} { return d.dep.Completion }),
+				"reason":      dependencyField(graphql.String, func(d externalDependency) interface{} { return d.dep.Reason }),
+				"criticality": dependencyField(graphql.String, func(d externalDependency) interface{} { return d.dep.Criticality }),
+				"from":        dependencyField(graphql.NewNonNull(itemType), func(d externalDependency) interface{} { return d.from }),
+				"targetRoadmap": &graphql.Field{
+					Type:        roadmapType,
+					Description: "The roadmap depended on, or null if it doesn't exist",
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						l, err := loaderFrom(p.Context)
+						if err != nil {
+							return nil, err
+						}
+						if rm := l.target(p.Source.(externalDependency).dep); rm != nil {
+							return rm, nil
+						}
+						return nil, nil
+					},
+				},
+				"target": &graphql.Field{
+					Type:        itemType,
+					Description: "The item depended on, or null if it doesn't exist or the dependency is on a milestone or completion",
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						l, err := loaderFrom(p.Context)
+						if err != nil {
+							return nil, err
+						}
+						d := p.Source.(externalDependency)
+						if rm := l.target(d.dep); rm != nil {
+							if it := d.dep.TargetItem(&rm.Roadmap); it != nil {
+								return item{roadmap: rm, item: it}, nil
+							}
+						}
+						return nil, nil
+					},
+				},
+				"targetMilestone": &graphql.Field{
+					Type:        milestoneType,
+					Description: "The milestone depended on, or null if it doesn't exist or the dependency isn't on a milestone",
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						l, err := loaderFrom(p.Context)
+						if err != nil {
+							return nil, err
+						}
+						d := p.Source.(externalDependency)
+						if rm := l.target(d.dep); rm != nil && d.dep.Milestone != "" {
+							if m := findMilestone(rm, d.dep.Milestone); m != nil {
+								return m, nil
+							}
+						}
+						return nil, nil
+					},
+				},
+				"error": &graphql.Field{
+					Type:        graphql.String,
+					Description: "Why the dependency can't be resolved, or null if it is valid",
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						l, err := loaderFrom(p.Context)
+						if err != nil {
+							return nil, err
+						}
+						if msg := validate(l, p.Source.(externalDependency).dep); msg != "" {
+							return msg, nil
+						}
+						return nil, nil
+					},
+				},
+				"valid": &graphql.Field{
+					Type: graphql.NewNonNull(graphql.Boolean),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						l, err := loaderFrom(p.Context)
+						if err != nil {
+							return nil, err
+						}
+						return validate(l, p.Source.(externalDependency).dep) == "", nil
+					},
+				},
+			}
+		}),
+	})
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"roadmaps": &graphql.Field{
+				Type:        nonNullList(roadmapType),
+				Description: "Roadmaps matching every given filter",
+				Args: graphql.FieldConfigArgument{
+					"serviceLine":     {Type: graphql.String},
+					"owner":           {Type: graphql.String, Description: "Roadmaps owned by, or with an item assigned to, this owner or team"},
+					"status":          {Type: statusEnum, Description: "Roadmaps with at least one item in this status"},
+					"confidence":      {Type: confidenceEnum, Description: "Roadmaps with at least one item of this confidence"},
+					"type":            {Type: graphql.String, Description: "Roadmaps with at least one item of this type"},
+					"health":          {Type: healthEnum, Description: "Roadmaps with this health now"},
+					"includeArchived": {Type: graphql.Boolean, DefaultValue: false, Description: "Also return archived roadmaps"},
+					"from":            {Type: graphql.String, Description: "Roadmaps with an item overlapping this date or later"},
+					"to":              {Type: graphql.String, Description: "Roadmaps with an item overlapping this date or earlier"},
+					"portfolio":       {Type: graphql.String},
+					"category":        {Type: graphql.String},
+					"tag":             {Type: graphql.String, Description: "Roadmaps with this tag, or with at least one item with it"},
+					"sort":            {Type: sortEnum, DefaultValue: storage.SortByCreatedAt},
+					"order":           {Type: orderEnum, DefaultValue: "asc"},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					filter := storage.ListFilter{}
+					filter.ServiceLine, _ = p.Args["serviceLine"].(string)
+					filter.Owner, _ = p.Args["owner"].(string)
+					filter.Status, _ = p.Args["status"].(models.RoadmapStatus)
+					filter.Confidence, _ = p.Args["confidence"].(models.Confidence)
+					filter.Type, _ = p.Args["type"].(string)
+					filter.Health, _ = p.Args["health"].(models.Health)
+					filter.ExcludeArchived = p.Args["includeArchived"] != true
+					filter.From, _ = p.Args["from"].(string)
+					filter.To, _ = p.Args["to"].(string)
+					filter.Portfolio, _ = p.Args["portfolio"].(string)
+					filter.Category, _ = p.Args["category"].(string)
+					if tag, ok := p.Args["tag"].(string); ok {
+						filter.Tag = models.NormalizeTag(tag)
+					}
+					if err := filter.Validate(); err != nil {
+						return nil, fmt.Errorf("invalid filter: %w", err)
+					}
+
+					roadmaps, err := store.List(filter)
+					if err != nil {
+						return nil, err
+					}
+					if err := storage.SortRoadmaps(roadmaps, p.Args["sort"].(string), p.Args["order"] == "desc"); err != nil {
+						return nil, err
+					}
+					if roadmaps == nil {
+						roadmaps = []*models.StoredRoadmap{}
+					}
+					return roadmaps, nil
+				},
+			},
+			"roadmap": &graphql.Field{
+				Type: roadmapType,
+				Args: graphql.FieldConfigArgument{
+					"id": {Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					l, err := loaderFrom(p.Context)
+					if err != nil {
+						return nil, err
+					}
+					if rm, ok := l.byID[p.Args["id"].(string)]; ok {
+						return rm, nil
+					}
+					return nil, nil
+				},
+			},
+			"externalDependencies": &graphql.Field{
+				Type:        nonNullList(dependencyType),
+				Description: "External dependencies across all roadmaps",
+				Args: graphql.FieldConfigArgument{
+					"valid":       {Type: graphql.Boolean, Description: "Only valid (true) or broken (false) dependencies"},
+					"criticality": {Type: graphql.String},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					l, err := loaderFrom(p.Context)
+					if err != nil {
+						return nil, err
+					}
+					valid, filterValid := p.Args["valid"].(bool)
+					criticality, _ := p.Args["criticality"].(string)
+
+					deps := []externalDependency{}
+					for _, rm := range l.all {
+						for _, d := range dependenciesOf(rm) {
+							if filterValid && (validate(l, d.dep) == "") != valid {
+								continue
+							}
+							if criticality != "" && d.dep.Criticality != criticality {
+								continue
+							}
+							deps = append(deps, d)
+						}
+					}
+					return deps, nil
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query})
+}
+
+// roadmapField builds a scalar field of Roadmap
+func roadmapField(t graphql.Output, get func(*models.StoredRoadmap) interface{}) *graphql.Field {
+	return &graphql.Field{
+		Type: t,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return get(p.Source.(*models.StoredRoadmap)), nil
+		},
+	}
+}
+
+// itemField builds a field of Item
+func itemField(t graphql.Output, get func(item) interface{}) *graphql.Field {
+	return &graphql.Field{
+		Type: t,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return get(p.Source.(item)), nil
+		},
+	}
+}
+
+// milestoneField builds a field of Milestone
+func milestoneField(t graphql.Output, get func(*models.Milestone) interface{}) *graphql.Field {
+	return &graphql.Field{
+		Type: t,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return get(p.Source.(*models.Milestone)), nil
+		},
+	}
+}
+
+// objectiveField builds a field of Objective
+func objectiveField(t graphql.Output, get func(*models.Objective) interface{}) *graphql.Field {
+	return &graphql.Field{
+		Type: t,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return get(p.Source.(*models.Objective)), nil
+		},
+	}
+}
+
+// dependencyField builds a field of ExternalDependency
+func dependencyField(t graphql.Output, get func(externalDependency) interface{}) *graphql.Field {
+	return &graphql.Field{
+		Type: t,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return get(p.Source.(externalDependency)), nil
+		},
+	}
+}
+
+// findItem looks up an item of a roadmap by ID
+func findItem(rm *models.StoredRoadmap, id string) (item, bool) {
+	for i := range rm.Roadmap.Items {
+		if rm.Roadmap.Items[i].ID == id {
+			return item{roadmap: rm, item: &rm.Roadmap.Items[i]}, true
+		}
+	}
+	return item{}, false
+}
+
+// findMilestone looks up a milestone of a roadmap by ID, or returns nil
+func findMilestone(rm *models.StoredRoadmap, id string) *models.Milestone {
+	for i := range rm.Roadmap.Milestones {
+		if rm.Roadmap.Milestones[i].ID == id {
+			return &rm.Roadmap.Milestones[i]
+		}
+	}
+	return nil
+}
+
+// dependenciesOf lists the external dependencies of every item in a roadmap
+func dependenciesOf(rm *models.StoredRoadmap) []externalDependency {
+	deps := []externalDependency{}
+	for i := range rm.Roadmap.Items {
+		it := item{roadmap: rm, item: &rm.Roadmap.Items[i]}
+		for _, dep := range it.item.ExternalDependencies {
+			deps = append(deps, externalDependency{from: it, dep: dep})
+		}
+	}
+	return deps
+}
+
+// dependsOn reports whether candidate, an item of rm, depends on target
+// through an internal or external dependency
+func dependsOn(l *loader, rm *models.StoredRoadmap, candidate *models.RoadmapItem, target item) bool {
+	if rm.ID == target.roadmap.ID {
+		for _, id := range candidate.Dependencies {
+			if id == target.item.ID {
+				return true
+			}
+		}
+	}
+	for _, dep := range candidate.ExternalDependencies {
+		if rm := l.target(dep); rm != nil && rm.ID == target.roadmap.ID {
+			if it := dep.TargetItem(&rm.Roadmap); it != nil && it.ID == target.item.ID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// validate returns why an external dependency can't be resolved, matching
+// the messages of dependency validation, or "" if it is valid
+func validate(l *loader, dep models.ExternalDependency) string {
+	rm := l.target(dep)
+	if rm == nil {
+		if dep.RoadmapID != "" {
+			return fmt.Sprintf("roadmap with ID '%s' not found", dep.RoadmapID)
+		}
+		return fmt.Sprintf("roadmap named '%s' not found", dep.RoadmapName)
+	}
+	switch {
+	case dep.Milestone != "":
+		if findMilestone(rm, dep.Milestone) == nil {
+			return fmt.Sprintf("milestone '%s' not found in roadmap '%s'", dep.Milestone, rm.Roadmap.Name)
+		}
+	case dep.Completion:
+	case dep.ItemName != "":
+		if dep.TargetItem(&rm.Roadmap) == nil {
+			return fmt.Sprintf("no single item named '%s' in roadmap '%s'", dep.ItemName, rm.Roadmap.Name)
+		}
+	default:
+		if _, ok := findItem(rm, dep.ItemID); !ok {
+			return fmt.Sprintf("item '%s' not found in roadmap '%s'", dep.ItemID, rm.Roadmap.Name)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,68 @@
+package grpcapi
+
+import (
+	"context"
+	"roadmap-visualizer/internal/auth"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// AuthInterceptors require a valid bearer token in the authorization
+// metadata of every call and attach the caller's identity to its context
+func AuthInterceptors(authenticator *auth.Authenticator) (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor) {
+	unary := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := authenticate(ctx, authenticator)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+
+	stream := func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(ss.Context(), authenticator)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+	}
+
+	return unary, stream
+}
+
+// authenticate verifies the bearer token of an incoming call
+func authenticate(ctx context.Context, authenticator *auth.Authenticator) (context.Context, error) {
+	var header string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("authorization"); len(values) > 0 {
+			header = values[0]
+		}
+	}
+
+	identity, err := authenticator.Verify(ctx, auth.BearerToken(header))
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid token: %v", err)
+	}
+	return auth.NewContext(ctx, identity), nil
+}
+
+// authenticatedStream overrides the context of a server stream
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+// callAuthor returns the author to record for a call: the authenticated
+// caller if there is one, otherwise the author named in the request
+func callAuthor(ctx context.Context, requested string) string {
+	if identity, ok := auth.FromContext(ctx); ok {
+		return identity.Author()
+	}
+	return requested
+}
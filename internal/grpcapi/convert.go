@@ -0,0 +1,109 @@
+package grpcapi
+
+import (
+	"roadmap-visualizer/internal/grpcapi/roadmapv1"
+	"roadmap-visualizer/internal/models"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var statusToProto = map[models.RoadmapStatus]roadmapv1.Status{
+	models.StatusPlanned:    roadmapv1.Status_STATUS_PLANNED,
+	models.StatusInProgress: roadmapv1.Status_STATUS_IN_PROGRESS,
+	models.StatusCompleted:  roadmapv1.Status_STATUS_COMPLETED,
+	models.StatusBlocked:    roadmapv1.Status_STATUS_BLOCKED,
+}
+
+// statusFromProto converts a proto status; unknown values become an empty
+// status, which fails validation
+func statusFromProto(s roadmapv1.Status) models.RoadmapStatus {
+	for status, p := range statusToProto {
+		if p == s {
+			return status
+		}
+	}
+	return ""
+}
+
+func storedToProto(stored *models.StoredRoadmap) *roadmapv1.StoredRoadmap {
+	return &roadmapv1.StoredRoadmap{
+		Id:        stored.ID,
+		Roadmap:   roadmapToProto(&stored.Roadmap),
+		CreatedAt: timestamppb.New(stored.CreatedAt),
+		UpdatedAt: timestamppb.New(stored.UpdatedAt),
+		FileName:  stored.FileName,
+		Revision:  int32(stored.CurrentRevision()),
+		UpdatedBy: stored.UpdatedBy,
+		CreatedBy: stored.CreatedBy,
+	}
+}
+
+func roadmapToProto(rm *models.Roadmap) *roadmapv1.Roadmap {
+	items := make([]*roadmapv1.RoadmapItem, len(rm.Items))
+	for i, item := range rm.Items {
+		deps := make([]*roadmapv1.ExternalDependency, len(item.ExternalDependencies))
+		for j, dep := range item.ExternalDependencies {
+			deps[j] = &roadmapv1.ExternalDependency{
+				RoadmapName: dep.RoadmapName,
+				RoadmapId:   dep.RoadmapID,
+				ItemId:      dep.ItemID,
+				Reason:      dep.Reason,
+				Criticality: dep.Criticality,
+			}
+		}
+		items[i] = &roadmapv1.RoadmapItem{
+			Id:                   item.ID,
+			Name:                 item.Name,
+			Start:                item.Start,
+			End:                  item.End,
+			Status:               statusToProto[item.Status],
+			Description:          item.Description,
+			Notes:                item.Notes,
+			Dependencies:         item.Dependencies,
+			ExternalDependencies: deps,
+		}
+	}
+
+	return &roadmapv1.Roadmap{
+		Name:        rm.Name,
+		ServiceLine: rm.ServiceLine,
+		Owner:       rm.Owner,
+		Notes:       rm.Notes,
+		Items:       items,
+	}
+}
+
+func roadmapFromProto(rm *roadmapv1.Roadmap) *models.Roadmap {
+	items := make([]models.RoadmapItem, len(rm.GetItems()))
+	for i, item := range rm.GetItems() {
+		var deps []models.ExternalDependency
+		for _, dep := range item.GetExternalDependencies() {
+			deps = append(deps, models.ExternalDependency{
+				RoadmapName: dep.GetRoadmapName(),
+				RoadmapID:   dep.GetRoadmapId(),
+				ItemID:      dep.GetItemId(),
+				Reason:      dep.GetReason(),
+				Criticality: dep.GetCriticality(),
+			})
+		}
+		items[i] = models.RoadmapItem{
+			ID:                   item.GetId(),
+			Name:                 item.GetName(),
+			Start:                item.GetStart(),
+			End:                  item.GetEnd(),
+			Status:               statusFromProto(item.GetStatus()),
+			Description:          item.GetDescription(),
+			Notes:                item.GetNotes(),
+			Dependencies:         item.GetDependencies(),
+			ExternalDependencies: deps,
+		}
+	}
+
+	return &models.Roadmap{
+		Name:        rm.GetName(),
+		ServiceLine: rm.GetServiceLine(),
+		Owner:       rm.GetOwner(),
+		Notes:       rm.GetNotes(),
+		Items:       items,
+	}
+}
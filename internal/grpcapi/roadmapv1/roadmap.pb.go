@@ -0,0 +1,1336 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: roadmap/v1/roadmap.proto
+
+package roadmapv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Status int32
+
+const (
+	Status_STATUS_UNSPECIFIED Status = 0
+	Status_STATUS_PLANNED     Status = 1
+	Status_STATUS_IN_PROGRESS Status = 2
+	Status_STATUS_COMPLETED   Status = 3
+	Status_STATUS_BLOCKED     Status = 4
+)
+
+// Enum value maps for Status.
+var (
+	Status_name = map[int32]string{
+		0: "STATUS_UNSPECIFIED",
+		1: "STATUS_PLANNED",
+		2: "STATUS_IN_PROGRESS",
+		3: "STATUS_COMPLETED",
+		4: "STATUS_BLOCKED",
+	}
+	Status_value = map[string]int32{
+		"STATUS_UNSPECIFIED": 0,
+		"STATUS_PLANNED":     1,
+		"STATUS_IN_PROGRESS": 2,
+		"STATUS_COMPLETED":   3,
+		"STATUS_BLOCKED":     4,
+	}
+)
+
+func (x Status) Enum() *Status {
+	p := new(Status)
+	*p = x
+	return p
+}
+
+func (x Status) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Status) Descriptor() protoreflect.EnumDescriptor {
+	return file_roadmap_v1_roadmap_proto_enumTypes[0].Descriptor()
+}
+
+func (Status) Type() protoreflect.EnumType {
+	return &file_roadmap_v1_roadmap_proto_enumTypes[0]
+}
+
+func (x Status) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Status.Descriptor instead.
+func (Status) EnumDescriptor() ([]byte, []int) {
+	return file_roadmap_v1_roadmap_proto_rawDescGZIP(), []int{0}
+}
+
+type SortField int32
+
+const (
+	SortField_SORT_FIELD_UNSPECIFIED  SortField = 0 // created_at
+	SortField_SORT_FIELD_NAME         SortField = 1
+	SortField_SORT_FIELD_CREATED_AT   SortField = 2
+	SortField_SORT_FIELD_UPDATED_AT   SortField = 3
+	SortField_SORT_FIELD_SERVICE_LINE SortField = 4
+)
+
+// Enum value maps for SortField.
+var (
+	SortField_name = map[int32]string{
+		0: "SORT_FIELD_UNSPECIFIED",
+		1: "SORT_FIELD_NAME",
+		2: "SORT_FIELD_CREATED_AT",
+		3: "SORT_FIELD_UPDATED_AT",
+		4: "SORT_FIELD_SERVICE_LINE",
+	}
+	SortField_value = map[string]int32{
+		"SORT_FIELD_UNSPECIFIED":  0,
+		"SORT_FIELD_NAME":         1,
+		"SORT_FIELD_CREATED_AT":   2,
+		"SORT_FIELD_UPDATED_AT":   3,
+		"SORT_FIELD_SERVICE_LINE": 4,
+	}
+)
+
+func (x SortField) Enum() *SortField {
+	p := new(SortField)
+	*p = x
+	return p
+}
+
+func (x SortField) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SortField) Descriptor() protoreflect.EnumDescriptor {
+	return file_roadmap_v1_roadmap_proto_enumTypes[1].Descriptor()
+}
+
+func (SortField) Type() protoreflect.EnumType {
+	return &file_roadmap_v1_roadmap_proto_enumTypes[1]
+}
+
+func (x SortField) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SortField.Descriptor instead.
+func (SortField) EnumDescriptor() ([]byte, []int) {
+	return file_roadmap_v1_roadmap_proto_rawDescGZIP(), []int{1}
+}
+
+type EventType int32
+
+const (
+	EventType_EVENT_TYPE_UNSPECIFIED EventType = 0
+	EventType_EVENT_TYPE_CREATED     EventType = 1
+	EventType_EVENT_TYPE_UPDATED     EventType = 2
+	EventType_EVENT_TYPE_DELETED     EventType = 3
+)
+
+// Enum value maps for EventType.
+var (
+	EventType_name = map[int32]string{
+		0: "EVENT_TYPE_UNSPECIFIED",
+		1: "EVENT_TYPE_CREATED",
+		2: "EVENT_TYPE_UPDATED",
+		3: "EVENT_TYPE_DELETED",
+	}
+	EventType_value = map[string]int32{
+		"EVENT_TYPE_UNSPECIFIED": 0,
+		"EVENT_TYPE_CREATED":     1,
+		"EVENT_TYPE_UPDATED":     2,
+		"EVENT_TYPE_DELETED":     3,
+	}
+)
+
+func (x EventType) Enum() *EventType {
+	p := new(EventType)
+	*p = x
+	return p
+}
+
+func (x EventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_roadmap_v1_roadmap_proto_enumTypes[2].Descriptor()
+}
+
+func (EventType) Type() protoreflect.EnumType {
+	return &file_roadmap_v1_roadmap_proto_enumTypes[2]
+}
+
+func (x EventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EventType.Descriptor instead.
+func (EventType) EnumDescriptor() ([]byte, []int) {
+	return file_roadmap_v1_roadmap_proto_rawDescGZIP(), []int{2}
+}
+
+// ExternalDependency is a dependency on an item in another roadmap.
+type ExternalDependency struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	RoadmapName string                 `protobuf:"bytes,1,opt,name=roadmap_name,json=roadmapName,proto3" json:"roadmap_name,omitempty"`
+	RoadmapId   string                 `protobuf:"bytes,2,opt,name=roadmap_id,json=roadmapId,proto3" json:"roadmap_id,omitempty"`
+	ItemId      string                 `protobuf:"bytes,3,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Reason      string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	// One of low, medium, high, or critical.
+	Criticality   string `protobuf:"bytes,5,opt,name=criticality,proto3" json:"criticality,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExternalDependency) Reset() {
+	*x = ExternalDependency{}
+	mi := &file_roadmap_v1_roadmap_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExternalDependency) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExternalDependency) ProtoMessage() {}
+
+func (x *ExternalDependency) ProtoReflect() protoreflect.Message {
+	mi := &file_roadmap_v1_roadmap_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExternalDependency.ProtoReflect.Descriptor instead.
+func (*ExternalDependency) Descriptor() ([]byte, []int) {
+	return file_roadmap_v1_roadmap_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ExternalDependency) GetRoadmapName() string {
+	if x != nil {
+		return x.RoadmapName
+	}
+	return ""
+}
+
+func (x *ExternalDependency) GetRoadmapId() string {
+	if x != nil {
+		return x.RoadmapId
+	}
+	return ""
+}
+
+func (x *ExternalDependency) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *ExternalDependency) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ExternalDependency) GetCriticality() string {
+	if x != nil {
+		return x.Criticality
+	}
+	return ""
+}
+
+type RoadmapItem struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Dates such as 2025-Q1, 2025-06, or 2025-06-15.
+	Start       string `protobuf:"bytes,3,opt,name=start,proto3" json:"start,omitempty"`
+	End         string `protobuf:"bytes,4,opt,name=end,proto3" json:"end,omitempty"`
+	Status      Status `protobuf:"varint,5,opt,name=status,proto3,enum=roadmap.v1.Status" json:"status,omitempty"`
+	Description string `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
+	Notes       string `protobuf:"bytes,7,opt,name=notes,proto3" json:"notes,omitempty"`
+	// IDs of items in the same roadmap this item depends on.
+	Dependencies         []string              `protobuf:"bytes,8,rep,name=dependencies,proto3" json:"dependencies,omitempty"`
+	ExternalDependencies []*ExternalDependency `protobuf:"bytes,9,rep,name=external_dependencies,json=externalDependencies,proto3" json:"external_dependencies,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *RoadmapItem) Reset() {
+	*x = RoadmapItem{}
+	mi := &file_roadmap_v1_roadmap_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoadmapItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoadmapItem) ProtoMessage() {}
+
+func (x *RoadmapItem) ProtoReflect() protoreflect.Message {
+	mi := &file_roadmap_v1_roadmap_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoadmapItem.ProtoReflect.Descriptor instead.
+func (*RoadmapItem) Descriptor() ([]byte, []int) {
+	return file_roadmap_v1_roadmap_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RoadmapItem) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RoadmapItem) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RoadmapItem) GetStart() string {
+	if x != nil {
+		return x.Start
+	}
+	return ""
+}
+
+func (x *RoadmapItem) GetEnd() string {
+	if x != nil {
+		return x.End
+	}
+	return ""
+}
+
+func (x *RoadmapItem) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_STATUS_UNSPECIFIED
+}
+
+func (x *RoadmapItem) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *RoadmapItem) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+func (x *RoadmapItem) GetDependencies() []string {
+	if x != nil {
+		return x.Dependencies
+	}
+	return nil
+}
+
+func (x *RoadmapItem) GetExternalDependencies() []*ExternalDependency {
+	if x != nil {
+		return x.ExternalDependencies
+	}
+	return nil
+}
+
+type Roadmap struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	ServiceLine   string                 `protobuf:"bytes,2,opt,name=service_line,json=serviceLine,proto3" json:"service_line,omitempty"`
+	Owner         string                 `protobuf:"bytes,3,opt,name=owner,proto3" json:"owner,omitempty"`
+	Notes         string                 `protobuf:"bytes,4,opt,name=notes,proto3" json:"notes,omitempty"`
+	Items         []*RoadmapItem         `protobuf:"bytes,5,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Roadmap) Reset() {
+	*x = Roadmap{}
+	mi := &file_roadmap_v1_roadmap_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Roadmap) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Roadmap) ProtoMessage() {}
+
+func (x *Roadmap) ProtoReflect() protoreflect.Message {
+	mi := &file_roadmap_v1_roadmap_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Roadmap.ProtoReflect.Descriptor instead.
+func (*Roadmap) Descriptor() ([]byte, []int) {
+	return file_roadmap_v1_roadmap_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Roadmap) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Roadmap) GetServiceLine() string {
+	if x != nil {
+		return x.ServiceLine
+	}
+	return ""
+}
+
+func (x *Roadmap) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *Roadmap) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+func (x *Roadmap) GetItems() []*RoadmapItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// StoredRoadmap is a roadmap with its storage metadata.
+type StoredRoadmap struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Roadmap       *Roadmap               `protobuf:"bytes,2,opt,name=roadmap,proto3" json:"roadmap,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	FileName      string                 `protobuf:"bytes,5,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	Revision      int32                  `protobuf:"varint,6,opt,name=revision,proto3" json:"revision,omitempty"`
+	UpdatedBy     string                 `protobuf:"bytes,7,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,8,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StoredRoadmap) Reset() {
+	*x = StoredRoadmap{}
+	mi := &file_roadmap_v1_roadmap_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StoredRoadmap) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoredRoadmap) ProtoMessage() {}
+
+func (x *StoredRoadmap) ProtoReflect() protoreflect.Message {
+	mi := &file_roadmap_v1_roadmap_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoredRoadmap.ProtoReflect.Descriptor instead.
+func (*StoredRoadmap) Descriptor() ([]byte, []int) {
+	return file_roadmap_v1_roadmap_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *StoredRoadmap) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *StoredRoadmap) GetRoadmap() *Roadmap {
+	if x != nil {
+		return x.Roadmap
+	}
+	return nil
+}
+
+func (x *StoredRoadmap) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *StoredRoadmap) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *StoredRoadmap) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *StoredRoadmap) GetRevision() int32 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *StoredRoadmap) GetUpdatedBy() string {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return ""
+}
+
+func (x *StoredRoadmap) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+type ListRoadmapsRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	ServiceLine string                 `protobuf:"bytes,1,opt,name=service_line,json=serviceLine,proto3" json:"service_line,omitempty"`
+	Owner       string                 `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	// Roadmaps with at least one item in this status.
+	Status Status `protobuf:"varint,3,opt,name=status,proto3,enum=roadmap.v1.Status" json:"status,omitempty"`
+	// Roadmaps with at least one item overlapping from..to; either may be empty.
+	From          string    `protobuf:"bytes,4,opt,name=from,proto3" json:"from,omitempty"`
+	To            string    `protobuf:"bytes,5,opt,name=to,proto3" json:"to,omitempty"`
+	Sort          SortField `protobuf:"varint,6,opt,name=sort,proto3,enum=roadmap.v1.SortField" json:"sort,omitempty"`
+	Descending    bool      `protobuf:"varint,7,opt,name=descending,proto3" json:"descending,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRoadmapsRequest) Reset() {
+	*x = ListRoadmapsRequest{}
+	mi := &file_roadmap_v1_roadmap_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRoadmapsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRoadmapsRequest) ProtoMessage() {}
+
+func (x *ListRoadmapsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_roadmap_v1_roadmap_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRoadmapsRequest.ProtoReflect.Descriptor instead.
+func (*ListRoadmapsRequest) Descriptor() ([]byte, []int) {
+	return file_roadmap_v1_roadmap_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListRoadmapsRequest) GetServiceLine() string {
+	if x != nil {
+		return x.ServiceLine
+	}
+	return ""
+}
+
+func (x *ListRoadmapsRequest) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *ListRoadmapsRequest) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_STATUS_UNSPECIFIED
+}
+
+func (x *ListRoadmapsRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *ListRoadmapsRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *ListRoadmapsRequest) GetSort() SortField {
+	if x != nil {
+		return x.Sort
+	}
+	return SortField_SORT_FIELD_UNSPECIFIED
+}
+
+func (x *ListRoadmapsRequest) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+type ListRoadmapsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Roadmaps      []*StoredRoadmap       `protobuf:"bytes,1,rep,name=roadmaps,proto3" json:"roadmaps,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRoadmapsResponse) Reset() {
+	*x = ListRoadmapsResponse{}
+	mi := &file_roadmap_v1_roadmap_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRoadmapsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRoadmapsResponse) ProtoMessage() {}
+
+func (x *ListRoadmapsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_roadmap_v1_roadmap_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRoadmapsResponse.ProtoReflect.Descriptor instead.
+func (*ListRoadmapsResponse) Descriptor() ([]byte, []int) {
+	return file_roadmap_v1_roadmap_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListRoadmapsResponse) GetRoadmaps() []*StoredRoadmap {
+	if x != nil {
+		return x.Roadmaps
+	}
+	return nil
+}
+
+type GetRoadmapRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRoadmapRequest) Reset() {
+	*x = GetRoadmapRequest{}
+	mi := &file_roadmap_v1_roadmap_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRoadmapRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRoadmapRequest) ProtoMessage() {}
+
+func (x *GetRoadmapRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_roadmap_v1_roadmap_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRoadmapRequest.ProtoReflect.Descriptor instead.
+func (*GetRoadmapRequest) Descriptor() ([]byte, []int) {
+	return file_roadmap_v1_roadmap_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetRoadmapRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetRoadmapResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Roadmap       *StoredRoadmap         `protobuf:"bytes,1,opt,name=roadmap,proto3" json:"roadmap,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRoadmapResponse) Reset() {
+	*x = GetRoadmapResponse{}
+	mi := &file_roadmap_v1_roadmap_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRoadmapResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRoadmapResponse) ProtoMessage() {}
+
+func (x *GetRoadmapResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_roadmap_v1_roadmap_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRoadmapResponse.ProtoReflect.Descriptor instead.
+func (*GetRoadmapResponse) Descriptor() ([]byte, []int) {
+	return file_roadmap_v1_roadmap_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetRoadmapResponse) GetRoadmap() *StoredRoadmap {
+	if x != nil {
+		return x.Roadmap
+	}
+	return nil
+}
+
+type CreateRoadmapRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Source:
+	//
+	//	*CreateRoadmapRequest_Roadmap
+	//	*CreateRoadmapRequest_Yaml
+	Source   isCreateRoadmapRequest_Source `protobuf_oneof:"source"`
+	FileName string                        `protobuf:"bytes,3,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	// Recorded as the author of the first revision. Ignored when the server
+	// requires authentication; the caller's identity is recorded instead.
+	Author        string `protobuf:"bytes,4,opt,name=author,proto3" json:"author,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRoadmapRequest) Reset() {
+	*x = CreateRoadmapRequest{}
+	mi := &file_roadmap_v1_roadmap_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRoadmapRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRoadmapRequest) ProtoMessage() {}
+
+func (x *CreateRoadmapRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_roadmap_v1_roadmap_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRoadmapRequest.ProtoReflect.Descriptor instead.
+func (*CreateRoadmapRequest) Descriptor() ([]byte, []int) {
+	return file_roadmap_v1_roadmap_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CreateRoadmapRequest) GetSource() isCreateRoadmapRequest_Source {
+	if x != nil {
+		return x.Source
+	}
+	return nil
+}
+
+func (x *CreateRoadmapRequest) GetRoadmap() *Roadmap {
+	if x != nil {
+		if x, ok := x.Source.(*CreateRoadmapRequest_Roadmap); ok {
+			return x.Roadmap
+		}
+	}
+	return nil
+}
+
+func (x *CreateRoadmapRequest) GetYaml() []byte {
+	if x != nil {
+		if x, ok := x.Source.(*CreateRoadmapRequest_Yaml); ok {
+			return x.Yaml
+		}
+	}
+	return nil
+}
+
+func (x *CreateRoadmapRequest) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *CreateRoadmapRequest) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+type isCreateRoadmapRequest_Source interface {
+	isCreateRoadmapRequest_Source()
+}
+
+type CreateRoadmapRequest_Roadmap struct {
+	Roadmap *Roadmap `protobuf:"bytes,1,opt,name=roadmap,proto3,oneof"`
+}
+
+type CreateRoadmapRequest_Yaml struct {
+	// A roadmap YAML document, as accepted by POST /api/v1/roadmaps.
+	Yaml []byte `protobuf:"bytes,2,opt,name=yaml,proto3,oneof"`
+}
+
+func (*CreateRoadmapRequest_Roadmap) isCreateRoadmapRequest_Source() {}
+
+func (*CreateRoadmapRequest_Yaml) isCreateRoadmapRequest_Source() {}
+
+type CreateRoadmapResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Roadmap       *StoredRoadmap         `protobuf:"bytes,1,opt,name=roadmap,proto3" json:"roadmap,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRoadmapResponse) Reset() {
+	*x = CreateRoadmapResponse{}
+	mi := &file_roadmap_v1_roadmap_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRoadmapResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRoadmapResponse) ProtoMessage() {}
+
+func (x *CreateRoadmapResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_roadmap_v1_roadmap_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRoadmapResponse.ProtoReflect.Descriptor instead.
+func (*CreateRoadmapResponse) Descriptor() ([]byte, []int) {
+	return file_roadmap_v1_roadmap_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CreateRoadmapResponse) GetRoadmap() *StoredRoadmap {
+	if x != nil {
+		return x.Roadmap
+	}
+	return nil
+}
+
+type DeleteRoadmapRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// When set, the delete fails with FAILED_PRECONDITION unless the roadmap
+	// is still at this revision.
+	IfRevision int32 `protobuf:"varint,2,opt,name=if_revision,json=ifRevision,proto3" json:"if_revision,omitempty"`
+	Permanent  bool  `protobuf:"varint,3,opt,name=permanent,proto3" json:"permanent,omitempty"`
+	// Delete the roadmap even though items in other roadmaps depend on it,
+	// leaving their dependencies unresolved.
+	Force         bool `protobuf:"varint,4,opt,name=force,proto3" json:"force,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRoadmapRequest) Reset() {
+	*x = DeleteRoadmapRequest{}
+	mi := &file_roadmap_v1_roadmap_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRoadmapRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRoadmapRequest) ProtoMessage() {}
+
+func (x *DeleteRoadmapRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_roadmap_v1_roadmap_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRoadmapRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRoadmapRequest) Descriptor() ([]byte, []int) {
+	return file_roadmap_v1_roadmap_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DeleteRoadmapRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeleteRoadmapRequest) GetIfRevision() int32 {
+	if x != nil {
+		return x.IfRevision
+	}
+	return 0
+}
+
+func (x *DeleteRoadmapRequest) GetPermanent() bool {
+	if x != nil {
+		return x.Permanent
+	}
+	return false
+}
+
+func (x *DeleteRoadmapRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+type DeleteRoadmapResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRoadmapResponse) Reset() {
+	*x = DeleteRoadmapResponse{}
+	mi := &file_roadmap_v1_roadmap_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRoadmapResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRoadmapResponse) ProtoMessage() {}
+
+func (x *DeleteRoadmapResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_roadmap_v1_roadmap_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRoadmapResponse.ProtoReflect.Descriptor instead.
+func (*DeleteRoadmapResponse) Descriptor() ([]byte, []int) {
+	return file_roadmap_v1_roadmap_proto_rawDescGZIP(), []int{11}
+}
+
+type WatchRoadmapsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Send an EVENT_TYPE_CREATED event for every existing roadmap before any
+	// changes.
+	IncludeExisting bool `protobuf:"varint,1,opt,name=include_existing,json=includeExisting,proto3" json:"include_existing,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *WatchRoadmapsRequest) Reset() {
+	*x = WatchRoadmapsRequest{}
+	mi := &file_roadmap_v1_roadmap_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchRoadmapsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchRoadmapsRequest) ProtoMessage() {}
+
+func (x *WatchRoadmapsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_roadmap_v1_roadmap_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchRoadmapsRequest.ProtoReflect.Descriptor instead.
+func (*WatchRoadmapsRequest) Descriptor() ([]byte, []int) {
+	return file_roadmap_v1_roadmap_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *WatchRoadmapsRequest) GetIncludeExisting() bool {
+	if x != nil {
+		return x.IncludeExisting
+	}
+	return false
+}
+
+// WatchRoadmapsResponse is one change to a roadmap.
+type WatchRoadmapsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Type  EventType              `protobuf:"varint,1,opt,name=type,proto3,enum=roadmap.v1.EventType" json:"type,omitempty"`
+	Id    string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	// The roadmap after the change; unset for EVENT_TYPE_DELETED.
+	Roadmap       *StoredRoadmap `protobuf:"bytes,3,opt,name=roadmap,proto3" json:"roadmap,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchRoadmapsResponse) Reset() {
+	*x = WatchRoadmapsResponse{}
+	mi := &file_roadmap_v1_roadmap_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchRoadmapsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchRoadmapsResponse) ProtoMessage() {}
+
+func (x *WatchRoadmapsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_roadmap_v1_roadmap_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchRoadmapsResponse.ProtoReflect.Descriptor instead.
+func (*WatchRoadmapsResponse) Descriptor() ([]byte, []int) {
+	return file_roadmap_v1_roadmap_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *WatchRoadmapsResponse) GetType() EventType {
+	if x != nil {
+		return x.Type
+	}
+	return EventType_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *WatchRoadmapsResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *WatchRoadmapsResponse) GetRoadmap() *StoredRoadmap {
+	if x != nil {
+		return x.Roadmap
+	}
+	return nil
+}
+
+var File_roadmap_v1_roadmap_proto protoreflect.FileDescriptor
+
+const file_roadmap_v1_roadmap_proto_rawDesc = "" +
+	"\n" +
+	"\x18roadmap/v1/roadmap.proto\x12\n" +
+	"roadmap.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa9\x01\n" +
+	"\x12ExternalDependency\x12!\n" +
+	"\froadmap_name\x18\x01 \x01(\tR\vroadmapName\x12\x1d\n" +
+	"\n" +
+	"roadmap_id\x18\x02 \x01(\tR\troadmapId\x12\x17\n" +
+	"\aitem_id\x18\x03 \x01(\tR\x06itemId\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12 \n" +
+	"\vcriticality\x18\x05 \x01(\tR\vcriticality\"\xb6\x02\n" +
+	"\vRoadmapItem\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05start\x18\x03 \x01(\tR\x05start\x12\x10\n" +
+	"\x03end\x18\x04 \x01(\tR\x03end\x12*\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x12.roadmap.v1.StatusR\x06status\x12 \n" +
+	"\vdescription\x18\x06 \x01(\tR\vdescription\x12\x14\n" +
+	"\x05notes\x18\a \x01(\tR\x05notes\x12\"\n" +
+	"\fdependencies\x18\b \x03(\tR\fdependencies\x12S\n" +
+	"\x15external_dependencies\x18\t \x03(\v2\x1e.roadmap.v1.ExternalDependencyR\x14externalDependencies\"\x9b\x01\n" +
+	"\aRoadmap\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12!\n" +
+	"\fservice_line\x18\x02 \x01(\tR\vserviceLine\x12\x14\n" +
+	"\x05owner\x18\x03 \x01(\tR\x05owner\x12\x14\n" +
+	"\x05notes\x18\x04 \x01(\tR\x05notes\x12-\n" +
+	"\x05items\x18\x05 \x03(\v2\x17.roadmap.v1.RoadmapItemR\x05items\"\xbb\x02\n" +
+	"\rStoredRoadmap\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12-\n" +
+	"\aroadmap\x18\x02 \x01(\v2\x13.roadmap.v1.RoadmapR\aroadmap\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1b\n" +
+	"\tfile_name\x18\x05 \x01(\tR\bfileName\x12\x1a\n" +
+	"\brevision\x18\x06 \x01(\x05R\brevision\x12\x1d\n" +
+	"\n" +
+	"updated_by\x18\a \x01(\tR\tupdatedBy\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\b \x01(\tR\tcreatedBy\"\xe9\x01\n" +
+	"\x13ListRoadmapsRequest\x12!\n" +
+	"\fservice_line\x18\x01 \x01(\tR\vserviceLine\x12\x14\n" +
+	"\x05owner\x18\x02 \x01(\tR\x05owner\x12*\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x12.roadmap.v1.StatusR\x06status\x12\x12\n" +
+	"\x04from\x18\x04 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x05 \x01(\tR\x02to\x12)\n" +
+	"\x04sort\x18\x06 \x01(\x0e2\x15.roadmap.v1.SortFieldR\x04sort\x12\x1e\n" +
+	"\n" +
+	"descending\x18\a \x01(\bR\n" +
+	"descending\"M\n" +
+	"\x14ListRoadmapsResponse\x125\n" +
+	"\broadmaps\x18\x01 \x03(\v2\x19.roadmap.v1.StoredRoadmapR\broadmaps\"#\n" +
+	"\x11GetRoadmapRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"I\n" +
+	"\x12GetRoadmapResponse\x123\n" +
+	"\aroadmap\x18\x01 \x01(\v2\x19.roadmap.v1.StoredRoadmapR\aroadmap\"\x9c\x01\n" +
+	"\x14CreateRoadmapRequest\x12/\n" +
+	"\aroadmap\x18\x01 \x01(\v2\x13.roadmap.v1.RoadmapH\x00R\aroadmap\x12\x14\n" +
+	"\x04yaml\x18\x02 \x01(\fH\x00R\x04yaml\x12\x1b\n" +
+	"\tfile_name\x18\x03 \x01(\tR\bfileName\x12\x16\n" +
+	"\x06author\x18\x04 \x01(\tR\x06authorB\b\n" +
+	"\x06source\"L\n" +
+	"\x15CreateRoadmapResponse\x123\n" +
+	"\aroadmap\x18\x01 \x01(\v2\x19.roadmap.v1.StoredRoadmapR\aroadmap\"{\n" +
+	"\x14DeleteRoadmapRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vif_revision\x18\x02 \x01(\x05R\n" +
+	"ifRevision\x12\x1c\n" +
+	"\tpermanent\x18\x03 \x01(\bR\tpermanent\x12\x14\n" +
+	"\x05force\x18\x04 \x01(\bR\x05force\"\x17\n" +
+	"\x15DeleteRoadmapResponse\"A\n" +
+	"\x14WatchRoadmapsRequest\x12)\n" +
+	"\x10include_existing\x18\x01 \x01(\bR\x0fincludeExisting\"\x87\x01\n" +
+	"\x15WatchRoadmapsResponse\x12)\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x15.roadmap.v1.EventTypeR\x04type\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x123\n" +
+	"\aroadmap\x18\x03 \x01(\v2\x19.roadmap.v1.StoredRoadmapR\aroadmap*v\n" +
+	"\x06Status\x12\x16\n" +
+	"\x12STATUS_UNSPECIFIED\x10\x00\x12\x12\n" +
+	"\x0eSTATUS_PLANNED\x10\x01\x12\x16\n" +
+	"\x12STATUS_IN_PROGRESS\x10\x02\x12\x14\n" +
+	"\x10STATUS_COMPLETED\x10\x03\x12\x12\n" +
+	"\x0eSTATUS_BLOCKED\x10\x04*\x8f\x01\n" +
+	"\tSortField\x12\x1a\n" +
+	"\x16SORT_FIELD_UNSPECIFIED\x10\x00\x12\x13\n" +
+	"\x0fSORT_FIELD_NAME\x10\x01\x12\x19\n" +
+	"\x15SORT_FIELD_CREATED_AT\x10\x02\x12\x19\n" +
+	"\x15SORT_FIELD_UPDATED_AT\x10\x03\x12\x1b\n" +
+	"\x17SORT_FIELD_SERVICE_LINE\x10\x04*o\n" +
+	"\tEventType\x12\x1a\n" +
+	"\x16EVENT_TYPE_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12EVENT_TYPE_CREATED\x10\x01\x12\x16\n" +
+	"\x12EVENT_TYPE_UPDATED\x10\x02\x12\x16\n" +
+	"\x12EVENT_TYPE_DELETED\x10\x032\xb4\x03\n" +
+	"\x0eRoadmapService\x12Q\n" +
+	"\fListRoadmaps\x12\x1f.roadmap.v1.ListRoadmapsRequest\x1a .roadmap.v1.ListRoadmapsResponse\x12K\n" +
+	"\n" +
+	"GetRoadmap\x12\x1d.roadmap.v1.GetRoadmapRequest\x1a\x1e.roadmap.v1.GetRoadmapResponse\x12T\n" +
+	"\rCreateRoadmap\x12 .roadmap.v1.CreateRoadmapRequest\x1a!.roadmap.v1.CreateRoadmapResponse\x12T\n" +
+	"\rDeleteRoadmap\x12 .roadmap.v1.DeleteRoadmapRequest\x1a!.roadmap.v1.DeleteRoadmapResponse\x12V\n" +
+	"\rWatchRoadmaps\x12 .roadmap.v1.WatchRoadmapsRequest\x1a!.roadmap.v1.WatchRoadmapsResponse0\x01B9Z7roadmap-visualizer/internal/grpcapi/roadmapv1;roadmapv1b\x06proto3"
+
+var (
+	file_roadmap_v1_roadmap_proto_rawDescOnce sync.Once
+	file_roadmap_v1_roadmap_proto_rawDescData []byte
+)
+
+func file_roadmap_v1_roadmap_proto_rawDescGZIP() []byte {
+	file_roadmap_v1_roadmap_proto_rawDescOnce.Do(func() {
+		file_roadmap_v1_roadmap_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_roadmap_v1_roadmap_proto_rawDesc), len(file_roadmap_v1_roadmap_proto_rawDesc)))
+	})
+	return file_roadmap_v1_roadmap_proto_rawDescData
+}
+
+var file_roadmap_v1_roadmap_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_roadmap_v1_roadmap_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_roadmap_v1_roadmap_proto_goTypes = []any{
+	(Status)(0),                   // 0: roadmap.v1.Status
+	(SortField)(0),                // 1: roadmap.v1.SortField
+	(EventType)(0),                // 2: roadmap.v1.EventType
+	(*ExternalDependency)(nil),    // 3: roadmap.v1.ExternalDependency
+	(*RoadmapItem)(nil),           // 4: roadmap.v1.RoadmapItem
+	(*Roadmap)(nil),               // 5: roadmap.v1.Roadmap
+	(*StoredRoadmap)(nil),         // 6: roadmap.v1.StoredRoadmap
+	(*ListRoadmapsRequest)(nil),   // 7: roadmap.v1.ListRoadmapsRequest
+	(*ListRoadmapsResponse)(nil),  // 8: roadmap.v1.ListRoadmapsResponse
+	(*GetRoadmapRequest)(nil),     // 9: roadmap.v1.GetRoadmapRequest
+	(*GetRoadmapResponse)(nil),    // 10: roadmap.v1.GetRoadmapResponse
+	(*CreateRoadmapRequest)(nil),  // 11: roadmap.v1.CreateRoadmapRequest
+	(*CreateRoadmapResponse)(nil), // 12: roadmap.v1.CreateRoadmapResponse
+	(*DeleteRoadmapRequest)(nil),  // 13: roadmap.v1.DeleteRoadmapRequest
+	(*DeleteRoadmapResponse)(nil), // 14: roadmap.v1.DeleteRoadmapResponse
+	(*WatchRoadmapsRequest)(nil),  // 15: roadmap.v1.WatchRoadmapsRequest
+	(*WatchRoadmapsResponse)(nil), // 16: roadmap.v1.WatchRoadmapsResponse
+	(*timestamppb.Timestamp)(nil), // 17: google.protobuf.Timestamp
+}
+var file_roadmap_v1_roadmap_proto_depIdxs = []int32{
+	0,  // 0: roadmap.v1.RoadmapItem.status:type_name -> roadmap.v1.Status
+	3,  // 1: roadmap.v1.RoadmapItem.external_dependencies:type_name -> roadmap.v1.ExternalDependency
+	4,  // 2: roadmap.v1.Roadmap.items:type_name -> roadmap.v1.RoadmapItem
+	5,  // 3: roadmap.v1.StoredRoadmap.roadmap:type_name -> roadmap.v1.Roadmap
+	17, // 4: roadmap.v1.StoredRoadmap.created_at:type_name -> google.protobuf.Timestamp
+	17, // 5: roadmap.v1.StoredRoadmap.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 6: roadmap.v1.ListRoadmapsRequest.status:type_name -> roadmap.v1.Status
+	1,  // 7: roadmap.v1.ListRoadmapsRequest.sort:type_name -> roadmap.v1.SortField
+	6,  // 8: roadmap.v1.ListRoadmapsResponse.roadmaps:type_name -> roadmap.v1.StoredRoadmap
+	6,  // 9: roadmap.v1.GetRoadmapResponse.roadmap:type_name -> roadmap.v1.StoredRoadmap
+	5,  // 10: roadmap.v1.CreateRoadmapRequest.roadmap:type_name -> roadmap.v1.Roadmap
+	6,  // 11: roadmap.v1.CreateRoadmapResponse.roadmap:type_name -> roadmap.v1.StoredRoadmap
+	2,  // 12: roadmap.v1.WatchRoadmapsResponse.type:type_name -> roadmap.v1.EventType
+	6,  // 13: roadmap.v1.WatchRoadmapsResponse.roadmap:type_name -> roadmap.v1.StoredRoadmap
+	7,  // 14: roadmap.v1.RoadmapService.ListRoadmaps:input_type -> roadmap.v1.ListRoadmapsRequest
+	9,  // 15: roadmap.v1.RoadmapService.GetRoadmap:input_type -> roadmap.v1.GetRoadmapRequest
+	11, // 16: roadmap.v1.RoadmapService.CreateRoadmap:input_type -> roadmap.v1.CreateRoadmapRequest
+	13, // 17: roadmap.v1.RoadmapService.DeleteRoadmap:input_type -> roadmap.v1.DeleteRoadmapRequest
+	15, // 18: roadmap.v1.RoadmapService.WatchRoadmaps:input_type -> roadmap.v1.WatchRoadmapsRequest
+	8,  // 19: roadmap.v1.RoadmapService.ListRoadmaps:output_type -> roadmap.v1.ListRoadmapsResponse
+	10, // 20: roadmap.v1.RoadmapService.GetRoadmap:output_type -> roadmap.v1.GetRoadmapResponse
+	12, // 21: roadmap.v1.RoadmapService.CreateRoadmap:output_type -> roadmap.v1.CreateRoadmapResponse
+	14, // 22: roadmap.v1.RoadmapService.DeleteRoadmap:output_type -> roadmap.v1.DeleteRoadmapResponse
+	16, // 23: roadmap.v1.RoadmapService.WatchRoadmaps:output_type -> roadmap.v1.WatchRoadmapsResponse
+	19, // [19:24] is the sub-list for method output_type
+	14, // [14:19] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
+}
+
+func init() { file_roadmap_v1_roadmap_proto_init() }
+func file_roadmap_v1_roadmap_proto_init() {
+	if File_roadmap_v1_roadmap_proto != nil {
+		return
+	}
+	file_roadmap_v1_roadmap_proto_msgTypes[8].OneofWrappers = []any{
+		(*CreateRoadmapRequest_Roadmap)(nil),
+		(*CreateRoadmapRequest_Yaml)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_roadmap_v1_roadmap_proto_rawDesc), len(file_roadmap_v1_roadmap_proto_rawDesc)),
+			NumEnums:      3,
+			NumMessages:   14,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_roadmap_v1_roadmap_proto_goTypes,
+		DependencyIndexes: file_roadmap_v1_roadmap_proto_depIdxs,
+		EnumInfos:         file_roadmap_v1_roadmap_proto_enumTypes,
+		MessageInfos:      file_roadmap_v1_roadmap_proto_msgTypes,
+	}.Build()
+	File_roadmap_v1_roadmap_proto = out.File
+	file_roadmap_v1_roadmap_proto_goTypes = nil
+	file_roadmap_v1_roadmap_proto_depIdxs = nil
+}
@@ -0,0 +1,264 @@
+// Package grpcapi serves roadmaps over gRPC for internal services, using the
+// same storage layer as the HTTP API. The service is defined in
+// api/proto/roadmap/v1/roadmap.proto; run `buf generate` after editing it.
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"roadmap-visualizer/internal/grpcapi/roadmapv1"
+	"roadmap-visualizer/internal/models"
+	"roadmap-visualizer/internal/parser"
+	"roadmap-visualizer/internal/servicelines"
+	"roadmap-visualizer/internal/storage"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Config holds optional behavior for Server
+type Config struct {
+	// SoftDelete moves deleted roadmaps to the trash instead of removing them
+	SoftDelete bool
+	// WatchInterval is how often WatchRoadmaps checks storage for changes
+	WatchInterval time.Duration
+	// Dependents is the reverse index of external dependencies checked
+	// before a delete; without it every roadmap is loaded
+	Dependents *storage.DependentsIndex
+}
+
+// Server implements roadmapv1.RoadmapServiceServer
+type Server struct {
+	roadmapv1.UnimplementedRoadmapServiceServer
+
+	storage storage.Storage
+	config  Config
+}
+
+// NewServer creates a gRPC roadmap service backed by storage
+func NewServer(storage storage.Storage, config Config) *Server {
+	if config.WatchInterval <= 0 {
+		config.WatchInterval = 2 * time.Second
+	}
+	return &Server{
+		storage: storage,
+		config:  config,
+	}
+}
+
+// sortFields maps the proto sort enum to storage sort fields
+var sortFields = map[roadmapv1.SortField]string{
+	roadmapv1.SortField_SORT_FIELD_UNSPECIFIED:  storage.SortByCreatedAt,
+	roadmapv1.SortField_SORT_FIELD_NAME:         storage.SortByName,
+	roadmapv1.SortField_SORT_FIELD_CREATED_AT:   storage.SortByCreatedAt,
+	roadmapv1.SortField_SORT_FIELD_UPDATED_AT:   storage.SortByUpdatedAt,
+	roadmapv1.SortField_SORT_FIELD_SERVICE_LINE: storage.SortByServiceLine,
+}
+
+// ListRoadmaps returns roadmaps matching every set filter
+func (s *Server) ListRoadmaps(ctx context.Context, req *roadmapv1.ListRoadmapsRequest) (*roadmapv1.ListRoadmapsResponse, error) {
+	filter := storage.ListFilter{
+		ServiceLine: req.GetServiceLine(),
+		Owner:       req.GetOwner(),
+		From:        req.GetFrom(),
+		To:          req.GetTo(),
+	}
+	if req.GetStatus() != roadmapv1.Status_STATUS_UNSPECIFIED {
+		filter.Status = statusFromProto(req.GetStatus())
+	}
+	if err := filter.Validate(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid filter: %v", err)
+	}
+
+	sortBy, ok := sortFields[req.GetSort()]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid sort: %v", req.GetSort())
+	}
+
+	roadmaps, err := s.storage.List(filter)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list roadmaps: %v", err)
+	}
+	if err := storage.SortRoadmaps(roadmaps, sortBy, req.GetDescending()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	resp := &roadmapv1.ListRoadmapsResponse{Roadmaps: make([]*roadmapv1.StoredRoadmap, len(roadmaps))}
+	for i, rm := range roadmaps {
+		resp.Roadmaps[i] = storedToProto(rm)
+	}
+	return resp, nil
+}
+
+// GetRoadmap returns one roadmap
+func (s *Server) GetRoadmap(ctx context.Context, req *roadmapv1.GetRoadmapRequest) (*roadmapv1.GetRoadmapResponse, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	stored, err := s.storage.Get(req.GetId())
+	if err != nil {
+		return nil, storageError(err, "failed to get roadmap")
+	}
+	return &roadmapv1.GetRoadmapResponse{Roadmap: storedToProto(stored)}, nil
+}
+
+// CreateRoadmap validates and stores a new roadmap
+func (s *Server) CreateRoadmap(ctx context.Context, req *roadmapv1.CreateRoadmapRequest) (*roadmapv1.CreateRoadmapResponse, error) {
+	var roadmap *models.Roadmap
+	switch source := req.GetSource().(type) {
+	case *roadmapv1.CreateRoadmapRequest_Roadmap:
+		roadmap = roadmapFromProto(source.Roadmap)
+		roadmap.NormalizeTags()
+		if err := roadmap.Validate(); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid roadmap: %v", err)
+		}
+	case *roadmapv1.CreateRoadmapRequest_Yaml:
+		var err error
+		roadmap, err = parser.ParseRoadmap(source.Yaml)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid roadmap: %v", err)
+		}
+	default:
+		return nil, status.Error(codes.InvalidArgument, "roadmap or yaml is required")
+	}
+	roadmap.SetBaselines(nil)
+
+	fileName := req.GetFileName()
+	if fileName == "" {
+		fileName = "uploaded.yaml"
+	}
+
+	stored, err := s.storage.Create(roadmap, fileName, callAuthor(ctx, req.GetAuthor()))
+	if err != nil {
+		return nil, storageError(err, "failed to store roadmap")
+	}
+	return &roadmapv1.CreateRoadmapResponse{Roadmap: storedToProto(stored)}, nil
+}
+
+// DeleteRoadmap deletes or trashes a roadmap
+func (s *Server) DeleteRoadmap(ctx context.Context, req *roadmapv1.DeleteRoadmapRequest) (*roadmapv1.DeleteRoadmapResponse, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	if req.GetIfRevision() > 0 {
+		stored, err := s.storage.Get(req.GetId())
+		if err != nil {
+			return nil, storageError(err, "failed to get roadmap")
+		}
+		if stored.CurrentRevision() != int(req.GetIfRevision()) {
+			return nil, status.Errorf(codes.FailedPrecondition, "roadmap is at revision %d", stored.CurrentRevision())
+		}
+	}
+
+	if !req.GetForce() {
+		err := storage.CheckDelete(s.storage, s.config.Dependents, req.GetId(), nil)
+		var dependentsErr *storage.DependentsError
+		if errors.As(err, &dependentsErr) {
+			items := make([]string, len(dependentsErr.Dependents))
+			for i, dependent := range dependentsErr.Dependents {
+				items[i] = dependent.RoadmapID + "/" + dependent.ItemID
+			}
+			return nil, status.Errorf(codes.FailedPrecondition,
+				"items in other roadmaps depend on this roadmap (%s); delete with force to break their dependencies", strings.Join(items, ", "))
+		}
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to find dependents: %v", err)
+		}
+	}
+
+	var err error
+	if s.config.SoftDelete && !req.GetPermanent() {
+		err = s.storage.Trash(req.GetId())
+	} else {
+		err = s.storage.Delete(req.GetId())
+	}
+	if err != nil {
+		return nil, storageError(err, "failed to delete roadmap")
+	}
+	return &roadmapv1.DeleteRoadmapResponse{}, nil
+}
+
+// WatchRoadmaps streams changes until the client disconnects. Storage is
+// polled so changes made through any API, or to files on disk, are seen.
+func (s *Server) WatchRoadmaps(req *roadmapv1.WatchRoadmapsRequest, stream roadmapv1.RoadmapService_WatchRoadmapsServer) error {
+	known := make(map[string]*models.StoredRoadmap)
+
+	roadmaps, err := s.storage.List(storage.ListFilter{})
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to list roadmaps: %v", err)
+	}
+	for _, rm := range roadmaps {
+		known[rm.ID] = rm
+		if req.GetIncludeExisting() {
+			if err := sendEvent(stream, roadmapv1.EventType_EVENT_TYPE_CREATED, rm.ID, rm); err != nil {
+				return err
+			}
+		}
+	}
+
+	ticker := time.NewTicker(s.config.WatchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		roadmaps, err := s.storage.List(storage.ListFilter{})
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to list roadmaps: %v", err)
+		}
+
+		current := make(map[string]*models.StoredRoadmap, len(roadmaps))
+		for _, rm := range roadmaps {
+			current[rm.ID] = rm
+			previous, ok := known[rm.ID]
+			switch {
+			case !ok:
+				err = sendEvent(stream, roadmapv1.EventType_EVENT_TYPE_CREATED, rm.ID, rm)
+			case previous.CurrentRevision() != rm.CurrentRevision() || !previous.UpdatedAt.Equal(rm.UpdatedAt):
+				err = sendEvent(stream, roadmapv1.EventType_EVENT_TYPE_UPDATED, rm.ID, rm)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		for id := range known {
+			if _, ok := current[id]; !ok {
+				if err := sendEvent(stream, roadmapv1.EventType_EVENT_TYPE_DELETED, id, nil); err != nil {
+					return err
+				}
+			}
+		}
+		known = current
+	}
+}
+
+// sendEvent sends one watch event
+func sendEvent(stream roadmapv1.RoadmapService_WatchRoadmapsServer, eventType roadmapv1.EventType, id string, rm *models.StoredRoadmap) error {
+	event := &roadmapv1.WatchRoadmapsResponse{Type: eventType, Id: id}
+	if rm != nil {
+		event.Roadmap = storedToProto(rm)
+	}
+	return stream.Send(event)
+}
+
+// storageError maps a storage error to a gRPC status
+func storageError(err error, msg string) error {
+	if errors.Is(err, storage.ErrRevisionMismatch) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if errors.Is(err, servicelines.ErrUnknown) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if errors.Is(err, storage.ErrNotFound) || errors.Is(err, storage.ErrNotInTrash) || errors.Is(err, storage.ErrRevisionNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return status.Errorf(codes.Internal, "%s: %v", msg, err)
+}
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"roadmap-visualizer/internal/apierror"
+	"roadmap-visualizer/internal/storage"
+	"time"
+)
+
+// AdminHandler handles operational endpoints such as backup and restore
+type AdminHandler struct {
+	storage storage.Storage
+}
+
+// NewAdminHandler creates a new admin handler
+func NewAdminHandler(storage storage.Storage) *AdminHandler {
+	return &AdminHandler{
+		storage: storage,
+	}
+}
+
+// Backup handles POST /api/admin/backup
+// Streams a tar.gz archive of every roadmap and its revision history
+func (h *AdminHandler) Backup(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	fileName := fmt.Sprintf("roadmaps-backup-%s.tar.gz", time.Now().UTC().Format("20060102T150405Z"))
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+
+	// Headers are already sent once the archive starts streaming, so a
+	// failure part way through can only be logged
+	if err := storage.WriteBackup(h.storage, w); err != nil {
+		log.Printf("Backup failed: %v", err)
+	}
+}
+
+// Restore handles POST /api/admin/restore
+// Accepts a tar.gz archive produced by Backup. Every roadmap is validated
+// before anything is written; roadmaps with matching IDs are replaced.
+func (h *AdminHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	defer r.Body.Close()
+
+	manifest, records, err := storage.ReadBackup(r.Body)
+	if err != nil {
+		apierror.Write(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid backup: %v", err))
+		return
+	}
+
+	if err := storage.RestoreBackup(h.storage, records); err != nil {
+		apierror.Write(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to restore backup: %v", err))
+		return
+	}
+
+	ids := make([]string, len(records))
+	for i, rec := range records {
+		ids[i] = rec.Roadmap.ID
+	}
+
+	response := map[string]interface{}{
+		"backup_created_at": manifest.CreatedAt,
+		"restored":          len(records),
+		"ids":               ids,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// Reindex handles POST /api/admin/reindex
+// Registers roadmap files that were added to storage outside the API
+func (h *AdminHandler) Reindex(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	reindexer, ok := h.storage.(storage.Reindexer)
+	if !ok {
+		apierror.Write(w, r, http.StatusNotImplemented, "Reindex is not supported by this storage driver")
+		return
+	}
+
+	result, err := reindexer.Reindex()
+	if errors.Is(err, storage.ErrReindexNotSupported) {
+		apierror.Write(w, r, http.StatusNotImplemented, "Reindex is not supported by this storage driver")
+		return
+	}
+	if err != nil {
+		apierror.Write(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to reindex: %v", err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// HandleAdmin routes admin requests
+func (h *AdminHandler) HandleAdmin(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	switch r.URL.Path {
+	case "/api/admin/backup":
+		h.Backup(w, r)
+	case "/api/admin/restore":
+		h.Restore(w, r)
+	case "/api/admin/reindex":
+		h.Reindex(w, r)
+	default:
+		apierror.Write(w, r, http.StatusNotFound, "Not found")
+	}
+}
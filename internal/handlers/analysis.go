@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"roadmap-visualizer/internal/apierror"
+	"roadmap-visualizer/internal/models"
+	"roadmap-visualizer/internal/storage"
+)
+
+// impactRequest is the body of POST /api/analysis/impact
+type impactRequest struct {
+	RoadmapID string `json:"roadmap_id"`
+	ItemID    string `json:"item_id"`
+	// NewEnd is the hypothetical end of the item, in any item date format
+	NewEnd string `json:"new_end"`
+}
+
+// AnalyzeImpact handles POST /api/analysis/impact
+// Works out which items across all roadmaps could no longer start on time if
+// the item ended at new_end, grouped by roadmap and by the criticality of the
+// dependency that pushes them back. Nothing is changed.
+func (h *RoadmapHandler) AnalyzeImpact(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req impactRequest
+	body := h.limitBody(w, r)
+	defer r.Body.Close()
+	if err := json.NewDecoder(body).Decode(&req); err != nil {
+		if body.tooLarge() {
+			h.writeTooLarge(w, r)
+			return
+		}
+		apierror.Write(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid request body: %v", err))
+		return
+	}
+
+	if req.RoadmapID == "" || req.ItemID == "" || req.NewEnd == "" {
+		apierror.Write(w, r, http.StatusBadRequest, "roadmap_id, item_id, and new_end are required")
+		return
+	}
+	if _, _, err := models.ParsePeriod(req.NewEnd); err != nil {
+		apierror.Write(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid new_end: %v", err))
+		return
+	}
+
+	stored, ok := h.loadRoadmap(w, r, req.RoadmapID)
+	if !ok {
+		return
+	}
+	if itemIndex(stored.Roadmap.Items, req.ItemID) == -1 {
+		apierror.Write(w, r, http.StatusNotFound, "Item not found")
+		return
+	}
+
+	roadmaps, err := h.storage.List(storage.ListFilter{})
+	if err != nil {
+		apierror.Write(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to list roadmaps: %v", err))
+		return
+	}
+
+	analysis, err := models.AnalyzeImpact(roadmaps, stored.ID, req.ItemID, req.NewEnd)
+	if err != nil {
+		apierror.Write(w, r, http.StatusUnprocessableEntity, fmt.Sprintf("Cannot analyze impact: %v", err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(analysis)
+}
+
+// HandleAnalysis routes what-if analysis requests
+func (h *RoadmapHandler) HandleAnalysis(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	switch r.URL.Path {
+	case "/api/analysis/impact":
+		h.AnalyzeImpact(w, r)
+	default:
+		apierror.Write(w, r, http.StatusNotFound, "Not found")
+	}
+}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Version prefixes of the REST API. Handlers parse request paths in their
+// unversioned /api/... form; each version's routes rewrite requests to that
+// form, so a later version can reuse a handler as-is or mount its own.
+const (
+	apiPrefix = "/api"
+	v1Prefix  = "/api/v1"
+)
+
+// API groups the handlers that make up the REST API
+type API struct {
+	Roadmaps     *RoadmapHandler
+	Admin        *AdminHandler
+	Search       *SearchHandler
+	Webhooks     *WebhookHandler
+	OpenAPI      *OpenAPIHandler
+	ServiceLines *ServiceLineHandler
+}
+
+// LegacyConfig controls the unversioned /api/... aliases kept for clients
+// written before /api/v1
+type LegacyConfig struct {
+	// Enabled serves the unversioned paths
+	Enabled bool
+	// Sunset, if set, is announced in a Sunset header as the date the
+	// unversioned paths will be removed
+	Sunset time.Time
+}
+
+// route is a path under a version prefix and the handler serving it
+type route struct {
+	path    string
+	handler http.Handler
+}
+
+// v1Routes lists the /api/v1 endpoints, relative to the version prefix
+func (a *API) v1Routes() []route {
+	return []route{
+		{"/roadmaps", http.HandlerFunc(a.Roadmaps.HandleRoadmaps)},
+		{"/roadmaps/", http.HandlerFunc(a.Roadmaps.HandleRoadmaps)},
+		{"/dependencies/", http.HandlerFunc(a.Roadmaps.HandleDependencies)},
+		{"/trash", http.HandlerFunc(a.Roadmaps.HandleTrash)},
+		{"/trash/", http.HandlerFunc(a.Roadmaps.HandleTrash)},
+		{"/admin/", http.HandlerFunc(a.Admin.HandleAdmin)},
+		{"/search", http.HandlerFunc(a.Search.HandleSearch)},
+		{"/tags", http.HandlerFunc(a.Roadmaps.HandleTags)},
+		{"/owners", http.HandlerFunc(a.Roadmaps.HandleOwners)},
+		{"/people", http.HandlerFunc(a.Roadmaps.HandlePeople)},
+		{"/portfolios", http.HandlerFunc(a.Roadmaps.HandlePortfolios)},
+		{"/objectives", http.HandlerFunc(a.Roadmaps.HandleObjectives)},
+		{"/reports/", http.HandlerFunc(a.Roadmaps.HandleReports)},
+		{"/import/", http.HandlerFunc(a.Roadmaps.HandleImport)},
+		{"/analysis/", http.HandlerFunc(a.Roadmaps.HandleAnalysis)},
+		{"/service-lines", http.HandlerFunc(a.ServiceLines.HandleServiceLines)},
+		{"/service-lines/", http.HandlerFunc(a.ServiceLines.HandleServiceLines)},
+		{"/webhooks", http.HandlerFunc(a.Webhooks.HandleWebhooks)},
+		{"/webhooks/", http.HandlerFunc(a.Webhooks.HandleWebhooks)},
+		{"/openapi.json", a.OpenAPI},
+	}
+}
+
+// Register mounts /api/v1 on mux, and the unversioned paths as deprecated
+// aliases of v1 when legacy.Enabled is set
+func (a *API) Register(mux *http.ServeMux, legacy LegacyConfig) {
+	for _, rt := range a.v1Routes() {
+		mux.Handle(v1Prefix+rt.path, unversioned(v1Prefix, rt.handler))
+		if legacy.Enabled {
+			mux.Handle(apiPrefix+rt.path, deprecated(legacy, rt.handler))
+		}
+	}
+}
+
+// unversioned rewrites /api/vN/... requests to /api/... before calling h
+func unversioned(prefix string, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r2 := new(http.Request)
+		*r2 = *r
+		r2.URL = new(url.URL)
+		*r2.URL = *r.URL
+		r2.URL.Path = apiPrefix + strings.TrimPrefix(r.URL.Path, prefix)
+		r2.URL.RawPath = ""
+		h.ServeHTTP(w, r2)
+	})
+}
+
+// legacyKey marks the context of requests made on an unversioned alias
+type legacyKey struct{}
+
+// legacyRequest reports whether r came in on an unversioned alias, whose
+// clients predate requirements added in v1 such as If-Match
+func legacyRequest(r *http.Request) bool {
+	legacy, _ := r.Context().Value(legacyKey{}).(bool)
+	return legacy
+}
+
+// deprecated serves an unversioned path, pointing clients at its v1 successor
+func deprecated(legacy LegacyConfig, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		successor := v1Prefix + strings.TrimPrefix(r.URL.Path, apiPrefix)
+		w.Header().Set("Deprecation", "true")
+		w.Header().Set("Link", fmt.Sprintf("<%s>; rel=\"successor-version\"", successor))
+		if !legacy.Sunset.IsZero() {
+			w.Header().Set("Sunset", legacy.Sunset.UTC().Format(http.TimeFormat))
+		}
+		h.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), legacyKey{}, true)))
+	})
+}
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"roadmap-visualizer/internal/apierror"
+	"roadmap-visualizer/internal/models"
+	"roadmap-visualizer/internal/storage"
+	"strings"
+)
+
+// cloneRequest is the body of POST /api/roadmaps/{id}/clone
+type cloneRequest struct {
+	// Name of the copy; defaults to the original name with " (copy)" appended
+	Name string `json:"name"`
+	// ShiftMonths moves every item's start and end by this many months
+	ShiftMonths int `json:"shift_months"`
+}
+
+// CloneRoadmap handles POST /api/roadmaps/{id}/clone
+// Stores a copy of the roadmap under a new name, and so a new ID, optionally
+// moving all item dates, e.g. to plan the next quarter from the current one
+func (h *RoadmapHandler) CloneRoadmap(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Extract ID from path
+	id := strings.TrimPrefix(r.URL.Path, "/api/roadmaps/")
+	id = strings.TrimSuffix(id, "/clone")
+	if id == "" || strings.Contains(id, "/") {
+		apierror.Write(w, r, http.StatusBadRequest, "Invalid roadmap ID")
+		return
+	}
+
+	// The body is optional
+	var req cloneRequest
+	body := h.limitBody(w, r)
+	defer r.Body.Close()
+	if err := json.NewDecoder(body).Decode(&req); err != nil && err != io.EOF {
+		if body.tooLarge() {
+			h.writeTooLarge(w, r)
+			return
+		}
+		apierror.Write(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid request body: %v", err))
+		return
+	}
+
+	stored, ok := h.loadRoadmap(w, r, id)
+	if !ok {
+		return
+	}
+
+	clone := stored.Roadmap
+	clone.Name = strings.TrimSpace(req.Name)
+	if clone.Name == "" {
+		clone.Name = stored.Roadmap.Name + " (copy)"
+	}
+
+	clone.Stakeholders = append([]models.Stakeholder(nil), stored.Roadmap.Stakeholders...)
+	clone.Milestones = append([]models.Milestone(nil), stored.Roadmap.Milestones...)
+	clone.Objectives = append([]models.Objective(nil), stored.Roadmap.Objectives...)
+	clone.Teams = append([]models.Team(nil), stored.Roadmap.Teams...)
+
+	// Status history and baselines belong to the original
+	clone.Items = copyItems(stored.Roadmap.Items)
+	for i := range clone.Items {
+		clone.Items[i].StatusChangedAt = nil
+		clone.Items[i].StatusHistory = nil
+		clone.Items[i].Baseline = nil
+	}
+
+	if req.ShiftMonths != 0 {
+		if err := clone.ShiftDates(req.ShiftMonths); err != nil {
+			apierror.Write(w, r, http.StatusBadRequest, fmt.Sprintf("Cannot shift dates: %v", err))
+			return
+		}
+	}
+	clone.SetBaselines(nil)
+	if err := clone.Validate(); err != nil {
+		apierror.Write(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid roadmap: %v", err))
+		return
+	}
+
+	fileName := storage.Slugify(clone.Name) + ".yaml"
+	created, err := h.storage.Create(&clone, fileName, requestAuthor(r))
+	if err != nil {
+		writeStorageError(w, r, err, "store roadmap")
+		return
+	}
+
+	w.Header().Set("ETag", etag(created))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(created)
+}
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"roadmap-visualizer/internal/apierror"
+	"roadmap-visualizer/internal/models"
+	"strings"
+)
+
+// commentRequest is the body of a comment POST
+type commentRequest struct {
+	Body string `json:"body"`
+}
+
+// HandleRoadmapComments routes /api/roadmaps/{id}/comments
+// GET lists the comments on the roadmap and all its items, oldest first;
+// POST adds a comment on the roadmap as a whole
+func (h *RoadmapHandler) HandleRoadmapComments(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, "/api/roadmaps/")
+	id = strings.TrimSuffix(id, "/comments")
+	if id == "" || strings.Contains(id, "/") {
+		apierror.Write(w, r, http.StatusBadRequest, "Invalid roadmap ID")
+		return
+	}
+
+	h.handleComments(w, r, id, "")
+}
+
+// handleComments serves GET and POST on the comments of a roadmap, or of one
+// of its items when itemID is set. The item must exist in the current
+// revision.
+func (h *RoadmapHandler) handleComments(w http.ResponseWriter, r *http.Request, id, itemID string) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	if itemID != "" {
+		stored, ok := h.loadRoadmap(w, r, id)
+		if !ok {
+			return
+		}
+		if itemIndex(stored.Roadmap.Items, itemID) == -1 {
+			apierror.Write(w, r, http.StatusNotFound, "Item not found")
+			return
+		}
+	}
+
+	if r.Method == http.MethodPost {
+		h.addComment(w, r, id, itemID)
+		return
+	}
+
+	comments, err := h.storage.ListComments(id)
+	if err != nil {
+		writeStorageError(w, r, err, "list comments")
+		return
+	}
+	if itemID != "" {
+		onItem := []*models.Comment{}
+		for _, comment := range comments {
+			if comment.ItemID == itemID {
+				onItem = append(onItem, comment)
+			}
+		}
+		comments = onItem
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(comments)
+}
+
+// addComment handles POST on a comments collection
+func (h *RoadmapHandler) addComment(w http.ResponseWriter, r *http.Request, id, itemID string) {
+	var req commentRequest
+	body := h.limitBody(w, r)
+	defer r.Body.Close()
+	if err := json.NewDecoder(body).Decode(&req); err != nil {
+		if body.tooLarge() {
+			h.writeTooLarge(w, r)
+			return
+		}
+		apierror.Write(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid request body: %v", err))
+		return
+	}
+
+	comment := &models.Comment{
+		ItemID: itemID,
+		Author: requestAuthor(r),
+		Body:   req.Body,
+	}
+	if err := comment.Validate(); err != nil {
+		apierror.Write(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	created, err := h.storage.AddComment(id, comment)
+	if err != nil {
+		writeStorageError(w, r, err, "add comment")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(created)
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"roadmap-visualizer/internal/models"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// notModified sets the ETag and Last-Modified headers for a response and
+// answers 304 Not Modified if the client's copy is current. If-None-Match
+// takes precedence over If-Modified-Since, as RFC 9110 requires. It reports
+// whether the 304 was sent.
+func notModified(w http.ResponseWriter, r *http.Request, tag string, modified time.Time) bool {
+	w.Header().Set("ETag", tag)
+	if !modified.IsZero() {
+		w.Header().Set("Last-Modified", modified.UTC().Format(http.TimeFormat))
+	}
+
+	if match := r.Header.Get("If-None-Match"); match != "" {
+		if !etagMatches(match, tag, true) {
+			return false
+		}
+	} else if since := r.Header.Get("If-Modified-Since"); since != "" && !modified.IsZero() {
+		t, err := http.ParseTime(since)
+		// Last-Modified has one-second precision
+		if err != nil || modified.Truncate(time.Second).After(t) {
+			return false
+		}
+	} else {
+		return false
+	}
+
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+// computedETag identifies a response worked out from more than the roadmaps
+// it holds: the query it answers, the day, which health and effective
+// statuses move with, and the ID, revision, and update time of every roadmap
+// read to work it out
+func computedETag(r *http.Request, read []*models.StoredRoadmap, now time.Time) string {
+	sorted := append([]*models.StoredRoadmap(nil), read...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n%s\n", r.URL.Query().Encode(), now.Format("2006-01-02"))
+	for _, rm := range sorted {
+		fmt.Fprintf(h, "%s\x00%s\x00%s\n", rm.ID, strconv.Itoa(rm.CurrentRevision()), rm.UpdatedAt.UTC().Format(time.RFC3339Nano))
+	}
+	return fmt.Sprintf("%q", hex.EncodeToString(h.Sum(nil))[:32])
+}
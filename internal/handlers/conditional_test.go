@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"roadmap-visualizer/internal/storage"
+	"testing"
+	"time"
+)
+
+func TestListETagCoversOtherRoadmapsAndQuery(t *testing.T) {
+	h := NewRoadmapHandler(storage.NewMemoryStorage(), Config{})
+	createRoadmap(t, h, "Platform")
+	payments := createRoadmap(t, h, "Payments")
+
+	list := func(path, ifNoneMatch string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, path, nil)
+		if ifNoneMatch != "" {
+			r.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		w := httptest.NewRecorder()
+		h.HandleRoadmaps(w, r)
+		return w
+	}
+
+	tag := list("/api/roadmaps?service_line=Platform", "").Header().Get("ETag")
+	if w := list("/api/roadmaps?service_line=Platform", tag); w.Code != http.StatusNotModified {
+		t.Fatalf("unchanged listing: status %d, want 304", w.Code)
+	}
+	if w := list("/api/roadmaps?service_line=Platform&include_archived=true", tag); w.Code != http.StatusOK {
+		t.Errorf("other query with the same ETag: status %d, want 200", w.Code)
+	}
+
+	// A roadmap outside the listing changing can change the effective
+	// statuses in it
+	patch := `{"items": {"a": {"status": "blocked"}}}`
+	if w := serve(h, http.MethodPatch, "/api/roadmaps/"+payments, "application/merge-patch+json", patch); w.Code != http.StatusOK {
+		t.Fatalf("update: status %d: %s", w.Code, w.Body)
+	}
+	if w := list("/api/roadmaps?service_line=Platform", tag); w.Code != http.StatusOK {
+		t.Errorf("listing after another roadmap changed: status %d, want 200", w.Code)
+	}
+}
+
+func TestGetRoadmapIsNeverNotModified(t *testing.T) {
+	h := NewRoadmapHandler(storage.NewMemoryStorage(), Config{})
+	id := createRoadmap(t, h, "Platform")
+
+	w := serve(h, http.MethodGet, "/api/roadmaps/"+id, "", "")
+	r := httptest.NewRequest(http.MethodGet, "/api/roadmaps/"+id, nil)
+	r.Header.Set("If-None-Match", w.Header().Get("ETag"))
+	w = httptest.NewRecorder()
+	h.HandleRoadmaps(w, r)
+	if w.Code != http.StatusOK || w.Header().Get("ETag") == "" {
+		t.Errorf("GET with If-None-Match: status %d, ETag %q; want 200 with the revision ETag", w.Code, w.Header().Get("ETag"))
+	}
+}
+
+func TestComputedETagChangesWithTheDate(t *testing.T) {
+	h := NewRoadmapHandler(storage.NewMemoryStorage(), Config{})
+	createRoadmap(t, h, "Platform")
+	roadmaps, err := h.storage.List(storage.ListFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/api/roadmaps", nil)
+	today := time.Date(2026, 3, 31, 23, 0, 0, 0, time.UTC)
+	if computedETag(r, roadmaps, today) != computedETag(r, roadmaps, today.Add(30*time.Minute)) {
+		t.Error("ETag changed within a day")
+	}
+	// Health moves to late once an end date has passed
+	if computedETag(r, roadmaps, today) == computedETag(r, roadmaps, today.Add(2*time.Hour)) {
+		t.Error("ETag is the same on the next day, when health may differ")
+	}
+}
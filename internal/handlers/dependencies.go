@@ -0,0 +1,572 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"roadmap-visualizer/internal/apierror"
+	"roadmap-visualizer/internal/models"
+	"roadmap-visualizer/internal/storage"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DependencyCycles handles GET /api/dependencies/cycles
+// Follows the internal and external dependencies of every roadmap and returns
+// the loops among their items, each as a path of roadmap:item nodes.
+// ?cross_roadmap=true lists only the loops that span more than one roadmap.
+func (h *RoadmapHandler) DependencyCycles(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	roadmaps, err := h.storage.List(storage.ListFilter{})
+	if err != nil {
+		apierror.Write(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to list roadmaps: %v", err))
+		return
+	}
+
+	cycles := models.FindDependencyCycles(roadmaps)
+	crossRoadmap := 0
+	for _, cycle := range cycles {
+		if cycle.CrossRoadmap {
+			crossRoadmap++
+		}
+	}
+	if r.URL.Query().Get("cross_roadmap") == "true" {
+		spanning := []models.DependencyCycle{}
+		for _, cycle := range cycles {
+			if cycle.CrossRoadmap {
+				spanning = append(spanning, cycle)
+			}
+		}
+		cycles = spanning
+	}
+
+	response := map[string]interface{}{
+		"total":         len(cycles),
+		"cross_roadmap": crossRoadmap,
+		"cycles":        cycles,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// DependencyOrphans handles GET /api/dependencies/orphans
+// Lists the external dependencies that no longer resolve, usually because the
+// roadmap or item they point at was deleted, each with suggested fixes.
+// ?roadmap_id= lists only the orphans of that roadmap.
+func (h *RoadmapHandler) DependencyOrphans(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	roadmaps, err := h.storage.List(storage.ListFilter{})
+	if err != nil {
+		apierror.Write(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to list roadmaps: %v", err))
+		return
+	}
+	trashed, err := h.storage.ListTrash()
+	if err != nil {
+		apierror.Write(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to list trash: %v", err))
+		return
+	}
+
+	orphans := models.FindOrphanedDependencies(roadmaps, trashed)
+	if roadmapID := r.URL.Query().Get("roadmap_id"); roadmapID != "" {
+		filtered := orphans[:0]
+		for _, orphan := range orphans {
+			if orphan.RoadmapID == roadmapID {
+				filtered = append(filtered, orphan)
+			}
+		}
+		orphans = filtered
+	}
+
+	response := map[string]interface{}{
+		"total":   len(orphans),
+		"orphans": orphans,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// pruneRequest is the body of POST /api/dependencies/orphans/prune
+type pruneRequest struct {
+	Action     string   `json:"action"`
+	RoadmapIDs []string `json:"roadmap_ids"`
+}
+
+// PruneOrphans handles POST /api/dependencies/orphans/prune
+// Removes the orphaned external dependencies, or with "action": "annotate"
+// marks them orphaned with the reason, writing each changed roadmap as a new
+// revision. "roadmap_ids" limits the prune to those roadmaps.
+func (h *RoadmapHandler) PruneOrphans(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req pruneRequest
+	body := h.limitBody(w, r)
+	defer r.Body.Close()
+	if err := json.NewDecoder(body).Decode(&req); err != nil {
+		if body.tooLarge() {
+			h.writeTooLarge(w, r)
+			return
+		}
+		apierror.Write(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid request body: %v", err))
+		return
+	}
+	if req.Action != storage.PruneRemove && req.Action != storage.PruneAnnotate {
+		apierror.Write(w, r, http.StatusBadRequest, "Invalid action (must be remove or annotate)")
+		return
+	}
+
+	result, err := storage.PruneOrphanedDependencies(h.storage, req.Action, req.RoadmapIDs, requestAuthor(r))
+	if err != nil {
+		if errors.Is(err, storage.ErrRevisionMismatch) {
+			apierror.Write(w, r, http.StatusPreconditionFailed, "A roadmap was modified during the prune; reload and retry")
+		} else {
+			writeStorageError(w, r, err, "prune dependencies")
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// DependencyGraph handles GET /api/dependencies/graph
+// Returns the items of every roadmap as nodes and their internal and external
+// dependencies as typed edges. ?service_line= and ?roadmaps=a,b narrow the
+// roadmaps drawn; items they depend on, or that depend on them, in other
+// roadmaps are still drawn, marked out of scope. ?format=cytoscape wraps the
+// nodes and edges as Cytoscape.js elements. Archived roadmaps and items are
+// left out unless ?include_archived=true.
+func (h *RoadmapHandler) DependencyGraph(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	query := r.URL.Query()
+	format := query.Get("format")
+	if format != "" && format != "cytoscape" {
+		apierror.Write(w, r, http.StatusBadRequest, "Invalid format (must be cytoscape or omitted)")
+		return
+	}
+
+	graph, ok := h.buildDependencyGraph(w, r, query.Get("service_line"), splitIDs(query.Get("roadmaps")))
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if format == "cytoscape" {
+		type element struct {
+			Data interface{} `json:"data"`
+		}
+		nodes := make([]element, len(graph.Nodes))
+		for i := range graph.Nodes {
+			nodes[i] = element{Data: graph.Nodes[i]}
+		}
+		edges := make([]element, len(graph.Edges))
+		for i := range graph.Edges {
+			edges[i] = element{Data: graph.Edges[i]}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"elements": map[string]interface{}{"nodes": nodes, "edges": edges},
+		})
+		return
+	}
+	json.NewEncoder(w).Encode(graph)
+}
+
+// DependencyGraphDOT handles GET /api/dependencies/graph.dot
+// Returns the dependency graph in Graphviz DOT syntax, with each roadmap as a
+// cluster, items colored by status, and external dependencies in bold. Takes
+// the same parameters as DependencyGraph apart from ?format=.
+func (h *RoadmapHandler) DependencyGraphDOT(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	query := r.URL.Query()
+	graph, ok := h.buildDependencyGraph(w, r, query.Get("service_line"), splitIDs(query.Get("roadmaps")))
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/vnd.graphviz; charset=utf-8")
+	io.WriteString(w, graph.DOT())
+}
+
+// DependencyGraphMermaid handles GET /api/dependencies/mermaid
+// Returns the dependency graph as a Mermaid flowchart, with each roadmap as a
+// subgraph, items colored by status, and external dependencies as thick
+// links. Takes the same parameters as DependencyGraph apart from ?format=.
+func (h *RoadmapHandler) DependencyGraphMermaid(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	query := r.URL.Query()
+	graph, ok := h.buildDependencyGraph(w, r, query.Get("service_line"), splitIDs(query.Get("roadmaps")))
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	io.WriteString(w, graph.Mermaid())
+}
+
+// GetRoadmapGraphDOT handles GET /api/roadmaps/{id}/graph.dot
+// Returns the roadmap's dependency graph in Graphviz DOT syntax, including the
+// items of other roadmaps joined to it by a dependency
+func (h *RoadmapHandler) GetRoadmapGraphDOT(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Extract ID from path
+	id := strings.TrimPrefix(r.URL.Path, "/api/roadmaps/")
+	id = strings.TrimSuffix(id, "/graph.dot")
+	if id == "" || strings.Contains(id, "/") {
+		apierror.Write(w, r, http.StatusBadRequest, "Invalid roadmap ID")
+		return
+	}
+
+	stored, err := h.storage.Get(id)
+	if err != nil {
+		writeStorageError(w, r, err, "get roadmap")
+		return
+	}
+
+	graph, ok := h.buildDependencyGraph(w, r, "", []string{stored.ID})
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/vnd.graphviz; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", stored.ID+".dot"))
+	io.WriteString(w, graph.DOT())
+}
+
+// buildDependencyGraph draws the dependency graph of the stored roadmaps,
+// leaving out archived ones unless ?include_archived=true. If a service line
+// or roadmap IDs are given, only the roadmaps matching both are in scope.
+// Responds 404 and returns false if an ID isn't found.
+func (h *RoadmapHandler) buildDependencyGraph(w http.ResponseWriter, r *http.Request, serviceLine string, ids []string) (models.DependencyGraph, bool) {
+	roadmaps, err := h.storage.List(storage.ListFilter{ExcludeArchived: !includeArchived(r)})
+	if err != nil {
+		apierror.Write(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to list roadmaps: %v", err))
+		return models.DependencyGraph{}, false
+	}
+	for i, rm := range roadmaps {
+		roadmaps[i] = visibleRoadmap(r, rm)
+	}
+
+	var scope map[string]bool
+	if len(ids) > 0 || serviceLine != "" {
+		scope = make(map[string]bool)
+		for _, rm := range roadmaps {
+			scope[rm.ID] = serviceLine == "" || rm.Roadmap.ServiceLine == serviceLine
+		}
+		if len(ids) > 0 {
+			wanted := make(map[string]bool)
+			for _, id := range ids {
+				if _, ok := scope[id]; !ok {
+					apierror.Write(w, r, http.StatusNotFound, fmt.Sprintf("Roadmap not found: %s", id))
+					return models.DependencyGraph{}, false
+				}
+				wanted[id] = true
+			}
+			for id := range scope {
+				scope[id] = scope[id] && wanted[id]
+			}
+		}
+	}
+
+	return models.BuildDependencyGraph(roadmaps, scope), true
+}
+
+// splitIDs splits a comma-separated list of IDs, or returns nil if it is empty
+func splitIDs(value string) []string {
+	var ids []string
+	for _, id := range strings.Split(value, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+const (
+	defaultDependencyDepth = 10
+	maxDependencyDepth     = 50
+)
+
+// getItemDependencies handles GET /api/roadmaps/{id}/items/{itemID}/dependencies
+// Returns the items the item depends on, internal and external, as a tree.
+// ?transitive=true follows their dependencies in turn, ?depth= levels deep
+// (default 10); each item's dependencies are listed once and loops are cut.
+func (h *RoadmapHandler) getItemDependencies(w http.ResponseWriter, r *http.Request, id, itemID string) {
+	query := r.URL.Query()
+	depth := 1
+	if query.Get("transitive") == "true" {
+		depth = defaultDependencyDepth
+		if value := query.Get("depth"); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 || n > maxDependencyDepth {
+				apierror.Write(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid depth (must be 1-%d)", maxDependencyDepth))
+				return
+			}
+			depth = n
+		}
+	}
+
+	stored, ok := h.loadRoadmap(w, r, id)
+	if !ok {
+		return
+	}
+	roadmaps, err := h.storage.List(storage.ListFilter{})
+	if err != nil {
+		apierror.Write(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to list roadmaps: %v", err))
+		return
+	}
+
+	upstream, ok := models.Upstream(roadmaps, stored.ID, itemID, depth)
+	if !ok {
+		apierror.Write(w, r, http.StatusNotFound, "Item not found")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(upstream)
+}
+
+// itemDependencyResult is the response of POST
+// /api/roadmaps/{id}/items/{itemID}/external-dependencies
+type itemDependencyResult struct {
+	Item       models.RoadmapItem                  `json:"item"`
+	Validation models.ExternalDependencyValidation `json:"validation"`
+}
+
+// addExternalDependency handles POST
+// /api/roadmaps/{id}/items/{itemID}/external-dependencies
+// Adds the external dependency in the JSON body to the item once it resolves
+// against the stored roadmaps; one that doesn't is refused with 422 and its
+// validation, which suggests near matches. The validation is returned with
+// the updated item, so a date conflict can be shown straight away.
+func (h *RoadmapHandler) addExternalDependency(w http.ResponseWriter, r *http.Request, id, itemID string) {
+	body := h.limitBody(w, r)
+	defer r.Body.Close()
+
+	var dep models.ExternalDependency
+	if err := json.NewDecoder(body).Decode(&dep); err != nil {
+		if body.tooLarge() {
+			h.writeTooLarge(w, r)
+			return
+		}
+		apierror.Write(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid dependency: %v", err))
+		return
+	}
+	if err := dep.Validate(); err != nil {
+		apierror.Write(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid dependency: %v", err))
+		return
+	}
+
+	stored, ok := h.loadRoadmap(w, r, id)
+	if !ok {
+		return
+	}
+	if !h.checkIfMatch(w, r, stored) {
+		return
+	}
+
+	index := itemIndex(stored.Roadmap.Items, itemID)
+	if index == -1 {
+		apierror.Write(w, r, http.StatusNotFound, "Item not found")
+		return
+	}
+	for _, existing := range stored.Roadmap.Items[index].ExternalDependencies {
+		if matchesDependency(existing, dep) {
+			apierror.Write(w, r, http.StatusConflict, fmt.Sprintf("Item %s already depends on %s", itemID, dependencyName(dep)))
+			return
+		}
+	}
+
+	roadmaps, err := h.storage.List(storage.ListFilter{})
+	if err != nil {
+		apierror.Write(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to list roadmaps: %v", err))
+		return
+	}
+	against := make([]models.StoredRoadmap, 0, len(roadmaps))
+	for _, rm := range roadmaps {
+		against = append(against, *rm)
+	}
+	validation := models.ValidateItemDependency(stored, &stored.Roadmap.Items[index], dep, against)
+	if !validation.Valid {
+		apierror.WriteDetails(w, r, http.StatusUnprocessableEntity, fmt.Sprintf("Invalid dependency: %s", validation.Error), validation)
+		return
+	}
+
+	items := copyItems(stored.Roadmap.Items)
+	items[index].ExternalDependencies = append(append([]models.ExternalDependency(nil), items[index].ExternalDependencies...), dep)
+	updated, ok := h.saveItems(w, r, stored, items)
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(itemDependencyResult{Item: updated.Roadmap.Items[index], Validation: validation})
+}
+
+// removeExternalDependency handles DELETE
+// /api/roadmaps/{id}/items/{itemID}/external-dependencies
+// Removes the item's dependencies on the roadmap given by ?roadmap= or
+// ?roadmap_id= and the target given by ?item=, ?item_name=, ?milestone=, or
+// ?completion=true
+func (h *RoadmapHandler) removeExternalDependency(w http.ResponseWriter, r *http.Request, id, itemID string) {
+	query := r.URL.Query()
+	dep := models.ExternalDependency{
+		RoadmapName: query.Get("roadmap"),
+		RoadmapID:   query.Get("roadmap_id"),
+		ItemID:      query.Get("item"),
+		ItemName:    query.Get("item_name"),
+		Milestone:   query.Get("milestone"),
+		Completion:  query.Get("completion") == "true",
+	}
+	if err := dep.Validate(); err != nil {
+		apierror.Write(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid dependency: %v", err))
+		return
+	}
+
+	stored, ok := h.loadRoadmap(w, r, id)
+	if !ok {
+		return
+	}
+	if !h.checkIfMatch(w, r, stored) {
+		return
+	}
+
+	index := itemIndex(stored.Roadmap.Items, itemID)
+	if index == -1 {
+		apierror.Write(w, r, http.StatusNotFound, "Item not found")
+		return
+	}
+
+	items := copyItems(stored.Roadmap.Items)
+	var kept []models.ExternalDependency
+	for _, existing := range items[index].ExternalDependencies {
+		if !matchesDependency(existing, dep) {
+			kept = append(kept, existing)
+		}
+	}
+	if len(kept) == len(items[index].ExternalDependencies) {
+		apierror.Write(w, r, http.StatusNotFound, fmt.Sprintf("Item %s does not depend on %s", itemID, dependencyName(dep)))
+		return
+	}
+	items[index].ExternalDependencies = kept
+	if _, ok := h.saveItems(w, r, stored, items); !ok {
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// matchesDependency reports whether existing points at the same target as
+// dep, in the roadmap dep names by name, ID, or both
+func matchesDependency(existing, dep models.ExternalDependency) bool {
+	if existing.Target() != dep.Target() {
+		return false
+	}
+	if dep.RoadmapName != "" && existing.RoadmapName != dep.RoadmapName {
+		return false
+	}
+	return dep.RoadmapID == "" || existing.RoadmapID == dep.RoadmapID
+}
+
+// dependencyName describes a dependency as roadmap:target for messages
+func dependencyName(dep models.ExternalDependency) string {
+	roadmap := dep.RoadmapName
+	if roadmap == "" {
+		roadmap = dep.RoadmapID
+	}
+	return roadmap + ":" + dep.Target()
+}
+
+// DependencySummary handles GET /api/dependencies/summary
+// Rolls up the validation of every external dependency, its date conflicts,
+// lead time violations, and criticality, by roadmap and by service line, for
+// a dependencies dashboard. ?service_line= narrows the roadmaps counted, which
+// still resolve against every roadmap; archived roadmaps and items are left
+// out unless ?include_archived=true.
+func (h *RoadmapHandler) DependencySummary(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	targets, err := h.storage.List(storage.ListFilter{})
+	if err != nil {
+		apierror.Write(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to list roadmaps: %v", err))
+		return
+	}
+	serviceLine := r.URL.Query().Get("service_line")
+	var roadmaps []*models.StoredRoadmap
+	for _, rm := range targets {
+		if serviceLine != "" && rm.Roadmap.ServiceLine != serviceLine {
+			continue
+		}
+		if rm.Roadmap.Archived && !includeArchived(r) {
+			continue
+		}
+		roadmaps = append(roadmaps, visibleRoadmap(r, rm))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(models.SummarizeDependencies(roadmaps, targets))
+}
+
+// DependencyRisks handles GET /api/dependencies/risks
+// Scores every external dependency from 0 to 100 by its criticality, the
+// slack between the two items, the status of the item depended on, and the
+// health of its roadmap, highest first. ?service_line= narrows the roadmaps
+// whose dependencies are scored; they still resolve against every roadmap.
+func (h *RoadmapHandler) DependencyRisks(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	targets, err := h.storage.List(storage.ListFilter{})
+	if err != nil {
+		apierror.Write(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to list roadmaps: %v", err))
+		return
+	}
+	roadmaps := targets
+	if serviceLine := r.URL.Query().Get("service_line"); serviceLine != "" {
+		roadmaps = nil
+		for _, rm := range targets {
+			if rm.Roadmap.ServiceLine == serviceLine {
+				roadmaps = append(roadmaps, rm)
+			}
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(models.ScoreDependencyRisks(roadmaps, targets, time.Now()))
+}
@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"roadmap-visualizer/internal/apierror"
+	"roadmap-visualizer/internal/models"
+	"roadmap-visualizer/internal/servicelines"
+	"roadmap-visualizer/internal/storage"
+)
+
+// writeStorageError sends the error response for a failed storage call,
+// mapping the storage package's typed errors to their statuses. action says
+// what failed, e.g. "get roadmap", for the message of unexpected errors.
+func writeStorageError(w http.ResponseWriter, r *http.Request, err error, action string) {
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		apierror.Write(w, r, http.StatusNotFound, "Roadmap not found")
+	case errors.Is(err, storage.ErrNotInTrash):
+		apierror.Write(w, r, http.StatusNotFound, "Roadmap not found in trash")
+	case errors.Is(err, storage.ErrRevisionNotFound):
+		apierror.Write(w, r, http.StatusNotFound, "Revision not found")
+	case errors.Is(err, storage.ErrRevisionMismatch):
+		apierror.Write(w, r, http.StatusPreconditionFailed, "Roadmap was modified by another update; reload and retry")
+	case errors.Is(err, servicelines.ErrUnknown):
+		apierror.Write(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid roadmap: %v (register it with POST /api/v1/service-lines)", err))
+	default:
+		apierror.Write(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to %s: %v", action, err))
+	}
+}
+
+// writeTooLarge sends the 413 response for a body cut off by limitBody
+func (h *RoadmapHandler) writeTooLarge(w http.ResponseWriter, r *http.Request) {
+	apierror.WriteDetails(w, r, http.StatusRequestEntityTooLarge, "Request body too large",
+		map[string]int64{"max_bytes": h.config.MaxUploadBytes})
+}
+
+// roadmapChecker is implemented by storage wrappers that refuse some roadmaps,
+// such as servicelines.Strict, so batches can be checked before any is stored
+type roadmapChecker interface {
+	CheckRoadmap(roadmap *models.Roadmap) error
+}
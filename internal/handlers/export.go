@@ -0,0 +1,558 @@
+package handlers
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"roadmap-visualizer/internal/apierror"
+	"roadmap-visualizer/internal/models"
+	"roadmap-visualizer/internal/parser"
+	"roadmap-visualizer/internal/render"
+	"roadmap-visualizer/internal/storage"
+	"roadmap-visualizer/internal/xlsx"
+	"strings"
+	"time"
+)
+
+// exportManifest is written as manifest.json at the root of a zip export
+type exportManifest struct {
+	ExportedAt time.Time               `json:"exported_at"`
+	Count      int                     `json:"count"`
+	Roadmaps   []models.RoadmapSummary `json:"roadmaps"`
+}
+
+// ExportRoadmaps handles GET /api/roadmaps/export
+// ?format=zip (default) streams a zip with each roadmap's YAML and metadata;
+// ?format=yaml streams one multi-document YAML file that can be uploaded
+// again through POST /api/roadmaps/batch; ?format=csv streams the items of
+// every roadmap as CSV, leaving archived items out unless ?include_archived=true
+func (h *RoadmapHandler) ExportRoadmaps(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "zip"
+	}
+	if format != "zip" && format != "yaml" && format != "csv" {
+		apierror.Write(w, r, http.StatusBadRequest, "Invalid format (must be zip, yaml, or csv)")
+		return
+	}
+
+	roadmaps, err := h.storage.List(storage.ListFilter{})
+	if err != nil {
+		apierror.Write(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to list roadmaps: %v", err))
+		return
+	}
+
+	now := time.Now().UTC()
+	fileName := fmt.Sprintf("roadmaps-export-%s.%s", now.Format("20060102T150405Z"), format)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+
+	// Headers are already sent once the export starts streaming, so a
+	// failure part way through can only be logged
+	if format == "yaml" {
+		w.Header().Set("Content-Type", "application/x-yaml")
+		if err := writeYAMLExport(w, roadmaps); err != nil {
+			log.Printf("Export failed: %v", err)
+		}
+		return
+	}
+	if format == "csv" {
+		for i, rm := range roadmaps {
+			roadmaps[i] = visibleRoadmap(r, rm)
+		}
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		if err := models.WriteCSV(w, roadmaps); err != nil {
+			log.Printf("Export failed: %v", err)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	if err := writeZipExport(w, roadmaps, now); err != nil {
+		log.Printf("Export failed: %v", err)
+	}
+}
+
+// writeZipExport writes manifest.json, roadmaps/{id}.yaml, and
+// metadata/{id}.json for every roadmap
+func writeZipExport(w http.ResponseWriter, roadmaps []*models.StoredRoadmap, now time.Time) error {
+	zw := zip.NewWriter(w)
+
+	manifest := exportManifest{
+		ExportedAt: now,
+		Count:      len(roadmaps),
+		Roadmaps:   make([]models.RoadmapSummary, len(roadmaps)),
+	}
+	for i, rm := range roadmaps {
+		manifest.Roadmaps[i] = rm.Summary()
+	}
+
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize manifest: %w", err)
+	}
+	if err := writeZipFile(zw, "manifest.json", manifestData, now); err != nil {
+		return err
+	}
+
+	for _, rm := range roadmaps {
+		yamlData, err := parser.SerializeRoadmap(&rm.Roadmap)
+		if err != nil {
+			return fmt.Errorf("failed to serialize roadmap %s: %w", rm.ID, err)
+		}
+		if err := writeZipFile(zw, fmt.Sprintf("roadmaps/%s.yaml", rm.ID), yamlData, rm.UpdatedAt); err != nil {
+			return err
+		}
+
+		metaData, err := json.MarshalIndent(rm, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to serialize metadata for %s: %w", rm.ID, err)
+		}
+		if err := writeZipFile(zw, fmt.Sprintf("metadata/%s.json", rm.ID), metaData, rm.UpdatedAt); err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+// writeZipFile adds a single compressed file to the archive
+func writeZipFile(zw *zip.Writer, name string, data []byte, modTime time.Time) error {
+	fw, err := zw.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: modTime,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add %s: %w", name, err)
+	}
+	if _, err := fw.Write(data); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+// writeYAMLExport writes every roadmap as one YAML document, with its
+// metadata as comments so the file stays valid input for a batch upload
+func writeYAMLExport(w http.ResponseWriter, roadmaps []*models.StoredRoadmap) error {
+	for _, rm := range roadmaps {
+		yamlData, err := parser.SerializeRoadmap(&rm.Roadmap)
+		if err != nil {
+			return fmt.Errorf("failed to serialize roadmap %s: %w", rm.ID, err)
+		}
+
+		header := fmt.Sprintf("---\n# id: %s\n# file_name: %s\n# created_at: %s\n# updated_at: %s\n# revision: %d\n",
+			rm.ID, rm.FileName, rm.CreatedAt.Format(time.RFC3339), rm.UpdatedAt.Format(time.RFC3339), rm.CurrentRevision())
+		if rm.CreatedBy != "" {
+			header += fmt.Sprintf("# created_by: %s\n", rm.CreatedBy)
+		}
+		if rm.UpdatedBy != "" {
+			header += fmt.Sprintf("# updated_by: %s\n", rm.UpdatedBy)
+		}
+
+		if _, err := w.Write([]byte(header)); err != nil {
+			return err
+		}
+		if _, err := w.Write(yamlData); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetRoadmapYAML handles GET /api/roadmaps/{id}/yaml
+// Returns the roadmap as the YAML document kept in storage, as an attachment
+// named {id}.yaml that can be edited and uploaded again
+func (h *RoadmapHandler) GetRoadmapYAML(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Extract ID from path
+	id := strings.TrimPrefix(r.URL.Path, "/api/roadmaps/")
+	id = strings.TrimSuffix(id, "/yaml")
+	if id == "" || strings.Contains(id, "/") {
+		apierror.Write(w, r, http.StatusBadRequest, "Invalid roadmap ID")
+		return
+	}
+
+	stored, err := h.storage.Get(id)
+	if err != nil {
+		writeStorageError(w, r, err, "get roadmap")
+		return
+	}
+
+	if notModified(w, r, etag(stored), stored.UpdatedAt) {
+		return
+	}
+
+	yamlData, err := parser.SerializeRoadmap(&stored.Roadmap)
+	if err != nil {
+		apierror.Write(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to serialize roadmap: %v", err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-yaml")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", stored.ID+".yaml"))
+	w.Write(yamlData)
+}
+
+// GetRoadmapCSV handles GET /api/roadmaps/{id}/export.csv
+// Returns the roadmap's items as CSV, one row per item, as an attachment
+// named {id}.csv. Archived items are left out unless ?include_archived=true.
+func (h *RoadmapHandler) GetRoadmapCSV(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Extract ID from path
+	id := strings.TrimPrefix(r.URL.Path, "/api/roadmaps/")
+	id = strings.TrimSuffix(id, "/export.csv")
+	if id == "" || strings.Contains(id, "/") {
+		apierror.Write(w, r, http.StatusBadRequest, "Invalid roadmap ID")
+		return
+	}
+
+	stored, err := h.storage.Get(id)
+	if err != nil {
+		writeStorageError(w, r, err, "get roadmap")
+		return
+	}
+
+	var b strings.Builder
+	if err := models.WriteCSV(&b, []*models.StoredRoadmap{visibleRoadmap(r, stored)}); err != nil {
+		apierror.Write(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to write CSV: %v", err))
+		return
+	}
+
+	w.Header().Set("ETag", etag(stored))
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", stored.ID+".csv"))
+	io.WriteString(w, b.String())
+}
+
+// GetRoadmapXLSX handles GET /api/roadmaps/{id}/export.xlsx
+// Returns the roadmap as an Excel workbook named {id}.xlsx, with an Items
+// sheet of the CSV export's columns and a Timeline sheet with a column per
+// month and each item's months shaded by status. Archived items are left out
+// unless ?include_archived=true.
+func (h *RoadmapHandler) GetRoadmapXLSX(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Extract ID from path
+	id := strings.TrimPrefix(r.URL.Path, "/api/roadmaps/")
+	id = strings.TrimSuffix(id, "/export.xlsx")
+	if id == "" || strings.Contains(id, "/") {
+		apierror.Write(w, r, http.StatusBadRequest, "Invalid roadmap ID")
+		return
+	}
+
+	stored, err := h.storage.Get(id)
+	if err != nil {
+		writeStorageError(w, r, err, "get roadmap")
+		return
+	}
+
+	var b bytes.Buffer
+	if err := xlsx.Write(&b, roadmapSheets(visibleRoadmap(r, stored))); err != nil {
+		apierror.Write(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to write workbook: %v", err))
+		return
+	}
+
+	w.Header().Set("ETag", etag(stored))
+	w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", stored.ID+".xlsx"))
+	w.Write(b.Bytes())
+}
+
+// roadmapSheets lays a roadmap out as the Items and Timeline sheets of a
+// workbook, each with a bold header row that stays in view
+func roadmapSheets(stored *models.StoredRoadmap) []xlsx.Sheet {
+	items := xlsx.Sheet{Name: "Items", FrozenRows: 1}
+	header := make([]xlsx.Cell, len(models.ItemColumns))
+	for i, column := range models.ItemColumns {
+		header[i] = xlsx.Cell{Text: column, Bold: true}
+		items.Widths = append(items.Widths, 14)
+	}
+	items.Rows = append(items.Rows, header)
+	for _, row := range models.ItemRows([]*models.StoredRoadmap{stored}) {
+		cells := make([]xlsx.Cell, len(row))
+		for i, text := range row {
+			cells[i] = xlsx.Cell{Text: text}
+		}
+		items.Rows = append(items.Rows, cells)
+	}
+
+	// The item and its status stay in view while scrolling through months
+	timeline := stored.MonthlyTimeline()
+	gantt := xlsx.Sheet{Name: "Timeline", FrozenRows: 1, FrozenColumns: 2, Widths: []float64{30, 12}}
+	header = []xlsx.Cell{{Text: "item", Bold: true}, {Text: "status", Bold: true}}
+	for _, month := range timeline.Months {
+		header = append(header, xlsx.Cell{Text: month.Format("Jan 2006"), Bold: true})
+		gantt.Widths = append(gantt.Widths, 9)
+	}
+	gantt.Rows = append(gantt.Rows, header)
+	for _, row := range timeline.Rows {
+		cells := []xlsx.Cell{{Text: row.Name}, {Text: string(row.Status), Fill: row.Fill}}
+		for _, runs := range row.Months {
+			cell := xlsx.Cell{}
+			if runs {
+				cell.Fill = row.Fill
+			}
+			cells = append(cells, cell)
+		}
+		gantt.Rows = append(gantt.Rows, cells)
+	}
+
+	return []xlsx.Sheet{items, gantt}
+}
+
+// GetRoadmapMarkdown handles GET /api/roadmaps/{id}/export.md
+// Returns the roadmap as Markdown for wikis and release notes, with its items
+// grouped by ?group_by=status (default) or quarter. Archived items are left
+// out unless ?include_archived=true.
+func (h *RoadmapHandler) GetRoadmapMarkdown(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Extract ID from path
+	id := strings.TrimPrefix(r.URL.Path, "/api/roadmaps/")
+	id = strings.TrimSuffix(id, "/export.md")
+	if id == "" || strings.Contains(id, "/") {
+		apierror.Write(w, r, http.StatusBadRequest, "Invalid roadmap ID")
+		return
+	}
+
+	groupBy := r.URL.Query().Get("group_by")
+	if groupBy == "" {
+		groupBy = models.MarkdownByStatus
+	}
+	if groupBy != models.MarkdownByStatus && groupBy != models.MarkdownByQuarter {
+		apierror.Write(w, r, http.StatusBadRequest, "Invalid group_by (must be status or quarter)")
+		return
+	}
+
+	stored, err := h.storage.Get(id)
+	if err != nil {
+		writeStorageError(w, r, err, "get roadmap")
+		return
+	}
+
+	w.Header().Set("ETag", etag(stored))
+	w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+	io.WriteString(w, visibleRoadmap(r, stored).Markdown(groupBy))
+}
+
+// GetRoadmapCalendar handles GET /api/roadmaps/{id}/calendar.ics
+// Returns the roadmap's items and milestones as iCalendar events, only those
+// assigned to ?owner if given. Archived items are left out unless
+// ?include_archived=true.
+func (h *RoadmapHandler) GetRoadmapCalendar(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Extract ID from path
+	id := strings.TrimPrefix(r.URL.Path, "/api/roadmaps/")
+	id = strings.TrimSuffix(id, "/calendar.ics")
+	if id == "" || strings.Contains(id, "/") {
+		apierror.Write(w, r, http.StatusBadRequest, "Invalid roadmap ID")
+		return
+	}
+
+	stored, err := h.storage.Get(id)
+	if err != nil {
+		writeStorageError(w, r, err, "get roadmap")
+		return
+	}
+
+	w.Header().Set("ETag", etag(stored))
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", stored.ID+".ics"))
+	stored = visibleRoadmap(r, stored)
+	io.WriteString(w, models.ICalendar(stored.Roadmap.Name, []*models.StoredRoadmap{stored}, r.URL.Query().Get("owner")))
+}
+
+// RoadmapsCalendar handles GET /api/roadmaps/calendar.ics
+// A feed of the items and milestones of every roadmap for calendar apps to
+// subscribe to. With ?owner only the roadmaps owned by, and the items
+// assigned to, that owner or team are included. Archived roadmaps and items
+// are left out unless ?include_archived=true.
+func (h *RoadmapHandler) RoadmapsCalendar(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	owner := r.URL.Query().Get("owner")
+	roadmaps, err := h.storage.List(storage.ListFilter{Owner: owner, ExcludeArchived: !includeArchived(r)})
+	if err != nil {
+		apierror.Write(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to list roadmaps: %v", err))
+		return
+	}
+	for i, rm := range roadmaps {
+		roadmaps[i] = visibleRoadmap(r, rm)
+	}
+
+	name := "Roadmaps"
+	if owner != "" {
+		name = "Roadmaps: " + owner
+	}
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="roadmaps.ics"`)
+	io.WriteString(w, models.ICalendar(name, roadmaps, owner))
+}
+
+// GetRoadmapMermaid handles GET /api/roadmaps/{id}/mermaid
+// Returns the roadmap as a Mermaid gantt chart, to paste into wikis and
+// Markdown that render Mermaid. Archived items are left out unless
+// ?include_archived=true.
+func (h *RoadmapHandler) GetRoadmapMermaid(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Extract ID from path
+	id := strings.TrimPrefix(r.URL.Path, "/api/roadmaps/")
+	id = strings.TrimSuffix(id, "/mermaid")
+	if id == "" || strings.Contains(id, "/") {
+		apierror.Write(w, r, http.StatusBadRequest, "Invalid roadmap ID")
+		return
+	}
+
+	stored, err := h.storage.Get(id)
+	if err != nil {
+		writeStorageError(w, r, err, "get roadmap")
+		return
+	}
+
+	w.Header().Set("ETag", etag(stored))
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	io.WriteString(w, visibleRoadmap(r, stored).MermaidGantt())
+}
+
+// GetRoadmapRender handles GET /api/roadmaps/{id}/render.png and
+// GET /api/roadmaps/{id}/render.pdf
+// Draws the roadmap as a timeline for slide decks to embed: a bar per item
+// colored by status, a diamond per milestone, and a line on today. ?page sets
+// the page size (a4 by default, a3, letter, legal, or tabloid) and
+// ?orientation=portrait turns it upright. A PDF has as many pages as the
+// items need; a PNG is as wide as the page at 144 dpi and grows taller to fit
+// them. Archived items are left out unless ?include_archived=true.
+func (h *RoadmapHandler) GetRoadmapRender(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Extract ID and format from path
+	id, format, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/api/roadmaps/"), "/render.")
+	if id == "" || strings.Contains(id, "/") {
+		apierror.Write(w, r, http.StatusBadRequest, "Invalid roadmap ID")
+		return
+	}
+
+	pageName := strings.ToLower(r.URL.Query().Get("page"))
+	if pageName == "" {
+		pageName = "a4"
+	}
+	page, ok := render.Pages[pageName]
+	if !ok {
+		apierror.Write(w, r, http.StatusBadRequest, "Invalid page (must be a4, a3, letter, legal, or tabloid)")
+		return
+	}
+	switch r.URL.Query().Get("orientation") {
+	case "", "landscape":
+		page = page.Landscape()
+	case "portrait":
+	default:
+		apierror.Write(w, r, http.StatusBadRequest, "Invalid orientation (must be landscape or portrait)")
+		return
+	}
+
+	stored, err := h.storage.Get(id)
+	if err != nil {
+		writeStorageError(w, r, err, "get roadmap")
+		return
+	}
+
+	chart := roadmapChart(visibleRoadmap(r, stored), time.Now())
+	var b bytes.Buffer
+	contentType := "image/png"
+	if format == "pdf" {
+		contentType = "application/pdf"
+		err = render.PDF(&b, chart, page)
+	} else {
+		err = render.PNG(&b, chart, page)
+	}
+	if err != nil {
+		apierror.Write(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to render roadmap: %v", err))
+		return
+	}
+
+	w.Header().Set("ETag", etag(stored))
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", stored.ID+"."+format))
+	w.Write(b.Bytes())
+}
+
+// roadmapChart lays a roadmap out as a timeline chart: its items in roadmap
+// order, filled by status as in the workbook export, then its milestones
+func roadmapChart(stored *models.StoredRoadmap, now time.Time) render.Chart {
+	roadmap := &stored.Roadmap
+	chart := render.Chart{Title: roadmap.Name, Today: now}
+
+	var details []string
+	if roadmap.ServiceLine != "" {
+		details = append(details, roadmap.ServiceLine)
+	}
+	if roadmap.Owner != "" {
+		details = append(details, "owner "+roadmap.Owner)
+	}
+	details = append(details, fmt.Sprintf("revision %d, updated %s", stored.Revision, stored.UpdatedAt.Format("2006-01-02")))
+	chart.Subtitle = strings.Join(details, " - ")
+
+	fills := make(map[models.RoadmapStatus]string)
+	for _, row := range stored.MonthlyTimeline().Rows {
+		chart.Rows = append(chart.Rows, render.Row{Label: row.Name, Start: row.Start, End: row.End, Fill: row.Fill})
+		fills[row.Status] = row.Fill
+	}
+	for _, status := range []models.RoadmapStatus{models.StatusPlanned, models.StatusInProgress, models.StatusBlocked, models.StatusCompleted} {
+		if fill, ok := fills[status]; ok {
+			chart.Legend = append(chart.Legend, render.LegendEntry{Label: string(status), Fill: fill})
+		}
+	}
+
+	// A milestone is due on the last day of its date, as in calendar feeds
+	const milestoneFill = "#424242"
+	for _, milestone := range roadmap.Milestones {
+		_, due, err := models.ParsePeriod(milestone.Date)
+		if err != nil {
+			continue
+		}
+		chart.Rows = append(chart.Rows, render.Row{Label: milestone.Name, Start: due.AddDate(0, 0, -1), End: due, Fill: milestoneFill, Milestone: true})
+	}
+	if len(roadmap.Milestones) > 0 {
+		chart.Legend = append(chart.Legend, render.LegendEntry{Label: "milestone", Fill: milestoneFill})
+	}
+
+	return chart
+}
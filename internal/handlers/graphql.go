@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"roadmap-visualizer/internal/apierror"
+	"roadmap-visualizer/internal/graphapi"
+)
+
+// GraphQLHandler serves GraphQL queries over roadmaps and their dependencies
+type GraphQLHandler struct {
+	api *graphapi.API
+}
+
+// NewGraphQLHandler creates a new GraphQL handler
+func NewGraphQLHandler(api *graphapi.API) *GraphQLHandler {
+	return &GraphQLHandler{
+		api: api,
+	}
+}
+
+// graphQLRequest is the standard GraphQL-over-HTTP request body
+type graphQLRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Query handles GET and POST /graphql
+// POST takes a JSON body with query, operationName, and variables; GET takes
+// the same as query parameters, with variables JSON-encoded
+func (h *GraphQLHandler) Query(w http.ResponseWriter, r *http.Request) {
+	var req graphQLRequest
+
+	switch r.Method {
+	case http.MethodGet:
+		query := r.URL.Query()
+		req.Query = query.Get("query")
+		req.OperationName = query.Get("operationName")
+		if variables := query.Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				apierror.Write(w, r, http.StatusBadRequest, "Invalid variables: must be a JSON object")
+				return
+			}
+		}
+	case http.MethodPost:
+		defer r.Body.Close()
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			apierror.Write(w, r, http.StatusBadRequest, "Invalid request body: must be JSON with a query field")
+			return
+		}
+	default:
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	if req.Query == "" {
+		apierror.Write(w, r, http.StatusBadRequest, "Query is required")
+		return
+	}
+
+	// Field errors are reported in the result's errors list alongside
+	// whatever data could be resolved, so the status stays 200
+	result := h.api.Execute(r.Context(), req.Query, req.OperationName, req.Variables)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// HandleGraphQL routes GraphQL requests
+func (h *GraphQLHandler) HandleGraphQL(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	h.Query(w, r)
+}
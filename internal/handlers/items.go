@@ -0,0 +1,424 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"roadmap-visualizer/internal/apierror"
+	"roadmap-visualizer/internal/models"
+	"strings"
+	"time"
+)
+
+// parseItemPath extracts the roadmap ID, item ID, and action from
+// /api/roadmaps/{id}/items, /api/roadmaps/{id}/items/{itemID}, or
+// /api/roadmaps/{id}/items/{itemID}/{action}. itemID is empty for the
+// collection path and action is empty unless given.
+func parseItemPath(path string) (string, string, string, bool) {
+	parts := strings.Split(strings.TrimPrefix(path, "/api/roadmaps/"), "/")
+	if len(parts) < 2 || len(parts) > 4 || parts[0] == "" || parts[1] != "items" {
+		return "", "", "", false
+	}
+	if len(parts) == 2 {
+		return parts[0], "", "", true
+	}
+	if parts[2] == "" {
+		return "", "", "", false
+	}
+	if len(parts) == 3 {
+		return parts[0], parts[2], "", true
+	}
+	if parts[3] == "" {
+		return "", "", "", false
+	}
+	return parts[0], parts[2], parts[3], true
+}
+
+// HandleItems routes /api/roadmaps/{id}/items requests
+func (h *RoadmapHandler) HandleItems(w http.ResponseWriter, r *http.Request) {
+	id, itemID, action, ok := parseItemPath(r.URL.Path)
+	if !ok {
+		apierror.Write(w, r, http.StatusBadRequest, "Invalid item path")
+		return
+	}
+
+	switch action {
+	case "":
+	case "status":
+		if r.Method != http.MethodPatch {
+			apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+		h.setItemStatus(w, r, id, itemID)
+		return
+	case "comments":
+		h.handleComments(w, r, id, itemID)
+		return
+	case "history":
+		if r.Method != http.MethodGet {
+			apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+		h.getItemHistory(w, r, id, itemID)
+		return
+	case "dependencies":
+		if r.Method != http.MethodGet {
+			apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+		h.getItemDependencies(w, r, id, itemID)
+		return
+	case "external-dependencies":
+		switch r.Method {
+		case http.MethodPost:
+			h.addExternalDependency(w, r, id, itemID)
+		case http.MethodDelete:
+			h.removeExternalDependency(w, r, id, itemID)
+		default:
+			apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+		return
+	default:
+		apierror.Write(w, r, http.StatusNotFound, "Not found")
+		return
+	}
+
+	if itemID == "" {
+		switch r.Method {
+		case http.MethodGet:
+			h.listItems(w, r, id)
+		case http.MethodPost:
+			h.createItem(w, r, id)
+		default:
+			apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		h.getItem(w, r, id, itemID)
+	case http.MethodPut:
+		h.replaceItem(w, r, id, itemID)
+	case http.MethodDelete:
+		h.deleteItem(w, r, id, itemID)
+	default:
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// listItems handles GET /api/roadmaps/{id}/items
+// Archived items are left out unless ?include_archived=true.
+func (h *RoadmapHandler) listItems(w http.ResponseWriter, r *http.Request, id string) {
+	stored, ok := h.loadRoadmap(w, r, id)
+	if !ok {
+		return
+	}
+
+	items := visibleRoadmap(r, stored).Roadmap.Items
+	if items == nil {
+		items = []models.RoadmapItem{}
+	}
+
+	w.Header().Set("ETag", etag(stored))
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(items)
+}
+
+// getItem handles GET /api/roadmaps/{id}/items/{itemID}
+func (h *RoadmapHandler) getItem(w http.ResponseWriter, r *http.Request, id, itemID string) {
+	stored, ok := h.loadRoadmap(w, r, id)
+	if !ok {
+		return
+	}
+
+	index := itemIndex(stored.Roadmap.Items, itemID)
+	if index == -1 {
+		apierror.Write(w, r, http.StatusNotFound, "Item not found")
+		return
+	}
+
+	w.Header().Set("ETag", etag(stored))
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(stored.Roadmap.Items[index])
+}
+
+// createItem handles POST /api/roadmaps/{id}/items
+// Appends the item in the JSON body; its ID must not be in use yet
+func (h *RoadmapHandler) createItem(w http.ResponseWriter, r *http.Request, id string) {
+	item, ok := h.decodeItem(w, r)
+	if !ok {
+		return
+	}
+
+	stored, ok := h.loadRoadmap(w, r, id)
+	if !ok {
+		return
+	}
+	if !h.checkIfMatch(w, r, stored) {
+		return
+	}
+
+	if itemIndex(stored.Roadmap.Items, item.ID) != -1 {
+		apierror.Write(w, r, http.StatusConflict, fmt.Sprintf("Item %s already exists", item.ID))
+		return
+	}
+
+	items := append(copyItems(stored.Roadmap.Items), *item)
+	updated, ok := h.saveItems(w, r, stored, items)
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(updated.Roadmap.Items[itemIndex(updated.Roadmap.Items, item.ID)])
+}
+
+// replaceItem handles PUT /api/roadmaps/{id}/items/{itemID}
+// Replaces the item with the JSON body; the body may leave out the ID but
+// must not change it
+func (h *RoadmapHandler) replaceItem(w http.ResponseWriter, r *http.Request, id, itemID string) {
+	item, ok := h.decodeItem(w, r)
+	if !ok {
+		return
+	}
+	if item.ID == "" {
+		item.ID = itemID
+	}
+	if item.ID != itemID {
+		apierror.Write(w, r, http.StatusBadRequest, "Item id in the body must match the URL")
+		return
+	}
+
+	stored, ok := h.loadRoadmap(w, r, id)
+	if !ok {
+		return
+	}
+	if !h.checkIfMatch(w, r, stored) {
+		return
+	}
+
+	index := itemIndex(stored.Roadmap.Items, itemID)
+	if index == -1 {
+		apierror.Write(w, r, http.StatusNotFound, "Item not found")
+		return
+	}
+	if parent := stored.Roadmap.Items[index].RecurrenceOf; parent != "" {
+		apierror.Write(w, r, http.StatusConflict, fmt.Sprintf("Item %s is generated by the recurrence of %s; edit %s instead", itemID, parent, parent))
+		return
+	}
+
+	items := copyItems(stored.Roadmap.Items)
+	items[index] = *item
+	updated, ok := h.saveItems(w, r, stored, items)
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(updated.Roadmap.Items[itemIndex(updated.Roadmap.Items, itemID)])
+}
+
+// deleteItem handles DELETE /api/roadmaps/{id}/items/{itemID}
+// Fails validation while other items still depend on the item
+func (h *RoadmapHandler) deleteItem(w http.ResponseWriter, r *http.Request, id, itemID string) {
+	stored, ok := h.loadRoadmap(w, r, id)
+	if !ok {
+		return
+	}
+	if !h.checkIfMatch(w, r, stored) {
+		return
+	}
+
+	index := itemIndex(stored.Roadmap.Items, itemID)
+	if index == -1 {
+		apierror.Write(w, r, http.StatusNotFound, "Item not found")
+		return
+	}
+
+	items := copyItems(stored.Roadmap.Items)
+	items = append(items[:index], items[index+1:]...)
+	if _, ok := h.saveItems(w, r, stored, items); !ok {
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// itemStatusRequest is the body of PATCH /api/roadmaps/{id}/items/{itemID}/status
+type itemStatusRequest struct {
+	Status models.RoadmapStatus `json:"status"`
+}
+
+// setItemStatus handles PATCH /api/roadmaps/{id}/items/{itemID}/status
+// Changes only the item's status and records when it changed; setting the
+// status the item already has changes nothing
+func (h *RoadmapHandler) setItemStatus(w http.ResponseWriter, r *http.Request, id, itemID string) {
+	body := h.limitBody(w, r)
+	defer r.Body.Close()
+
+	var req itemStatusRequest
+	if err := json.NewDecoder(body).Decode(&req); err != nil {
+		if body.tooLarge() {
+			h.writeTooLarge(w, r)
+			return
+		}
+		apierror.Write(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid request body: %v", err))
+		return
+	}
+	if err := models.ValidateStatus(string(req.Status)); err != nil {
+		apierror.Write(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	stored, ok := h.loadRoadmap(w, r, id)
+	if !ok {
+		return
+	}
+	if !h.checkIfMatch(w, r, stored) {
+		return
+	}
+
+	index := itemIndex(stored.Roadmap.Items, itemID)
+	if index == -1 {
+		apierror.Write(w, r, http.StatusNotFound, "Item not found")
+		return
+	}
+
+	if stored.Roadmap.Items[index].Status != req.Status {
+		items := copyItems(stored.Roadmap.Items)
+		items[index].Status = req.Status
+		updated, ok := h.saveItems(w, r, stored, items)
+		if !ok {
+			return
+		}
+		stored = updated
+		index = itemIndex(stored.Roadmap.Items, itemID)
+	} else {
+		w.Header().Set("ETag", etag(stored))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(stored.Roadmap.Items[index])
+}
+
+// itemHistory is the response of GET /api/roadmaps/{id}/items/{itemID}/history
+type itemHistory struct {
+	ItemID  string                `json:"item_id"`
+	Status  models.RoadmapStatus  `json:"status"`
+	History []models.StatusChange `json:"history"`
+	// CycleTimeDays is set for completed items whose history shows when they
+	// went in progress and were completed
+	CycleTimeDays *float64 `json:"cycle_time_days,omitempty"`
+}
+
+// getItemHistory handles GET /api/roadmaps/{id}/items/{itemID}/history
+// Returns the item's status changes, oldest first, with its cycle time
+func (h *RoadmapHandler) getItemHistory(w http.ResponseWriter, r *http.Request, id, itemID string) {
+	stored, ok := h.loadRoadmap(w, r, id)
+	if !ok {
+		return
+	}
+
+	index := itemIndex(stored.Roadmap.Items, itemID)
+	if index == -1 {
+		apierror.Write(w, r, http.StatusNotFound, "Item not found")
+		return
+	}
+
+	item := &stored.Roadmap.Items[index]
+	resp := itemHistory{ItemID: item.ID, Status: item.Status, History: item.StatusHistory}
+	if resp.History == nil {
+		resp.History = []models.StatusChange{}
+	}
+	if cycle, ok := item.CycleTime(); ok {
+		days := math.Round(cycle.Hours()/24*10) / 10
+		resp.CycleTimeDays = &days
+	}
+
+	w.Header().Set("ETag", etag(stored))
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// decodeItem reads a roadmap item from the JSON request body
+func (h *RoadmapHandler) decodeItem(w http.ResponseWriter, r *http.Request) (*models.RoadmapItem, bool) {
+	body := h.limitBody(w, r)
+	defer r.Body.Close()
+
+	var item models.RoadmapItem
+	if err := json.NewDecoder(body).Decode(&item); err != nil {
+		if body.tooLarge() {
+			h.writeTooLarge(w, r)
+			return nil, false
+		}
+		apierror.Write(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid item: %v", err))
+		return nil, false
+	}
+	// Occurrences are generated from their recurring item on save, which
+	// would drop one sent by a client
+	if item.RecurrenceOf != "" {
+		apierror.Write(w, r, http.StatusBadRequest, "Invalid item: recurrence_of is set by the server on generated occurrences")
+		return nil, false
+	}
+
+	return &item, true
+}
+
+// loadRoadmap fetches a roadmap, writing the error response if it can't
+func (h *RoadmapHandler) loadRoadmap(w http.ResponseWriter, r *http.Request, id string) (*models.StoredRoadmap, bool) {
+	stored, err := h.storage.Get(id)
+	if err != nil {
+		writeStorageError(w, r, err, "get roadmap")
+		return nil, false
+	}
+	return stored, true
+}
+
+// saveItems validates the roadmap with its items replaced and stores it as a
+// new revision, as long as nothing else has changed the roadmap since it was
+// loaded. Recurring items are regenerated, items whose status changed are
+// stamped, and baselines are kept. The ETag of the new revision is set on
+// success.
+func (h *RoadmapHandler) saveItems(w http.ResponseWriter, r *http.Request, stored *models.StoredRoadmap, items []models.RoadmapItem) (*models.StoredRoadmap, bool) {
+	roadmap := stored.Roadmap
+	roadmap.Items = items
+	roadmap.NormalizeTags()
+	if err := roadmap.ExpandRecurrences(); err != nil {
+		apierror.Write(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid roadmap: %v", err))
+		return nil, false
+	}
+	roadmap.StampStatusChanges(&stored.Roadmap, time.Now().UTC(), requestAuthor(r))
+	roadmap.SetBaselines(&stored.Roadmap)
+	if err := roadmap.Validate(); err != nil {
+		apierror.Write(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid roadmap: %v", err))
+		return nil, false
+	}
+
+	updated, err := h.storage.Update(stored.ID, &roadmap, requestAuthor(r), stored.CurrentRevision())
+	if err != nil {
+		writeStorageError(w, r, err, "update roadmap")
+		return nil, false
+	}
+
+	w.Header().Set("ETag", etag(updated))
+	return updated, true
+}
+
+// itemIndex returns the position of the item with the given ID, or -1
+func itemIndex(items []models.RoadmapItem, itemID string) int {
+	for i, item := range items {
+		if item.ID == itemID {
+			return i
+		}
+	}
+	return -1
+}
+
+// copyItems returns a copy of items that can be changed without touching
+// the stored roadmap
+func copyItems(items []models.RoadmapItem) []models.RoadmapItem {
+	return append([]models.RoadmapItem(nil), items...)
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"roadmap-visualizer/internal/models"
+	"roadmap-visualizer/internal/storage"
+	"strings"
+	"testing"
+)
+
+// createRecurringRoadmap stores a roadmap whose item a recurs once, as a-2
+func createRecurringRoadmap(t *testing.T, h *RoadmapHandler) string {
+	t.Helper()
+	body := strings.Replace(roadmapYAML("Platform"), "status: planned}", "status: planned, recurrence: {every: quarter, count: 2}}", 1)
+	w := serve(h, http.MethodPost, "/api/roadmaps", "application/x-yaml", body)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create: status %d: %s", w.Code, w.Body)
+	}
+	var created models.StoredRoadmap
+	if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+		t.Fatal(err)
+	}
+	if itemIndex(created.Roadmap.Items, "a-2") == -1 {
+		t.Fatalf("no occurrence a-2 in %+v", created.Roadmap.Items)
+	}
+	return created.ID
+}
+
+func TestCreateItemRejectsRecurrenceOf(t *testing.T) {
+	h := NewRoadmapHandler(storage.NewMemoryStorage(), Config{})
+	id := createRecurringRoadmap(t, h)
+
+	body := `{"id": "x", "name": "X", "start": "2026-01-01", "end": "2026-03-31", "status": "planned", "recurrence_of": "a"}`
+	if w := serve(h, http.MethodPost, "/api/roadmaps/"+id+"/items", "application/json", body); w.Code != http.StatusBadRequest {
+		t.Errorf("create with recurrence_of: status %d, want 400: %s", w.Code, w.Body)
+	}
+
+	body = `{"id": "b", "name": "B", "start": "2026-01-01", "end": "2026-03-31", "status": "planned"}`
+	w := serve(h, http.MethodPost, "/api/roadmaps/"+id+"/items", "application/json", body)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create: status %d: %s", w.Code, w.Body)
+	}
+	var item models.RoadmapItem
+	if err := json.NewDecoder(w.Body).Decode(&item); err != nil || item.ID != "b" {
+		t.Errorf("created item %q, err %v; want b", item.ID, err)
+	}
+}
+
+func TestReplaceOccurrenceConflicts(t *testing.T) {
+	h := NewRoadmapHandler(storage.NewMemoryStorage(), Config{})
+	id := createRecurringRoadmap(t, h)
+
+	body := `{"name": "Renamed", "start": "2026-04-01", "end": "2026-06-30", "status": "planned"}`
+	if w := serve(h, http.MethodPut, "/api/roadmaps/"+id+"/items/a-2", "application/json", body); w.Code != http.StatusConflict {
+		t.Errorf("replace occurrence: status %d, want 409: %s", w.Code, w.Body)
+	}
+
+	body = `{"name": "Renamed", "start": "2026-01-01", "end": "2026-03-31", "status": "planned", "recurrence": {"every": "quarter", "count": 2}}`
+	w := serve(h, http.MethodPut, "/api/roadmaps/"+id+"/items/a", "application/json", body)
+	if w.Code != http.StatusOK {
+		t.Fatalf("replace recurring item: status %d: %s", w.Code, w.Body)
+	}
+	var item models.RoadmapItem
+	if err := json.NewDecoder(w.Body).Decode(&item); err != nil || item.ID != "a" || item.Name != "Renamed" {
+		t.Errorf("replaced item %q named %q, err %v", item.ID, item.Name, err)
+	}
+}
@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"roadmap-visualizer/internal/apierror"
+	"roadmap-visualizer/internal/jira"
+	"roadmap-visualizer/internal/models"
+	"roadmap-visualizer/internal/storage"
+	"strings"
+)
+
+// jiraImportRequest is the body of POST /api/import/jira
+type jiraImportRequest struct {
+	// Project is the key of the Jira project to import the epics of
+	Project string `json:"project"`
+	// JQL selects the issues instead; it is required without a project
+	JQL string `json:"jql"`
+	// Name of the roadmap; defaults to the project key
+	Name string `json:"name"`
+	// ServiceLine of the roadmap; required
+	ServiceLine string `json:"service_line"`
+}
+
+// jiraImportResult is the response of a Jira import
+type jiraImportResult struct {
+	*models.StoredRoadmap
+	// Issues is how many issues the search returned, and Truncated whether
+	// more matched than the import reads
+	Issues    int  `json:"issues"`
+	Truncated bool `json:"truncated"`
+	// Warnings lists the issues skipped and the dependencies dropped
+	Warnings []string `json:"warnings"`
+}
+
+// HandleImport routes import requests
+func (h *RoadmapHandler) HandleImport(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Author")
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	switch r.URL.Path {
+	case "/api/import/jira":
+		h.ImportJira(w, r)
+	default:
+		apierror.Write(w, r, http.StatusNotFound, "Not found")
+	}
+}
+
+// ImportJira handles POST /api/import/jira
+// Searches the configured Jira site for the epics of a project, or the issues
+// matching a JQL query, and stores them as a new roadmap as mapped by
+// jira.Client.Roadmap
+func (h *RoadmapHandler) ImportJira(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	if h.config.Jira == nil {
+		apierror.Write(w, r, http.StatusServiceUnavailable, "Jira import is not configured (set JIRA_URL)")
+		return
+	}
+
+	var req jiraImportRequest
+	body := h.limitBody(w, r)
+	defer r.Body.Close()
+	if err := json.NewDecoder(body).Decode(&req); err != nil {
+		if body.tooLarge() {
+			h.writeTooLarge(w, r)
+			return
+		}
+		apierror.Write(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid request body: %v", err))
+		return
+	}
+
+	req.Project = strings.TrimSpace(req.Project)
+	req.ServiceLine = strings.TrimSpace(req.ServiceLine)
+	jql := strings.TrimSpace(req.JQL)
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		name = req.Project
+	}
+	switch {
+	case req.Project == "" && jql == "":
+		apierror.Write(w, r, http.StatusBadRequest, "project or jql is required")
+		return
+	case name == "":
+		apierror.Write(w, r, http.StatusBadRequest, "name is required when importing by jql")
+		return
+	case req.ServiceLine == "":
+		apierror.Write(w, r, http.StatusBadRequest, "service_line is required")
+		return
+	case jql == "":
+		jql = "project = " + jira.QuoteJQL(req.Project) + " AND issuetype = Epic ORDER BY Rank ASC"
+	}
+
+	issues, truncated, err := h.config.Jira.Search(r.Context(), jql)
+	if err != nil {
+		var jiraErr *jira.Error
+		if errors.As(err, &jiraErr) && jiraErr.StatusCode == http.StatusBadRequest {
+			apierror.Write(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid Jira query: %s", jiraErr.Message))
+			return
+		}
+		apierror.Write(w, r, http.StatusBadGateway, fmt.Sprintf("Failed to search Jira: %v", err))
+		return
+	}
+
+	roadmap, warnings := h.config.Jira.Roadmap(name, req.ServiceLine, issues)
+	roadmap.SetBaselines(nil)
+	if err := roadmap.Validate(); err != nil {
+		apierror.WriteDetails(w, r, http.StatusUnprocessableEntity, fmt.Sprintf("Imported roadmap is invalid: %v", err), warnings)
+		return
+	}
+
+	stored, err := h.storage.Create(roadmap, storage.Slugify(name)+".yaml", requestAuthor(r))
+	if err != nil {
+		writeStorageError(w, r, err, "store roadmap")
+		return
+	}
+
+	if warnings == nil {
+		warnings = []string{}
+	}
+	w.Header().Set("ETag", etag(stored))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(jiraImportResult{
+		StoredRoadmap: stored,
+		Issues:        len(issues),
+		Truncated:     truncated,
+		Warnings:      warnings,
+	})
+}
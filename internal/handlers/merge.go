@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"roadmap-visualizer/internal/apierror"
+	"roadmap-visualizer/internal/models"
+	"roadmap-visualizer/internal/storage"
+	"strings"
+)
+
+// mergeRequest is the body of POST /api/roadmaps/merge
+type mergeRequest struct {
+	// IDs of the two roadmaps to merge; the first supplies the owner and
+	// any field left out of the request
+	IDs         []string `json:"ids"`
+	Name        string   `json:"name"`
+	ServiceLine string   `json:"service_line"`
+	// OnConflict is fail (default), rename, or skip
+	OnConflict string `json:"on_conflict"`
+}
+
+// mergeResult is the response for a merge. RenamedItems maps the old ID of
+// every item of the second roadmap that was renamed to its new ID.
+type mergeResult struct {
+	*models.StoredRoadmap
+	RenamedItems map[string]string `json:"renamed_items,omitempty"`
+}
+
+// MergeRoadmaps handles POST /api/roadmaps/merge
+// Stores a new roadmap with the items of both roadmaps; the originals are
+// left in place. Item IDs used by both are handled per on_conflict, and
+// dependencies between the two roadmaps become internal dependencies.
+func (h *RoadmapHandler) MergeRoadmaps(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req mergeRequest
+	body := h.limitBody(w, r)
+	defer r.Body.Close()
+	if err := json.NewDecoder(body).Decode(&req); err != nil {
+		if body.tooLarge() {
+			h.writeTooLarge(w, r)
+			return
+		}
+		apierror.Write(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid request body: %v", err))
+		return
+	}
+
+	if len(req.IDs) != 2 || req.IDs[0] == req.IDs[1] {
+		apierror.Write(w, r, http.StatusBadRequest, "ids must list two different roadmaps")
+		return
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		apierror.Write(w, r, http.StatusBadRequest, "name is required")
+		return
+	}
+	if req.OnConflict == "" {
+		req.OnConflict = models.MergeConflictFail
+	}
+
+	first, ok := h.loadRoadmap(w, r, req.IDs[0])
+	if !ok {
+		return
+	}
+	second, ok := h.loadRoadmap(w, r, req.IDs[1])
+	if !ok {
+		return
+	}
+
+	merged, renamed, err := models.MergeRoadmaps(first, second, req.OnConflict)
+	if err != nil {
+		if errors.Is(err, models.ErrItemIDConflict) {
+			apierror.Write(w, r, http.StatusConflict, fmt.Sprintf("Cannot merge: %v (set on_conflict to rename or skip)", err))
+		} else {
+			apierror.Write(w, r, http.StatusBadRequest, err.Error())
+		}
+		return
+	}
+	merged.Name = req.Name
+	if req.ServiceLine != "" {
+		merged.ServiceLine = req.ServiceLine
+	}
+	merged.SetBaselines(nil)
+	if err := merged.Validate(); err != nil {
+		apierror.Write(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid roadmap: %v", err))
+		return
+	}
+
+	fileName := storage.Slugify(merged.Name) + ".yaml"
+	created, err := h.storage.Create(merged, fileName, requestAuthor(r))
+	if err != nil {
+		writeStorageError(w, r, err, "store roadmap")
+		return
+	}
+
+	w.Header().Set("ETag", etag(created))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(mergeResult{StoredRoadmap: created, RenamedItems: renamed})
+}
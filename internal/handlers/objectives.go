@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"roadmap-visualizer/internal/apierror"
+	"roadmap-visualizer/internal/models"
+	"roadmap-visualizer/internal/storage"
+)
+
+// ListObjectives handles GET /api/objectives
+// Returns every objective declared by a roadmap with the items across all
+// roadmaps that contribute to it, their status counts and completion, and an
+// aggregate status. ?service_line= only looks at roadmaps in that service line.
+func (h *RoadmapHandler) ListObjectives(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	roadmaps, err := h.storage.List(storage.ListFilter{ServiceLine: r.URL.Query().Get("service_line")})
+	if err != nil {
+		apierror.Write(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to list roadmaps: %v", err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(models.GroupObjectives(roadmaps))
+}
+
+// HandleObjectives routes objective requests
+func (h *RoadmapHandler) HandleObjectives(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if r.URL.Path != "/api/objectives" {
+		apierror.Write(w, r, http.StatusNotFound, "Not found")
+		return
+	}
+
+	h.ListObjectives(w, r)
+}
@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"roadmap-visualizer/internal/apierror"
+	"roadmap-visualizer/internal/openapi"
+)
+
+// OpenAPIHandler serves the OpenAPI description of the API
+type OpenAPIHandler struct {
+	document []byte
+}
+
+// NewOpenAPIHandler builds the OpenAPI document once so every request serves
+// the same bytes
+func NewOpenAPIHandler() (*OpenAPIHandler, error) {
+	document, err := json.MarshalIndent(openapi.Build(), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return &OpenAPIHandler{document: document}, nil
+}
+
+// ServeHTTP handles GET /api/openapi.json
+func (h *OpenAPIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS so browser-based tools can load the document
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	if r.Method != http.MethodGet {
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(h.document)
+}
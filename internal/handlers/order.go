@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"roadmap-visualizer/internal/apierror"
+	"roadmap-visualizer/internal/models"
+	"strings"
+)
+
+// GetOrder handles GET /api/roadmaps/{id}/order
+// Returns the roadmap's items in an order that respects their dependencies,
+// grouped into sets that can run in parallel. Responds 409 with the cycles if
+// the dependencies loop.
+func (h *RoadmapHandler) GetOrder(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Extract ID from path
+	id := strings.TrimPrefix(r.URL.Path, "/api/roadmaps/")
+	id = strings.TrimSuffix(id, "/order")
+	if id == "" || strings.Contains(id, "/") {
+		apierror.Write(w, r, http.StatusBadRequest, "Invalid roadmap ID")
+		return
+	}
+
+	stored, err := h.storage.Get(id)
+	if err != nil {
+		writeStorageError(w, r, err, "get roadmap")
+		return
+	}
+
+	order, err := visibleRoadmap(r, stored).Order()
+	var cycleErr *models.CycleError
+	if errors.As(err, &cycleErr) {
+		apierror.WriteDetails(w, r, http.StatusConflict, fmt.Sprintf("Cannot order items: %v", err), map[string]interface{}{
+			"cycles": cycleErr.Cycles,
+		})
+		return
+	}
+
+	w.Header().Set("ETag", etag(stored))
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(order)
+}
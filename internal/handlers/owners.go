@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"roadmap-visualizer/internal/apierror"
+	"roadmap-visualizer/internal/models"
+	"roadmap-visualizer/internal/storage"
+)
+
+// ListOwners handles GET /api/owners
+// Returns every item owner and team with the number of items and roadmaps
+// assigned to them, most assigned first. ?service_line= counts only roadmaps
+// in that service line. GET /api/roadmaps?owner= lists the roadmaps involved.
+func (h *RoadmapHandler) ListOwners(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	roadmaps, err := h.storage.List(storage.ListFilter{ServiceLine: r.URL.Query().Get("service_line")})
+	if err != nil {
+		apierror.Write(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to list roadmaps: %v", err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(models.CountAssignments(roadmaps))
+}
+
+// HandleOwners routes owner requests
+func (h *RoadmapHandler) HandleOwners(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if r.URL.Path != "/api/owners" {
+		apierror.Write(w, r, http.StatusNotFound, "Not found")
+		return
+	}
+
+	h.ListOwners(w, r)
+}
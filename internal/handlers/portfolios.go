@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"roadmap-visualizer/internal/apierror"
+	"roadmap-visualizer/internal/models"
+	"roadmap-visualizer/internal/storage"
+)
+
+// ListPortfolios handles GET /api/portfolios
+// Returns the roadmaps grouped by portfolio with item counts, status counts,
+// and completion for each group. ?service_line=, ?category=, and ?tag= group
+// only matching roadmaps.
+func (h *RoadmapHandler) ListPortfolios(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	query := r.URL.Query()
+	roadmaps, err := h.storage.List(storage.ListFilter{
+		ServiceLine: query.Get("service_line"),
+		Category:    query.Get("category"),
+		Tag:         models.NormalizeTag(query.Get("tag")),
+	})
+	if err != nil {
+		apierror.Write(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to list roadmaps: %v", err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(models.GroupPortfolios(roadmaps))
+}
+
+// HandlePortfolios routes portfolio requests
+func (h *RoadmapHandler) HandlePortfolios(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if r.URL.Path != "/api/portfolios" {
+		apierror.Write(w, r, http.StatusNotFound, "Not found")
+		return
+	}
+
+	h.ListPortfolios(w, r)
+}
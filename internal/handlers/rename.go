@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"roadmap-visualizer/internal/apierror"
+	"roadmap-visualizer/internal/storage"
+	"strings"
+)
+
+// renameRequest is the body of POST /api/roadmaps/{id}/rename
+type renameRequest struct {
+	Name string `json:"name"`
+}
+
+// RenameRoadmap handles POST /api/roadmaps/{id}/rename
+// Renames the roadmap and rewrites external dependencies that refer to it by
+// name in every other roadmap, so none of them break
+func (h *RoadmapHandler) RenameRoadmap(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Extract ID from path
+	id := strings.TrimPrefix(r.URL.Path, "/api/roadmaps/")
+	id = strings.TrimSuffix(id, "/rename")
+	if id == "" || strings.Contains(id, "/") {
+		apierror.Write(w, r, http.StatusBadRequest, "Invalid roadmap ID")
+		return
+	}
+
+	var req renameRequest
+	body := h.limitBody(w, r)
+	defer r.Body.Close()
+	if err := json.NewDecoder(body).Decode(&req); err != nil {
+		if body.tooLarge() {
+			h.writeTooLarge(w, r)
+			return
+		}
+		apierror.Write(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid request body: %v", err))
+		return
+	}
+	if strings.TrimSpace(req.Name) == "" {
+		apierror.Write(w, r, http.StatusBadRequest, "name is required")
+		return
+	}
+
+	stored, ok := h.loadRoadmap(w, r, id)
+	if !ok {
+		return
+	}
+	if !h.checkIfMatch(w, r, stored) {
+		return
+	}
+
+	result, err := storage.RenameRoadmap(h.storage, id, req.Name, requestAuthor(r), stored.CurrentRevision())
+	if err != nil {
+		if errors.Is(err, storage.ErrRevisionMismatch) {
+			apierror.Write(w, r, http.StatusPreconditionFailed, "A roadmap was modified during the rename; reload and retry")
+		} else if errors.Is(err, storage.ErrNameTaken) {
+			apierror.Write(w, r, http.StatusConflict, fmt.Sprintf("Cannot rename: %v", err))
+		} else {
+			writeStorageError(w, r, err, "rename roadmap")
+		}
+		return
+	}
+
+	w.Header().Set("ETag", etag(result.Roadmap))
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
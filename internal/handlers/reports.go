@@ -0,0 +1,224 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"roadmap-visualizer/internal/apierror"
+	"roadmap-visualizer/internal/models"
+	"roadmap-visualizer/internal/storage"
+)
+
+// EffortReport handles GET /api/reports/effort
+// Totals item effort estimates in person-weeks per roadmap, service line, and
+// owner. ?service_line= counts only roadmaps in that service line.
+func (h *RoadmapHandler) EffortReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	roadmaps, err := h.storage.List(storage.ListFilter{ServiceLine: r.URL.Query().Get("service_line")})
+	if err != nil {
+		apierror.Write(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to list roadmaps: %v", err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(models.ReportEffort(roadmaps))
+}
+
+// RiskReport handles GET /api/reports/risks
+// Lists the high-risk items that are not completed across all roadmaps, with
+// their dependencies. ?level=medium also includes medium-risk items, and
+// ?service_line= only looks at roadmaps in that service line.
+func (h *RoadmapHandler) RiskReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	query := r.URL.Query()
+	level := models.RiskHigh
+	if value := query.Get("level"); value != "" {
+		if err := models.ValidateRiskLevel(value); err != nil {
+			apierror.Write(w, r, http.StatusBadRequest, err.Error())
+			return
+		}
+		level = models.RiskLevel(value)
+	}
+
+	// Dependencies may be in any service line, so every roadmap is loaded
+	roadmaps, err := h.storage.List(storage.ListFilter{})
+	if err != nil {
+		apierror.Write(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to list roadmaps: %v", err))
+		return
+	}
+
+	entries := models.ReportRisks(roadmaps, level)
+	if serviceLine := query.Get("service_line"); serviceLine != "" {
+		filtered := entries[:0]
+		for _, entry := range entries {
+			if entry.ServiceLine == serviceLine {
+				filtered = append(filtered, entry)
+			}
+		}
+		entries = filtered
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(entries)
+}
+
+// SpendReport handles GET /api/reports/spend
+// Totals item budgets and actual costs per roadmap, and per service line and
+// currency. ?service_line= counts only roadmaps in that service line.
+func (h *RoadmapHandler) SpendReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	roadmaps, err := h.storage.List(storage.ListFilter{ServiceLine: r.URL.Query().Get("service_line")})
+	if err != nil {
+		apierror.Write(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to list roadmaps: %v", err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(models.ReportSpend(roadmaps))
+}
+
+// CycleTimeReport handles GET /api/reports/cycle-time
+// Summarizes how long completed items took from first going in progress to
+// being completed, per roadmap and service line. ?service_line= counts only
+// roadmaps in that service line.
+func (h *RoadmapHandler) CycleTimeReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	roadmaps, err := h.storage.List(storage.ListFilter{ServiceLine: r.URL.Query().Get("service_line")})
+	if err != nil {
+		apierror.Write(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to list roadmaps: %v", err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(models.ReportCycleTimes(roadmaps))
+}
+
+// TypeReport handles GET /api/reports/types
+// Counts the items of each type per roadmap and service line, to show the mix
+// of feature and maintenance work. ?service_line= counts only roadmaps in that
+// service line.
+func (h *RoadmapHandler) TypeReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	roadmaps, err := h.storage.List(storage.ListFilter{ServiceLine: r.URL.Query().Get("service_line")})
+	if err != nil {
+		apierror.Write(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to list roadmaps: %v", err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(models.ReportItemTypes(roadmaps))
+}
+
+// CapacityReport handles GET /api/reports/capacity
+// Compares, month by month, the people items allocate from each team with the
+// team's capacity, flagging over-allocated months. ?service_line= counts only
+// roadmaps in that service line, and ?over_allocated=true keeps only the
+// over-allocated months.
+func (h *RoadmapHandler) CapacityReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	query := r.URL.Query()
+	roadmaps, err := h.storage.List(storage.ListFilter{ServiceLine: query.Get("service_line")})
+	if err != nil {
+		apierror.Write(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to list roadmaps: %v", err))
+		return
+	}
+
+	teams := models.ReportCapacity(roadmaps)
+	if query.Get("over_allocated") == "true" {
+		for i := range teams {
+			months := teams[i].Months[:0]
+			for _, month := range teams[i].Months {
+				if month.OverAllocated {
+					months = append(months, month)
+				}
+			}
+			teams[i].Months = months
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(teams)
+}
+
+// DependencyMatrixReport handles GET /api/reports/dependency-matrix
+// Counts the external dependencies between every pair of roadmaps, split by
+// criticality, as a matrix for a heatmap of which teams are most coupled.
+// ?service_line= keeps only roadmaps in that service line on both axes.
+// Archived roadmaps and items are left out unless ?include_archived=true.
+func (h *RoadmapHandler) DependencyMatrixReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	roadmaps, err := h.storage.List(storage.ListFilter{
+		ServiceLine:     r.URL.Query().Get("service_line"),
+		ExcludeArchived: !includeArchived(r),
+	})
+	if err != nil {
+		apierror.Write(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to list roadmaps: %v", err))
+		return
+	}
+	for i, rm := range roadmaps {
+		roadmaps[i] = visibleRoadmap(r, rm)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(models.ReportDependencyMatrix(roadmaps))
+}
+
+// HandleReports routes report requests
+func (h *RoadmapHandler) HandleReports(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	switch r.URL.Path {
+	case "/api/reports/effort":
+		h.EffortReport(w, r)
+	case "/api/reports/risks":
+		h.RiskReport(w, r)
+	case "/api/reports/spend":
+		h.SpendReport(w, r)
+	case "/api/reports/cycle-time":
+		h.CycleTimeReport(w, r)
+	case "/api/reports/types":
+		h.TypeReport(w, r)
+	case "/api/reports/capacity":
+		h.CapacityReport(w, r)
+	case "/api/reports/dependency-matrix":
+		h.DependencyMatrixReport(w, r)
+	default:
+		apierror.Write(w, r, http.StatusNotFound, "Not found")
+	}
+}
@@ -2,48 +2,242 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"path/filepath"
+	"roadmap-visualizer/internal/apierror"
+	"roadmap-visualizer/internal/auth"
+	"roadmap-visualizer/internal/jira"
 	"roadmap-visualizer/internal/models"
 	"roadmap-visualizer/internal/parser"
 	"roadmap-visualizer/internal/storage"
+	"strconv"
 	"strings"
+	"time"
 )
 
+// Config holds optional behavior for RoadmapHandler
+type Config struct {
+	// SoftDelete moves deleted roadmaps to the trash instead of removing them
+	SoftDelete bool
+	// RequireIfMatch rejects updates and deletes under /api/v1 that don't send
+	// an If-Match header; the unversioned aliases never require it
+	RequireIfMatch bool
+	// MaxUploadBytes caps the size of request bodies; zero or less means no limit
+	MaxUploadBytes int64
+	// Dependents answers dependents queries from a reverse index; without it
+	// every roadmap is loaded and scanned
+	Dependents *storage.DependentsIndex
+	// Jira is the site POST /api/import/jira imports from; without it Jira
+	// import is unavailable
+	Jira *jira.Client
+}
+
 // RoadmapHandler handles roadmap-related HTTP requests
 type RoadmapHandler struct {
-	storage *storage.FileStorage
+	storage storage.Storage
+	config  Config
 }
 
 // NewRoadmapHandler creates a new roadmap handler
-func NewRoadmapHandler(storage *storage.FileStorage) *RoadmapHandler {
+func NewRoadmapHandler(storage storage.Storage, config Config) *RoadmapHandler {
 	return &RoadmapHandler{
 		storage: storage,
+		config:  config,
+	}
+}
+
+// Values of the on_duplicate query parameter, which controls what happens
+// when an upload has the same content as a stored roadmap
+const (
+	duplicateReturn = "return" // respond 200 with the existing roadmap (default)
+	duplicateReject = "reject" // respond 409
+	duplicateAllow  = "allow"  // store another copy
+)
+
+// createResult is the response for an uploaded roadmap. Duplicate is set when
+// an existing roadmap with identical content was returned instead.
+type createResult struct {
+	*models.StoredRoadmap
+	Duplicate bool `json:"duplicate"`
+}
+
+// listResult is a roadmap in the full list response. Completion is the
+// roadmap's done percentage, as in the summary view.
+type listResult struct {
+	*models.StoredRoadmap
+	Completion int `json:"completion"`
+	healthResult
+	EffectiveStatus map[string]models.EffectiveStatus `json:"effective_status"`
+}
+
+// healthResult is the health of a roadmap and of each of its items, worked
+// out when the response is written
+type healthResult struct {
+	Health     models.Health            `json:"health"`
+	ItemHealth map[string]models.Health `json:"item_health"`
+}
+
+// getResult is the response for a single roadmap
+type getResult struct {
+	*models.StoredRoadmap
+	healthResult
+	EffectiveStatus   map[string]models.EffectiveStatus `json:"effective_status"`
+	BlockedByExternal models.ExternalExposure           `json:"blocked_by_external"`
+}
+
+// includeArchived reads ?include_archived from the request
+func includeArchived(r *http.Request) bool {
+	return r.URL.Query().Get("include_archived") == "true"
+}
+
+// visibleRoadmap returns the stored roadmap as the request should see it,
+// without archived items unless ?include_archived=true
+func visibleRoadmap(r *http.Request, stored *models.StoredRoadmap) *models.StoredRoadmap {
+	if includeArchived(r) {
+		return stored
+	}
+	return stored.WithoutArchived()
+}
+
+// newHealthResult works out the health of a stored roadmap now
+func newHealthResult(stored *models.StoredRoadmap) healthResult {
+	now := time.Now()
+	return healthResult{
+		Health:     stored.Roadmap.Health(now),
+		ItemHealth: stored.Roadmap.ItemHealth(now),
+	}
+}
+
+// itemStatuses picks the effective statuses of the roadmap's items out of all
+// of them, so archived items left out of the response are left out here too
+func itemStatuses(all map[string]map[string]models.EffectiveStatus, stored *models.StoredRoadmap) map[string]models.EffectiveStatus {
+	statuses := make(map[string]models.EffectiveStatus, len(stored.Roadmap.Items))
+	for _, item := range stored.Roadmap.Items {
+		if status, ok := all[stored.ID][item.ID]; ok {
+			statuses[item.ID] = status
+		}
+	}
+	return statuses
+}
+
+// duplicatePolicy reads ?on_duplicate from the request
+func duplicatePolicy(r *http.Request) (string, error) {
+	policy := r.URL.Query().Get("on_duplicate")
+	switch policy {
+	case "":
+		return duplicateReturn, nil
+	case duplicateReturn, duplicateReject, duplicateAllow:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("invalid on_duplicate %q (must be return, reject, or allow)", policy)
+	}
+}
+
+// roadmapsByContent returns stored roadmaps keyed by content hash
+func (h *RoadmapHandler) roadmapsByContent() (map[string]*models.StoredRoadmap, error) {
+	roadmaps, err := h.storage.List(storage.ListFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	byHash := make(map[string]*models.StoredRoadmap, len(roadmaps))
+	for _, rm := range roadmaps {
+		hash := rm.Roadmap.ContentHash()
+		if _, ok := byHash[hash]; !ok {
+			byHash[hash] = rm
+		}
+	}
+
+	return byHash, nil
+}
+
+// uploadBody reads a size-limited request body and remembers why a read
+// failed, since the YAML decoder reports reader errors only as text
+type uploadBody struct {
+	r   io.Reader
+	err error
+}
+
+func (b *uploadBody) Read(p []byte) (int, error) {
+	n, err := b.r.Read(p)
+	if err != nil && err != io.EOF {
+		b.err = err
+	}
+	return n, err
+}
+
+// tooLarge reports whether reading stopped at the upload size limit
+func (b *uploadBody) tooLarge() bool {
+	var maxErr *http.MaxBytesError
+	return errors.As(b.err, &maxErr)
+}
+
+// limitBody wraps the request body so reads fail past MaxUploadBytes
+func (h *RoadmapHandler) limitBody(w http.ResponseWriter, r *http.Request) *uploadBody {
+	if h.config.MaxUploadBytes <= 0 {
+		return &uploadBody{r: r.Body}
 	}
+	return &uploadBody{r: http.MaxBytesReader(w, r.Body, h.config.MaxUploadBytes)}
 }
 
 // CreateRoadmap handles POST /api/roadmaps
+// Uploading content identical to a stored roadmap is handled per ?on_duplicate
+// The body is YAML, JSON of the same schema with Content-Type application/json,
+// or Markdown as read by parser.DecodeRoadmapMarkdown with text/markdown
+// multipart/form-data uploads are handled as by createFromForm
 func (h *RoadmapHandler) CreateRoadmap(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
-	// Read the request body
-	body, err := io.ReadAll(r.Body)
+	policy, err := duplicatePolicy(r)
 	if err != nil {
-		http.Error(w, "Failed to read request body", http.StatusBadRequest)
+		apierror.Write(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if isMultipart(r) {
+		h.createFromForm(w, r, policy)
 		return
 	}
+
+	// Parse the roadmap straight from the request body
+	body := h.limitBody(w, r)
 	defer r.Body.Close()
 
-	// Parse YAML
-	roadmap, err := parser.ParseRoadmap(body)
+	roadmap, err := roadmapDecoder(r)(body)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Invalid roadmap: %v", err), http.StatusBadRequest)
+		if body.tooLarge() {
+			h.writeTooLarge(w, r)
+			return
+		}
+		apierror.Write(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid roadmap: %v", err))
 		return
 	}
+	roadmap.SetBaselines(nil)
+
+	if policy != duplicateAllow {
+		existing, err := h.roadmapsByContent()
+		if err != nil {
+			apierror.Write(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to check for duplicates: %v", err))
+			return
+		}
+
+		if duplicate, ok := existing[roadmap.ContentHash()]; ok {
+			if policy == duplicateReject {
+				apierror.Write(w, r, http.StatusConflict, fmt.Sprintf("Identical roadmap already exists: %s", duplicate.ID))
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(createResult{StoredRoadmap: duplicate, Duplicate: true})
+			return
+		}
+	}
 
 	// Store roadmap
 	fileName := "uploaded.yaml"
@@ -51,38 +245,55 @@ func (h *RoadmapHandler) CreateRoadmap(w http.ResponseWriter, r *http.Request) {
 		fileName = fileNameHeader
 	}
 
-	stored, err := h.storage.Create(roadmap, fileName)
+	stored, err := h.storage.Create(roadmap, fileName, requestAuthor(r))
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to store roadmap: %v", err), http.StatusInternalServerError)
+		writeStorageError(w, r, err, "store roadmap")
 		return
 	}
 
 	// Return created roadmap
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(stored)
+	json.NewEncoder(w).Encode(createResult{StoredRoadmap: stored})
 }
 
 // CreateMultipleRoadmaps handles POST /api/roadmaps/batch
 // This endpoint parses files with multiple roadmap documents separated by ---
+// Duplicates, including repeats within the file, are handled per ?on_duplicate
+// With Content-Type application/json the body is a JSON array of documents instead
+// multipart/form-data uploads are handled as by createFromForm
 func (h *RoadmapHandler) CreateMultipleRoadmaps(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
-	// Read the request body
-	body, err := io.ReadAll(r.Body)
+	policy, err := duplicatePolicy(r)
 	if err != nil {
-		http.Error(w, "Failed to read request body", http.StatusBadRequest)
+		apierror.Write(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if isMultipart(r) {
+		h.createFromForm(w, r, policy)
 		return
 	}
+
+	// Parse multiple roadmaps from YAML or JSON as the request body streams in
+	body := h.limitBody(w, r)
 	defer r.Body.Close()
 
-	// Parse multiple roadmaps from YAML
-	roadmaps, err := parser.ParseMultipleRoadmaps(body)
+	decode := parser.DecodeMultipleRoadmaps
+	if isJSON(r) {
+		decode = parser.DecodeMultipleRoadmapsJSON
+	}
+	roadmaps, err := decode(body)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Invalid roadmap file: %v", err), http.StatusBadRequest)
+		if body.tooLarge() {
+			h.writeTooLarge(w, r)
+			return
+		}
+		apierror.Write(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid roadmap file: %v", err))
 		return
 	}
 
@@ -92,26 +303,98 @@ func (h *RoadmapHandler) CreateMultipleRoadmaps(w http.ResponseWriter, r *http.R
 		baseFileName = fileNameHeader
 	}
 
+	// Create unique filename for each roadmap
+	uploads := make([]upload, len(roadmaps))
+	for i, roadmap := range roadmaps {
+		uploads[i] = upload{roadmap: roadmap, fileName: partFileName(baseFileName, i)}
+	}
+
+	h.storeUploads(w, r, policy, uploads)
+}
+
+// upload is a parsed roadmap waiting to be stored under fileName
+type upload struct {
+	roadmap  *models.Roadmap
+	fileName string
+}
+
+// partFileName names the i-th (0-based) roadmap of a multi-document file
+func partFileName(baseFileName string, i int) string {
+	return fmt.Sprintf("%s-part%d.yaml", strings.TrimSuffix(baseFileName, filepath.Ext(baseFileName)), i+1)
+}
+
+// storeUploads stores several uploaded roadmaps and responds with all of them.
+// Duplicates, including repeats within the upload, are handled per policy.
+func (h *RoadmapHandler) storeUploads(w http.ResponseWriter, r *http.Request, policy string, uploads []upload) {
+	// Baselines are part of the content, so they're set before comparing
+	for _, u := range uploads {
+		u.roadmap.SetBaselines(nil)
+	}
+
+	existing := make(map[string]*models.StoredRoadmap)
+	if policy != duplicateAllow {
+		var err error
+		existing, err = h.roadmapsByContent()
+		if err != nil {
+			apierror.Write(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to check for duplicates: %v", err))
+			return
+		}
+	}
+
+	// Reject before storing anything so the batch isn't half applied
+	if checker, ok := h.storage.(roadmapChecker); ok {
+		for i, u := range uploads {
+			if err := checker.CheckRoadmap(u.roadmap); err != nil {
+				apierror.Write(w, r, http.StatusBadRequest, fmt.Sprintf("Roadmap %d (%s): %v", i+1, u.roadmap.Name, err))
+				return
+			}
+		}
+	}
+	if policy == duplicateReject {
+		seen := make(map[string]bool)
+		for i, u := range uploads {
+			hash := u.roadmap.ContentHash()
+			if duplicate, ok := existing[hash]; ok {
+				apierror.Write(w, r, http.StatusConflict, fmt.Sprintf("Roadmap %d (%s): identical roadmap already exists: %s", i+1, u.roadmap.Name, duplicate.ID))
+				return
+			}
+			if seen[hash] {
+				apierror.Write(w, r, http.StatusConflict, fmt.Sprintf("Roadmap %d (%s): repeats an earlier roadmap in the upload", i+1, u.roadmap.Name))
+				return
+			}
+			seen[hash] = true
+		}
+	}
+
 	// Store each roadmap
 	var storedRoadmaps []interface{}
-	for i, roadmap := range roadmaps {
-		// Create unique filename for each roadmap
-		fileName := fmt.Sprintf("%s-part%d.yaml", strings.TrimSuffix(baseFileName, ".yaml"), i+1)
+	duplicates := 0
+	for i, u := range uploads {
+		hash := u.roadmap.ContentHash()
+		if duplicate, ok := existing[hash]; ok && policy == duplicateReturn {
+			storedRoadmaps = append(storedRoadmaps, createResult{StoredRoadmap: duplicate, Duplicate: true})
+			duplicates++
+			continue
+		}
 
-		stored, err := h.storage.Create(roadmap, fileName)
+		stored, err := h.storage.Create(u.roadmap, u.fileName, requestAuthor(r))
 		if err != nil {
 			// If we fail partway through, we've already stored some roadmaps
 			// Return an error but also include what was stored
-			http.Error(w, fmt.Sprintf("Failed to store roadmap %d (%s): %v", i+1, roadmap.Name, err), http.StatusInternalServerError)
+			apierror.Write(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to store roadmap %d (%s): %v", i+1, u.roadmap.Name, err))
 			return
 		}
-		storedRoadmaps = append(storedRoadmaps, stored)
+		storedRoadmaps = append(storedRoadmaps, createResult{StoredRoadmap: stored})
+		if policy != duplicateAllow {
+			existing[hash] = stored
+		}
 	}
 
 	// Return all created roadmaps
 	response := map[string]interface{}{
-		"count":    len(storedRoadmaps),
-		"roadmaps": storedRoadmaps,
+		"count":      len(storedRoadmaps),
+		"duplicates": duplicates,
+		"roadmaps":   storedRoadmaps,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -120,82 +403,664 @@ func (h *RoadmapHandler) CreateMultipleRoadmaps(w http.ResponseWriter, r *http.R
 }
 
 // ListRoadmaps handles GET /api/roadmaps
+// ?view=summary returns lightweight summaries instead of full roadmaps.
+// ?service_line=, ?owner=, ?status=, ?confidence=, ?type=, ?health=, and ?from=/?to= narrow the list.
+// ?sort=name|created_at|updated_at|service_line with ?order=asc|desc orders it.
+// Archived roadmaps and items are left out unless ?include_archived=true.
+// Supports If-None-Match and If-Modified-Since; only the ETag reflects deletions.
 func (h *RoadmapHandler) ListRoadmaps(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	view := r.URL.Query().Get("view")
+	if view != "" && view != "full" && view != "summary" {
+		apierror.Write(w, r, http.StatusBadRequest, "Invalid view (must be full or summary)")
+		return
+	}
+
+	query := r.URL.Query()
+	filter := storage.ListFilter{
+		ServiceLine: query.Get("service_line"),
+		Owner:       query.Get("owner"),
+		Status:      models.RoadmapStatus(query.Get("status")),
+		Confidence:  models.Confidence(query.Get("confidence")),
+		Type:        query.Get("type"),
+		Health:      models.Health(query.Get("health")),
+		From:        query.Get("from"),
+		To:          query.Get("to"),
+		Portfolio:   query.Get("portfolio"),
+		Category:    query.Get("category"),
+		Tag:         models.NormalizeTag(query.Get("tag")),
+		Custom:      customFilter(query),
+	}
+	filter.ExcludeArchived = !includeArchived(r)
+	if err := filter.Validate(); err != nil {
+		apierror.Write(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid filter: %v", err))
 		return
 	}
 
-	roadmaps, err := h.storage.List()
+	sortBy := query.Get("sort")
+	if sortBy == "" {
+		sortBy = storage.SortByCreatedAt
+	}
+	order := query.Get("order")
+	if order != "" && order != "asc" && order != "desc" {
+		apierror.Write(w, r, http.StatusBadRequest, "Invalid order (must be asc or desc)")
+		return
+	}
+
+	roadmaps, err := h.storage.List(filter)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to list roadmaps: %v", err), http.StatusInternalServerError)
+		apierror.Write(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to list roadmaps: %v", err))
+		return
+	}
+	if err := storage.SortRoadmaps(roadmaps, sortBy, order == "desc"); err != nil {
+		apierror.Write(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	if roadmaps == nil {
+		roadmaps = []*models.StoredRoadmap{}
+	}
+
+	// Effective statuses are worked out from every roadmap, archived ones
+	// included, since any of them may hold an item up, and with health they
+	// move as days pass, so the ETag covers all of them and the date. The
+	// response has no Last-Modified, which couldn't say as much.
+	all, err := h.storage.List(storage.ListFilter{})
+	if err != nil {
+		apierror.Write(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to list roadmaps: %v", err))
+		return
+	}
+	now := time.Now()
+	if notModified(w, r, computedETag(r, all, now), time.Time{}) {
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(roadmaps)
+
+	if view == "summary" {
+		summaries := make([]models.RoadmapSummary, len(roadmaps))
+		for i, rm := range roadmaps {
+			summaries[i] = visibleRoadmap(r, rm).Summary()
+		}
+		json.NewEncoder(w).Encode(summaries)
+		return
+	}
+
+	statuses := models.EffectiveStatuses(all, now)
+	results := make([]listResult, len(roadmaps))
+	for i, rm := range roadmaps {
+		rm = visibleRoadmap(r, rm)
+		results[i] = listResult{
+			StoredRoadmap:   rm,
+			Completion:      rm.Roadmap.Completion(),
+			healthResult:    newHealthResult(rm),
+			EffectiveStatus: itemStatuses(statuses, rm),
+		}
+	}
+	json.NewEncoder(w).Encode(results)
+}
+
+// customFilter collects ?custom.<name>=<value> parameters into a custom field
+// filter, or returns nil if there are none
+func customFilter(query url.Values) map[string]string {
+	var fields map[string]string
+	for key, values := range query {
+		if name, ok := strings.CutPrefix(key, "custom."); ok {
+			if fields == nil {
+				fields = make(map[string]string)
+			}
+			fields[name] = values[0]
+		}
+	}
+	return fields
 }
 
 // GetRoadmap handles GET /api/roadmaps/{id}
+// Archived items are left out unless ?include_archived=true.
 func (h *RoadmapHandler) GetRoadmap(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
 	// Extract ID from path
 	id := strings.TrimPrefix(r.URL.Path, "/api/roadmaps/")
 	if id == "" || strings.Contains(id, "/") {
-		http.Error(w, "Invalid roadmap ID", http.StatusBadRequest)
+		apierror.Write(w, r, http.StatusBadRequest, "Invalid roadmap ID")
 		return
 	}
 
 	stored, err := h.storage.Get(id)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			http.Error(w, "Roadmap not found", http.StatusNotFound)
-		} else {
-			http.Error(w, fmt.Sprintf("Failed to get roadmap: %v", err), http.StatusInternalServerError)
+		writeStorageError(w, r, err, "get roadmap")
+		return
+	}
+
+	// The ETag is the revision, for If-Match on writes. It can't answer
+	// If-None-Match, since health and effective statuses also depend on other
+	// roadmaps and the date, so the roadmap is always sent in full.
+	w.Header().Set("ETag", etag(stored))
+
+	// Every roadmap, archived ones included, may hold an item up
+	roadmaps, err := h.storage.List(storage.ListFilter{})
+	if err != nil {
+		apierror.Write(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to list roadmaps: %v", err))
+		return
+	}
+	now := time.Now()
+
+	w.Header().Set("Content-Type", "application/json")
+	stored = visibleRoadmap(r, stored)
+	json.NewEncoder(w).Encode(getResult{
+		StoredRoadmap:     stored,
+		healthResult:      newHealthResult(stored),
+		EffectiveStatus:   itemStatuses(models.EffectiveStatuses(roadmaps, now), stored),
+		BlockedByExternal: models.BlockedByExternal(stored, roadmaps, now),
+	})
+}
+
+// PatchRoadmap handles PATCH /api/roadmaps/{id}
+// Accepts a JSON merge patch; "items" may be keyed by item ID to edit single items
+func (h *RoadmapHandler) PatchRoadmap(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPatch {
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Extract ID from path
+	id := strings.TrimPrefix(r.URL.Path, "/api/roadmaps/")
+	if id == "" || strings.Contains(id, "/") {
+		apierror.Write(w, r, http.StatusBadRequest, "Invalid roadmap ID")
+		return
+	}
+
+	// Read the request body
+	body, err := io.ReadAll(h.limitBody(w, r))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			h.writeTooLarge(w, r)
+			return
 		}
+		apierror.Write(w, r, http.StatusBadRequest, "Failed to read request body")
+		return
+	}
+	defer r.Body.Close()
+
+	stored, err := h.storage.Get(id)
+	if err != nil {
+		writeStorageError(w, r, err, "get roadmap")
+		return
+	}
+
+	if !h.checkIfMatch(w, r, stored) {
+		return
+	}
+
+	// Merge the patch and re-validate the result
+	patched, err := stored.Roadmap.ApplyMergePatch(body)
+	if err != nil {
+		apierror.Write(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid patch: %v", err))
+		return
+	}
+	patched.NormalizeTags()
+	if err := patched.ExpandRecurrences(); err != nil {
+		apierror.Write(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid roadmap: %v", err))
+		return
+	}
+	if err := patched.Validate(); err != nil {
+		apierror.Write(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid roadmap: %v", err))
+		return
+	}
+	patched.StampStatusChanges(&stored.Roadmap, time.Now().UTC(), requestAuthor(r))
+	patched.SetBaselines(&stored.Roadmap)
+
+	// The patch was applied to this revision, so only store it if nothing
+	// else has been written in the meantime
+	updated, err := h.storage.Update(id, patched, requestAuthor(r), stored.CurrentRevision())
+	if err != nil {
+		writeStorageError(w, r, err, "update roadmap")
 		return
 	}
 
+	w.Header().Set("ETag", etag(updated))
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(stored)
+	json.NewEncoder(w).Encode(updated)
 }
 
 // DeleteRoadmap handles DELETE /api/roadmaps/{id}
+// With soft delete enabled the roadmap is moved to the trash unless ?permanent=true.
+// Responds 409 with the dependents if items in other roadmaps depend on it,
+// unless ?force=true.
 func (h *RoadmapHandler) DeleteRoadmap(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodDelete {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
 	// Extract ID from path
 	id := strings.TrimPrefix(r.URL.Path, "/api/roadmaps/")
 	if id == "" || strings.Contains(id, "/") {
-		http.Error(w, "Invalid roadmap ID", http.StatusBadRequest)
+		apierror.Write(w, r, http.StatusBadRequest, "Invalid roadmap ID")
 		return
 	}
 
-	err := h.storage.Delete(id)
+	if r.Header.Get("If-Match") != "" || h.requireIfMatch(r) {
+		stored, err := h.storage.Get(id)
+		if err != nil {
+			writeStorageError(w, r, err, "get roadmap")
+			return
+		}
+		if !h.checkIfMatch(w, r, stored) {
+			return
+		}
+	}
+
+	// Other teams' roadmaps would be left with dependencies that no longer
+	// resolve, so make the caller confirm
+	if r.URL.Query().Get("force") != "true" {
+		err := storage.CheckDelete(h.storage, h.config.Dependents, id, nil)
+		var dependentsErr *storage.DependentsError
+		if errors.As(err, &dependentsErr) {
+			apierror.WriteDetails(w, r, http.StatusConflict,
+				"Items in other roadmaps depend on this roadmap; delete with force=true to break their dependencies",
+				map[string]interface{}{"dependents": dependentsErr.Dependents})
+			return
+		}
+		if err != nil {
+			apierror.Write(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to find dependents: %v", err))
+			return
+		}
+	}
+
+	var err error
+	if h.config.SoftDelete && r.URL.Query().Get("permanent") != "true" {
+		err = h.storage.Trash(id)
+	} else {
+		err = h.storage.Delete(id)
+	}
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			http.Error(w, "Roadmap not found", http.StatusNotFound)
+		writeStorageError(w, r, err, "delete roadmap")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// batchDeleteRequest is the body of DELETE /api/roadmaps
+type batchDeleteRequest struct {
+	IDs []string `json:"ids"`
+}
+
+// batchDeleteResult reports what happened to one roadmap in a batch delete
+type batchDeleteResult struct {
+	ID         string                     `json:"id"`
+	Deleted    bool                       `json:"deleted"`
+	Error      string                     `json:"error,omitempty"`
+	Dependents []models.ExternalDependent `json:"dependents,omitempty"`
+}
+
+// DeleteRoadmaps handles DELETE /api/roadmaps
+// Deletes the roadmaps listed in a JSON body of {"ids": [...]}, or every
+// roadmap matching ?service_line=, and reports the outcome for each ID.
+// Soft delete and ?force=true apply as for single deletes, except that
+// dependents among the roadmaps being deleted don't count; If-Match is not
+// checked.
+func (h *RoadmapHandler) DeleteRoadmaps(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req batchDeleteRequest
+	body := h.limitBody(w, r)
+	defer r.Body.Close()
+	if err := json.NewDecoder(body).Decode(&req); err != nil && err != io.EOF {
+		if body.tooLarge() {
+			h.writeTooLarge(w, r)
+			return
+		}
+		apierror.Write(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid request body: %v", err))
+		return
+	}
+
+	serviceLine := r.URL.Query().Get("service_line")
+	switch {
+	case len(req.IDs) > 0 && serviceLine != "":
+		apierror.Write(w, r, http.StatusBadRequest, "Specify either ids or service_line, not both")
+		return
+	case len(req.IDs) == 0 && serviceLine == "":
+		apierror.Write(w, r, http.StatusBadRequest, "Specify the roadmaps to delete with ids or service_line")
+		return
+	}
+
+	ids := req.IDs
+	if serviceLine != "" {
+		roadmaps, err := h.storage.List(storage.ListFilter{ServiceLine: serviceLine})
+		if err != nil {
+			apierror.Write(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to list roadmaps: %v", err))
+			return
+		}
+		for _, rm := range roadmaps {
+			ids = append(ids, rm.ID)
+		}
+	}
+
+	batch := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		batch[id] = true
+	}
+
+	trash := h.config.SoftDelete && r.URL.Query().Get("permanent") != "true"
+	force := r.URL.Query().Get("force") == "true"
+	results := make([]batchDeleteResult, 0, len(ids))
+	deleted := 0
+	for _, id := range ids {
+		if !force {
+			err := storage.CheckDelete(h.storage, h.config.Dependents, id, batch)
+			if err != nil {
+				result := batchDeleteResult{ID: id, Error: err.Error()}
+				var dependentsErr *storage.DependentsError
+				if errors.As(err, &dependentsErr) {
+					result.Dependents = dependentsErr.Dependents
+				}
+				results = append(results, result)
+				continue
+			}
+		}
+
+		var err error
+		if trash {
+			err = h.storage.Trash(id)
 		} else {
-			http.Error(w, fmt.Sprintf("Failed to delete roadmap: %v", err), http.StatusInternalServerError)
+			err = h.storage.Delete(id)
 		}
+		if err != nil {
+			results = append(results, batchDeleteResult{ID: id, Error: err.Error()})
+			continue
+		}
+		results = append(results, batchDeleteResult{ID: id, Deleted: true})
+		deleted++
+	}
+
+	response := map[string]interface{}{
+		"deleted": deleted,
+		"failed":  len(results) - deleted,
+		"results": results,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// RestoreRoadmap handles POST /api/roadmaps/{id}/restore
+// Moves a soft-deleted roadmap out of the trash
+func (h *RoadmapHandler) RestoreRoadmap(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Extract ID from path
+	id := strings.TrimPrefix(r.URL.Path, "/api/roadmaps/")
+	id = strings.TrimSuffix(id, "/restore")
+	if id == "" || strings.Contains(id, "/") {
+		apierror.Write(w, r, http.StatusBadRequest, "Invalid roadmap ID")
+		return
+	}
+
+	stored, err := h.storage.Restore(id)
+	if err != nil {
+		writeStorageError(w, r, err, "restore roadmap")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(stored)
+}
+
+// ListTrash handles GET /api/trash
+func (h *RoadmapHandler) ListTrash(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	roadmaps, err := h.storage.ListTrash()
+	if err != nil {
+		apierror.Write(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to list trash: %v", err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(roadmaps)
+}
+
+// PurgeRoadmap handles DELETE /api/trash/{id}
+// Permanently removes a roadmap from the trash
+func (h *RoadmapHandler) PurgeRoadmap(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Extract ID from path
+	id := strings.TrimPrefix(r.URL.Path, "/api/trash/")
+	if id == "" || strings.Contains(id, "/") {
+		apierror.Write(w, r, http.StatusBadRequest, "Invalid roadmap ID")
+		return
+	}
+
+	if err := h.storage.Purge(id); err != nil {
+		writeStorageError(w, r, err, "purge roadmap")
 		return
 	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// ListRevisions handles GET /api/roadmaps/{id}/revisions
+// Returns the revision history without the roadmap content
+func (h *RoadmapHandler) ListRevisions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Extract ID from path
+	id := strings.TrimPrefix(r.URL.Path, "/api/roadmaps/")
+	id = strings.TrimSuffix(id, "/revisions")
+	if id == "" || strings.Contains(id, "/") {
+		apierror.Write(w, r, http.StatusBadRequest, "Invalid roadmap ID")
+		return
+	}
+
+	revisions, err := h.storage.ListRevisions(id)
+	if err != nil {
+		writeStorageError(w, r, err, "list revisions")
+		return
+	}
+
+	type RevisionSummary struct {
+		Revision  int       `json:"revision"`
+		CreatedAt time.Time `json:"created_at"`
+		Author    string    `json:"author,omitempty"`
+	}
+
+	summaries := make([]RevisionSummary, 0, len(revisions))
+	for _, rev := range revisions {
+		summaries = append(summaries, RevisionSummary{
+			Revision:  rev.Number,
+			CreatedAt: rev.CreatedAt,
+			Author:    rev.Author,
+		})
+	}
+
+	response := map[string]interface{}{
+		"roadmap_id": id,
+		"revisions":  summaries,
+		"count":      len(summaries),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetRevision handles GET /api/roadmaps/{id}/revisions/{n}
+func (h *RoadmapHandler) GetRevision(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	id, revision, ok := parseRevisionPath(r.URL.Path, "")
+	if !ok {
+		apierror.Write(w, r, http.StatusBadRequest, "Invalid revision path")
+		return
+	}
+
+	rev, err := h.storage.GetRevision(id, revision)
+	if err != nil {
+		writeStorageError(w, r, err, "get revision")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(rev)
+}
+
+// RestoreRevision handles POST /api/roadmaps/{id}/revisions/{n}/restore
+// Restoring records the old content as a new revision, so history is never rewritten
+func (h *RoadmapHandler) RestoreRevision(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	id, revision, ok := parseRevisionPath(r.URL.Path, "/restore")
+	if !ok {
+		apierror.Write(w, r, http.StatusBadRequest, "Invalid revision path")
+		return
+	}
+
+	current, err := h.storage.Get(id)
+	if err != nil {
+		writeStorageError(w, r, err, "get roadmap")
+		return
+	}
+	if !h.checkIfMatch(w, r, current) {
+		return
+	}
+
+	rev, err := h.storage.GetRevision(id, revision)
+	if err != nil {
+		writeStorageError(w, r, err, "get revision")
+		return
+	}
+
+	// The restored content is a new change: items whose status it changes
+	// are stamped now, and history recorded since is kept, as are the
+	// baselines. The items are copied so the revision as read isn't altered.
+	restored := rev.Roadmap
+	restored.Items = append([]models.RoadmapItem(nil), rev.Roadmap.Items...)
+	restored.StampStatusChanges(&current.Roadmap, time.Now().UTC(), requestAuthor(r))
+	restored.SetBaselines(&current.Roadmap)
+
+	updated, err := h.storage.Update(id, &restored, requestAuthor(r), current.CurrentRevision())
+	if err != nil {
+		writeStorageError(w, r, err, "restore revision")
+		return
+	}
+
+	w.Header().Set("ETag", etag(updated))
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(updated)
+}
+
+// parseRevisionPath extracts the roadmap ID and revision number from
+// /api/roadmaps/{id}/revisions/{n}{suffix}
+func parseRevisionPath(path, suffix string) (string, int, bool) {
+	rest := strings.TrimPrefix(path, "/api/roadmaps/")
+	rest = strings.TrimSuffix(rest, suffix)
+
+	parts := strings.Split(rest, "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] != "revisions" {
+		return "", 0, false
+	}
+
+	revision, err := strconv.Atoi(parts[2])
+	if err != nil || revision < 1 {
+		return "", 0, false
+	}
+
+	return parts[0], revision, true
+}
+
+// requestAuthor returns the author recorded on revisions for a request: the
+// authenticated caller when authentication is enabled, otherwise X-Author
+func requestAuthor(r *http.Request) string {
+	if identity, ok := auth.FromContext(r.Context()); ok {
+		return identity.Author()
+	}
+	return r.Header.Get("X-Author")
+}
+
+// etag returns the entity tag of a stored roadmap, which is its revision number
+func etag(stored *models.StoredRoadmap) string {
+	return fmt.Sprintf("%q", strconv.Itoa(stored.CurrentRevision()))
+}
+
+// etagMatches reports whether an If-Match or If-None-Match header value
+// matches tag. Weak comparison ignores W/ prefixes, as If-None-Match allows.
+func etagMatches(header, tag string, weak bool) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if weak {
+			candidate = strings.TrimPrefix(candidate, "W/")
+		}
+		if candidate == "*" || candidate == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// requireIfMatch reports whether r must send If-Match
+func (h *RoadmapHandler) requireIfMatch(r *http.Request) bool {
+	return h.config.RequireIfMatch && !legacyRequest(r)
+}
+
+// checkIfMatch enforces the If-Match precondition against the current
+// roadmap. It writes a 428 or 412 response and returns false when the
+// request must not go ahead.
+func (h *RoadmapHandler) checkIfMatch(w http.ResponseWriter, r *http.Request, current *models.StoredRoadmap) bool {
+	match := r.Header.Get("If-Match")
+	if match == "" {
+		if h.requireIfMatch(r) {
+			apierror.Write(w, r, http.StatusPreconditionRequired, "If-Match header is required; send the ETag from GET /api/v1/roadmaps/{id}")
+			return false
+		}
+		return true
+	}
+
+	if !etagMatches(match, etag(current), false) {
+		w.Header().Set("ETag", etag(current))
+		apierror.WriteDetails(w, r, http.StatusPreconditionFailed, "Roadmap was modified by another update; reload and retry",
+			map[string]int{"current_revision": current.CurrentRevision()})
+		return false
+	}
+
+	return true
+}
+
 // GetRoadmapDependencies handles GET /api/roadmaps/{id}/dependencies
 // Returns all external dependencies for items in the roadmap
 func (h *RoadmapHandler) GetRoadmapDependencies(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
@@ -203,24 +1068,20 @@ func (h *RoadmapHandler) GetRoadmapDependencies(w http.ResponseWriter, r *http.R
 	id := strings.TrimPrefix(r.URL.Path, "/api/roadmaps/")
 	id = strings.TrimSuffix(id, "/dependencies")
 	if id == "" || strings.Contains(id, "/") {
-		http.Error(w, "Invalid roadmap ID", http.StatusBadRequest)
+		apierror.Write(w, r, http.StatusBadRequest, "Invalid roadmap ID")
 		return
 	}
 
 	stored, err := h.storage.Get(id)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			http.Error(w, "Roadmap not found", http.StatusNotFound)
-		} else {
-			http.Error(w, fmt.Sprintf("Failed to get roadmap: %v", err), http.StatusInternalServerError)
-		}
+		writeStorageError(w, r, err, "get roadmap")
 		return
 	}
 
 	// Collect all external dependencies
 	type DependencyInfo struct {
-		ItemID               string                       `json:"item_id"`
-		ItemName             string                       `json:"item_name"`
+		ItemID               string                      `json:"item_id"`
+		ItemName             string                      `json:"item_name"`
 		ExternalDependencies []models.ExternalDependency `json:"external_dependencies"`
 	}
 
@@ -245,11 +1106,17 @@ func (h *RoadmapHandler) GetRoadmapDependencies(w http.ResponseWriter, r *http.R
 	json.NewEncoder(w).Encode(response)
 }
 
+// dependents returns the items of other roadmaps that depend on the roadmap,
+// from the reverse index if there is one
+func (h *RoadmapHandler) dependents(id string) ([]models.ExternalDependent, error) {
+	return storage.FindDependents(h.storage, h.config.Dependents, id)
+}
+
 // GetRoadmapDependents handles GET /api/roadmaps/{id}/dependents
 // Returns all roadmap items that depend on this roadmap
 func (h *RoadmapHandler) GetRoadmapDependents(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
@@ -257,27 +1124,20 @@ func (h *RoadmapHandler) GetRoadmapDependents(w http.ResponseWriter, r *http.Req
 	id := strings.TrimPrefix(r.URL.Path, "/api/roadmaps/")
 	id = strings.TrimSuffix(id, "/dependents")
 	if id == "" || strings.Contains(id, "/") {
-		http.Error(w, "Invalid roadmap ID", http.StatusBadRequest)
+		apierror.Write(w, r, http.StatusBadRequest, "Invalid roadmap ID")
 		return
 	}
 
-	// Get all roadmaps
-	allRoadmaps, err := h.storage.List()
+	stored, err := h.storage.Get(id)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to list roadmaps: %v", err), http.StatusInternalServerError)
+		writeStorageError(w, r, err, "get roadmap")
 		return
 	}
 
 	// Find dependents
-	dependents := storage.GetExternalDependents(id, allRoadmaps)
-
-	stored, err := h.storage.Get(id)
+	dependents, err := h.dependents(id)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			http.Error(w, "Roadmap not found", http.StatusNotFound)
-		} else {
-			http.Error(w, fmt.Sprintf("Failed to get roadmap: %v", err), http.StatusInternalServerError)
-		}
+		apierror.Write(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to list roadmaps: %v", err))
 		return
 	}
 
@@ -293,51 +1153,144 @@ func (h *RoadmapHandler) GetRoadmapDependents(w http.ResponseWriter, r *http.Req
 }
 
 // ValidateDependencies handles GET /api/dependencies/validate
-// Validates all external dependencies across all roadmaps
+// Validates all external dependencies across all roadmaps, and flags external
+// and internal dependencies that end after the item depending on them starts.
+// ?roadmap_id= and ?service_line= narrow the results to the dependencies of
+// those roadmaps, which still resolve against every roadmap, and
+// ?min_criticality= to external dependencies at least that critical (internal
+// ones have no criticality, so are left out). The counts cover what is left;
+// ?only_invalid=true then lists only the dependencies that don't resolve.
 func (h *RoadmapHandler) ValidateDependencies(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	query := r.URL.Query()
+	roadmapID, serviceLine := query.Get("roadmap_id"), query.Get("service_line")
+	minCriticality := query.Get("min_criticality")
+	switch minCriticality {
+	case "", "low", "medium", "high", "critical":
+	default:
+		apierror.Write(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid min_criticality '%s' (must be low, medium, high, or critical)", minCriticality))
 		return
 	}
 
 	// Get all roadmaps
-	allRoadmaps, err := h.storage.List()
+	allRoadmaps, err := h.storage.List(storage.ListFilter{})
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to list roadmaps: %v", err), http.StatusInternalServerError)
+		apierror.Write(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to list roadmaps: %v", err))
 		return
 	}
 
-	// Validate external dependencies
-	validations := storage.ValidateExternalDependencies(allRoadmaps)
+	inScope := make(map[string]bool, len(allRoadmaps))
+	found := roadmapID == ""
+	for _, rm := range allRoadmaps {
+		found = found || rm.ID == roadmapID
+		if (roadmapID == "" || rm.ID == roadmapID) && (serviceLine == "" || rm.Roadmap.ServiceLine == serviceLine) {
+			inScope[rm.ID] = true
+		}
+	}
+	if !found {
+		apierror.Write(w, r, http.StatusNotFound, "Roadmap not found")
+		return
+	}
+	critical := func(criticality string) bool {
+		return minCriticality == "" || models.AtLeastCriticality(criticality, minCriticality)
+	}
+
+	// Validate external dependencies, and the dates of internal ones
+	validations := []models.ExternalDependencyValidation{}
+	for _, v := range storage.ValidateExternalDependencies(allRoadmaps) {
+		if inScope[v.RoadmapID] && critical(v.Criticality) {
+			validations = append(validations, v)
+		}
+	}
+	internalConflicts := []models.DependencyDateConflict{}
+	if minCriticality == "" {
+		for _, c := range storage.ValidateInternalDependencies(allRoadmaps) {
+			if inScope[c.RoadmapID] {
+				internalConflicts = append(internalConflicts, c)
+			}
+		}
+	}
+	leadTimeViolations := []models.LeadTimeViolation{}
+	for _, v := range storage.ValidateLeadTimes(allRoadmaps) {
+		if inScope[v.RoadmapID] && critical(v.Criticality) {
+			leadTimeViolations = append(leadTimeViolations, v)
+		}
+	}
 
 	// Count valid and invalid
 	validCount := 0
 	invalidCount := 0
+	conflictCount := len(internalConflicts)
 	for _, v := range validations {
 		if v.Valid {
 			validCount++
 		} else {
 			invalidCount++
 		}
+		if v.DateConflict != "" {
+			conflictCount++
+		}
+	}
+	total := len(validations)
+	if query.Get("only_invalid") == "true" {
+		invalid := []models.ExternalDependencyValidation{}
+		for _, v := range validations {
+			if !v.Valid {
+				invalid = append(invalid, v)
+			}
+		}
+		validations = invalid
 	}
 
 	response := map[string]interface{}{
-		"total":    len(validations),
-		"valid":    validCount,
-		"invalid":  invalidCount,
-		"results":  validations,
+		"total":                total,
+		"valid":                validCount,
+		"invalid":              invalidCount,
+		"results":              validations,
+		"date_conflicts":       conflictCount,
+		"internal_conflicts":   internalConflicts,
+		"lead_times":           models.LeadTimes,
+		"lead_time_violations": leadTimeViolations,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
 }
 
+// roadmapSubresources are the handlers of /api/roadmaps/{id}/{segment}, by
+// segment
+var roadmapSubresources = map[string]func(*RoadmapHandler, http.ResponseWriter, *http.Request){
+	"restore":       (*RoadmapHandler).RestoreRoadmap,
+	"dependencies":  (*RoadmapHandler).GetRoadmapDependencies,
+	"dependents":    (*RoadmapHandler).GetRoadmapDependents,
+	"rename":        (*RoadmapHandler).RenameRoadmap,
+	"slippage":      (*RoadmapHandler).GetSlippage,
+	"order":         (*RoadmapHandler).GetOrder,
+	"critical-path": (*RoadmapHandler).GetCriticalPath,
+	"graph.dot":     (*RoadmapHandler).GetRoadmapGraphDOT,
+	"mermaid":       (*RoadmapHandler).GetRoadmapMermaid,
+	"clone":         (*RoadmapHandler).CloneRoadmap,
+	"comments":      (*RoadmapHandler).HandleRoadmapComments,
+	"yaml":          (*RoadmapHandler).GetRoadmapYAML,
+	"export.csv":    (*RoadmapHandler).GetRoadmapCSV,
+	"export.xlsx":   (*RoadmapHandler).GetRoadmapXLSX,
+	"export.md":     (*RoadmapHandler).GetRoadmapMarkdown,
+	"calendar.ics":  (*RoadmapHandler).GetRoadmapCalendar,
+	"render.png":    (*RoadmapHandler).GetRoadmapRender,
+	"render.pdf":    (*RoadmapHandler).GetRoadmapRender,
+}
+
 // HandleRoadmaps routes roadmap requests
 func (h *RoadmapHandler) HandleRoadmaps(w http.ResponseWriter, r *http.Request) {
 	// Enable CORS
 	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, DELETE, OPTIONS")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, X-File-Name")
+	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-File-Name, X-Author, If-Match, If-None-Match, If-Modified-Since")
+	w.Header().Set("Access-Control-Expose-Headers", "ETag, Last-Modified, X-Request-ID, Content-Disposition")
 
 	if r.Method == http.MethodOptions {
 		w.WriteHeader(http.StatusOK)
@@ -352,35 +1305,81 @@ func (h *RoadmapHandler) HandleRoadmaps(w http.ResponseWriter, r *http.Request)
 			h.CreateRoadmap(w, r)
 		case http.MethodGet:
 			h.ListRoadmaps(w, r)
+		case http.MethodDelete:
+			h.DeleteRoadmaps(w, r)
 		default:
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
 		}
 	} else if path == "/api/roadmaps/batch" {
 		// Handle batch upload of multiple roadmaps
 		if r.Method == http.MethodPost {
 			h.CreateMultipleRoadmaps(w, r)
 		} else {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
 		}
+	} else if path == "/api/roadmaps/merge" {
+		h.MergeRoadmaps(w, r)
+	} else if path == "/api/roadmaps/validate" {
+		h.ValidateRoadmaps(w, r)
+	} else if path == "/api/roadmaps/export" {
+		h.ExportRoadmaps(w, r)
+	} else if path == "/api/roadmaps/calendar.ics" {
+		h.RoadmapsCalendar(w, r)
 	} else if strings.HasPrefix(path, "/api/roadmaps/") {
-		// Check for sub-endpoints
-		if strings.HasSuffix(path, "/dependencies") {
-			h.GetRoadmapDependencies(w, r)
-		} else if strings.HasSuffix(path, "/dependents") {
-			h.GetRoadmapDependents(w, r)
-		} else {
-			// Regular roadmap GET/DELETE
+		// Route on the segments after the ID, so a roadmap whose ID matches a
+		// sub-resource, such as "yaml", is still reachable
+		segments := strings.Split(strings.TrimPrefix(path, "/api/roadmaps/"), "/")
+		switch {
+		case len(segments) == 1:
+			// Regular roadmap GET/PATCH/DELETE
 			switch r.Method {
 			case http.MethodGet:
 				h.GetRoadmap(w, r)
+			case http.MethodPatch:
+				h.PatchRoadmap(w, r)
 			case http.MethodDelete:
 				h.DeleteRoadmap(w, r)
 			default:
-				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
 			}
+		case segments[1] == "items":
+			h.HandleItems(w, r)
+		case segments[1] == "revisions" && len(segments) == 2:
+			h.ListRevisions(w, r)
+		case segments[1] == "revisions" && len(segments) == 4 && segments[3] == "restore":
+			h.RestoreRevision(w, r)
+		case segments[1] == "revisions":
+			h.GetRevision(w, r)
+		case len(segments) == 2 && roadmapSubresources[segments[1]] != nil:
+			roadmapSubresources[segments[1]](h, w, r)
+		default:
+			apierror.Write(w, r, http.StatusNotFound, "Not found")
 		}
 	} else {
-		http.Error(w, "Not found", http.StatusNotFound)
+		apierror.Write(w, r, http.StatusNotFound, "Not found")
+	}
+}
+
+// HandleTrash routes trash requests
+func (h *RoadmapHandler) HandleTrash(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Methods", "GET, DELETE, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	path := r.URL.Path
+
+	if path == "/api/trash" {
+		h.ListTrash(w, r)
+	} else if strings.HasPrefix(path, "/api/trash/") {
+		h.PurgeRoadmap(w, r)
+	} else {
+		apierror.Write(w, r, http.StatusNotFound, "Not found")
 	}
 }
 
@@ -388,8 +1387,8 @@ func (h *RoadmapHandler) HandleRoadmaps(w http.ResponseWriter, r *http.Request)
 func (h *RoadmapHandler) HandleDependencies(w http.ResponseWriter, r *http.Request) {
 	// Enable CORS
 	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
 
 	if r.Method == http.MethodOptions {
 		w.WriteHeader(http.StatusOK)
@@ -400,7 +1399,23 @@ func (h *RoadmapHandler) HandleDependencies(w http.ResponseWriter, r *http.Reque
 
 	if path == "/api/dependencies/validate" {
 		h.ValidateDependencies(w, r)
+	} else if path == "/api/dependencies/cycles" {
+		h.DependencyCycles(w, r)
+	} else if path == "/api/dependencies/graph" {
+		h.DependencyGraph(w, r)
+	} else if path == "/api/dependencies/graph.dot" {
+		h.DependencyGraphDOT(w, r)
+	} else if path == "/api/dependencies/mermaid" {
+		h.DependencyGraphMermaid(w, r)
+	} else if path == "/api/dependencies/risks" {
+		h.DependencyRisks(w, r)
+	} else if path == "/api/dependencies/summary" {
+		h.DependencySummary(w, r)
+	} else if path == "/api/dependencies/orphans" {
+		h.DependencyOrphans(w, r)
+	} else if path == "/api/dependencies/orphans/prune" {
+		h.PruneOrphans(w, r)
 	} else {
-		http.Error(w, "Not found", http.StatusNotFound)
+		apierror.Write(w, r, http.StatusNotFound, "Not found")
 	}
 }
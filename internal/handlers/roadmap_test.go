@@ -0,0 +1,287 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"roadmap-visualizer/internal/models"
+	"roadmap-visualizer/internal/storage"
+	"strings"
+	"testing"
+)
+
+// serve sends a request through HandleRoadmaps
+func serve(h *RoadmapHandler, method, path, contentType, body string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, path, strings.NewReader(body))
+	if contentType != "" {
+		r.Header.Set("Content-Type", contentType)
+	}
+	w := httptest.NewRecorder()
+	h.HandleRoadmaps(w, r)
+	return w
+}
+
+func roadmapYAML(name string) string {
+	return fmt.Sprintf(`roadmap:
+  name: %q
+  service_line: Platform
+  items:
+    - {id: a, name: A, start: 2026-01-01, end: 2026-03-31, status: planned}
+`, name)
+}
+
+// createRoadmap uploads a roadmap named name and returns its ID
+func createRoadmap(t *testing.T, h *RoadmapHandler, name string) string {
+	t.Helper()
+	w := serve(h, http.MethodPost, "/api/roadmaps", "application/x-yaml", roadmapYAML(name))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create %q: status %d: %s", name, w.Code, w.Body)
+	}
+	var created models.StoredRoadmap
+	if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+		t.Fatalf("create %q: %v", name, err)
+	}
+	return created.ID
+}
+
+func TestRoadmapsNamedAfterSubresources(t *testing.T) {
+	h := NewRoadmapHandler(storage.NewMemoryStorage(), Config{})
+
+	names := []string{"items", "revisions"}
+	for segment := range roadmapSubresources {
+		names = append(names, segment)
+	}
+	for _, name := range names {
+		id := createRoadmap(t, h, name)
+
+		w := serve(h, http.MethodGet, "/api/roadmaps/"+id, "", "")
+		if w.Code != http.StatusOK {
+			t.Errorf("GET roadmap %s (named %q): status %d: %s", id, name, w.Code, w.Body)
+			continue
+		}
+		var got models.StoredRoadmap
+		if err := json.NewDecoder(w.Body).Decode(&got); err != nil || got.ID != id {
+			t.Errorf("GET roadmap %s (named %q): got ID %q, err %v", id, name, got.ID, err)
+		}
+
+		if w := serve(h, http.MethodGet, "/api/roadmaps/"+id+"/yaml", "", ""); w.Code != http.StatusOK {
+			t.Errorf("GET roadmap %s YAML: status %d: %s", id, w.Code, w.Body)
+		}
+		if w := serve(h, http.MethodGet, "/api/roadmaps/"+id+"/revisions", "", ""); w.Code != http.StatusOK {
+			t.Errorf("GET roadmap %s revisions: status %d: %s", id, w.Code, w.Body)
+		}
+	}
+}
+
+func TestCreateRoadmapNormalizesTags(t *testing.T) {
+	h := NewRoadmapHandler(storage.NewMemoryStorage(), Config{})
+	body := strings.Replace(roadmapYAML("Platform"), "status: planned}", "status: planned, tags: [Data Platform, data_platform]}", 1)
+
+	w := serve(h, http.MethodPost, "/api/roadmaps", "application/x-yaml", body)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create: status %d: %s", w.Code, w.Body)
+	}
+	var created models.StoredRoadmap
+	if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+		t.Fatal(err)
+	}
+	if tags := created.Roadmap.Items[0].Tags; len(tags) != 1 || tags[0] != "data-platform" {
+		t.Errorf("tags = %q, want [data-platform]", tags)
+	}
+}
+
+func TestUnknownRoadmapSubresource(t *testing.T) {
+	h := NewRoadmapHandler(storage.NewMemoryStorage(), Config{})
+	id := createRoadmap(t, h, "Platform")
+
+	for _, path := range []string{"/api/roadmaps/" + id + "/nope", "/api/roadmaps/" + id + "/yaml/extra"} {
+		if w := serve(h, http.MethodGet, path, "", ""); w.Code != http.StatusNotFound {
+			t.Errorf("GET %s: status %d, want 404", path, w.Code)
+		}
+	}
+}
+
+// replica is a handler with its own dependents index over a shared backend,
+// as a server replica would have
+func replica(t *testing.T, shared storage.Storage) *RoadmapHandler {
+	t.Helper()
+	index, err := storage.NewDependentsIndex(shared)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewRoadmapHandler(index, Config{Dependents: index})
+}
+
+func TestDeleteRoadmapChecksDependentsAcrossReplicas(t *testing.T) {
+	shared, err := storage.NewSQLiteStorage(filepath.Join(t.TempDir(), "roadmaps.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer shared.Close()
+	replicaA, replicaB := replica(t, shared), replica(t, shared)
+
+	id := createRoadmap(t, replicaA, "Platform")
+	dependent := strings.Replace(roadmapYAML("Payments"), "status: planned}",
+		"status: planned, external_dependencies: [{roadmap: Platform, item: a}]}", 1)
+	if w := serve(replicaB, http.MethodPost, "/api/roadmaps", "application/x-yaml", dependent); w.Code != http.StatusCreated {
+		t.Fatalf("create dependent: status %d: %s", w.Code, w.Body)
+	}
+
+	if w := serve(replicaA, http.MethodDelete, "/api/roadmaps/"+id, "", ""); w.Code != http.StatusConflict {
+		t.Fatalf("delete with a dependent on another replica: status %d, want 409: %s", w.Code, w.Body)
+	}
+	if w := serve(replicaA, http.MethodDelete, "/api/roadmaps/"+id+"?force=true", "", ""); w.Code != http.StatusNoContent {
+		t.Errorf("forced delete: status %d, want 204: %s", w.Code, w.Body)
+	}
+}
+
+func TestDeleteRoadmapsChecksDependentsOutsideTheBatch(t *testing.T) {
+	h := replica(t, storage.NewMemoryStorage())
+	platform := createRoadmap(t, h, "Platform")
+	dependent := strings.Replace(roadmapYAML("Payments"), "status: planned}",
+		"status: planned, external_dependencies: [{roadmap: Platform, item: a}]}", 1)
+	w := serve(h, http.MethodPost, "/api/roadmaps", "application/x-yaml", dependent)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create dependent: status %d: %s", w.Code, w.Body)
+	}
+	var payments models.StoredRoadmap
+	if err := json.Unmarshal(w.Body.Bytes(), &payments); err != nil {
+		t.Fatal(err)
+	}
+
+	var response struct {
+		Deleted int                 `json:"deleted"`
+		Results []batchDeleteResult `json:"results"`
+	}
+	w = serve(h, http.MethodDelete, "/api/roadmaps", "application/json", `{"ids": ["`+platform+`"]}`)
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("batch delete: status %d: %s", w.Code, w.Body)
+	}
+	if response.Deleted != 0 || len(response.Results[0].Dependents) != 1 {
+		t.Fatalf("batch delete with a dependent outside it = %+v, want it refused with the dependent", response)
+	}
+
+	// The dependent roadmap goes too, so nothing is left broken
+	w = serve(h, http.MethodDelete, "/api/roadmaps", "application/json",
+		`{"ids": ["`+platform+`", "`+payments.ID+`"]}`)
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("batch delete: status %d: %s", w.Code, w.Body)
+	}
+	if response.Deleted != 2 {
+		t.Errorf("batch delete with its dependent = %+v, want both deleted", response)
+	}
+}
+
+func TestUploadMultipartJSON(t *testing.T) {
+	h := NewRoadmapHandler(storage.NewMemoryStorage(), Config{})
+
+	var body strings.Builder
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("file", "roadmaps.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// An array, which only parses as JSON, not as a YAML document
+	part.Write([]byte(`[
+		{"roadmap": {"name": "Platform", "service_line": "Infra", "items": [
+			{"id": "a", "name": "A", "start": "2026-01-01", "end": "2026-03-31", "status": "planned"}]}},
+		{"roadmap": {"name": "Payments", "service_line": "Commerce", "items": [
+			{"id": "b", "name": "B", "start": "2026-01-01", "end": "2026-03-31", "status": "planned"}]}}
+	]`))
+	form.Close()
+
+	w := serve(h, http.MethodPost, "/api/roadmaps", form.FormDataContentType(), body.String())
+	if w.Code != http.StatusCreated {
+		t.Fatalf("upload: status %d: %s", w.Code, w.Body)
+	}
+	roadmaps, err := h.storage.List(storage.ListFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := make(map[string]string)
+	for _, rm := range roadmaps {
+		files[rm.Roadmap.Name] = rm.FileName
+	}
+	want := map[string]string{"Platform": "roadmaps-part1.yaml", "Payments": "roadmaps-part2.yaml"}
+	if !reflect.DeepEqual(files, want) {
+		t.Errorf("stored roadmaps and files = %v, want %v", files, want)
+	}
+}
+
+func TestRestoreRevisionStampsStatusChanges(t *testing.T) {
+	h := NewRoadmapHandler(storage.NewMemoryStorage(), Config{})
+	id := createRoadmap(t, h, "Platform")
+	if w := serve(h, http.MethodPatch, "/api/roadmaps/"+id, "application/merge-patch+json",
+		`{"items": {"a": {"status": "blocked"}}}`); w.Code != http.StatusOK {
+		t.Fatalf("patch: status %d: %s", w.Code, w.Body)
+	}
+
+	w := serve(h, http.MethodPost, "/api/roadmaps/"+id+"/revisions/1/restore", "", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("restore: status %d: %s", w.Code, w.Body)
+	}
+	var restored models.StoredRoadmap
+	if err := json.NewDecoder(w.Body).Decode(&restored); err != nil {
+		t.Fatal(err)
+	}
+	item := restored.Roadmap.Items[0]
+	if item.Status != models.StatusPlanned || item.StatusChangedAt == nil {
+		t.Fatalf("restored item = %+v, want planned with the change stamped", item)
+	}
+	if n := len(item.StatusHistory); n != 2 || item.StatusHistory[1].From != models.StatusBlocked {
+		t.Errorf("status history = %+v, want the block and the restore kept", item.StatusHistory)
+	}
+}
+
+func TestRestoreRevisionKeepsBaselines(t *testing.T) {
+	h := NewRoadmapHandler(storage.NewMemoryStorage(), Config{})
+	// Stored before baselines existed, so revision 1 has none
+	stored, err := h.storage.Create(&models.Roadmap{Name: "Platform", ServiceLine: "Platform", Items: []models.RoadmapItem{
+		{ID: "a", Name: "A", Start: "2026-01-01", End: "2026-03-31", Status: models.StatusPlanned},
+	}}, "platform.yaml", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w := serve(h, http.MethodPatch, "/api/roadmaps/"+stored.ID, "application/merge-patch+json",
+		`{"items": {"a": {"end": "2026-06-30"}}}`); w.Code != http.StatusOK {
+		t.Fatalf("patch: status %d: %s", w.Code, w.Body)
+	}
+
+	w := serve(h, http.MethodPost, "/api/roadmaps/"+stored.ID+"/revisions/1/restore", "", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("restore: status %d: %s", w.Code, w.Body)
+	}
+	var restored models.StoredRoadmap
+	if err := json.NewDecoder(w.Body).Decode(&restored); err != nil {
+		t.Fatal(err)
+	}
+	want := models.Baseline{Start: "2026-01-01", End: "2026-03-31"}
+	if baseline := restored.Roadmap.Items[0].Baseline; baseline == nil || *baseline != want {
+		t.Errorf("baseline = %v, want %v", baseline, want)
+	}
+}
+
+func TestIfMatchRequiredOnlyUnderV1(t *testing.T) {
+	h := NewRoadmapHandler(storage.NewMemoryStorage(), Config{RequireIfMatch: true})
+	mux := http.NewServeMux()
+	(&API{Roadmaps: h}).Register(mux, LegacyConfig{Enabled: true})
+	id := createRoadmap(t, h, "Platform")
+
+	patch := func(path string) int {
+		r := httptest.NewRequest(http.MethodPatch, path, strings.NewReader(`{"description": "Edited"}`))
+		r.Header.Set("Content-Type", "application/merge-patch+json")
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, r)
+		return w.Code
+	}
+	if code := patch("/api/v1/roadmaps/" + id); code != http.StatusPreconditionRequired {
+		t.Errorf("v1 patch without If-Match: status %d, want 428", code)
+	}
+	if code := patch("/api/roadmaps/" + id); code != http.StatusOK {
+		t.Errorf("unversioned patch without If-Match: status %d, want 200", code)
+	}
+}
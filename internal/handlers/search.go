@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"roadmap-visualizer/internal/apierror"
+	"roadmap-visualizer/internal/models"
+	"roadmap-visualizer/internal/search"
+	"roadmap-visualizer/internal/storage"
+	"strconv"
+	"strings"
+)
+
+// Limits for the number of search results
+const (
+	defaultSearchLimit = 50
+	maxSearchLimit     = 500
+)
+
+// SearchHandler handles full-text search across stored roadmaps
+type SearchHandler struct {
+	storage storage.Storage
+	index   *search.Index
+}
+
+// NewSearchHandler creates a new search handler
+func NewSearchHandler(storage storage.Storage) *SearchHandler {
+	return &SearchHandler{
+		storage: storage,
+		index:   search.NewIndex(),
+	}
+}
+
+// Search handles GET /api/search?q=...
+// Matches roadmap names and notes and item names, descriptions, notes, and
+// tags. Every word of q must appear in the same field; words match as prefixes.
+// ?tag= keeps only matches in items with that tag.
+func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	if query == "" {
+		apierror.Write(w, r, http.StatusBadRequest, "Query parameter q is required")
+		return
+	}
+
+	limit := defaultSearchLimit
+	if value := r.URL.Query().Get("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > maxSearchLimit {
+			apierror.Write(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid limit (must be 1-%d)", maxSearchLimit))
+			return
+		}
+		limit = n
+	}
+
+	// Roadmaps can change through any write path, including files edited on
+	// disk, so reconcile the index with storage before every search. Only
+	// changed roadmaps are re-indexed.
+	roadmaps, err := h.storage.List(storage.ListFilter{})
+	if err != nil {
+		apierror.Write(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to list roadmaps: %v", err))
+		return
+	}
+	h.index.Sync(roadmaps)
+
+	tag := models.NormalizeTag(r.URL.Query().Get("tag"))
+	matches := h.index.Search(query, tag, limit)
+
+	response := map[string]interface{}{
+		"query":   query,
+		"count":   len(matches),
+		"results": matches,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// HandleSearch routes search requests
+func (h *SearchHandler) HandleSearch(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if r.URL.Path != "/api/search" {
+		apierror.Write(w, r, http.StatusNotFound, "Not found")
+		return
+	}
+
+	h.Search(w, r)
+}
@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"roadmap-visualizer/internal/apierror"
+	"roadmap-visualizer/internal/servicelines"
+	"roadmap-visualizer/internal/storage"
+	"strings"
+)
+
+// ServiceLineHandler handles the service line registry
+type ServiceLineHandler struct {
+	storage  storage.Storage
+	registry *servicelines.Registry
+}
+
+// NewServiceLineHandler creates a new service line handler
+func NewServiceLineHandler(store storage.Storage, registry *servicelines.Registry) *ServiceLineHandler {
+	return &ServiceLineHandler{
+		storage:  store,
+		registry: registry,
+	}
+}
+
+// serviceLineRequest is the body of POST /api/service-lines
+type serviceLineRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// CreateServiceLine handles POST /api/service-lines
+func (h *ServiceLineHandler) CreateServiceLine(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	var req serviceLineRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apierror.Write(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid service line: %v", err))
+		return
+	}
+
+	line, err := h.registry.Create(req.Name, req.Description)
+	if err != nil {
+		switch {
+		case errors.Is(err, servicelines.ErrExists):
+			apierror.Write(w, r, http.StatusConflict, "Service line already registered")
+		case servicelines.ValidateName(req.Name) != nil:
+			apierror.Write(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid service line: %v", err))
+		default:
+			apierror.Write(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to register service line: %v", err))
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(line)
+}
+
+// ListServiceLines handles GET /api/service-lines
+// Returns the registered service lines and any others used by roadmaps, with
+// the roadmaps, item and status counts, and completion of each
+func (h *ServiceLineHandler) ListServiceLines(w http.ResponseWriter, r *http.Request) {
+	roadmaps, err := h.storage.List(storage.ListFilter{})
+	if err != nil {
+		apierror.Write(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to list roadmaps: %v", err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(servicelines.Summarize(h.registry.List(), roadmaps))
+}
+
+// GetServiceLine handles GET /api/service-lines/{name}
+func (h *ServiceLineHandler) GetServiceLine(w http.ResponseWriter, r *http.Request, name string) {
+	roadmaps, err := h.storage.List(storage.ListFilter{ServiceLine: name})
+	if err != nil {
+		apierror.Write(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to list roadmaps: %v", err))
+		return
+	}
+
+	var registered []*servicelines.ServiceLine
+	if line, err := h.registry.Get(name); err == nil {
+		registered = append(registered, line)
+	}
+	summaries := servicelines.Summarize(registered, roadmaps)
+	if len(summaries) == 0 {
+		apierror.Write(w, r, http.StatusNotFound, "Service line not found")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(summaries[0])
+}
+
+// RenameServiceLine handles POST /api/service-lines/{name}/rename
+// Renames the service line and moves every roadmap in it to the new name
+func (h *ServiceLineHandler) RenameServiceLine(w http.ResponseWriter, r *http.Request, name string) {
+	if r.Method != http.MethodPost {
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	defer r.Body.Close()
+
+	var req renameRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apierror.Write(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid request body: %v", err))
+		return
+	}
+	if err := servicelines.ValidateName(req.Name); err != nil {
+		apierror.Write(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := servicelines.Rename(h.storage, h.registry, name, req.Name, requestAuthor(r))
+	if err != nil {
+		switch {
+		case errors.Is(err, servicelines.ErrNotFound):
+			apierror.Write(w, r, http.StatusNotFound, "Service line not found")
+		case errors.Is(err, servicelines.ErrExists):
+			apierror.Write(w, r, http.StatusConflict, "Cannot rename: the new name is already registered")
+		case errors.Is(err, storage.ErrRevisionMismatch):
+			apierror.Write(w, r, http.StatusPreconditionFailed, "A roadmap was modified during the rename; reload and retry")
+		default:
+			writeStorageError(w, r, err, "rename service line")
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// DeleteServiceLine handles DELETE /api/service-lines/{name}
+// Only service lines no roadmap uses can be removed
+func (h *ServiceLineHandler) DeleteServiceLine(w http.ResponseWriter, r *http.Request, name string) {
+	roadmaps, err := h.storage.List(storage.ListFilter{ServiceLine: name})
+	if err != nil {
+		apierror.Write(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to list roadmaps: %v", err))
+		return
+	}
+	if len(roadmaps) > 0 {
+		apierror.Write(w, r, http.StatusConflict, fmt.Sprintf("Cannot remove: %d roadmap(s) use this service line; rename it instead", len(roadmaps)))
+		return
+	}
+
+	if err := h.registry.Delete(name); err != nil {
+		if errors.Is(err, servicelines.ErrNotFound) {
+			apierror.Write(w, r, http.StatusNotFound, "Service line not found")
+		} else {
+			apierror.Write(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to remove service line: %v", err))
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleServiceLines routes service line requests
+func (h *ServiceLineHandler) HandleServiceLines(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, DELETE, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Author")
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	path := r.URL.Path
+
+	if path == "/api/service-lines" {
+		switch r.Method {
+		case http.MethodPost:
+			h.CreateServiceLine(w, r)
+		case http.MethodGet:
+			h.ListServiceLines(w, r)
+		default:
+			apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+		return
+	}
+
+	name := strings.TrimPrefix(path, "/api/service-lines/")
+	if strings.HasSuffix(name, "/rename") {
+		name = strings.TrimSuffix(name, "/rename")
+		if name == "" || strings.Contains(name, "/") {
+			apierror.Write(w, r, http.StatusNotFound, "Not found")
+			return
+		}
+		h.RenameServiceLine(w, r, name)
+		return
+	}
+	if name == "" || strings.Contains(name, "/") {
+		apierror.Write(w, r, http.StatusNotFound, "Not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		h.GetServiceLine(w, r, name)
+	case http.MethodDelete:
+		h.DeleteServiceLine(w, r, name)
+	default:
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"roadmap-visualizer/internal/apierror"
+	"strings"
+)
+
+// GetSlippage handles GET /api/roadmaps/{id}/slippage
+// Reports how many days each item's start and end have drifted from the
+// baseline it was first stored with. ?slipped=true lists only items that now
+// end later than planned.
+func (h *RoadmapHandler) GetSlippage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Extract ID from path
+	id := strings.TrimPrefix(r.URL.Path, "/api/roadmaps/")
+	id = strings.TrimSuffix(id, "/slippage")
+	if id == "" || strings.Contains(id, "/") {
+		apierror.Write(w, r, http.StatusBadRequest, "Invalid roadmap ID")
+		return
+	}
+
+	stored, err := h.storage.Get(id)
+	if err != nil {
+		writeStorageError(w, r, err, "get roadmap")
+		return
+	}
+
+	report := stored.Slippage()
+	if r.URL.Query().Get("slipped") == "true" {
+		slipped := report.Items[:0]
+		for _, entry := range report.Items {
+			if entry.EndDriftDays > 0 {
+				slipped = append(slipped, entry)
+			}
+		}
+		report.Items = slipped
+	}
+
+	w.Header().Set("ETag", etag(stored))
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
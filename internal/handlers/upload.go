@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"roadmap-visualizer/internal/apierror"
+	"roadmap-visualizer/internal/models"
+	"roadmap-visualizer/internal/parser"
+	"strings"
+)
+
+// isMultipart reports whether the request body is a multipart/form-data upload
+func isMultipart(r *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return err == nil && mediaType == "multipart/form-data"
+}
+
+// isJSON reports whether the request body is JSON rather than YAML
+func isJSON(r *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return err == nil && mediaType == "application/json"
+}
+
+// roadmapDecoder picks the parser for a single roadmap by the request's
+// Content-Type: JSON, Markdown, or otherwise YAML
+func roadmapDecoder(r *http.Request) func(io.Reader) (*models.Roadmap, error) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case "application/json":
+		return parser.DecodeRoadmapJSON
+	case "text/markdown":
+		return parser.DecodeRoadmapMarkdown
+	default:
+		return parser.DecodeRoadmap
+	}
+}
+
+// partDecoder picks the parser for a file of a multipart upload by the part's
+// Content-Type as roadmapDecoder does, or else by the file's extension: JSON
+// holding a roadmap or an array of them, Markdown, or otherwise YAML documents
+// separated by ---
+func partDecoder(part *multipart.Part) func(io.Reader) ([]*models.Roadmap, error) {
+	mediaType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+	ext := strings.ToLower(filepath.Ext(part.FileName()))
+	switch {
+	case mediaType == "application/json" || ext == ".json":
+		return decodeJSONRoadmaps
+	case mediaType == "text/markdown" || ext == ".md" || ext == ".markdown":
+		return func(r io.Reader) ([]*models.Roadmap, error) {
+			roadmap, err := parser.DecodeRoadmapMarkdown(r)
+			if err != nil {
+				return nil, err
+			}
+			return []*models.Roadmap{roadmap}, nil
+		}
+	default:
+		return parser.DecodeMultipleRoadmaps
+	}
+}
+
+// decodeJSONRoadmaps parses a JSON roadmap document, or an array of them
+func decodeJSONRoadmaps(r io.Reader) ([]*models.Roadmap, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+		return parser.DecodeMultipleRoadmapsJSON(bytes.NewReader(data))
+	}
+	roadmap, err := parser.DecodeRoadmapJSON(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	return []*models.Roadmap{roadmap}, nil
+}
+
+// createFromForm stores the roadmaps in every file of a multipart/form-data
+// upload, so browsers can upload from a plain <form>. Each file is named after
+// its part and is parsed as JSON, Markdown, or YAML as partDecoder picks; a
+// YAML file may hold several documents separated by ---. Form fields without
+// a file are ignored. The response has the same shape as a batch upload.
+func (h *RoadmapHandler) createFromForm(w http.ResponseWriter, r *http.Request, policy string) {
+	_, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || params["boundary"] == "" {
+		apierror.Write(w, r, http.StatusBadRequest, "Invalid multipart upload: missing boundary")
+		return
+	}
+
+	body := h.limitBody(w, r)
+	defer r.Body.Close()
+
+	// Parse each file as it streams in
+	var uploads []upload
+	mr := multipart.NewReader(body, params["boundary"])
+	for {
+		part, err := mr.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			if body.tooLarge() {
+				h.writeTooLarge(w, r)
+				return
+			}
+			apierror.Write(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid multipart upload: %v", err))
+			return
+		}
+
+		fileName := part.FileName()
+		if fileName == "" {
+			part.Close()
+			continue
+		}
+
+		roadmaps, err := partDecoder(part)(part)
+		part.Close()
+		if err != nil {
+			if body.tooLarge() {
+				h.writeTooLarge(w, r)
+				return
+			}
+			apierror.Write(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid roadmap file %s: %v", fileName, err))
+			return
+		}
+
+		for i, roadmap := range roadmaps {
+			name := fileName
+			if len(roadmaps) > 1 {
+				name = partFileName(fileName, i)
+			}
+			uploads = append(uploads, upload{roadmap: roadmap, fileName: name})
+		}
+	}
+
+	if len(uploads) == 0 {
+		apierror.Write(w, r, http.StatusBadRequest, "No files in upload")
+		return
+	}
+
+	h.storeUploads(w, r, policy, uploads)
+}
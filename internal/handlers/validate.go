@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"roadmap-visualizer/internal/apierror"
+	"roadmap-visualizer/internal/models"
+	"roadmap-visualizer/internal/parser"
+	"roadmap-visualizer/internal/storage"
+	"strings"
+)
+
+// validationIssue is one error or warning found in an uploaded document
+type validationIssue struct {
+	Document int    `json:"document"`
+	Roadmap  string `json:"roadmap,omitempty"`
+	Item     string `json:"item,omitempty"`
+	Message  string `json:"message"`
+}
+
+// validationReport is the response of a dry-run validation. Errors would make
+// an upload fail; warnings would not.
+type validationReport struct {
+	Valid    bool              `json:"valid"`
+	Roadmaps int               `json:"roadmaps"`
+	Errors   []validationIssue `json:"errors"`
+	Warnings []validationIssue `json:"warnings"`
+}
+
+// ValidateRoadmaps handles POST /api/roadmaps/validate
+// Parses and validates one or more YAML documents without storing them.
+// External dependencies are checked against stored roadmaps and the other
+// documents in the payload; unresolved ones are reported as warnings, as is
+// content identical to a stored roadmap. Responds 422 if there are errors.
+func (h *RoadmapHandler) ValidateRoadmaps(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	body := h.limitBody(w, r)
+	defer r.Body.Close()
+
+	report := validationReport{
+		Errors:   []validationIssue{},
+		Warnings: []validationIssue{},
+	}
+
+	roadmaps, err := parser.DecodeDocuments(body)
+	if body.tooLarge() {
+		h.writeTooLarge(w, r)
+		return
+	}
+	if err != nil {
+		report.Errors = append(report.Errors, validationIssue{Document: len(roadmaps) + 1, Message: err.Error()})
+	} else if len(roadmaps) == 0 {
+		report.Errors = append(report.Errors, validationIssue{Document: 1, Message: "no roadmaps found in file"})
+	}
+	report.Roadmaps = len(roadmaps)
+
+	// Tags are normalized as on upload, and recurring items are expanded
+	// first, so dependencies on their occurrences resolve
+	expandErrs := make([]error, len(roadmaps))
+	for i, roadmap := range roadmaps {
+		roadmap.NormalizeTags()
+		expandErrs[i] = roadmap.ExpandRecurrences()
+	}
+
+	stored, err := h.storage.List(storage.ListFilter{})
+	if err != nil {
+		apierror.Write(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to list roadmaps: %v", err))
+		return
+	}
+
+	// Dependencies may point at stored roadmaps or at other documents in the
+	// same payload, which would be stored alongside this one
+	targets := make([]models.StoredRoadmap, 0, len(stored)+len(roadmaps))
+	byHash := make(map[string]*models.StoredRoadmap, len(stored))
+	for _, rm := range stored {
+		targets = append(targets, *rm)
+		byHash[rm.Roadmap.ContentHash()] = rm
+	}
+	for _, roadmap := range roadmaps {
+		targets = append(targets, models.StoredRoadmap{Roadmap: *roadmap})
+	}
+
+	for i, roadmap := range roadmaps {
+		doc := i + 1
+		if err := expandErrs[i]; err != nil {
+			report.Errors = append(report.Errors, validationIssue{Document: doc, Roadmap: roadmap.Name, Message: err.Error()})
+		}
+		for _, verr := range roadmap.ValidationErrors() {
+			report.Errors = append(report.Errors, validationIssue{Document: doc, Roadmap: roadmap.Name, Message: verr.Error()})
+		}
+
+		for _, v := range models.ValidateRoadmapDependencies(roadmap, targets) {
+			if !v.Valid {
+				report.Warnings = append(report.Warnings, validationIssue{
+					Document: doc,
+					Roadmap:  roadmap.Name,
+					Item:     strings.TrimPrefix(v.RoadmapItemID, roadmap.Name+":"),
+					Message:  fmt.Sprintf("external dependency %s: %s", v.DependencyDesc, v.Error),
+				})
+			}
+		}
+
+		if duplicate, ok := byHash[roadmap.ContentHash()]; ok {
+			report.Warnings = append(report.Warnings, validationIssue{
+				Document: doc,
+				Roadmap:  roadmap.Name,
+				Message:  fmt.Sprintf("identical to stored roadmap %s", duplicate.ID),
+			})
+		}
+	}
+
+	report.Valid = len(report.Errors) == 0
+
+	w.Header().Set("Content-Type", "application/json")
+	if !report.Valid {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	}
+	json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"roadmap-visualizer/internal/apierror"
+	"roadmap-visualizer/internal/webhooks"
+	"strings"
+)
+
+// WebhookHandler handles webhook registration and delivery logs
+type WebhookHandler struct {
+	dispatcher *webhooks.Dispatcher
+}
+
+// NewWebhookHandler creates a new webhook handler
+func NewWebhookHandler(dispatcher *webhooks.Dispatcher) *WebhookHandler {
+	return &WebhookHandler{
+		dispatcher: dispatcher,
+	}
+}
+
+// webhookRequest is the body of POST /api/webhooks
+type webhookRequest struct {
+	URL    string   `json:"url"`
+	Secret string   `json:"secret"`
+	Events []string `json:"events"`
+}
+
+// CreateWebhook handles POST /api/webhooks
+func (h *WebhookHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	var req webhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apierror.Write(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid webhook: %v", err))
+		return
+	}
+
+	candidate := webhooks.Webhook{URL: req.URL, Events: req.Events}
+	if err := candidate.Validate(); err != nil {
+		apierror.Write(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid webhook: %v", err))
+		return
+	}
+
+	hook, err := h.dispatcher.Register(req.URL, req.Secret, req.Events)
+	if err != nil {
+		apierror.Write(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to register webhook: %v", err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(hook)
+}
+
+// ListWebhooks handles GET /api/webhooks
+func (h *WebhookHandler) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.dispatcher.List())
+}
+
+// GetWebhook handles GET /api/webhooks/{id}
+func (h *WebhookHandler) GetWebhook(w http.ResponseWriter, r *http.Request, id string) {
+	hook, err := h.dispatcher.Get(id)
+	if err != nil {
+		apierror.Write(w, r, http.StatusNotFound, "Webhook not found")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(hook)
+}
+
+// DeleteWebhook handles DELETE /api/webhooks/{id}
+func (h *WebhookHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request, id string) {
+	if err := h.dispatcher.Delete(id); err != nil {
+		if errors.Is(err, webhooks.ErrNotFound) {
+			apierror.Write(w, r, http.StatusNotFound, "Webhook not found")
+		} else {
+			apierror.Write(w, r, http.StatusInternalServerError, fmt.Sprintf("Failed to delete webhook: %v", err))
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListDeliveries handles GET /api/webhooks/{id}/deliveries
+// Returns the most recent deliveries, newest first, with every attempt
+func (h *WebhookHandler) ListDeliveries(w http.ResponseWriter, r *http.Request, id string) {
+	if r.Method != http.MethodGet {
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	deliveries, err := h.dispatcher.Deliveries(id)
+	if err != nil {
+		apierror.Write(w, r, http.StatusNotFound, "Webhook not found")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(deliveries)
+}
+
+// HandleWebhooks routes webhook requests
+func (h *WebhookHandler) HandleWebhooks(w http.ResponseWriter, r *http.Request) {
+	// Enable CORS
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, DELETE, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	path := r.URL.Path
+
+	if path == "/api/webhooks" {
+		switch r.Method {
+		case http.MethodPost:
+			h.CreateWebhook(w, r)
+		case http.MethodGet:
+			h.ListWebhooks(w, r)
+		default:
+			apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+		return
+	}
+
+	id := strings.TrimPrefix(path, "/api/webhooks/")
+	if strings.HasSuffix(id, "/deliveries") {
+		id = strings.TrimSuffix(id, "/deliveries")
+		if id == "" || strings.Contains(id, "/") {
+			apierror.Write(w, r, http.StatusNotFound, "Not found")
+			return
+		}
+		h.ListDeliveries(w, r, id)
+		return
+	}
+	if id == "" || strings.Contains(id, "/") {
+		apierror.Write(w, r, http.StatusNotFound, "Not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		h.GetWebhook(w, r, id)
+	case http.MethodDelete:
+		h.DeleteWebhook(w, r, id)
+	default:
+		apierror.Write(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
@@ -0,0 +1,391 @@
+// Package jira reads issues from a Jira project through its REST API and
+// maps them into a roadmap, one item per issue.
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config holds the connection settings for a Client
+type Config struct {
+	// BaseURL is the Jira site, such as https://example.atlassian.net
+	BaseURL string
+	// User and Token authenticate with basic auth: an email address and API
+	// token on Jira Cloud. Without a user, Token is sent as a bearer
+	// (personal access) token, as Jira Data Center expects.
+	User  string
+	Token string
+	// SprintField is the ID of the custom field holding an issue's sprints,
+	// which differs between sites; customfield_10020 by default
+	SprintField string
+	// APIVersion is the REST API searched: 3 by default, for Jira Cloud,
+	// which pages with nextPageToken, or 2 for Jira Data Center and Server,
+	// which page with startAt
+	APIVersion int
+	// MaxIssues caps how many issues one import reads; 1000 by default
+	MaxIssues int
+	// Timeout bounds each HTTP request
+	Timeout time.Duration
+}
+
+// Client searches a Jira site for issues
+type Client struct {
+	config Config
+	client *http.Client
+}
+
+// New creates a client for the Jira site in config
+func New(config Config) *Client {
+	config.BaseURL = strings.TrimSuffix(config.BaseURL, "/")
+	if config.SprintField == "" {
+		config.SprintField = "customfield_10020"
+	}
+	if config.APIVersion == 0 {
+		config.APIVersion = 3
+	}
+	if config.MaxIssues <= 0 {
+		config.MaxIssues = 1000
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 30 * time.Second
+	}
+	return &Client{config: config, client: &http.Client{Timeout: config.Timeout}}
+}
+
+// Error is an error response from Jira
+type Error struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("Jira responded %d: %s", e.StatusCode, e.Message)
+}
+
+// Issue is the part of a Jira issue that is mapped into a roadmap item
+type Issue struct {
+	Key         string
+	Summary     string
+	Description string
+	Status      string
+	// StatusCategory is new, indeterminate, or done
+	StatusCategory string
+	Assignee       string
+	Created        time.Time
+	DueDate        string
+	FixVersions    []Version
+	Sprints        []Sprint
+	Links          []IssueLink
+}
+
+// Version is a fix version of an issue
+type Version struct {
+	Name        string
+	StartDate   string
+	ReleaseDate string
+}
+
+// Sprint is a sprint an issue was planned in; dates are YYYY-MM-DD
+type Sprint struct {
+	Name      string
+	StartDate string
+	EndDate   string
+}
+
+// IssueLink links an issue to another. Inward is set when the other issue
+// is on the inward side of the link type, e.g. "is blocked by" for Blocks.
+type IssueLink struct {
+	Type   string
+	Key    string
+	Inward bool
+}
+
+// searchFields are the issue fields requested, besides the sprint field
+var searchFields = []string{"summary", "description", "status", "assignee", "created", "duedate", "fixVersions", "issuelinks"}
+
+// Search returns the issues matching jql in their Jira order, reading pages
+// until there are no more or MaxIssues is reached. truncated reports whether
+// more issues matched than were read.
+func (c *Client) Search(ctx context.Context, jql string) (issues []Issue, truncated bool, err error) {
+	fields := strings.Join(append(searchFields, c.config.SprintField), ",")
+	var nextPageToken string
+	for {
+		query := url.Values{
+			"jql":        {jql},
+			"maxResults": {strconv.Itoa(min(100, c.config.MaxIssues-len(issues)))},
+			"fields":     {fields},
+		}
+		path := "/rest/api/3/search/jql?"
+		if c.config.APIVersion == 2 {
+			path = "/rest/api/2/search?"
+			query.Set("startAt", strconv.Itoa(len(issues)))
+		} else if nextPageToken != "" {
+			query.Set("nextPageToken", nextPageToken)
+		}
+		var page searchPage
+		if err := c.get(ctx, path+query.Encode(), &page); err != nil {
+			return nil, false, err
+		}
+		for _, raw := range page.Issues {
+			issue, err := c.parseIssue(raw)
+			if err != nil {
+				return nil, false, fmt.Errorf("failed to read issue %s: %w", raw.Key, err)
+			}
+			issues = append(issues, issue)
+		}
+
+		last := page.IsLast || page.NextPageToken == ""
+		if c.config.APIVersion == 2 {
+			last = len(issues) >= page.Total
+		}
+		switch {
+		case last || len(page.Issues) == 0:
+			return issues, false, nil
+		case len(issues) >= c.config.MaxIssues:
+			return issues, true, nil
+		}
+		nextPageToken = page.NextPageToken
+	}
+}
+
+// QuoteJQL makes s a JQL string literal: in double quotes, with backslashes
+// and double quotes escaped
+func QuoteJQL(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// IssueURL is where an issue is shown on the Jira site
+func (c *Client) IssueURL(key string) string {
+	return c.config.BaseURL + "/browse/" + url.PathEscape(key)
+}
+
+func (c *Client) get(ctx context.Context, path string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.config.BaseURL+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.config.User != "" {
+		req.SetBasicAuth(c.config.User, c.config.Token)
+	} else if c.config.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.config.Token)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return responseError(resp)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to parse Jira response: %w", err)
+	}
+	return nil
+}
+
+// responseError reads the error messages Jira sends with a failed request
+func responseError(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	var errs struct {
+		ErrorMessages []string          `json:"errorMessages"`
+		Errors        map[string]string `json:"errors"`
+	}
+	var messages []string
+	if json.Unmarshal(body, &errs) == nil {
+		messages = append(messages, errs.ErrorMessages...)
+		for field, message := range errs.Errors {
+			messages = append(messages, field+": "+message)
+		}
+	}
+	if len(messages) == 0 {
+		messages = append(messages, http.StatusText(resp.StatusCode))
+	}
+	return &Error{StatusCode: resp.StatusCode, Message: strings.Join(messages, "; ")}
+}
+
+// searchPage is a page of search results. Version 3 of the API sends the
+// token of the next page until the last one, and version 2 the total.
+type searchPage struct {
+	Issues        []rawIssue `json:"issues"`
+	NextPageToken string     `json:"nextPageToken"`
+	IsLast        bool       `json:"isLast"`
+	Total         int        `json:"total"`
+}
+
+type rawIssue struct {
+	Key    string          `json:"key"`
+	Fields json.RawMessage `json:"fields"`
+}
+
+// parseIssue reads the fields of an issue, any of which may be missing or null
+func (c *Client) parseIssue(raw rawIssue) (Issue, error) {
+	issue := Issue{Key: raw.Key}
+	var fields struct {
+		Summary string `json:"summary"`
+		// Description is a string in version 2 of the API and an Atlassian
+		// Document Format document in version 3
+		Description json.RawMessage `json:"description"`
+		Status      *struct {
+			Name           string `json:"name"`
+			StatusCategory struct {
+				Key string `json:"key"`
+			} `json:"statusCategory"`
+		} `json:"status"`
+		Assignee *struct {
+			DisplayName string `json:"displayName"`
+		} `json:"assignee"`
+		Created     string `json:"created"`
+		DueDate     string `json:"duedate"`
+		FixVersions []struct {
+			Name        string `json:"name"`
+			StartDate   string `json:"startDate"`
+			ReleaseDate string `json:"releaseDate"`
+		} `json:"fixVersions"`
+		IssueLinks []struct {
+			Type struct {
+				Name string `json:"name"`
+			} `json:"type"`
+			InwardIssue *struct {
+				Key string `json:"key"`
+			} `json:"inwardIssue"`
+			OutwardIssue *struct {
+				Key string `json:"key"`
+			} `json:"outwardIssue"`
+		} `json:"issuelinks"`
+	}
+	if err := json.Unmarshal(raw.Fields, &fields); err != nil {
+		return issue, err
+	}
+	// The sprint field's ID is only known at run time
+	var custom map[string]json.RawMessage
+	if err := json.Unmarshal(raw.Fields, &custom); err != nil {
+		return issue, err
+	}
+
+	issue.Summary = fields.Summary
+	issue.Description = descriptionText(fields.Description)
+	if fields.Status != nil {
+		issue.Status = fields.Status.Name
+		issue.StatusCategory = fields.Status.StatusCategory.Key
+	}
+	if fields.Assignee != nil {
+		issue.Assignee = fields.Assignee.DisplayName
+	}
+	if created, err := time.Parse("2006-01-02T15:04:05.000-0700", fields.Created); err == nil {
+		issue.Created = created
+	}
+	issue.DueDate = fields.DueDate
+	for _, v := range fields.FixVersions {
+		issue.FixVersions = append(issue.FixVersions, Version{Name: v.Name, StartDate: v.StartDate, ReleaseDate: v.ReleaseDate})
+	}
+	for _, link := range fields.IssueLinks {
+		switch {
+		case link.InwardIssue != nil:
+			issue.Links = append(issue.Links, IssueLink{Type: link.Type.Name, Key: link.InwardIssue.Key, Inward: true})
+		case link.OutwardIssue != nil:
+			issue.Links = append(issue.Links, IssueLink{Type: link.Type.Name, Key: link.OutwardIssue.Key})
+		}
+	}
+	issue.Sprints = parseSprints(custom[c.config.SprintField])
+	return issue, nil
+}
+
+// descriptionText reads a description sent as a string, or as an Atlassian
+// Document Format document as plain text, one line per paragraph, heading, or
+// list item
+func descriptionText(raw json.RawMessage) string {
+	var text string
+	if json.Unmarshal(raw, &text) == nil {
+		return text
+	}
+	var doc adfNode
+	if json.Unmarshal(raw, &doc) != nil {
+		return ""
+	}
+	var b strings.Builder
+	doc.writeText(&b)
+	return strings.TrimSpace(b.String())
+}
+
+// adfNode is a node of an Atlassian Document Format document
+type adfNode struct {
+	Type    string    `json:"type"`
+	Text    string    `json:"text"`
+	Content []adfNode `json:"content"`
+}
+
+func (n *adfNode) writeText(b *strings.Builder) {
+	switch n.Type {
+	case "text":
+		b.WriteString(n.Text)
+	case "hardBreak":
+		b.WriteByte('\n')
+	}
+	for i := range n.Content {
+		n.Content[i].writeText(b)
+	}
+	switch n.Type {
+	case "paragraph", "heading", "codeBlock":
+		if !strings.HasSuffix(b.String(), "\n") {
+			b.WriteByte('\n')
+		}
+	}
+}
+
+// parseSprints reads the sprint field, a list of sprint objects on Jira
+// Cloud, or on older servers of strings such as
+// "com.atlassian.greenhopper.service.sprint.Sprint@1f[id=1,name=S1,startDate=2026-01-05T09:00:00.000Z,...]".
+// Sprints it can't read are left out.
+func parseSprints(raw json.RawMessage) []Sprint {
+	var values []json.RawMessage
+	if json.Unmarshal(raw, &values) != nil {
+		return nil
+	}
+
+	var sprints []Sprint
+	for _, value := range values {
+		var sprint struct {
+			Name      string `json:"name"`
+			StartDate string `json:"startDate"`
+			EndDate   string `json:"endDate"`
+		}
+		var text string
+		if json.Unmarshal(value, &text) == nil {
+			attrs := make(map[string]string)
+			if _, list, ok := strings.Cut(strings.TrimSuffix(text, "]"), "["); ok {
+				for _, pair := range strings.Split(list, ",") {
+					if k, v, ok := strings.Cut(pair, "="); ok {
+						attrs[k] = v
+					}
+				}
+			}
+			sprint.Name, sprint.StartDate, sprint.EndDate = attrs["name"], attrs["startDate"], attrs["endDate"]
+		} else if json.Unmarshal(value, &sprint) != nil {
+			continue
+		}
+		sprints = append(sprints, Sprint{Name: sprint.Name, StartDate: datePart(sprint.StartDate), EndDate: datePart(sprint.EndDate)})
+	}
+	return sprints
+}
+
+// datePart keeps the YYYY-MM-DD of a timestamp, or returns "" if it has none
+func datePart(timestamp string) string {
+	if len(timestamp) < 10 {
+		return ""
+	}
+	if _, err := time.Parse("2006-01-02", timestamp[:10]); err != nil {
+		return ""
+	}
+	return timestamp[:10]
+}
@@ -0,0 +1,99 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestQuoteJQL(t *testing.T) {
+	for in, want := range map[string]string{
+		"PLAT":         `"PLAT"`,
+		`PLAT" OR x=y`: `"PLAT\" OR x=y"`,
+		`a\b`:          `"a\\b"`,
+	} {
+		if got := QuoteJQL(in); got != want {
+			t.Errorf("QuoteJQL(%q) = %s, want %s", in, got, want)
+		}
+	}
+}
+
+// issueJSON is an issue as the search API returns it, with the description
+// as an Atlassian Document Format document
+func issueJSON(key string) map[string]any {
+	return map[string]any{"key": key, "fields": map[string]any{
+		"summary": "Issue " + key,
+		"description": map[string]any{"type": "doc", "version": 1, "content": []any{
+			map[string]any{"type": "paragraph", "content": []any{map[string]any{"type": "text", "text": "About " + key}}},
+			map[string]any{"type": "paragraph", "content": []any{map[string]any{"type": "text", "text": "More"}}},
+		}},
+	}}
+}
+
+func TestSearchPagesWithNextPageToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/search/jql" {
+			http.NotFound(w, r)
+			return
+		}
+		// Six issues, at most two a page, with the offset of the next page as
+		// its token
+		offset, _ := strconv.Atoi(r.URL.Query().Get("nextPageToken"))
+		maxResults, _ := strconv.Atoi(r.URL.Query().Get("maxResults"))
+		var issues []any
+		for n := offset + 1; n <= min(offset+min(maxResults, 2), 6); n++ {
+			issues = append(issues, issueJSON(fmt.Sprintf("PLAT-%d", n)))
+		}
+		next := offset + len(issues)
+		response := map[string]any{"issues": issues, "isLast": next == 6}
+		if next < 6 {
+			response["nextPageToken"] = strconv.Itoa(next)
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	issues, truncated, err := New(Config{BaseURL: server.URL}).Search(context.Background(), `project = "PLAT"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 6 || truncated {
+		t.Fatalf("got %d issues, truncated %v; want 6, false", len(issues), truncated)
+	}
+	if issues[5].Key != "PLAT-6" || issues[0].Description != "About PLAT-1\nMore" {
+		t.Errorf("issues[5].Key = %q, issues[0].Description = %q", issues[5].Key, issues[0].Description)
+	}
+
+	issues, truncated, err = New(Config{BaseURL: server.URL, MaxIssues: 3}).Search(context.Background(), `project = "PLAT"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 3 || !truncated {
+		t.Errorf("with MaxIssues 3 got %d issues, truncated %v; want 3, true", len(issues), truncated)
+	}
+}
+
+func TestSearchAPIVersion2(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/2/search" {
+			http.NotFound(w, r)
+			return
+		}
+		startAt, _ := strconv.Atoi(r.URL.Query().Get("startAt"))
+		issue := map[string]any{"key": fmt.Sprintf("PLAT-%d", startAt+1), "fields": map[string]any{"description": "Plain text"}}
+		json.NewEncoder(w).Encode(map[string]any{"issues": []any{issue}, "total": 3})
+	}))
+	defer server.Close()
+
+	issues, truncated, err := New(Config{BaseURL: server.URL, APIVersion: 2}).Search(context.Background(), `project = "PLAT"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 3 || truncated || issues[2].Key != "PLAT-3" || issues[0].Description != "Plain text" {
+		t.Errorf("got %d issues, truncated %v: %+v", len(issues), truncated, issues)
+	}
+}
@@ -0,0 +1,143 @@
+package jira
+
+import (
+	"fmt"
+	"roadmap-visualizer/internal/models"
+	"strings"
+	"unicode/utf8"
+)
+
+// DependencyLinkType is the issue link type read as a dependency: an issue
+// that "is blocked by" another depends on it
+const DependencyLinkType = "Blocks"
+
+// Roadmap maps issues into a roadmap, one item per issue keyed by its issue
+// key, in the order given. An item runs through its sprints if it has any
+// with dates, or else up to the release of its last fix version, from the
+// start of its first one, or else up to its due date. Without a start it
+// starts when the issue was created, or on its end date if that is later.
+// Issues with nothing to end them by are skipped with a warning. Blocks
+// links between the issues become dependencies, and links to issues that
+// weren't imported are dropped with a warning.
+func (c *Client) Roadmap(name, serviceLine string, issues []Issue) (*models.Roadmap, []string) {
+	roadmap := &models.Roadmap{Name: name, ServiceLine: serviceLine}
+	var warnings []string
+
+	imported := make(map[string]bool, len(issues))
+	for _, issue := range issues {
+		start, end := schedule(issue)
+		if end == "" {
+			warnings = append(warnings, fmt.Sprintf("%s: skipped, no sprint, fix version, or due date to schedule it by", issue.Key))
+			continue
+		}
+		imported[issue.Key] = true
+
+		item := models.RoadmapItem{
+			ID:          issue.Key,
+			Name:        issue.Summary,
+			Start:       start,
+			End:         end,
+			Status:      itemStatus(issue),
+			Description: issue.Description,
+			Links:       []models.Link{{Title: issue.Key + " in Jira", URL: c.IssueURL(issue.Key)}},
+		}
+		if item.Name == "" {
+			item.Name = issue.Key
+		}
+		if utf8.RuneCountInString(issue.Assignee) <= 100 {
+			item.Owner = strings.TrimSpace(issue.Assignee)
+		}
+		roadmap.Items = append(roadmap.Items, item)
+	}
+
+	// A link shows on the issues at both of its ends, so it may be seen twice
+	seen := make(map[[2]string]bool)
+	dependsOn := func(item *models.RoadmapItem, key string) {
+		if seen[[2]string{item.ID, key}] {
+			return
+		}
+		seen[[2]string{item.ID, key}] = true
+		if !imported[key] {
+			warnings = append(warnings, fmt.Sprintf("%s: dropped dependency on %s, which wasn't imported", item.ID, key))
+			return
+		}
+		item.Dependencies = append(item.Dependencies, key)
+	}
+	byKey := make(map[string]*models.RoadmapItem, len(roadmap.Items))
+	for i := range roadmap.Items {
+		byKey[roadmap.Items[i].ID] = &roadmap.Items[i]
+	}
+	for _, issue := range issues {
+		item, ok := byKey[issue.Key]
+		if !ok {
+			continue
+		}
+		for _, link := range issue.Links {
+			if !strings.EqualFold(link.Type, DependencyLinkType) {
+				continue
+			}
+			if link.Inward {
+				dependsOn(item, link.Key)
+			} else if blocked, ok := byKey[link.Key]; ok {
+				dependsOn(blocked, issue.Key)
+			}
+		}
+	}
+
+	return roadmap, warnings
+}
+
+// schedule works out the start and end dates of an issue, as YYYY-MM-DD
+func schedule(issue Issue) (start, end string) {
+	for _, sprint := range issue.Sprints {
+		if sprint.StartDate == "" || sprint.EndDate == "" {
+			continue
+		}
+		if start == "" || sprint.StartDate < start {
+			start = sprint.StartDate
+		}
+		if sprint.EndDate > end {
+			end = sprint.EndDate
+		}
+	}
+	if end == "" {
+		for _, version := range issue.FixVersions {
+			if version.ReleaseDate > end {
+				end = version.ReleaseDate
+			}
+			if version.StartDate != "" && (start == "" || version.StartDate < start) {
+				start = version.StartDate
+			}
+		}
+	}
+	if end == "" {
+		end = issue.DueDate
+	}
+	if end == "" {
+		return "", ""
+	}
+
+	if start == "" && !issue.Created.IsZero() {
+		start = issue.Created.Format("2006-01-02")
+	}
+	if start == "" || start > end {
+		start = end
+	}
+	return start, end
+}
+
+// itemStatus maps an issue's status: a status named like "Blocked" is
+// blocked, and otherwise its category decides
+func itemStatus(issue Issue) models.RoadmapStatus {
+	if strings.Contains(strings.ToLower(issue.Status), "block") {
+		return models.StatusBlocked
+	}
+	switch issue.StatusCategory {
+	case "indeterminate":
+		return models.StatusInProgress
+	case "done":
+		return models.StatusCompleted
+	default:
+		return models.StatusPlanned
+	}
+}
@@ -0,0 +1,25 @@
+package models
+
+// WithoutArchived returns a copy of the stored roadmap with its archived items
+// left out, or the roadmap itself if none are archived. The archived items
+// stay in storage, so dependencies on them still resolve.
+func (s *StoredRoadmap) WithoutArchived() *StoredRoadmap {
+	archived := 0
+	for i := range s.Roadmap.Items {
+		if s.Roadmap.Items[i].Archived {
+			archived++
+		}
+	}
+	if archived == 0 {
+		return s
+	}
+
+	visible := *s
+	visible.Roadmap.Items = make([]RoadmapItem, 0, len(s.Roadmap.Items)-archived)
+	for _, item := range s.Roadmap.Items {
+		if !item.Archived {
+			visible.Roadmap.Items = append(visible.Roadmap.Items, item)
+		}
+	}
+	return &visible
+}
@@ -0,0 +1,106 @@
+package models
+
+import (
+	"math"
+	"time"
+)
+
+// Baseline is the start and end an item was first planned with
+type Baseline struct {
+	Start string `yaml:"start" json:"start"`
+	End   string `yaml:"end" json:"end"`
+}
+
+// SetBaselines gives every item the baseline of the item with the same ID in
+// previous, or that item's dates if it has none, so baselines can't be edited.
+// Items that aren't in previous keep the baseline they came with, such as one
+// in an exported roadmap, or get their current dates. previous is nil for a
+// new roadmap.
+func (r *Roadmap) SetBaselines(previous *Roadmap) {
+	before := make(map[string]*RoadmapItem)
+	if previous != nil {
+		for i := range previous.Items {
+			before[previous.Items[i].ID] = &previous.Items[i]
+		}
+	}
+
+	for i := range r.Items {
+		item := &r.Items[i]
+		if old, ok := before[item.ID]; ok {
+			if old.Baseline != nil {
+				baseline := *old.Baseline
+				item.Baseline = &baseline
+			} else {
+				item.Baseline = &Baseline{Start: old.Start, End: old.End}
+			}
+		} else if item.Baseline == nil {
+			item.Baseline = &Baseline{Start: item.Start, End: item.End}
+		}
+	}
+}
+
+// ItemSlippage is how far an item's dates have moved from its baseline
+type ItemSlippage struct {
+	ItemID   string        `json:"item_id"`
+	ItemName string        `json:"item_name"`
+	Status   RoadmapStatus `json:"status"`
+	Baseline Baseline      `json:"baseline"`
+	Start    string        `json:"start"`
+	End      string        `json:"end"`
+	// StartDriftDays and EndDriftDays are how many days later the item now
+	// starts and ends than planned; negative when pulled in
+	StartDriftDays int `json:"start_drift_days"`
+	EndDriftDays   int `json:"end_drift_days"`
+}
+
+// SlippageReport is the drift of a roadmap's items against their baselines
+type SlippageReport struct {
+	RoadmapID   string `json:"roadmap_id"`
+	RoadmapName string `json:"roadmap_name"`
+	// Slipped counts the items that now end later than planned
+	Slipped int            `json:"slipped"`
+	Items   []ItemSlippage `json:"items"`
+}
+
+// Slippage compares the items of a stored roadmap with their baselines, in
+// item order. Items without a baseline, or whose dates can't be parsed, are
+// left out.
+func (s *StoredRoadmap) Slippage() SlippageReport {
+	report := SlippageReport{RoadmapID: s.ID, RoadmapName: s.Roadmap.Name, Items: []ItemSlippage{}}
+	for i := range s.Roadmap.Items {
+		item := &s.Roadmap.Items[i]
+		if item.Baseline == nil {
+			continue
+		}
+		start, end, err := item.ItemSpan()
+		if err != nil {
+			continue
+		}
+		baseline := RoadmapItem{ID: item.ID, Start: item.Baseline.Start, End: item.Baseline.End}
+		baseStart, baseEnd, err := baseline.ItemSpan()
+		if err != nil {
+			continue
+		}
+
+		entry := ItemSlippage{
+			ItemID:         item.ID,
+			ItemName:       item.Name,
+			Status:         item.Status,
+			Baseline:       *item.Baseline,
+			Start:          item.Start,
+			End:            item.End,
+			StartDriftDays: daysBetween(baseStart, start),
+			EndDriftDays:   daysBetween(baseEnd, end),
+		}
+		if entry.EndDriftDays > 0 {
+			report.Slipped++
+		}
+		report.Items = append(report.Items, entry)
+	}
+	return report
+}
+
+// daysBetween returns the whole days from a to b
+func daysBetween(a, b time.Time) int {
+	return int(math.Round(b.Sub(a).Hours() / 24))
+}
@@ -0,0 +1,187 @@
+package models
+
+import "time"
+
+// BlockingLink is a dependency that holds an item up: it isn't completed and
+// its end date has passed
+type BlockingLink struct {
+	DependencyNode
+	Status RoadmapStatus `json:"status"`
+	// EndDate is the last day of the dependency, inclusive
+	EndDate string `json:"end_date"`
+	// Type is how the item before it in the chain depends on it, internal or
+	// external
+	Type        string `json:"type"`
+	Criticality string `json:"criticality,omitempty"`
+}
+
+// EffectiveStatus is an item's status once its dependencies are taken into
+// account
+type EffectiveStatus struct {
+	Status RoadmapStatus `json:"status"`
+	// Inferred is set when the item is blocked only because of its
+	// dependencies, not because its status says so
+	Inferred bool `json:"inferred,omitempty"`
+	// BlockedBy is the dependencies holding the item up directly
+	BlockedBy []BlockingLink `json:"blocked_by,omitempty"`
+	// Chain follows the first of them through the dependencies holding it up
+	// in turn, ending at the one that is overdue for a reason of its own
+	Chain []BlockingLink `json:"chain,omitempty"`
+}
+
+// EffectiveStatuses works out the effective status of every item of the
+// roadmaps at now, keyed by roadmap ID and then item ID. An item that isn't
+// completed is effectively blocked when any of its internal or external
+// dependencies isn't completed and has ended by now; otherwise its effective
+// status is its status. External dependencies that don't resolve are ignored.
+func EffectiveStatuses(roadmaps []*StoredRoadmap, now time.Time) map[string]map[string]EffectiveStatus {
+	g := newDependencyGraph(roadmaps)
+
+	overdue := make([]bool, len(g.nodes))
+	for n, item := range g.items {
+		if _, end, err := item.ItemSpan(); err == nil && item.Status != StatusCompleted && !end.After(now) {
+			overdue[n] = true
+		}
+	}
+
+	link := func(edge dependencyEdge) BlockingLink {
+		l := BlockingLink{DependencyNode: g.nodes[edge.to], Status: g.items[edge.to].Status, Type: EdgeInternal}
+		if dates, err := g.items[edge.to].Dates(); err == nil {
+			l.EndDate = dates.EndDate
+		}
+		if edge.external {
+			l.Type = EdgeExternal
+			l.Criticality = edge.criticality
+		}
+		return l
+	}
+	blockers := func(n int) []dependencyEdge {
+		var edges []dependencyEdge
+		listed := make(map[int]bool)
+		for _, edge := range g.edges[n] {
+			if overdue[edge.to] && !listed[edge.to] {
+				listed[edge.to] = true
+				edges = append(edges, edge)
+			}
+		}
+		return edges
+	}
+
+	statuses := make(map[string]map[string]EffectiveStatus)
+	for n, item := range g.items {
+		status := EffectiveStatus{Status: item.Status}
+		if item.Status != StatusCompleted {
+			edges := blockers(n)
+			for _, edge := range edges {
+				status.BlockedBy = append(status.BlockedBy, link(edge))
+			}
+			if len(edges) > 0 {
+				status.Inferred = item.Status != StatusBlocked
+				status.Status = StatusBlocked
+
+				// Follow the first blocker until one has no blockers of its
+				// own or the chain loops back on itself
+				visited := map[int]bool{n: true}
+				for edge := edges[0]; !visited[edge.to]; {
+					visited[edge.to] = true
+					status.Chain = append(status.Chain, link(edge))
+					next := blockers(edge.to)
+					if len(next) == 0 {
+						break
+					}
+					edge = next[0]
+				}
+			}
+		}
+
+		roadmapID := g.nodes[n].RoadmapID
+		if statuses[roadmapID] == nil {
+			statuses[roadmapID] = make(map[string]EffectiveStatus)
+		}
+		statuses[roadmapID][item.ID] = status
+	}
+	return statuses
+}
+
+// ExternalGate is an external dependency an item waits for that isn't
+// completed yet
+type ExternalGate struct {
+	RoadmapID   string `json:"roadmap_id"`
+	RoadmapName string `json:"roadmap_name"`
+	// Target is what the item depends on in that roadmap, as in
+	// ExternalDependency.Target
+	Target      string        `json:"target"`
+	Status      RoadmapStatus `json:"status"`
+	Criticality string        `json:"criticality,omitempty"`
+	// Overdue is set once the dependency was due to be done
+	Overdue bool `json:"overdue,omitempty"`
+}
+
+// GatedItem is an item waiting for external dependencies
+type GatedItem struct {
+	ItemID   string         `json:"item_id"`
+	ItemName string         `json:"item_name"`
+	Status   RoadmapStatus  `json:"status"`
+	GatedBy  []ExternalGate `json:"gated_by"`
+}
+
+// ExternalExposure is how much of a roadmap waits on other roadmaps
+type ExternalExposure struct {
+	// Items are the roadmap's gated items, in roadmap order
+	Items []GatedItem `json:"items"`
+	// Gated counts the items and Criticality the dependencies gating them
+	Gated       int             `json:"gated"`
+	Criticality DependencyCount `json:"criticality"`
+}
+
+// BlockedByExternal lists the items of the roadmap that aren't completed and
+// wait for external dependencies, resolved against roadmaps, that aren't
+// completed either, as of now. A dependency on a milestone or a whole roadmap
+// is completed once every item delivering it is. Dependencies that don't
+// resolve are left out, since there is nothing to wait for.
+func BlockedByExternal(stored *StoredRoadmap, roadmaps []*StoredRoadmap, now time.Time) ExternalExposure {
+	byName := make(map[string]*StoredRoadmap)
+	byID := make(map[string]*StoredRoadmap)
+	for _, rm := range roadmaps {
+		byName[rm.Roadmap.Name] = rm
+		byID[rm.ID] = rm
+	}
+
+	exposure := ExternalExposure{Items: []GatedItem{}}
+	for _, item := range stored.Roadmap.Items {
+		if item.Status == StatusCompleted {
+			continue
+		}
+		gated := GatedItem{ItemID: item.ID, ItemName: item.Name, Status: item.Status}
+		for _, dep := range item.ExternalDependencies {
+			target := byName[dep.RoadmapName]
+			if dep.RoadmapID != "" {
+				target = byID[dep.RoadmapID]
+			}
+			if target == nil || !dep.resolves(&target.Roadmap) {
+				continue
+			}
+			status := dep.targetStatus(&target.Roadmap)
+			if status == StatusCompleted {
+				continue
+			}
+			gate := ExternalGate{
+				RoadmapID:   target.ID,
+				RoadmapName: target.Roadmap.Name,
+				Target:      dep.Target(),
+				Status:      status,
+				Criticality: dep.Criticality,
+			}
+			if due, _, ok := dep.due(&target.Roadmap); ok && !due.After(now) {
+				gate.Overdue = true
+			}
+			gated.GatedBy = append(gated.GatedBy, gate)
+			exposure.Criticality.add(dep.Criticality)
+		}
+		if len(gated.GatedBy) > 0 {
+			exposure.Items = append(exposure.Items, gated)
+			exposure.Gated++
+		}
+	}
+	return exposure
+}
@@ -0,0 +1,192 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// Team is a team that items allocate people from. Capacity is the number of
+// full-time people it has each month, unless a period override says
+// otherwise. Roadmaps that declare a team with the same name share it.
+type Team struct {
+	Name     string  `yaml:"name" json:"name"`
+	Capacity float64 `yaml:"capacity" json:"capacity"`
+	// Periods override the capacity for the months they cover; a later
+	// override wins over an earlier one
+	Periods []TeamPeriod `yaml:"periods,omitempty" json:"periods,omitempty"`
+}
+
+// TeamPeriod is a team's capacity for a quarter, month, or year, e.g. lower
+// over a holiday quarter
+type TeamPeriod struct {
+	Period   string  `yaml:"period" json:"period"`
+	Capacity float64 `yaml:"capacity" json:"capacity"`
+}
+
+// Allocation is how many full-time people of a team an item takes for every
+// month it runs
+type Allocation struct {
+	Team   string  `yaml:"team" json:"team"`
+	People float64 `yaml:"people" json:"people"`
+}
+
+// Validate checks that a team has a name and that its capacities are sensible
+func (t *Team) Validate() error {
+	if t.Name == "" {
+		return fmt.Errorf("team name is required")
+	}
+	if err := validatePeople("team capacity", t.Capacity); err != nil {
+		return err
+	}
+	for _, period := range t.Periods {
+		if _, _, err := ParsePeriod(period.Period); err != nil {
+			return fmt.Errorf("team period: %w", err)
+		}
+		if err := validatePeople("team period capacity", period.Capacity); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate checks that an allocation names a team and takes some people
+func (a *Allocation) Validate() error {
+	if a.Team == "" {
+		return fmt.Errorf("allocation team is required")
+	}
+	if !(a.People > 0) || math.IsInf(a.People, 0) {
+		return fmt.Errorf("allocation people must be a positive number")
+	}
+	return nil
+}
+
+// validatePeople checks that a number of people is not negative
+func validatePeople(field string, people float64) error {
+	if math.IsNaN(people) || math.IsInf(people, 0) || people < 0 {
+		return fmt.Errorf("%s must be a number of people, 0 or more", field)
+	}
+	return nil
+}
+
+// CapacityAt returns the team's capacity in the month starting at month
+func (t *Team) CapacityAt(month time.Time) float64 {
+	capacity := t.Capacity
+	for _, period := range t.Periods {
+		start, end, err := ParsePeriod(period.Period)
+		if err == nil && !month.Before(start) && month.Before(end) {
+			capacity = period.Capacity
+		}
+	}
+	return capacity
+}
+
+// AllocatedItem is an item's share of a team in one month
+type AllocatedItem struct {
+	RoadmapID string  `json:"roadmap_id"`
+	ItemID    string  `json:"item_id"`
+	ItemName  string  `json:"item_name"`
+	People    float64 `json:"people"`
+}
+
+// MonthLoad compares what a team has allocated in a month with its capacity
+type MonthLoad struct {
+	// Month is in YYYY-MM form
+	Month         string          `json:"month"`
+	Capacity      float64         `json:"capacity"`
+	Allocated     float64         `json:"allocated"`
+	OverAllocated bool            `json:"over_allocated"`
+	Items         []AllocatedItem `json:"items"`
+}
+
+// TeamLoad is a team's load in every month one of its allocated items runs
+type TeamLoad struct {
+	Team string `json:"team"`
+	// Roadmaps are the IDs of the roadmaps that declare the team
+	Roadmaps []string    `json:"roadmaps"`
+	Months   []MonthLoad `json:"months"`
+	// OverAllocated counts the months allocated beyond capacity
+	OverAllocated int `json:"over_allocated"`
+}
+
+// ReportCapacity works out, month by month, how many people the items of the
+// roadmaps take from each team and flags the months where that exceeds the
+// team's capacity. Teams are ordered by name and months by date; months with
+// nothing allocated are left out. The capacity of a team declared by several
+// roadmaps is that of the first. Items count for every month they overlap,
+// and items whose dates can't be parsed are left out.
+func ReportCapacity(roadmaps []*StoredRoadmap) []TeamLoad {
+	teams := make(map[string]*Team)
+	loads := make(map[string]*TeamLoad)
+	for _, rm := range roadmaps {
+		for i := range rm.Roadmap.Teams {
+			team := &rm.Roadmap.Teams[i]
+			load, ok := loads[team.Name]
+			if !ok {
+				teams[team.Name] = team
+				load = &TeamLoad{Team: team.Name, Roadmaps: []string{}, Months: []MonthLoad{}}
+				loads[team.Name] = load
+			}
+			load.Roadmaps = append(load.Roadmaps, rm.ID)
+		}
+	}
+
+	months := make(map[string]map[time.Time]*MonthLoad)
+	for _, rm := range roadmaps {
+		for i := range rm.Roadmap.Items {
+			item := &rm.Roadmap.Items[i]
+			if len(item.Allocations) == 0 {
+				continue
+			}
+			start, end, err := item.ItemSpan()
+			if err != nil {
+				continue
+			}
+			for _, allocation := range item.Allocations {
+				team, ok := teams[allocation.Team]
+				if !ok {
+					continue
+				}
+				if months[team.Name] == nil {
+					months[team.Name] = make(map[time.Time]*MonthLoad)
+				}
+				for month := monthStart(start); month.Before(end); month = month.AddDate(0, 1, 0) {
+					load, ok := months[team.Name][month]
+					if !ok {
+						load = &MonthLoad{Month: month.Format("2006-01"), Capacity: team.CapacityAt(month), Items: []AllocatedItem{}}
+						months[team.Name][month] = load
+					}
+					load.Allocated += allocation.People
+					load.Items = append(load.Items, AllocatedItem{
+						RoadmapID: rm.ID,
+						ItemID:    item.ID,
+						ItemName:  item.Name,
+						People:    allocation.People,
+					})
+				}
+			}
+		}
+	}
+
+	result := make([]TeamLoad, 0, len(loads))
+	for name, load := range loads {
+		for _, month := range months[name] {
+			month.Allocated = math.Round(month.Allocated*100) / 100
+			month.OverAllocated = month.Allocated > month.Capacity
+			if month.OverAllocated {
+				load.OverAllocated++
+			}
+			load.Months = append(load.Months, *month)
+		}
+		sort.Slice(load.Months, func(i, j int) bool { return load.Months[i].Month < load.Months[j].Month })
+		result = append(result, *load)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Team < result[j].Team })
+	return result
+}
+
+// monthStart returns the first day of t's month
+func monthStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
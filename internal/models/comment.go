@@ -0,0 +1,34 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxCommentLength bounds comment bodies
+const maxCommentLength = 10000
+
+// Comment is a remark on a roadmap or one of its items. Comments are kept
+// alongside the roadmap rather than in its content, so adding one doesn't
+// create a revision.
+type Comment struct {
+	ID string `json:"id"`
+	// ItemID is set for comments on an item rather than the whole roadmap
+	ItemID    string    `json:"item_id,omitempty"`
+	Author    string    `json:"author,omitempty"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Validate checks that the comment has a body of a reasonable length
+func (c *Comment) Validate() error {
+	if strings.TrimSpace(c.Body) == "" {
+		return fmt.Errorf("comment body is required")
+	}
+	if utf8.RuneCountInString(c.Body) > maxCommentLength {
+		return fmt.Errorf("comment body must be at most %d characters", maxCommentLength)
+	}
+	return nil
+}
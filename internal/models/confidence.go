@@ -0,0 +1,23 @@
+package models
+
+import "fmt"
+
+// Confidence is how firmly an item is planned, so firm commitments can be
+// told apart from aspirational entries
+type Confidence string
+
+const (
+	ConfidenceCommitted   Confidence = "committed"
+	ConfidenceLikely      Confidence = "likely"
+	ConfidenceExploratory Confidence = "exploratory"
+)
+
+// ValidateConfidence checks if a confidence level is valid
+func ValidateConfidence(confidence string) error {
+	switch Confidence(confidence) {
+	case ConfidenceCommitted, ConfidenceLikely, ConfidenceExploratory:
+		return nil
+	default:
+		return fmt.Errorf("invalid confidence '%s' (must be committed, likely, or exploratory)", confidence)
+	}
+}
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"fmt"
+	"net/mail"
+	"regexp"
+)
+
+// slackPattern matches a Slack member handle such as @jane.doe or a channel
+// such as #identity-team
+var slackPattern = regexp.MustCompile(`^[@#][a-z0-9][a-z0-9._-]{0,79}$`)
+
+// Contact is an owner of a roadmap and where to reach them, so notifications
+// have somewhere to go
+type Contact struct {
+	Name  string `yaml:"name" json:"name"`
+	Email string `yaml:"email,omitempty" json:"email,omitempty"`
+	// Slack is a member handle (@jane.doe) or a channel (#identity-team)
+	Slack string `yaml:"slack,omitempty" json:"slack,omitempty"`
+}
+
+// Validate checks the name, email address, and Slack handle of a contact
+func (c *Contact) Validate() error {
+	if c.Name == "" {
+		return fmt.Errorf("owner name is required")
+	}
+	if err := validateName("owner name", c.Name); err != nil {
+		return err
+	}
+	if c.Email != "" {
+		if addr, err := mail.ParseAddress(c.Email); err != nil || addr.Address != c.Email {
+			return fmt.Errorf("invalid owner email '%s' (must be a plain address such as jane@example.com)", c.Email)
+		}
+	}
+	if c.Slack != "" && !slackPattern.MatchString(c.Slack) {
+		return fmt.Errorf("invalid owner slack '%s' (must be a lower-case @handle or #channel)", c.Slack)
+	}
+	return nil
+}
+
+// OwnedBy reports whether name is the roadmap's owner or one of its owners
+func (r *Roadmap) OwnedBy(name string) bool {
+	if name == "" {
+		return false
+	}
+	if r.Owner == name {
+		return true
+	}
+	for i := range r.Owners {
+		if r.Owners[i].Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,129 @@
+package models
+
+import "time"
+
+// ItemSlack is how far an item's end can slip before it delays the roadmap
+type ItemSlack struct {
+	ID     string        `json:"id"`
+	Name   string        `json:"name"`
+	Status RoadmapStatus `json:"status"`
+	Start  string        `json:"start"`
+	End    string        `json:"end"`
+	// LatestEndDate is the last day the item can end on, inclusive, without
+	// pushing back the items that depend on it or the roadmap's end
+	LatestEndDate string `json:"latest_end_date"`
+	// SlackDays is the days between its end and its latest end; it is
+	// negative when an item that depends on it starts before it ends
+	SlackDays int  `json:"slack_days"`
+	Critical  bool `json:"critical"`
+}
+
+// CriticalPath is the chain of dependent items that decides when a roadmap
+// ends, and the slack of every item
+type CriticalPath struct {
+	RoadmapID   string `json:"roadmap_id"`
+	RoadmapName string `json:"roadmap_name"`
+	// EndDate is the last day of the roadmap's last item, inclusive
+	EndDate string `json:"end_date,omitempty"`
+	// Path is the IDs of the critical chain, first to last
+	Path []string `json:"path"`
+	// Items are in dependency order, as returned by Order
+	Items []ItemSlack `json:"items"`
+}
+
+// CriticalPath works out how late each item can end without moving the
+// roadmap's end, treating every dependency as finish-to-start and keeping
+// each item's duration. Items with no slack are critical. The path starts at
+// the item that ends last and follows, at each step, the dependency with the
+// least slack back to an item that depends on nothing. External dependencies
+// only count when they point back into this roadmap. If the dependencies
+// loop, a *CycleError lists the cycles.
+func (s *StoredRoadmap) CriticalPath() (CriticalPath, error) {
+	result := CriticalPath{RoadmapID: s.ID, RoadmapName: s.Roadmap.Name, Path: []string{}, Items: []ItemSlack{}}
+
+	g := newDependencyGraph([]*StoredRoadmap{s})
+	levels, ok := g.levels()
+	if !ok {
+		return result, &CycleError{Cycles: FindDependencyCycles([]*StoredRoadmap{s})}
+	}
+
+	starts := make([]time.Time, len(g.nodes))
+	ends := make([]time.Time, len(g.nodes))
+	scheduled := make([]bool, len(g.nodes))
+	var finish time.Time
+	for n, item := range g.items {
+		start, end, err := item.ItemSpan()
+		if err != nil {
+			continue
+		}
+		starts[n], ends[n], scheduled[n] = start, end, true
+		if end.After(finish) {
+			finish = end
+		}
+	}
+	if finish.IsZero() {
+		return result, nil
+	}
+	result.EndDate = finish.AddDate(0, 0, -1).Format("2006-01-02")
+
+	// Walk back from the last group, so every dependent's latest end is
+	// known before the items it depends on
+	latestEnds := make([]time.Time, len(g.nodes))
+	for n := range latestEnds {
+		latestEnds[n] = finish
+	}
+	for level := len(levels) - 1; level >= 0; level-- {
+		for _, n := range levels[level] {
+			if !scheduled[n] {
+				continue
+			}
+			latestStart := latestEnds[n].Add(-ends[n].Sub(starts[n]))
+			for _, edge := range g.edges[n] {
+				if latestStart.Before(latestEnds[edge.to]) {
+					latestEnds[edge.to] = latestStart
+				}
+			}
+		}
+	}
+
+	slack := make([]int, len(g.nodes))
+	last := -1
+	for _, nodes := range levels {
+		for _, n := range nodes {
+			if !scheduled[n] {
+				continue
+			}
+			item := g.items[n]
+			slack[n] = daysBetween(ends[n], latestEnds[n])
+			result.Items = append(result.Items, ItemSlack{
+				ID:            item.ID,
+				Name:          item.Name,
+				Status:        item.Status,
+				Start:         item.Start,
+				End:           item.End,
+				LatestEndDate: latestEnds[n].AddDate(0, 0, -1).Format("2006-01-02"),
+				SlackDays:     slack[n],
+				Critical:      slack[n] <= 0,
+			})
+			if ends[n].Equal(finish) && (last < 0 || slack[n] < slack[last]) {
+				last = n
+			}
+		}
+	}
+
+	var path []string
+	for n := last; n >= 0; {
+		path = append(path, g.nodes[n].ItemID)
+		next := -1
+		for _, edge := range g.edges[n] {
+			if scheduled[edge.to] && (next < 0 || slack[edge.to] < slack[next]) {
+				next = edge.to
+			}
+		}
+		n = next
+	}
+	for i := len(path) - 1; i >= 0; i-- {
+		result.Path = append(result.Path, path[i])
+	}
+	return result, nil
+}
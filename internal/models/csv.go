@@ -0,0 +1,74 @@
+package models
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+)
+
+// ItemColumns names the columns of ItemRows
+var ItemColumns = []string{
+	"roadmap_id", "roadmap", "service_line",
+	"item_id", "item", "status", "start", "end", "start_date", "end_date",
+	"team", "owner", "milestone", "dependencies", "external_dependencies",
+}
+
+// ItemRows flattens the items of the roadmaps into one row each, for
+// spreadsheets. start and end are as written and start_date and end_date the
+// days they cover, left empty if they don't parse. dependencies lists the IDs
+// of the items depended on and external_dependencies each external
+// dependency as roadmap:target, with its criticality in parentheses if it has
+// one, both separated by "; ".
+func ItemRows(roadmaps []*StoredRoadmap) [][]string {
+	var rows [][]string
+	for _, rm := range roadmaps {
+		for i := range rm.Roadmap.Items {
+			item := &rm.Roadmap.Items[i]
+			dates, _ := item.Dates()
+
+			external := make([]string, len(item.ExternalDependencies))
+			for j, dep := range item.ExternalDependencies {
+				external[j] = dep.RoadmapName + ":" + dep.Target()
+				if dep.Criticality != "" {
+					external[j] += " (" + dep.Criticality + ")"
+				}
+			}
+
+			rows = append(rows, []string{
+				rm.ID, rm.Roadmap.Name, rm.Roadmap.ServiceLine,
+				item.ID, item.Name, string(item.Status), item.Start, item.End, dates.StartDate, dates.EndDate,
+				item.Team, item.Owner, item.Milestone,
+				strings.Join(item.Dependencies, "; "), strings.Join(external, "; "),
+			})
+		}
+	}
+	return rows
+}
+
+// WriteCSV writes the ItemRows of the roadmaps as CSV under a header row
+func WriteCSV(w io.Writer, roadmaps []*StoredRoadmap) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(ItemColumns); err != nil {
+		return err
+	}
+
+	for _, row := range ItemRows(roadmaps) {
+		for j := range row {
+			row[j] = csvCell(row[j])
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// csvCell quotes text that a spreadsheet would otherwise run as a formula
+func csvCell(s string) string {
+	if s != "" && strings.ContainsRune("=+-@", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
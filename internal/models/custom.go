@@ -0,0 +1,53 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Limits for item custom fields
+const (
+	maxCustomKeyLength   = 64
+	maxCustomValueLength = 1000
+)
+
+// ValidateCustomKey checks the name of an item custom field. Names are
+// letters, digits, underscores, and hyphens, so they can be used as query
+// parameters such as ?custom.cost_center=
+func ValidateCustomKey(key string) error {
+	if key == "" {
+		return fmt.Errorf("custom field names must not be empty")
+	}
+	if len(key) > maxCustomKeyLength {
+		return fmt.Errorf("custom field name %s must be at most %d characters", key, maxCustomKeyLength)
+	}
+	for _, c := range key {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '_', c == '-':
+		default:
+			return fmt.Errorf("invalid custom field name %q (use letters, digits, _ and -)", key)
+		}
+	}
+	return nil
+}
+
+// HasCustomFields reports whether the item has every one of the custom field
+// values
+func (r *RoadmapItem) HasCustomFields(fields map[string]string) bool {
+	for key, value := range fields {
+		if actual, ok := r.Custom[key]; !ok || actual != value {
+			return false
+		}
+	}
+	return true
+}
+
+// CustomKeys returns the names of the item's custom fields in order
+func (r *RoadmapItem) CustomKeys() []string {
+	keys := make([]string, 0, len(r.Custom))
+	for key := range r.Custom {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
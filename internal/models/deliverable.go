@@ -0,0 +1,80 @@
+package models
+
+import (
+	"fmt"
+	"math"
+)
+
+// Deliverable is one entry in an item's checklist
+type Deliverable struct {
+	Name string `yaml:"name" json:"name"`
+	Done bool   `yaml:"done,omitempty" json:"done"`
+	// Due is when the deliverable is due, in any item date format
+	Due string `yaml:"due,omitempty" json:"due,omitempty"`
+}
+
+// Validate checks that a deliverable has a name and a valid due date
+func (d *Deliverable) Validate() error {
+	if d.Name == "" {
+		return fmt.Errorf("deliverable name is required")
+	}
+	if d.Due != "" {
+		if _, _, err := ParsePeriod(d.Due); err != nil {
+			return fmt.Errorf("deliverable %s due: %w", d.Name, err)
+		}
+	}
+	return nil
+}
+
+// DeliverableCount is how many of an item's deliverables are done, added to
+// the item by RoadmapItem.MarshalJSON
+type DeliverableCount struct {
+	Done  int `json:"deliverables_done"`
+	Total int `json:"deliverables_total"`
+}
+
+// CountDeliverables counts the item's deliverables and those that are done
+func (r *RoadmapItem) CountDeliverables() DeliverableCount {
+	count := DeliverableCount{Total: len(r.Deliverables)}
+	for _, deliverable := range r.Deliverables {
+		if deliverable.Done {
+			count.Done++
+		}
+	}
+	return count
+}
+
+// deliverablesProgress returns the percentage of the item's deliverables that
+// are done
+func (r *RoadmapItem) deliverablesProgress() int {
+	count := r.CountDeliverables()
+	if count.Total == 0 {
+		return 0
+	}
+	return int(math.Round(float64(count.Done) / float64(count.Total) * 100))
+}
+
+// validateDeliverables checks every deliverable, that names aren't repeated,
+// and that progress is either set or worked out from the deliverables
+func (r *RoadmapItem) validateDeliverables() error {
+	names := make(map[string]bool, len(r.Deliverables))
+	for i := range r.Deliverables {
+		if err := r.Deliverables[i].Validate(); err != nil {
+			return fmt.Errorf("deliverable %d: %w", i, err)
+		}
+		if names[r.Deliverables[i].Name] {
+			return fmt.Errorf("duplicate deliverable: %s", r.Deliverables[i].Name)
+		}
+		names[r.Deliverables[i].Name] = true
+	}
+
+	if r.ProgressFromDeliverables {
+		if len(r.Deliverables) == 0 {
+			return fmt.Errorf("progress_from_deliverables needs at least one deliverable")
+		}
+		if r.Progress != nil {
+			return fmt.Errorf("progress cannot be set when progress_from_deliverables is")
+		}
+	}
+	return nil
+}
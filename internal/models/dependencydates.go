@@ -0,0 +1,85 @@
+package models
+
+import "fmt"
+
+// Severities of a dependency date conflict
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityInfo    = "info"
+)
+
+// DependencySeverity returns how serious a date conflict on a dependency of
+// the given criticality is: an error for critical and high, info for low, and
+// a warning otherwise, including for internal dependencies, which have none
+func DependencySeverity(criticality string) string {
+	switch criticality {
+	case "critical", "high":
+		return SeverityError
+	case "low":
+		return SeverityInfo
+	default:
+		return SeverityWarning
+	}
+}
+
+// DependencyDateConflict is an internal dependency that ends after the item
+// that depends on it starts
+type DependencyDateConflict struct {
+	// RoadmapItemID and DependencyDesc are roadmap name:item ID, as in
+	// ExternalDependencyValidation
+	RoadmapItemID  string `json:"roadmap_item_id"`
+	DependencyDesc string `json:"dependency_desc"`
+	RoadmapID      string `json:"roadmap_id"`
+	DateConflict   string `json:"date_conflict"`
+	Severity       string `json:"severity"`
+}
+
+// ValidateInternalDependencies checks the dates of every internal dependency
+// across the roadmaps and returns those that end after the item depending on
+// them starts. Roadmap validation only rejects items that end before their
+// dependency starts, so these overlaps are allowed but worth a look.
+func ValidateInternalDependencies(roadmaps []StoredRoadmap) []DependencyDateConflict {
+	var conflicts []DependencyDateConflict
+	for i := range roadmaps {
+		roadmap := &roadmaps[i].Roadmap
+		for j := range roadmap.Items {
+			item := &roadmap.Items[j]
+			for _, depID := range item.Dependencies {
+				conflict := dateConflict(item, roadmap.item(depID))
+				if conflict == "" {
+					continue
+				}
+				conflicts = append(conflicts, DependencyDateConflict{
+					RoadmapItemID:  fmt.Sprintf("%s:%s", roadmap.Name, item.ID),
+					DependencyDesc: fmt.Sprintf("%s:%s", roadmap.Name, depID),
+					RoadmapID:      roadmaps[i].ID,
+					DateConflict:   conflict,
+					Severity:       DependencySeverity(""),
+				})
+			}
+		}
+	}
+	return conflicts
+}
+
+// dateConflict describes how the dependency runs past the start of the item
+// that depends on it, or returns "" if it doesn't or either has dates that
+// don't parse
+func dateConflict(item, dep *RoadmapItem) string {
+	if dep == nil {
+		return ""
+	}
+	start, _, err := item.ItemSpan()
+	if err != nil {
+		return ""
+	}
+	_, depEnd, err := dep.ItemSpan()
+	if err != nil {
+		return ""
+	}
+	if !depEnd.After(start) {
+		return ""
+	}
+	return fmt.Sprintf("%s ends (%s) after %s starts (%s)", dep.ID, dep.End, item.ID, item.Start)
+}
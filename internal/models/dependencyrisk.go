@@ -0,0 +1,135 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// DependencyRisk is how likely an external dependency is to hold up the item
+// that has it, scored from 0 (no risk) to 100
+type DependencyRisk struct {
+	RoadmapID   string             `json:"roadmap_id"`
+	RoadmapName string             `json:"roadmap_name"`
+	ItemID      string             `json:"item_id"`
+	ItemName    string             `json:"item_name"`
+	Dependency  ExternalDependency `json:"dependency"`
+	Score       int                `json:"score"`
+	// SlackDays is the days from the end of the item depended on to the start
+	// of the item depending on it; negative when they overlap
+	SlackDays    *int          `json:"slack_days,omitempty"`
+	TargetStatus RoadmapStatus `json:"target_status,omitempty"`
+	TargetHealth Health        `json:"target_health,omitempty"`
+	// Error is set when the dependency doesn't resolve
+	Error string `json:"error,omitempty"`
+}
+
+// criticalityWeight scales a dependency's risk by how much it matters; an
+// unset criticality counts as medium
+var criticalityWeight = map[string]float64{"critical": 1, "high": 0.75, "medium": 0.5, "low": 0.25, "": 0.5}
+
+// statusRisk is how much the status of the item depended on adds to the risk
+var statusRisk = map[RoadmapStatus]float64{StatusBlocked: 1, StatusPlanned: 0.75, StatusInProgress: 0.5, StatusCompleted: 0}
+
+// healthRisk is how much the health of the roadmap depended on adds to it
+var healthRisk = map[Health]float64{HealthLate: 1, HealthAtRisk: 0.5, HealthOnTrack: 0}
+
+// ScoreDependencyRisks scores every external dependency of the roadmaps' items,
+// resolved against targets, at now, highest first. The score is 100 times the
+// criticality weight (critical 1, high 0.75, medium or unset 0.5, low 0.25)
+// times a mix of:
+//
+//   - slack, 40%: full when the items overlap, then 75% up to 14 days, 50% up
+//     to 30, 25% up to 90, and nothing beyond
+//   - the status of the item depended on, 35%: full for blocked, 75% for
+//     planned, 50% for in progress
+//   - the health of its roadmap now, 25%: full when late, half when at risk
+//
+// A milestone or roadmap depended on counts as the items that deliver it, as
+// rolled up by its status, and is due at its date or the end of its last
+// item. A dependency on a completed item, or of a completed item, scores 0,
+// and one that doesn't resolve scores 100. Archived roadmaps and items are left out
+// as dependents but still resolve as targets.
+func ScoreDependencyRisks(roadmaps []*StoredRoadmap, targets []*StoredRoadmap, now time.Time) []DependencyRisk {
+	byName := make(map[string]*StoredRoadmap)
+	byID := make(map[string]*StoredRoadmap)
+	for _, rm := range targets {
+		byName[rm.Roadmap.Name] = rm
+		byID[rm.ID] = rm
+	}
+	health := make(map[*StoredRoadmap]Health)
+
+	risks := []DependencyRisk{}
+	for _, rm := range roadmaps {
+		if rm.Roadmap.Archived {
+			continue
+		}
+		for i := range rm.Roadmap.Items {
+			item := &rm.Roadmap.Items[i]
+			if item.Archived {
+				continue
+			}
+			for _, dep := range item.ExternalDependencies {
+				risk := DependencyRisk{
+					RoadmapID:   rm.ID,
+					RoadmapName: rm.Roadmap.Name,
+					ItemID:      item.ID,
+					ItemName:    item.Name,
+					Dependency:  dep,
+				}
+
+				target := byName[dep.RoadmapName]
+				if dep.RoadmapID != "" {
+					target = byID[dep.RoadmapID]
+				}
+				if target == nil || !dep.resolves(&target.Roadmap) {
+					risk.Score = 100
+					risk.Error = fmt.Sprintf("%s:%s not found", dep.RoadmapName, dep.Target())
+					risks = append(risks, risk)
+					continue
+				}
+
+				if _, ok := health[target]; !ok {
+					health[target] = target.Roadmap.Health(now)
+				}
+				risk.TargetStatus = dep.targetStatus(&target.Roadmap)
+				risk.TargetHealth = health[target]
+
+				slackRisk := 0.0
+				start, _, err := item.ItemSpan()
+				targetEnd, _, ok := dep.due(&target.Roadmap)
+				if err == nil && ok {
+					slack := daysBetween(targetEnd, start)
+					risk.SlackDays = &slack
+					slackRisk = slackFactor(slack)
+				}
+
+				if item.Status != StatusCompleted && risk.TargetStatus != StatusCompleted {
+					mix := 0.4*slackRisk + 0.35*statusRisk[risk.TargetStatus] + 0.25*healthRisk[risk.TargetHealth]
+					risk.Score = int(math.Round(100 * criticalityWeight[dep.Criticality] * mix))
+				}
+				risks = append(risks, risk)
+			}
+		}
+	}
+
+	sort.SliceStable(risks, func(i, j int) bool { return risks[i].Score > risks[j].Score })
+	return risks
+}
+
+// slackFactor is how much the days between two dependent items add to risk
+func slackFactor(days int) float64 {
+	switch {
+	case days < 0:
+		return 1
+	case days <= 14:
+		return 0.75
+	case days <= 30:
+		return 0.5
+	case days <= 90:
+		return 0.25
+	default:
+		return 0
+	}
+}
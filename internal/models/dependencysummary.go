@@ -0,0 +1,146 @@
+package models
+
+import "sort"
+
+// DependencyHealth counts the state of a set of external dependencies
+type DependencyHealth struct {
+	Total int `json:"total"`
+	Valid int `json:"valid"`
+	// Unresolved dependencies don't resolve; Acknowledged ones don't either,
+	// but an annotating prune has marked them orphaned for their author to
+	// fix, so they are counted apart
+	Unresolved   int `json:"unresolved"`
+	Acknowledged int `json:"acknowledged"`
+	// DateConflicts counts the external dependencies that end after the item
+	// depending on them starts, split by severity in BySeverity
+	DateConflicts int            `json:"date_conflicts"`
+	BySeverity    map[string]int `json:"by_severity"`
+	// InternalConflicts counts the same for dependencies within a roadmap
+	InternalConflicts  int `json:"internal_conflicts"`
+	LeadTimeViolations int `json:"lead_time_violations"`
+	// Criticality counts the external dependencies by criticality
+	Criticality DependencyCount `json:"criticality"`
+}
+
+func newDependencyHealth() DependencyHealth {
+	return DependencyHealth{BySeverity: map[string]int{SeverityError: 0, SeverityWarning: 0, SeverityInfo: 0}}
+}
+
+// add counts the external dependency with the given validation
+func (h *DependencyHealth) add(dep ExternalDependency, validation ExternalDependencyValidation) {
+	h.Total++
+	switch {
+	case validation.Valid:
+		h.Valid++
+	case dep.Orphaned != "":
+		h.Acknowledged++
+	default:
+		h.Unresolved++
+	}
+	if validation.DateConflict != "" {
+		h.DateConflicts++
+		h.BySeverity[validation.Severity]++
+	}
+	h.Criticality.add(dep.Criticality)
+}
+
+// merge adds the counts of other
+func (h *DependencyHealth) merge(other DependencyHealth) {
+	h.Total += other.Total
+	h.Valid += other.Valid
+	h.Unresolved += other.Unresolved
+	h.Acknowledged += other.Acknowledged
+	h.DateConflicts += other.DateConflicts
+	for severity, n := range other.BySeverity {
+		h.BySeverity[severity] += n
+	}
+	h.InternalConflicts += other.InternalConflicts
+	h.LeadTimeViolations += other.LeadTimeViolations
+	c := &h.Criticality
+	c.Total += other.Criticality.Total
+	c.Critical += other.Criticality.Critical
+	c.High += other.Criticality.High
+	c.Medium += other.Criticality.Medium
+	c.Low += other.Criticality.Low
+	c.Unset += other.Criticality.Unset
+}
+
+// RoadmapDependencyHealth is the dependency health of one roadmap
+type RoadmapDependencyHealth struct {
+	RoadmapID   string `json:"roadmap_id"`
+	RoadmapName string `json:"roadmap_name"`
+	ServiceLine string `json:"service_line"`
+	DependencyHealth
+}
+
+// ServiceLineDependencyHealth is the dependency health of the roadmaps of a
+// service line together
+type ServiceLineDependencyHealth struct {
+	ServiceLine string `json:"service_line"`
+	Roadmaps    int    `json:"roadmaps"`
+	DependencyHealth
+}
+
+// DependencySummary rolls the dependency health of roadmaps up by roadmap,
+// by service line, and overall
+type DependencySummary struct {
+	Total        DependencyHealth              `json:"total"`
+	Roadmaps     []RoadmapDependencyHealth     `json:"roadmaps"`
+	ServiceLines []ServiceLineDependencyHealth `json:"service_lines"`
+}
+
+// SummarizeDependencies validates the dependencies of the roadmaps' items
+// against targets, as the validate endpoint does, and counts the results
+// where the dependencies are declared. Roadmaps are ordered by ID and service
+// lines by name.
+func SummarizeDependencies(roadmaps []*StoredRoadmap, targets []*StoredRoadmap) DependencySummary {
+	values := make([]StoredRoadmap, len(targets))
+	for i, rm := range targets {
+		values[i] = *rm
+	}
+	t := newDependencyTargets(values)
+
+	sorted := append([]*StoredRoadmap(nil), roadmaps...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+
+	summary := DependencySummary{
+		Total:        newDependencyHealth(),
+		Roadmaps:     make([]RoadmapDependencyHealth, 0, len(sorted)),
+		ServiceLines: []ServiceLineDependencyHealth{},
+	}
+	lines := make(map[string]*ServiceLineDependencyHealth)
+	for _, rm := range sorted {
+		roadmap := &rm.Roadmap
+		health := newDependencyHealth()
+		for i := range roadmap.Items {
+			item := &roadmap.Items[i]
+			for _, extDep := range item.ExternalDependencies {
+				health.add(extDep, t.check(roadmap, item, extDep))
+				if _, ok := t.leadTime(roadmap, item, extDep); ok {
+					health.LeadTimeViolations++
+				}
+			}
+		}
+		health.InternalConflicts = len(ValidateInternalDependencies([]StoredRoadmap{*rm}))
+
+		summary.Roadmaps = append(summary.Roadmaps, RoadmapDependencyHealth{
+			RoadmapID:        rm.ID,
+			RoadmapName:      roadmap.Name,
+			ServiceLine:      roadmap.ServiceLine,
+			DependencyHealth: health,
+		})
+		line, ok := lines[roadmap.ServiceLine]
+		if !ok {
+			line = &ServiceLineDependencyHealth{ServiceLine: roadmap.ServiceLine, DependencyHealth: newDependencyHealth()}
+			lines[roadmap.ServiceLine] = line
+		}
+		line.Roadmaps++
+		line.merge(health)
+		summary.Total.merge(health)
+	}
+
+	for _, name := range sortedKeys(lines) {
+		summary.ServiceLines = append(summary.ServiceLines, *lines[name])
+	}
+	return summary
+}
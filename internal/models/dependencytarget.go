@@ -0,0 +1,171 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Target describes what the dependency points at in its roadmap: the item
+// ID, "item_name:" and the item's name, "milestone:" and the milestone ID, or
+// "completion" for the whole roadmap
+func (d ExternalDependency) Target() string {
+	switch {
+	case d.ItemName != "":
+		return "item_name:" + d.ItemName
+	case d.Milestone != "":
+		return "milestone:" + d.Milestone
+	case d.Completion:
+		return "completion"
+	default:
+		return d.ItemID
+	}
+}
+
+// resolves reports whether what the dependency points at exists in target.
+// Any roadmap can be depended on for completion.
+func (d ExternalDependency) resolves(target *Roadmap) bool {
+	switch {
+	case d.Milestone != "":
+		return target.milestone(d.Milestone) != nil
+	case d.Completion:
+		return true
+	default:
+		return d.TargetItem(target) != nil
+	}
+}
+
+// TargetItem returns the item of target the dependency points at, by its ID
+// or else by its name, which must match exactly one item apart from case. It
+// returns nil for a dependency on a milestone or completion.
+func (d ExternalDependency) TargetItem(target *Roadmap) *RoadmapItem {
+	if d.ItemName == "" {
+		return target.item(d.ItemID)
+	}
+	if matches := target.itemsNamed(d.ItemName); len(matches) == 1 {
+		return matches[0]
+	}
+	return nil
+}
+
+// waitsOn returns the items of target the dependency waits for: the item
+// depended on, the items delivering the milestone, or every item for
+// completion
+func (d ExternalDependency) waitsOn(target *Roadmap) []*RoadmapItem {
+	if d.Milestone == "" && !d.Completion {
+		if item := d.TargetItem(target); item != nil {
+			return []*RoadmapItem{item}
+		}
+		return nil
+	}
+	var items []*RoadmapItem
+	for i := range target.Items {
+		if d.Completion || target.Items[i].Milestone == d.Milestone {
+			items = append(items, &target.Items[i])
+		}
+	}
+	return items
+}
+
+// targetStatus rolls up the status of the items the dependency waits for in
+// target: completed when all of them are, or there are none, blocked when any
+// is, in progress once any has started, and planned otherwise
+func (d ExternalDependency) targetStatus(target *Roadmap) RoadmapStatus {
+	status := StatusCompleted
+	for _, item := range d.waitsOn(target) {
+		switch {
+		case item.Status == StatusBlocked:
+			return StatusBlocked
+		case item.Status == StatusInProgress:
+			status = StatusInProgress
+		case item.Status == StatusPlanned && status == StatusCompleted:
+			status = StatusPlanned
+		}
+	}
+	return status
+}
+
+// due returns when what the dependency points at in target is done, with
+// the date it is planned for: the end of the item, the milestone's date, or
+// the end of the roadmap's last item for completion. It returns false when
+// that date doesn't parse or, for completion, the roadmap has no items.
+func (d ExternalDependency) due(target *Roadmap) (time.Time, string, bool) {
+	switch {
+	case d.Milestone != "":
+		milestone := target.milestone(d.Milestone)
+		if milestone == nil {
+			return time.Time{}, "", false
+		}
+		_, end, err := ParsePeriod(milestone.Date)
+		return end, milestone.Date, err == nil
+	case d.Completion:
+		var last time.Time
+		var date string
+		for i := range target.Items {
+			_, end, err := target.Items[i].ItemSpan()
+			if err != nil {
+				return time.Time{}, "", false
+			}
+			if end.After(last) {
+				last, date = end, target.Items[i].End
+			}
+		}
+		return last, date, date != ""
+	default:
+		item := d.TargetItem(target)
+		if item == nil {
+			return time.Time{}, "", false
+		}
+		_, end, err := item.ItemSpan()
+		return end, item.End, err == nil
+	}
+}
+
+// Progress returns the rolled up status of what the dependency points at in
+// target and the date it is due, which is "" if it has none
+func (d ExternalDependency) Progress(target *Roadmap) (RoadmapStatus, string) {
+	_, date, _ := d.due(target)
+	return d.targetStatus(target), date
+}
+
+// externalDateConflict describes how what the dependency points at in target
+// runs past the start of the item that depends on it, or returns "" if it
+// doesn't or either has dates that don't parse
+func externalDateConflict(item *RoadmapItem, dep ExternalDependency, target *Roadmap) string {
+	if dep.Milestone == "" && !dep.Completion {
+		return dateConflict(item, dep.TargetItem(target))
+	}
+	start, _, err := item.ItemSpan()
+	if err != nil {
+		return ""
+	}
+	due, date, ok := dep.due(target)
+	if !ok || !due.After(start) {
+		return ""
+	}
+	if dep.Completion {
+		return fmt.Sprintf("%s completes (%s) after %s starts (%s)", target.Name, date, item.ID, item.Start)
+	}
+	return fmt.Sprintf("milestone %s is due (%s) after %s starts (%s)", dep.Milestone, date, item.ID, item.Start)
+}
+
+// itemsNamed returns the roadmap's items with the given name, apart from case
+func (r *Roadmap) itemsNamed(name string) []*RoadmapItem {
+	var items []*RoadmapItem
+	for i := range r.Items {
+		if strings.EqualFold(r.Items[i].Name, name) {
+			items = append(items, &r.Items[i])
+		}
+	}
+	return items
+}
+
+// milestone returns the roadmap's milestone with the given ID, or nil
+func (r *Roadmap) milestone(id string) *Milestone {
+	for i := range r.Milestones {
+		if r.Milestones[i].ID == id {
+			return &r.Milestones[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// colorPattern matches #RGB and #RRGGBB hex colors
+var colorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// Icons an item can be drawn with; the visualizer maps each to a symbol
+var Icons = []string{
+	"bell",
+	"bug",
+	"chart",
+	"cloud",
+	"database",
+	"flag",
+	"lock",
+	"people",
+	"rocket",
+	"shield",
+	"star",
+	"wrench",
+}
+
+// ValidateColor checks that a color is a #RGB or #RRGGBB hex color
+func ValidateColor(color string) error {
+	if !colorPattern.MatchString(color) {
+		return fmt.Errorf("invalid color '%s' (must be a hex color such as #1976d2)", color)
+	}
+	return nil
+}
+
+// ValidateIcon checks that an icon is one of Icons
+func ValidateIcon(icon string) error {
+	if !containsString(Icons, icon) {
+		return fmt.Errorf("invalid icon '%s' (must be one of %v)", icon, Icons)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// statusFill is the fill color of an item's node in a DOT graph
+var statusFill = map[RoadmapStatus]string{
+	StatusPlanned:    "#e0e0e0",
+	StatusInProgress: "#90caf9",
+	StatusCompleted:  "#a5d6a7",
+	StatusBlocked:    "#ef9a9a",
+}
+
+// DOT renders the graph in Graphviz DOT syntax. Each roadmap is a cluster,
+// items are filled by status, and external dependencies are bold edges
+// labelled with their criticality. Items outside the requested roadmaps have
+// a dashed outline. Edges point from the item that depends to the item it
+// depends on, as in the graph.
+func (g DependencyGraph) DOT() string {
+	var b strings.Builder
+	b.WriteString("digraph dependencies {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box, style=\"rounded,filled\", fontname=\"Helvetica\"];\n")
+	b.WriteString("  edge [fontname=\"Helvetica\", fontsize=10];\n")
+
+	// Nodes are grouped by roadmap ID already, so each cluster is one run
+	for i := 0; i < len(g.Nodes); {
+		roadmapID := g.Nodes[i].RoadmapID
+		fmt.Fprintf(&b, "\n  subgraph %s {\n", dotQuote("cluster_"+roadmapID))
+		fmt.Fprintf(&b, "    label=%s;\n", dotQuote(g.Nodes[i].RoadmapName))
+		for ; i < len(g.Nodes) && g.Nodes[i].RoadmapID == roadmapID; i++ {
+			node := g.Nodes[i]
+			fill, ok := statusFill[node.Status]
+			if !ok {
+				fill = "#ffffff"
+			}
+			style := ""
+			if !node.InScope {
+				style = ", style=\"rounded,filled,dashed\""
+			}
+			fmt.Fprintf(&b, "    %s [label=%s, fillcolor=%s%s];\n",
+				dotQuote(node.ID), dotQuote(node.Label+"\n"+node.Start+" to "+node.End), dotQuote(fill), style)
+		}
+		b.WriteString("  }\n")
+	}
+
+	if len(g.Edges) > 0 {
+		b.WriteString("\n")
+	}
+	for _, edge := range g.Edges {
+		var attrs []string
+		if edge.Type == EdgeExternal {
+			attrs = append(attrs, "style=bold")
+			if edge.Criticality != "" {
+				attrs = append(attrs, "label="+dotQuote(edge.Criticality))
+			}
+		}
+		fmt.Fprintf(&b, "  %s -> %s", dotQuote(edge.Source), dotQuote(edge.Target))
+		if len(attrs) > 0 {
+			fmt.Fprintf(&b, " [%s]", strings.Join(attrs, ", "))
+		}
+		b.WriteString(";\n")
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// dotQuote returns s as a quoted DOT string
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
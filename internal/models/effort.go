@@ -0,0 +1,170 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Units of an effort estimate
+const (
+	EffortPersonDays   = "person-days"
+	EffortPersonWeeks  = "person-weeks"
+	EffortPersonMonths = "person-months"
+	EffortTShirt       = "t-shirt" // a size instead of a value
+)
+
+// tShirtWeeks is the person-weeks each t-shirt size counts as in totals
+var tShirtWeeks = map[string]float64{
+	"XS": 1,
+	"S":  2,
+	"M":  4,
+	"L":  8,
+	"XL": 16,
+}
+
+// Effort is an estimate of the work in an item: a value in person-days,
+// person-weeks, or person-months, or a t-shirt size
+type Effort struct {
+	Value float64 `yaml:"value,omitempty" json:"value,omitempty"`
+	Unit  string  `yaml:"unit" json:"unit"`
+	// Size is XS, S, M, L, or XL when Unit is t-shirt
+	Size string `yaml:"size,omitempty" json:"size,omitempty"`
+}
+
+// Validate checks the unit and that the estimate fits it
+func (e *Effort) Validate() error {
+	switch e.Unit {
+	case EffortPersonDays, EffortPersonWeeks, EffortPersonMonths:
+		if !(e.Value > 0) || math.IsInf(e.Value, 0) {
+			return fmt.Errorf("effort value must be a positive number of %s", e.Unit)
+		}
+		if e.Size != "" {
+			return fmt.Errorf("effort size is only used with unit t-shirt")
+		}
+	case EffortTShirt:
+		if _, ok := tShirtWeeks[e.Size]; !ok {
+			return fmt.Errorf("invalid effort size '%s' (must be XS, S, M, L, or XL)", e.Size)
+		}
+		if e.Value != 0 {
+			return fmt.Errorf("effort value is not used with unit t-shirt")
+		}
+	default:
+		return fmt.Errorf("invalid effort unit '%s' (must be person-days, person-weeks, person-months, or t-shirt)", e.Unit)
+	}
+	return nil
+}
+
+// PersonWeeks converts the estimate to person-weeks, counting five days to a
+// week, 52/12 weeks to a month, and t-shirt sizes as in tShirtWeeks
+func (e *Effort) PersonWeeks() float64 {
+	switch e.Unit {
+	case EffortPersonDays:
+		return e.Value / 5
+	case EffortPersonWeeks:
+		return e.Value
+	case EffortPersonMonths:
+		return e.Value * 52 / 12
+	default:
+		return tShirtWeeks[e.Size]
+	}
+}
+
+// EffortTotal is the estimated work of a group of items
+type EffortTotal struct {
+	// ID is set for roadmaps
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name"`
+	// PersonWeeks is the sum of the estimates, rounded to one decimal
+	PersonWeeks float64 `json:"person_weeks"`
+	// Items is the number of items in the group, and Estimated how many of
+	// them have an estimate
+	Items     int `json:"items"`
+	Estimated int `json:"estimated"`
+}
+
+// EffortReport totals item estimates for capacity planning
+type EffortReport struct {
+	Roadmaps     []EffortTotal `json:"roadmaps"`
+	ServiceLines []EffortTotal `json:"service_lines"`
+	// Owners groups items by their owner, or their team if they have no
+	// owner, or the roadmap owner if they have neither; an empty name
+	// collects the rest
+	Owners []EffortTotal `json:"owners"`
+}
+
+// ReportEffort totals the effort of the items in the roadmaps, each list
+// largest first and then by name
+func ReportEffort(roadmaps []*StoredRoadmap) EffortReport {
+	serviceLines := make(map[string]*EffortTotal)
+	owners := make(map[string]*EffortTotal)
+	report := EffortReport{Roadmaps: make([]EffortTotal, 0, len(roadmaps))}
+
+	for _, rm := range roadmaps {
+		total := EffortTotal{ID: rm.ID, Name: rm.Roadmap.Name}
+		serviceLine := effortGroup(serviceLines, rm.Roadmap.ServiceLine)
+		for i := range rm.Roadmap.Items {
+			item := &rm.Roadmap.Items[i]
+			owner := effortGroup(owners, firstNonEmpty(item.Owner, item.Team, rm.Roadmap.Owner))
+			for _, t := range []*EffortTotal{&total, serviceLine, owner} {
+				t.add(item)
+			}
+		}
+		report.Roadmaps = append(report.Roadmaps, total)
+	}
+
+	report.ServiceLines = totalsOf(serviceLines)
+	report.Owners = totalsOf(owners)
+	for _, totals := range [][]EffortTotal{report.Roadmaps, report.ServiceLines, report.Owners} {
+		for i := range totals {
+			totals[i].PersonWeeks = math.Round(totals[i].PersonWeeks*10) / 10
+		}
+		sortTotals(totals)
+	}
+	return report
+}
+
+// add counts an item in the total
+func (t *EffortTotal) add(item *RoadmapItem) {
+	t.Items++
+	if item.Effort != nil {
+		t.Estimated++
+		t.PersonWeeks += item.Effort.PersonWeeks()
+	}
+}
+
+// effortGroup returns the total for name, adding it if needed
+func effortGroup(groups map[string]*EffortTotal, name string) *EffortTotal {
+	group, ok := groups[name]
+	if !ok {
+		group = &EffortTotal{Name: name}
+		groups[name] = group
+	}
+	return group
+}
+
+func totalsOf(groups map[string]*EffortTotal) []EffortTotal {
+	totals := make([]EffortTotal, 0, len(groups))
+	for _, group := range groups {
+		totals = append(totals, *group)
+	}
+	return totals
+}
+
+func sortTotals(totals []EffortTotal) {
+	sort.Slice(totals, func(i, j int) bool {
+		if totals[i].PersonWeeks != totals[j].PersonWeeks {
+			return totals[i].PersonWeeks > totals[j].PersonWeeks
+		}
+		return totals[i].Name < totals[j].Name
+	})
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,86 @@
+package models
+
+import (
+	"sort"
+	"strings"
+)
+
+// maxSuggestions is how many near matches a validation suggests at most
+const maxSuggestions = 3
+
+// nearestMatches returns up to maxSuggestions candidates close enough to
+// value to be a likely typo of it, closest first. Case is ignored, and a
+// candidate is close enough within an edit distance of a third of value's
+// length, but at least 2.
+func nearestMatches(value string, candidates []string) []string {
+	limit := len(value) / 3
+	if limit < 2 {
+		limit = 2
+	}
+
+	type match struct {
+		candidate string
+		distance  int
+	}
+	var matches []match
+	seen := make(map[string]bool)
+	for _, candidate := range candidates {
+		if seen[candidate] || candidate == value {
+			continue
+		}
+		seen[candidate] = true
+		if d := editDistance(strings.ToLower(value), strings.ToLower(candidate)); d <= limit {
+			matches = append(matches, match{candidate, d})
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].distance != matches[j].distance {
+			return matches[i].distance < matches[j].distance
+		}
+		return matches[i].candidate < matches[j].candidate
+	})
+
+	var nearest []string
+	for i := 0; i < len(matches) && i < maxSuggestions; i++ {
+		nearest = append(nearest, matches[i].candidate)
+	}
+	return nearest
+}
+
+// editDistance returns the number of single-character insertions,
+// deletions, substitutions, and swaps of adjacent characters that turn a into
+// b; swaps count once since they are the most common typo
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(ra)][len(rb)]
+}
+
+// sortedKeys returns the keys of a map in order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
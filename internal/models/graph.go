@@ -0,0 +1,275 @@
+package models
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DependencyNode is an item in the dependency graph of all roadmaps
+type DependencyNode struct {
+	RoadmapID   string `json:"roadmap_id"`
+	RoadmapName string `json:"roadmap_name"`
+	ItemID      string `json:"item_id"`
+	ItemName    string `json:"item_name"`
+}
+
+// Key identifies the node as roadmap_id:item_id
+func (n DependencyNode) Key() string {
+	return n.RoadmapID + ":" + n.ItemID
+}
+
+// dependencyEdge points from an item to one it depends on
+type dependencyEdge struct {
+	to          int
+	external    bool
+	criticality string
+}
+
+// dependencyGraph joins the internal and external dependencies of a set of
+// roadmaps into one graph. Nodes are ordered by roadmap ID, then as the items
+// are listed; edges keep the order the dependencies are listed in.
+type dependencyGraph struct {
+	nodes    []DependencyNode
+	items    []*RoadmapItem
+	roadmaps []*StoredRoadmap
+	index    map[string]int
+	edges    [][]dependencyEdge
+}
+
+// newDependencyGraph builds the graph of the roadmaps' items. External
+// dependencies resolve by roadmap ID if one is given, else by name, as when
+// they are validated; dependencies that don't resolve are left out.
+func newDependencyGraph(roadmaps []*StoredRoadmap) *dependencyGraph {
+	sorted := append([]*StoredRoadmap(nil), roadmaps...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+
+	g := &dependencyGraph{index: make(map[string]int)}
+	byName := make(map[string]*StoredRoadmap)
+	byID := make(map[string]*StoredRoadmap)
+	for _, rm := range sorted {
+		byName[rm.Roadmap.Name] = rm
+		byID[rm.ID] = rm
+		for i := range rm.Roadmap.Items {
+			item := &rm.Roadmap.Items[i]
+			node := DependencyNode{RoadmapID: rm.ID, RoadmapName: rm.Roadmap.Name, ItemID: item.ID, ItemName: item.Name}
+			g.index[node.Key()] = len(g.nodes)
+			g.nodes = append(g.nodes, node)
+			g.items = append(g.items, item)
+			g.roadmaps = append(g.roadmaps, rm)
+		}
+	}
+
+	g.edges = make([][]dependencyEdge, len(g.nodes))
+	for from, node := range g.nodes {
+		item := g.items[from]
+		for _, depID := range item.Dependencies {
+			if to, ok := g.index[node.RoadmapID+":"+depID]; ok {
+				g.edges[from] = append(g.edges[from], dependencyEdge{to: to})
+			}
+		}
+		for _, extDep := range item.ExternalDependencies {
+			target := byName[extDep.RoadmapName]
+			if extDep.RoadmapID != "" {
+				target = byID[extDep.RoadmapID]
+			}
+			if target == nil {
+				continue
+			}
+			item := extDep.TargetItem(&target.Roadmap)
+			if item == nil {
+				continue
+			}
+			if to, ok := g.index[target.ID+":"+item.ID]; ok {
+				g.edges[from] = append(g.edges[from], dependencyEdge{to: to, external: true, criticality: extDep.Criticality})
+			}
+		}
+	}
+	return g
+}
+
+// Kinds of dependency edge
+const (
+	EdgeInternal = "internal"
+	EdgeExternal = "external"
+)
+
+// GraphNode is an item drawn in a dependency graph
+type GraphNode struct {
+	// ID is the item as roadmap_id:item_id
+	ID          string        `json:"id"`
+	Label       string        `json:"label"`
+	RoadmapID   string        `json:"roadmap_id"`
+	RoadmapName string        `json:"roadmap_name"`
+	ServiceLine string        `json:"service_line"`
+	ItemID      string        `json:"item_id"`
+	Status      RoadmapStatus `json:"status"`
+	Start       string        `json:"start"`
+	End         string        `json:"end"`
+	// InScope is false for items outside the requested roadmaps that are
+	// drawn because an edge joins them to one inside
+	InScope bool `json:"in_scope"`
+}
+
+// GraphEdge is a dependency drawn in a dependency graph. It points from the
+// item that depends (Source) to the item it depends on (Target).
+type GraphEdge struct {
+	// ID is source->target
+	ID     string `json:"id"`
+	Source string `json:"source"`
+	Target string `json:"target"`
+	// Type is internal or external
+	Type        string `json:"type"`
+	Criticality string `json:"criticality,omitempty"`
+}
+
+// DependencyGraph is the items and dependencies of a set of roadmaps as nodes
+// and edges, ready for a graph library to lay out
+type DependencyGraph struct {
+	Nodes []GraphNode `json:"nodes"`
+	Edges []GraphEdge `json:"edges"`
+}
+
+// BuildDependencyGraph draws the items and dependencies of the roadmaps whose
+// IDs are in scope, or of all of them if scope is nil. External dependencies
+// are resolved against all the roadmaps, and items outside the scope are drawn
+// when an edge joins them to one inside, so no edge is left dangling. An item
+// listing the same dependency twice gets one edge.
+func BuildDependencyGraph(roadmaps []*StoredRoadmap, scope map[string]bool) DependencyGraph {
+	g := newDependencyGraph(roadmaps)
+	inScope := func(n int) bool { return scope == nil || scope[g.nodes[n].RoadmapID] }
+
+	drawn := make([]bool, len(g.nodes))
+	seen := make(map[string]bool)
+	graph := DependencyGraph{Nodes: []GraphNode{}, Edges: []GraphEdge{}}
+	for from, edges := range g.edges {
+		for _, edge := range edges {
+			if !inScope(from) && !inScope(edge.to) {
+				continue
+			}
+			drawn[from], drawn[edge.to] = true, true
+			e := GraphEdge{Source: g.nodes[from].Key(), Target: g.nodes[edge.to].Key(), Type: EdgeInternal}
+			e.ID = e.Source + "->" + e.Target
+			if edge.external {
+				e.Type = EdgeExternal
+				e.Criticality = edge.criticality
+			}
+			if seen[e.ID] {
+				continue
+			}
+			seen[e.ID] = true
+			graph.Edges = append(graph.Edges, e)
+		}
+	}
+	for n, node := range g.nodes {
+		if !drawn[n] && !inScope(n) {
+			continue
+		}
+		item := g.items[n]
+		graph.Nodes = append(graph.Nodes, GraphNode{
+			ID:          node.Key(),
+			Label:       item.Name,
+			RoadmapID:   node.RoadmapID,
+			RoadmapName: node.RoadmapName,
+			ServiceLine: g.roadmaps[n].Roadmap.ServiceLine,
+			ItemID:      item.ID,
+			Status:      item.Status,
+			Start:       item.Start,
+			End:         item.End,
+			InScope:     inScope(n),
+		})
+	}
+	return graph
+}
+
+// DependencyCycle is a loop of items that each depend on the next
+type DependencyCycle struct {
+	// Path is the items as roadmap_id:item_id; each depends on the next and
+	// the last on the first
+	Path  []string         `json:"path"`
+	Nodes []DependencyNode `json:"nodes"`
+	// CrossRoadmap is set when the items belong to more than one roadmap
+	CrossRoadmap bool `json:"cross_roadmap"`
+}
+
+// FindDependencyCycles follows the internal and external dependencies of the
+// roadmaps and returns the cycles among their items. Every cycle is closed by
+// a different dependency, so each set of items caught in a loop is reported at
+// least once. Paths start from the item of the lowest roadmap ID listed first.
+func FindDependencyCycles(roadmaps []*StoredRoadmap) []DependencyCycle {
+	g := newDependencyGraph(roadmaps)
+
+	const (
+		unvisited = iota
+		onStack
+		visited
+	)
+	state := make([]int, len(g.nodes))
+	position := make([]int, len(g.nodes))
+	var stack []int
+	seen := make(map[string]bool)
+	cycles := []DependencyCycle{}
+
+	var visit func(from int)
+	visit = func(from int) {
+		state[from] = onStack
+		position[from] = len(stack)
+		stack = append(stack, from)
+		for _, edge := range g.edges[from] {
+			switch state[edge.to] {
+			case unvisited:
+				visit(edge.to)
+			case onStack:
+				loop := rotateToLowest(stack[position[edge.to]:])
+				key := nodeListKey(loop)
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				cycles = append(cycles, g.cycle(loop))
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[from] = visited
+	}
+	for i := range g.nodes {
+		if state[i] == unvisited {
+			visit(i)
+		}
+	}
+	return cycles
+}
+
+// cycle describes a loop of node indexes
+func (g *dependencyGraph) cycle(loop []int) DependencyCycle {
+	cycle := DependencyCycle{Path: make([]string, len(loop)), Nodes: make([]DependencyNode, len(loop))}
+	for i, n := range loop {
+		cycle.Path[i] = g.nodes[n].Key()
+		cycle.Nodes[i] = g.nodes[n]
+		if g.nodes[n].RoadmapID != g.nodes[loop[0]].RoadmapID {
+			cycle.CrossRoadmap = true
+		}
+	}
+	return cycle
+}
+
+// rotateToLowest returns a copy of a loop of node indexes starting from its
+// lowest index, so the same loop found from different items looks the same
+func rotateToLowest(loop []int) []int {
+	lowest := 0
+	for i, n := range loop {
+		if n < loop[lowest] {
+			lowest = i
+		}
+	}
+	return append(append([]int(nil), loop[lowest:]...), loop[:lowest]...)
+}
+
+// nodeListKey joins node indexes into a map key
+func nodeListKey(nodes []int) string {
+	parts := make([]string, len(nodes))
+	for i, n := range nodes {
+		parts[i] = strconv.Itoa(n)
+	}
+	return strings.Join(parts, ",")
+}
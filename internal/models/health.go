@@ -0,0 +1,103 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Health is how an item or roadmap is doing against its dates, worked out
+// when asked rather than stored
+type Health string
+
+const (
+	HealthOnTrack Health = "on-track"
+	HealthAtRisk  Health = "at-risk"
+	HealthLate    Health = "late"
+)
+
+// healthRank orders health from best to worst
+var healthRank = map[Health]int{
+	HealthOnTrack: 0,
+	HealthAtRisk:  1,
+	HealthLate:    2,
+}
+
+// ValidateHealth checks if a health value is valid
+func ValidateHealth(health string) error {
+	if _, ok := healthRank[Health(health)]; !ok {
+		return fmt.Errorf("invalid health '%s' (must be on-track, at-risk, or late)", health)
+	}
+	return nil
+}
+
+// ItemHealth returns the health of every item of the roadmap at now, keyed by
+// item ID. An item that isn't completed is late once its end has passed, and
+// at risk while blocked, if it should have started but is still planned, or
+// if an item it depends on is blocked or late and not archived. Everything
+// else, including every completed item, is on track. Date rules are skipped
+// for items whose dates can't be parsed.
+func (r *Roadmap) ItemHealth(now time.Time) map[string]Health {
+	late := make(map[string]bool, len(r.Items))
+	// trouble holds the items that put the items depending on them at risk
+	trouble := make(map[string]bool, len(r.Items))
+	for i := range r.Items {
+		item := &r.Items[i]
+		if item.Status == StatusCompleted {
+			continue
+		}
+		if _, end, err := item.ItemSpan(); err == nil && !now.Before(end) {
+			late[item.ID] = true
+		}
+		if !item.Archived && (late[item.ID] || item.Status == StatusBlocked) {
+			trouble[item.ID] = true
+		}
+	}
+
+	health := make(map[string]Health, len(r.Items))
+	for i := range r.Items {
+		item := &r.Items[i]
+		switch {
+		case item.Status == StatusCompleted:
+			health[item.ID] = HealthOnTrack
+		case late[item.ID]:
+			health[item.ID] = HealthLate
+		case item.Status == StatusBlocked || item.overdueStart(now) || dependsOnTrouble(item, trouble):
+			health[item.ID] = HealthAtRisk
+		default:
+			health[item.ID] = HealthOnTrack
+		}
+	}
+	return health
+}
+
+// Health returns the worst health of the roadmap's items at now, leaving out
+// archived items, or on track if it has none
+func (r *Roadmap) Health(now time.Time) Health {
+	worst := HealthOnTrack
+	itemHealth := r.ItemHealth(now)
+	for i := range r.Items {
+		if health := itemHealth[r.Items[i].ID]; !r.Items[i].Archived && healthRank[health] > healthRank[worst] {
+			worst = health
+		}
+	}
+	return worst
+}
+
+// overdueStart reports whether a planned item's start has passed
+func (r *RoadmapItem) overdueStart(now time.Time) bool {
+	if r.Status != StatusPlanned {
+		return false
+	}
+	start, _, err := r.ItemSpan()
+	return err == nil && !now.Before(start)
+}
+
+// dependsOnTrouble reports whether any item the item depends on is in trouble
+func dependsOnTrouble(item *RoadmapItem, trouble map[string]bool) bool {
+	for _, dep := range item.Dependencies {
+		if trouble[dep] {
+			return true
+		}
+	}
+	return false
+}
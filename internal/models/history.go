@@ -0,0 +1,128 @@
+package models
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// StatusChange is one entry in an item's status history
+type StatusChange struct {
+	// From is empty for the status an item was created with
+	From   RoadmapStatus `yaml:"from,omitempty" json:"from,omitempty"`
+	To     RoadmapStatus `yaml:"to" json:"to"`
+	At     time.Time     `yaml:"at" json:"at"`
+	Author string        `yaml:"author,omitempty" json:"author,omitempty"`
+}
+
+// CycleTime is how long a completed item took, from when it first went in
+// progress to when it was last completed. ok is false if the item isn't
+// completed or its history doesn't have both changes.
+func (r *RoadmapItem) CycleTime() (cycle time.Duration, ok bool) {
+	if r.Status != StatusCompleted {
+		return 0, false
+	}
+
+	var started, completed *time.Time
+	for i := range r.StatusHistory {
+		change := &r.StatusHistory[i]
+		switch change.To {
+		case StatusInProgress:
+			if started == nil {
+				started = &change.At
+			}
+		case StatusCompleted:
+			completed = &change.At
+		}
+	}
+	if started == nil || completed == nil || completed.Before(*started) {
+		return 0, false
+	}
+	return completed.Sub(*started), true
+}
+
+// CycleTimeTotal summarizes the cycle times of a group of items
+type CycleTimeTotal struct {
+	// ID is set for roadmaps
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name"`
+	// Completed is the number of completed items with a known cycle time
+	Completed int `json:"completed"`
+	// AverageDays and MedianDays are rounded to one decimal, and zero when
+	// no item has a cycle time
+	AverageDays float64 `json:"average_days"`
+	MedianDays  float64 `json:"median_days"`
+
+	days []float64
+}
+
+// CycleTimeReport summarizes how long items take from in progress to
+// completed
+type CycleTimeReport struct {
+	Roadmaps     []CycleTimeTotal `json:"roadmaps"`
+	ServiceLines []CycleTimeTotal `json:"service_lines"`
+}
+
+// ReportCycleTimes summarizes the cycle times of the items in the roadmaps,
+// each list slowest first and then by name. Roadmaps without a completed item
+// with a known cycle time are included with a count of zero.
+func ReportCycleTimes(roadmaps []*StoredRoadmap) CycleTimeReport {
+	serviceLines := make(map[string]*CycleTimeTotal)
+	report := CycleTimeReport{Roadmaps: make([]CycleTimeTotal, 0, len(roadmaps))}
+
+	for _, rm := range roadmaps {
+		total := CycleTimeTotal{ID: rm.ID, Name: rm.Roadmap.Name}
+		serviceLine, ok := serviceLines[rm.Roadmap.ServiceLine]
+		if !ok {
+			serviceLine = &CycleTimeTotal{Name: rm.Roadmap.ServiceLine}
+			serviceLines[rm.Roadmap.ServiceLine] = serviceLine
+		}
+		for i := range rm.Roadmap.Items {
+			cycle, ok := rm.Roadmap.Items[i].CycleTime()
+			if !ok {
+				continue
+			}
+			days := cycle.Hours() / 24
+			total.days = append(total.days, days)
+			serviceLine.days = append(serviceLine.days, days)
+		}
+		report.Roadmaps = append(report.Roadmaps, total)
+	}
+
+	report.ServiceLines = make([]CycleTimeTotal, 0, len(serviceLines))
+	for _, group := range serviceLines {
+		report.ServiceLines = append(report.ServiceLines, *group)
+	}
+	for _, totals := range [][]CycleTimeTotal{report.Roadmaps, report.ServiceLines} {
+		for i := range totals {
+			totals[i].summarize()
+		}
+		sort.Slice(totals, func(i, j int) bool {
+			if totals[i].AverageDays != totals[j].AverageDays {
+				return totals[i].AverageDays > totals[j].AverageDays
+			}
+			return totals[i].Name < totals[j].Name
+		})
+	}
+	return report
+}
+
+// summarize works out the count, average, and median from the collected days
+func (t *CycleTimeTotal) summarize() {
+	t.Completed = len(t.days)
+	if t.Completed == 0 {
+		return
+	}
+
+	sort.Float64s(t.days)
+	var sum float64
+	for _, days := range t.days {
+		sum += days
+	}
+	median := t.days[t.Completed/2]
+	if t.Completed%2 == 0 {
+		median = (t.days[t.Completed/2-1] + median) / 2
+	}
+	t.AverageDays = math.Round(sum/float64(t.Completed)*10) / 10
+	t.MedianDays = math.Round(median*10) / 10
+}
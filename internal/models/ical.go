@@ -0,0 +1,127 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ICalendar renders the roadmaps as an iCalendar (.ics) feed named name, for
+// calendar apps to import or subscribe to. Each item is an all-day event from
+// the first to the last day it covers, and each milestone an all-day event on
+// the last day of its date. With an owner, only the items assigned to that
+// owner or team are included, unless they own the roadmap, when all of it
+// is. Items and milestones whose dates don't parse are left out.
+func ICalendar(name string, roadmaps []*StoredRoadmap, owner string) string {
+	var b strings.Builder
+	writeICalLine(&b, "BEGIN:VCALENDAR")
+	writeICalLine(&b, "VERSION:2.0")
+	writeICalLine(&b, "PRODID:-//roadmap-visualizer//Roadmaps//EN")
+	writeICalLine(&b, "CALSCALE:GREGORIAN")
+	writeICalLine(&b, "METHOD:PUBLISH")
+	writeICalLine(&b, "X-WR-CALNAME:"+icalText(name))
+
+	for _, rm := range roadmaps {
+		roadmap := &rm.Roadmap
+		whole := owner == "" || roadmap.OwnedBy(owner)
+		stamp := rm.UpdatedAt.UTC().Format("20060102T150405Z")
+
+		for i := range roadmap.Items {
+			item := &roadmap.Items[i]
+			if !whole && !item.AssignedTo(owner) {
+				continue
+			}
+			start, end, err := item.ItemSpan()
+			if err != nil {
+				continue
+			}
+			description := fmt.Sprintf("Status: %s", item.Status)
+			if item.Owner != "" {
+				description += "\nOwner: " + item.Owner
+			}
+			if item.Team != "" {
+				description += "\nTeam: " + item.Team
+			}
+			if item.Description != "" {
+				description += "\n\n" + item.Description
+			}
+			writeICalEvent(&b, icalEvent{
+				uid:         fmt.Sprintf("%s/items/%s", rm.ID, item.ID),
+				stamp:       stamp,
+				start:       start,
+				end:         end,
+				summary:     fmt.Sprintf("%s: %s", roadmap.Name, item.Name),
+				description: description,
+				category:    string(item.Status),
+			})
+		}
+
+		if !whole {
+			continue
+		}
+		for _, milestone := range roadmap.Milestones {
+			_, due, err := ParsePeriod(milestone.Date)
+			if err != nil {
+				continue
+			}
+			writeICalEvent(&b, icalEvent{
+				uid:         fmt.Sprintf("%s/milestones/%s", rm.ID, milestone.ID),
+				stamp:       stamp,
+				start:       due.AddDate(0, 0, -1),
+				end:         due,
+				summary:     fmt.Sprintf("%s: %s (milestone)", roadmap.Name, milestone.Name),
+				description: milestone.Description,
+				category:    "milestone",
+			})
+		}
+	}
+
+	writeICalLine(&b, "END:VCALENDAR")
+	return b.String()
+}
+
+// icalEvent is an all-day event from start up to, but not including, end
+type icalEvent struct {
+	uid         string
+	stamp       string
+	start, end  time.Time
+	summary     string
+	description string
+	category    string
+}
+
+func writeICalEvent(b *strings.Builder, event icalEvent) {
+	writeICalLine(b, "BEGIN:VEVENT")
+	writeICalLine(b, "UID:"+icalText(event.uid)+"@roadmap-visualizer")
+	writeICalLine(b, "DTSTAMP:"+event.stamp)
+	writeICalLine(b, "DTSTART;VALUE=DATE:"+event.start.Format("20060102"))
+	writeICalLine(b, "DTEND;VALUE=DATE:"+event.end.Format("20060102"))
+	writeICalLine(b, "SUMMARY:"+icalText(event.summary))
+	if event.description != "" {
+		writeICalLine(b, "DESCRIPTION:"+icalText(event.description))
+	}
+	writeICalLine(b, "CATEGORIES:"+icalText(event.category))
+	writeICalLine(b, "TRANSP:TRANSPARENT")
+	writeICalLine(b, "END:VEVENT")
+}
+
+// writeICalLine ends a content line with CRLF, folding it so no line is
+// longer than 75 bytes without splitting a character
+func writeICalLine(b *strings.Builder, line string) {
+	for limit := 75; len(line) > limit; limit = 74 {
+		cut := limit
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
+
+// icalText escapes a TEXT value
+func icalText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`).Replace(s)
+}
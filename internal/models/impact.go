@@ -0,0 +1,203 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ImpactedItem is an item that could no longer start on time if another item
+// slipped
+type ImpactedItem struct {
+	DependencyNode
+	Status RoadmapStatus `json:"status"`
+	Start  string        `json:"start"`
+	End    string        `json:"end"`
+	// NewStartDate and NewEndDate are the first and last day of the item,
+	// inclusive, once it is pushed back to wait for the dependency
+	NewStartDate string `json:"new_start_date"`
+	NewEndDate   string `json:"new_end_date"`
+	DelayDays    int    `json:"delay_days"`
+	// Via is the dependency that pushes it back, as roadmap_id:item_id, and
+	// Type and Criticality describe that dependency
+	Via         string `json:"via"`
+	Type        string `json:"type"`
+	Criticality string `json:"criticality,omitempty"`
+}
+
+// ImpactGroup is the impacted items of a roadmap pushed back through
+// dependencies of one criticality
+type ImpactGroup struct {
+	// Criticality is empty for internal dependencies and external ones
+	// without a criticality
+	Criticality string         `json:"criticality"`
+	Items       []ImpactedItem `json:"items"`
+}
+
+// RoadmapImpact is the impacted items of one roadmap
+type RoadmapImpact struct {
+	RoadmapID   string        `json:"roadmap_id"`
+	RoadmapName string        `json:"roadmap_name"`
+	Items       int           `json:"items"`
+	Groups      []ImpactGroup `json:"groups"`
+}
+
+// ImpactAnalysis is what would break if an item ended later
+type ImpactAnalysis struct {
+	Item DependencyNode `json:"item"`
+	End  string         `json:"end"`
+	// NewEnd is the hypothetical end, in any item date format
+	NewEnd   string          `json:"new_end"`
+	SlipDays int             `json:"slip_days"`
+	Total    int             `json:"total"`
+	Roadmaps []RoadmapImpact `json:"roadmaps"`
+}
+
+// criticalityRank orders criticalities from most to least critical
+var criticalityRank = map[string]int{"critical": 0, "high": 1, "medium": 2, "low": 3, "": 4}
+
+// AtLeastCriticality reports whether criticality is min or more critical; an
+// unset criticality is less critical than low
+func AtLeastCriticality(criticality, min string) bool {
+	return criticalityRank[criticality] <= criticalityRank[min]
+}
+
+// AnalyzeImpact works out which items, across the roadmaps, could no longer
+// start on time if the item ended at newEnd instead. An item is impacted when
+// it would start before a dependency it waits for ends; it is then pushed
+// back, keeping its duration, which may impact the items that depend on it in
+// turn. Only the delay added by the slip counts, so an item that already
+// overlapped its dependency is pushed back by no more than the slip. Roadmaps
+// are ordered by ID, groups from most to least critical, and items by delay,
+// longest first. It fails if there is no such item or newEnd can't be parsed.
+func AnalyzeImpact(roadmaps []*StoredRoadmap, roadmapID, itemID, newEnd string) (ImpactAnalysis, error) {
+	g := newDependencyGraph(roadmaps)
+	origin, ok := g.index[roadmapID+":"+itemID]
+	if !ok {
+		return ImpactAnalysis{}, fmt.Errorf("item %s:%s not found", roadmapID, itemID)
+	}
+	_, slippedEnd, err := ParsePeriod(newEnd)
+	if err != nil {
+		return ImpactAnalysis{}, fmt.Errorf("new end: %w", err)
+	}
+
+	starts := make([]time.Time, len(g.nodes))
+	ends := make([]time.Time, len(g.nodes))
+	scheduled := make([]bool, len(g.nodes))
+	for n, item := range g.items {
+		if start, end, err := item.ItemSpan(); err == nil {
+			starts[n], ends[n], scheduled[n] = start, end, true
+		}
+	}
+	if !scheduled[origin] {
+		return ImpactAnalysis{}, fmt.Errorf("item %s:%s has no valid dates", roadmapID, itemID)
+	}
+
+	analysis := ImpactAnalysis{
+		Item:     g.nodes[origin],
+		End:      g.items[origin].End,
+		NewEnd:   newEnd,
+		SlipDays: daysBetween(ends[origin], slippedEnd),
+		Roadmaps: []RoadmapImpact{},
+	}
+
+	dependents := make([][]int, len(g.nodes))
+	via := make(map[[2]int]dependencyEdge)
+	for from, edges := range g.edges {
+		for _, edge := range edges {
+			if _, ok := via[[2]int{edge.to, from}]; !ok {
+				dependents[edge.to] = append(dependents[edge.to], from)
+				via[[2]int{edge.to, from}] = edge
+			}
+		}
+	}
+
+	// Push items back from the slipped one outwards. An item is pushed again
+	// if a later dependency pushes it further; loops stop once every item
+	// in them has been pushed as many times as there are items.
+	newEnds := map[int]time.Time{origin: slippedEnd}
+	impacted := make(map[int]*ImpactedItem)
+	pushes := make([]int, len(g.nodes))
+	queue := []int{origin}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		for _, d := range dependents[n] {
+			if d == origin || !scheduled[d] || pushes[d] >= len(g.nodes) {
+				continue
+			}
+			waitFrom := starts[d]
+			if ends[n].After(waitFrom) {
+				waitFrom = ends[n]
+			}
+			delay := newEnds[n].Sub(waitFrom)
+			if delay <= 0 {
+				continue
+			}
+			if _, ok := impacted[d]; ok && newEnds[d].Sub(ends[d]) >= delay {
+				continue
+			}
+
+			edge := via[[2]int{n, d}]
+			entry := &ImpactedItem{
+				DependencyNode: g.nodes[d],
+				Status:         g.items[d].Status,
+				Start:          g.items[d].Start,
+				End:            g.items[d].End,
+				NewStartDate:   starts[d].Add(delay).Format("2006-01-02"),
+				NewEndDate:     ends[d].Add(delay).AddDate(0, 0, -1).Format("2006-01-02"),
+				DelayDays:      daysBetween(starts[d], starts[d].Add(delay)),
+				Via:            g.nodes[n].Key(),
+				Type:           EdgeInternal,
+			}
+			if edge.external {
+				entry.Type = EdgeExternal
+				entry.Criticality = edge.criticality
+			}
+			impacted[d] = entry
+			newEnds[d] = ends[d].Add(delay)
+			pushes[d]++
+			queue = append(queue, d)
+		}
+	}
+
+	byRoadmap := make(map[string]*RoadmapImpact)
+	for _, entry := range impacted {
+		impact, ok := byRoadmap[entry.RoadmapID]
+		if !ok {
+			impact = &RoadmapImpact{RoadmapID: entry.RoadmapID, RoadmapName: entry.RoadmapName}
+			byRoadmap[entry.RoadmapID] = impact
+		}
+		impact.Items++
+		var group *ImpactGroup
+		for i := range impact.Groups {
+			if impact.Groups[i].Criticality == entry.Criticality {
+				group = &impact.Groups[i]
+			}
+		}
+		if group == nil {
+			impact.Groups = append(impact.Groups, ImpactGroup{Criticality: entry.Criticality})
+			group = &impact.Groups[len(impact.Groups)-1]
+		}
+		group.Items = append(group.Items, *entry)
+	}
+
+	for _, impact := range byRoadmap {
+		sort.Slice(impact.Groups, func(i, j int) bool {
+			return criticalityRank[impact.Groups[i].Criticality] < criticalityRank[impact.Groups[j].Criticality]
+		})
+		for _, group := range impact.Groups {
+			items := group.Items
+			sort.Slice(items, func(i, j int) bool {
+				if items[i].DelayDays != items[j].DelayDays {
+					return items[i].DelayDays > items[j].DelayDays
+				}
+				return g.index[items[i].Key()] < g.index[items[j].Key()]
+			})
+		}
+		analysis.Total += impact.Items
+		analysis.Roadmaps = append(analysis.Roadmaps, *impact)
+	}
+	sort.Slice(analysis.Roadmaps, func(i, j int) bool { return analysis.Roadmaps[i].RoadmapID < analysis.Roadmaps[j].RoadmapID })
+	return analysis, nil
+}
@@ -0,0 +1,141 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// ItemTypes are the types an item may be classified as. The server replaces
+// them from ITEM_TYPES at startup, before any roadmap is validated.
+var ItemTypes = []string{
+	"feature",
+	"tech-debt",
+	"research",
+	"compliance",
+	"ops",
+}
+
+// ParseItemTypes reads a comma-separated list of item types, which must be
+// lower case with hyphens as tags are, and not repeated
+func ParseItemTypes(value string) ([]string, error) {
+	var types []string
+	for _, t := range strings.Split(value, ",") {
+		t = strings.TrimSpace(t)
+		if t == "" {
+			continue
+		}
+		if normalized := NormalizeTag(t); t != normalized {
+			return nil, fmt.Errorf("item type %q must be lower case with hyphens (use %q)", t, normalized)
+		}
+		if containsString(types, t) {
+			return nil, fmt.Errorf("duplicate item type: %s", t)
+		}
+		types = append(types, t)
+	}
+	if len(types) == 0 {
+		return nil, fmt.Errorf("no item types given")
+	}
+	return types, nil
+}
+
+// ValidateItemType checks that an item type is one of ItemTypes
+func ValidateItemType(itemType string) error {
+	if !containsString(ItemTypes, itemType) {
+		return fmt.Errorf("invalid type '%s' (must be one of %v)", itemType, ItemTypes)
+	}
+	return nil
+}
+
+// TypeCount is how many items of a group have one type
+type TypeCount struct {
+	// Type is empty for items without one
+	Type  string `json:"type"`
+	Items int    `json:"items"`
+	// Percent is the share of the group's items, rounded
+	Percent int `json:"percent"`
+}
+
+// TypeBreakdown is the mix of item types in a group of items
+type TypeBreakdown struct {
+	// ID is set for roadmaps
+	ID    string `json:"id,omitempty"`
+	Name  string `json:"name"`
+	Items int    `json:"items"`
+	// Types has every configured type in order, then any other types found,
+	// then the untyped items if there are any
+	Types []TypeCount `json:"types"`
+
+	counts map[string]int
+}
+
+// TypeReport is the mix of item types per roadmap and service line
+type TypeReport struct {
+	Roadmaps     []TypeBreakdown `json:"roadmaps"`
+	ServiceLines []TypeBreakdown `json:"service_lines"`
+}
+
+// ReportItemTypes counts the items of each type in the roadmaps, each list by
+// name
+func ReportItemTypes(roadmaps []*StoredRoadmap) TypeReport {
+	serviceLines := make(map[string]*TypeBreakdown)
+	report := TypeReport{Roadmaps: make([]TypeBreakdown, 0, len(roadmaps))}
+
+	for _, rm := range roadmaps {
+		breakdown := TypeBreakdown{ID: rm.ID, Name: rm.Roadmap.Name, counts: make(map[string]int)}
+		serviceLine, ok := serviceLines[rm.Roadmap.ServiceLine]
+		if !ok {
+			serviceLine = &TypeBreakdown{Name: rm.Roadmap.ServiceLine, counts: make(map[string]int)}
+			serviceLines[rm.Roadmap.ServiceLine] = serviceLine
+		}
+		for i := range rm.Roadmap.Items {
+			breakdown.counts[rm.Roadmap.Items[i].Type]++
+			serviceLine.counts[rm.Roadmap.Items[i].Type]++
+		}
+		report.Roadmaps = append(report.Roadmaps, breakdown)
+	}
+
+	report.ServiceLines = make([]TypeBreakdown, 0, len(serviceLines))
+	for _, group := range serviceLines {
+		report.ServiceLines = append(report.ServiceLines, *group)
+	}
+	for _, breakdowns := range [][]TypeBreakdown{report.Roadmaps, report.ServiceLines} {
+		for i := range breakdowns {
+			breakdowns[i].summarize()
+		}
+		sort.Slice(breakdowns, func(i, j int) bool {
+			if breakdowns[i].Name != breakdowns[j].Name {
+				return breakdowns[i].Name < breakdowns[j].Name
+			}
+			return breakdowns[i].ID < breakdowns[j].ID
+		})
+	}
+	return report
+}
+
+// summarize turns the collected counts into the item total and type list
+func (b *TypeBreakdown) summarize() {
+	types := append([]string(nil), ItemTypes...)
+	var other []string
+	for t, n := range b.counts {
+		b.Items += n
+		if t != "" && !containsString(types, t) {
+			other = append(other, t)
+		}
+	}
+	sort.Strings(other)
+	types = append(types, other...)
+	if b.counts[""] > 0 {
+		types = append(types, "")
+	}
+
+	b.Types = make([]TypeCount, 0, len(types))
+	for _, t := range types {
+		count := TypeCount{Type: t, Items: b.counts[t]}
+		if b.Items > 0 {
+			count.Percent = int(math.Round(float64(count.Items) / float64(b.Items) * 100))
+		}
+		b.Types = append(b.Types, count)
+	}
+}
@@ -0,0 +1,139 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// LeadTimes is the minimum number of days, by criticality, between the end of
+// an external dependency, or the date of a milestone depended on, and the
+// start of the item depending on it. There is
+// no policy by default; the server sets it from DEPENDENCY_LEAD_TIMES at
+// startup.
+var LeadTimes = map[string]int{}
+
+// ParseLeadTimes reads a comma-separated list of criticality=duration pairs,
+// such as critical=2w,high=5d, where a duration is a number of days or weeks
+// (a bare number is days)
+func ParseLeadTimes(value string) (map[string]int, error) {
+	leadTimes := make(map[string]int)
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		criticality, duration, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid lead time %q (must be criticality=duration)", pair)
+		}
+		criticality = strings.TrimSpace(criticality)
+		switch criticality {
+		case "low", "medium", "high", "critical":
+		default:
+			return nil, fmt.Errorf("invalid criticality '%s' (must be low, medium, high, or critical)", criticality)
+		}
+		if _, ok := leadTimes[criticality]; ok {
+			return nil, fmt.Errorf("duplicate lead time for %s", criticality)
+		}
+
+		duration = strings.TrimSpace(duration)
+		number, unit := strings.TrimSuffix(duration, "d"), 1
+		if weeks, ok := strings.CutSuffix(duration, "w"); ok {
+			number, unit = weeks, 7
+		}
+		days, err := strconv.Atoi(number)
+		if err != nil || days < 0 {
+			return nil, fmt.Errorf("invalid lead time %q for %s (must be a number of days or weeks, such as 5d or 2w)", duration, criticality)
+		}
+		leadTimes[criticality] = days * unit
+	}
+	return leadTimes, nil
+}
+
+// LeadTimeViolation is an external dependency that ends too close to the
+// start of the item depending on it for the lead time of its criticality
+type LeadTimeViolation struct {
+	// RoadmapItemID and DependencyDesc are roadmap name:item ID, as in
+	// ExternalDependencyValidation
+	RoadmapItemID  string `json:"roadmap_item_id"`
+	DependencyDesc string `json:"dependency_desc"`
+	RoadmapID      string `json:"roadmap_id"`
+	Criticality    string `json:"criticality"`
+	LeadTimeDays   int    `json:"lead_time_days"`
+	// SlackDays is the days from the end of the dependency to the start of
+	// the item; negative when they overlap
+	SlackDays int    `json:"slack_days"`
+	Message   string `json:"message"`
+	Severity  string `json:"severity"`
+}
+
+// ValidateLeadTimes checks every external dependency with a lead time for its
+// criticality and returns, as warnings, those that end fewer than that many
+// days before the item depending on them starts. A milestone is due at the
+// end of its date and a roadmap's completion at the end of its last item.
+// Dependencies that don't resolve or have dates that don't parse, and those
+// where either side is completed, are skipped.
+func ValidateLeadTimes(roadmaps []StoredRoadmap) []LeadTimeViolation {
+	violations := []LeadTimeViolation{}
+	if len(LeadTimes) == 0 {
+		return violations
+	}
+
+	targets := newDependencyTargets(roadmaps)
+	for i := range roadmaps {
+		roadmap := &roadmaps[i].Roadmap
+		for j := range roadmap.Items {
+			item := &roadmap.Items[j]
+			for _, extDep := range item.ExternalDependencies {
+				if violation, ok := targets.leadTime(roadmap, item, extDep); ok {
+					violation.RoadmapID = roadmaps[i].ID
+					violations = append(violations, violation)
+				}
+			}
+		}
+	}
+	return violations
+}
+
+// leadTime checks one external dependency of an item of the roadmap against
+// the lead time policy, returning the violation if there is one
+func (t *dependencyTargets) leadTime(roadmap *Roadmap, item *RoadmapItem, extDep ExternalDependency) (LeadTimeViolation, bool) {
+	leadTime, ok := LeadTimes[extDep.Criticality]
+	if !ok || item.Status == StatusCompleted {
+		return LeadTimeViolation{}, false
+	}
+	targetRoadmap := t.byName[extDep.RoadmapName]
+	if extDep.RoadmapID != "" {
+		targetRoadmap = t.byID[extDep.RoadmapID]
+	}
+	if targetRoadmap == nil {
+		return LeadTimeViolation{}, false
+	}
+	if !extDep.resolves(&targetRoadmap.Roadmap) || extDep.targetStatus(&targetRoadmap.Roadmap) == StatusCompleted {
+		return LeadTimeViolation{}, false
+	}
+
+	start, _, err := item.ItemSpan()
+	if err != nil {
+		return LeadTimeViolation{}, false
+	}
+	depEnd, _, ok := extDep.due(&targetRoadmap.Roadmap)
+	if !ok {
+		return LeadTimeViolation{}, false
+	}
+	slack := daysBetween(depEnd, start)
+	if slack >= leadTime {
+		return LeadTimeViolation{}, false
+	}
+	return LeadTimeViolation{
+		RoadmapItemID:  fmt.Sprintf("%s:%s", roadmap.Name, item.ID),
+		DependencyDesc: fmt.Sprintf("%s:%s", extDep.RoadmapName, extDep.Target()),
+		Criticality:    extDep.Criticality,
+		LeadTimeDays:   leadTime,
+		SlackDays:      slack,
+		Message: fmt.Sprintf("%s dependency %s ends %d days before %s starts (policy: at least %d)",
+			extDep.Criticality, extDep.Target(), slack, item.ID, leadTime),
+		Severity: SeverityWarning,
+	}, true
+}
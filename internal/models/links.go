@@ -0,0 +1,35 @@
+package models
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// maxLinkURLLength bounds link URLs, which browsers and proxies may truncate
+// beyond this
+const maxLinkURLLength = 2048
+
+// Link points from an item to a related page, such as a design doc or an epic
+type Link struct {
+	Title string `yaml:"title" json:"title"`
+	URL   string `yaml:"url" json:"url"`
+}
+
+// Validate checks that the link has a title and an absolute http or https URL
+func (l *Link) Validate() error {
+	if strings.TrimSpace(l.Title) == "" {
+		return fmt.Errorf("link title is required")
+	}
+	if l.URL == "" {
+		return fmt.Errorf("link url is required")
+	}
+	if len(l.URL) > maxLinkURLLength {
+		return fmt.Errorf("link url must be at most %d characters", maxLinkURLLength)
+	}
+	u, err := url.Parse(l.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid link url %q (must be an absolute http or https URL)", l.URL)
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Ways to group the items of a Markdown export
+const (
+	MarkdownByStatus  = "status"
+	MarkdownByQuarter = "quarter"
+)
+
+// markdownStatuses are the status sections of a Markdown export, in order
+var markdownStatuses = []struct {
+	status RoadmapStatus
+	title  string
+}{
+	{StatusInProgress, "In progress"},
+	{StatusBlocked, "Blocked"},
+	{StatusPlanned, "Planned"},
+	{StatusCompleted, "Completed"},
+}
+
+// Markdown renders the roadmap as Markdown to paste into wikis and release
+// notes: a section per status, or per fiscal quarter in which items start
+// when groupBy is MarkdownByQuarter (items whose start doesn't parse come
+// last, as unscheduled), then the milestones. Each item's dependencies are
+// called out in a quote under it, listing the items of the roadmap by name and
+// the external dependencies by roadmap, target, and criticality.
+func (s *StoredRoadmap) Markdown(groupBy string) string {
+	roadmap := &s.Roadmap
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", markdownText(roadmap.Name))
+	fmt.Fprintf(&b, "**Service line:** %s", markdownText(roadmap.ServiceLine))
+	if roadmap.Owner != "" {
+		fmt.Fprintf(&b, " · **Owner:** %s", markdownText(roadmap.Owner))
+	}
+	b.WriteString("\n\n")
+	if roadmap.Notes != "" {
+		fmt.Fprintf(&b, "%s\n\n", strings.TrimSpace(roadmap.Notes))
+	}
+
+	if groupBy == MarkdownByQuarter {
+		var quarters []string
+		byQuarter := make(map[string][]*RoadmapItem)
+		for i := range roadmap.Items {
+			item := &roadmap.Items[i]
+			quarter := ""
+			if start, _, err := ParsePeriod(item.Start); err == nil {
+				quarter = fiscalQuarter(start)
+			}
+			if _, ok := byQuarter[quarter]; !ok {
+				quarters = append(quarters, quarter)
+			}
+			byQuarter[quarter] = append(byQuarter[quarter], item)
+		}
+		// Quarters sort by name; the unscheduled come last
+		sort.Slice(quarters, func(i, j int) bool {
+			return quarters[j] == "" || quarters[i] != "" && quarters[i] < quarters[j]
+		})
+		for _, quarter := range quarters {
+			title := quarter
+			if title == "" {
+				title = "Unscheduled"
+			}
+			s.writeMarkdownSection(&b, title, byQuarter[quarter], true)
+		}
+	} else {
+		byStatus := make(map[RoadmapStatus][]*RoadmapItem)
+		for i := range roadmap.Items {
+			byStatus[roadmap.Items[i].Status] = append(byStatus[roadmap.Items[i].Status], &roadmap.Items[i])
+		}
+		for _, section := range markdownStatuses {
+			if items := byStatus[section.status]; len(items) > 0 {
+				s.writeMarkdownSection(&b, section.title, items, false)
+			}
+		}
+	}
+
+	if len(roadmap.Milestones) > 0 {
+		b.WriteString("## Milestones\n\n")
+		for _, milestone := range roadmap.Milestones {
+			fmt.Fprintf(&b, "- **%s** (`%s`): %s\n", markdownText(milestone.Name), milestone.ID, milestone.Date)
+		}
+		b.WriteString("\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// writeMarkdownSection writes a heading with the number of items under it,
+// then the items, with their status when the section isn't by status
+func (s *StoredRoadmap) writeMarkdownSection(b *strings.Builder, title string, items []*RoadmapItem, withStatus bool) {
+	fmt.Fprintf(b, "## %s (%d)\n\n", title, len(items))
+	for _, item := range items {
+		fmt.Fprintf(b, "- **%s** (`%s`): %s → %s", markdownText(item.Name), item.ID, item.Start, item.End)
+		if withStatus {
+			fmt.Fprintf(b, ", %s", item.Status)
+		}
+		if item.Team != "" {
+			fmt.Fprintf(b, ", %s", markdownText(item.Team))
+		}
+		b.WriteString("\n")
+
+		if len(item.Dependencies) > 0 || len(item.ExternalDependencies) > 0 {
+			b.WriteString("  > **Depends on**\n")
+		}
+		for _, id := range item.Dependencies {
+			name := id
+			if dep := s.Roadmap.item(id); dep != nil {
+				name = dep.Name
+			}
+			fmt.Fprintf(b, "  > - %s (`%s`)\n", markdownText(name), id)
+		}
+		for _, dep := range item.ExternalDependencies {
+			fmt.Fprintf(b, "  > - %s: %s", markdownText(dep.RoadmapName), dependencyTargetText(dep))
+			if dep.Criticality != "" {
+				fmt.Fprintf(b, " (%s)", dep.Criticality)
+			}
+			if dep.Reason != "" {
+				fmt.Fprintf(b, ", %s", markdownText(dep.Reason))
+			}
+			b.WriteString("\n")
+		}
+	}
+	b.WriteString("\n")
+}
+
+// dependencyTargetText describes what an external dependency points at
+func dependencyTargetText(dep ExternalDependency) string {
+	switch {
+	case dep.ItemName != "":
+		return "item named " + markdownText(dep.ItemName)
+	case dep.Milestone != "":
+		return "milestone `" + dep.Milestone + "`"
+	case dep.Completion:
+		return "completion"
+	default:
+		return "item `" + dep.ItemID + "`"
+	}
+}
+
+// markdownText escapes the characters Markdown would read as formatting and
+// keeps the text on one line
+func markdownText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "]", `\]`, "<", `\<`, "\n", " ").Replace(s)
+}
@@ -0,0 +1,87 @@
+package models
+
+import "sort"
+
+// DependencyCount is a number of external dependencies, split by criticality
+type DependencyCount struct {
+	Total    int `json:"total"`
+	Critical int `json:"critical"`
+	High     int `json:"high"`
+	Medium   int `json:"medium"`
+	Low      int `json:"low"`
+	// Unset counts dependencies without a criticality
+	Unset int `json:"unset"`
+}
+
+// add counts one dependency of the given criticality
+func (c *DependencyCount) add(criticality string) {
+	c.Total++
+	switch criticality {
+	case "critical":
+		c.Critical++
+	case "high":
+		c.High++
+	case "medium":
+		c.Medium++
+	case "low":
+		c.Low++
+	default:
+		c.Unset++
+	}
+}
+
+// MatrixRoadmap is a row and column of a dependency matrix
+type MatrixRoadmap struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	ServiceLine string `json:"service_line"`
+	// DependsOn counts the roadmap's dependencies on the others, its row,
+	// and DependedOnBy theirs on it, its column
+	DependsOn    DependencyCount `json:"depends_on"`
+	DependedOnBy DependencyCount `json:"depended_on_by"`
+}
+
+// DependencyMatrix counts the external dependencies between every pair of
+// roadmaps. Cells[i][j] is how many items of Roadmaps[i] depend on items of
+// Roadmaps[j].
+type DependencyMatrix struct {
+	Roadmaps []MatrixRoadmap     `json:"roadmaps"`
+	Cells    [][]DependencyCount `json:"cells"`
+	Total    DependencyCount     `json:"total"`
+}
+
+// ReportDependencyMatrix counts the external dependencies among the roadmaps,
+// which are ordered by ID. Dependencies on roadmaps outside the set, or that
+// don't resolve, aren't counted, and an item listing the same dependency
+// twice counts once.
+func ReportDependencyMatrix(roadmaps []*StoredRoadmap) DependencyMatrix {
+	g := newDependencyGraph(roadmaps)
+
+	sorted := append([]*StoredRoadmap(nil), roadmaps...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+	position := make(map[string]int, len(sorted))
+	matrix := DependencyMatrix{Roadmaps: make([]MatrixRoadmap, 0, len(sorted)), Cells: make([][]DependencyCount, 0, len(sorted))}
+	for _, rm := range sorted {
+		position[rm.ID] = len(matrix.Roadmaps)
+		matrix.Roadmaps = append(matrix.Roadmaps, MatrixRoadmap{ID: rm.ID, Name: rm.Roadmap.Name, ServiceLine: rm.Roadmap.ServiceLine})
+	}
+	for range matrix.Roadmaps {
+		matrix.Cells = append(matrix.Cells, make([]DependencyCount, len(matrix.Roadmaps)))
+	}
+
+	for from, edges := range g.edges {
+		counted := make(map[int]bool)
+		for _, edge := range edges {
+			if !edge.external || counted[edge.to] {
+				continue
+			}
+			counted[edge.to] = true
+			i, j := position[g.nodes[from].RoadmapID], position[g.nodes[edge.to].RoadmapID]
+			matrix.Cells[i][j].add(edge.criticality)
+			matrix.Roadmaps[i].DependsOn.add(edge.criticality)
+			matrix.Roadmaps[j].DependedOnBy.add(edge.criticality)
+			matrix.Total.add(edge.criticality)
+		}
+	}
+	return matrix
+}
@@ -0,0 +1,178 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Ways to resolve an item ID used by both roadmaps being merged
+const (
+	MergeConflictFail   = "fail"   // refuse to merge
+	MergeConflictRename = "rename" // give the second roadmap's item a numbered ID
+	MergeConflictSkip   = "skip"   // drop the second roadmap's item in favor of the first's
+)
+
+// ErrItemIDConflict is returned by MergeRoadmaps when both roadmaps have an
+// item with the same ID and conflicts are set to fail
+var ErrItemIDConflict = errors.New("item ID used in both roadmaps")
+
+// MergeRoadmaps combines the items of two roadmaps into a new roadmap that
+// takes its other fields from first. Item IDs used by both are resolved per
+// onConflict. External dependencies between the two become internal
+// dependencies, following any renamed IDs. Milestones and objectives are
+// combined by ID and teams by name. The returned map holds the new ID of every
+// item of second that was renamed.
+func MergeRoadmaps(first, second *StoredRoadmap, onConflict string) (*Roadmap, map[string]string, error) {
+	switch onConflict {
+	case MergeConflictFail, MergeConflictRename, MergeConflictSkip:
+	default:
+		return nil, nil, fmt.Errorf("invalid conflict handling %q (must be fail, rename, or skip)", onConflict)
+	}
+
+	merged := first.Roadmap
+	merged.Items = nil
+	if second.Roadmap.Notes != "" {
+		if merged.Notes != "" {
+			merged.Notes += "\n\n"
+		}
+		merged.Notes += second.Roadmap.Notes
+	}
+
+	// Milestones of second are added unless first has one with the same ID,
+	// which the items of both then share
+	merged.Milestones = append([]Milestone(nil), first.Roadmap.Milestones...)
+	milestoneIDs := make(map[string]bool, len(merged.Milestones))
+	for _, milestone := range merged.Milestones {
+		milestoneIDs[milestone.ID] = true
+	}
+	for _, milestone := range second.Roadmap.Milestones {
+		if !milestoneIDs[milestone.ID] {
+			merged.Milestones = append(merged.Milestones, milestone)
+			milestoneIDs[milestone.ID] = true
+		}
+	}
+
+	// Objectives are combined the same way
+	merged.Objectives = append([]Objective(nil), first.Roadmap.Objectives...)
+	objectiveIDs := make(map[string]bool, len(merged.Objectives))
+	for _, objective := range merged.Objectives {
+		objectiveIDs[objective.ID] = true
+	}
+	for _, objective := range second.Roadmap.Objectives {
+		if !objectiveIDs[objective.ID] {
+			merged.Objectives = append(merged.Objectives, objective)
+			objectiveIDs[objective.ID] = true
+		}
+	}
+
+	// And teams by name
+	merged.Teams = append([]Team(nil), first.Roadmap.Teams...)
+	teamNames := make(map[string]bool, len(merged.Teams))
+	for _, team := range merged.Teams {
+		teamNames[team.Name] = true
+	}
+	for _, team := range second.Roadmap.Teams {
+		if !teamNames[team.Name] {
+			merged.Teams = append(merged.Teams, team)
+			teamNames[team.Name] = true
+		}
+	}
+
+	firstIDs := make(map[string]bool, len(first.Roadmap.Items))
+	used := make(map[string]bool)
+	for _, item := range first.Roadmap.Items {
+		firstIDs[item.ID] = true
+		used[item.ID] = true
+		merged.Items = append(merged.Items, copyItem(item))
+	}
+
+	// Work out the ID each item of second ends up with
+	secondIDs := make(map[string]string, len(second.Roadmap.Items))
+	renamed := make(map[string]string)
+	skipped := make(map[string]bool)
+	for _, item := range second.Roadmap.Items {
+		if !used[item.ID] {
+			secondIDs[item.ID] = item.ID
+			used[item.ID] = true
+			continue
+		}
+		switch onConflict {
+		case MergeConflictFail:
+			return nil, nil, fmt.Errorf("%w: %s", ErrItemIDConflict, item.ID)
+		case MergeConflictSkip:
+			secondIDs[item.ID] = item.ID
+			skipped[item.ID] = true
+		case MergeConflictRename:
+			newID := item.ID
+			for n := 2; used[newID]; n++ {
+				newID = fmt.Sprintf("%s-%d", item.ID, n)
+			}
+			secondIDs[item.ID] = newID
+			renamed[item.ID] = newID
+			used[newID] = true
+		}
+	}
+
+	for _, item := range second.Roadmap.Items {
+		if skipped[item.ID] {
+			continue
+		}
+		item = copyItem(item)
+		item.ID = secondIDs[item.ID]
+		for i, dep := range item.Dependencies {
+			if id, ok := secondIDs[dep]; ok {
+				item.Dependencies[i] = id
+			}
+		}
+		merged.Items = append(merged.Items, item)
+	}
+
+	// Dependencies on either source roadmap now point inside the merged one
+	for i := range merged.Items {
+		item := &merged.Items[i]
+		var external []ExternalDependency
+		for _, dep := range item.ExternalDependencies {
+			target, ok := "", false
+			if targets(dep, first) {
+				target, ok = dep.ItemID, firstIDs[dep.ItemID]
+			} else if targets(dep, second) {
+				target, ok = secondIDs[dep.ItemID]
+			}
+			if !ok {
+				external = append(external, dep)
+				continue
+			}
+			if target != item.ID && !containsString(item.Dependencies, target) {
+				item.Dependencies = append(item.Dependencies, target)
+			}
+		}
+		item.ExternalDependencies = external
+	}
+
+	return &merged, renamed, nil
+}
+
+// targets reports whether an external dependency points at the roadmap
+func targets(dep ExternalDependency, rm *StoredRoadmap) bool {
+	if dep.RoadmapID != "" {
+		return dep.RoadmapID == rm.ID
+	}
+	return dep.RoadmapName == rm.Roadmap.Name
+}
+
+// copyItem returns a copy of the item whose dependency lists can be changed
+// without affecting the original
+func copyItem(item RoadmapItem) RoadmapItem {
+	item.Dependencies = append([]string(nil), item.Dependencies...)
+	item.ExternalDependencies = append([]ExternalDependency(nil), item.ExternalDependencies...)
+	return item
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ganttTags are the Mermaid gantt task tags for each status
+var ganttTags = map[RoadmapStatus]string{
+	StatusInProgress: "active",
+	StatusCompleted:  "done",
+	StatusBlocked:    "crit",
+}
+
+// MermaidGantt renders the roadmap as a Mermaid gantt chart, with a section
+// per team in the order teams first appear (items without a team come
+// first, under the roadmap's name) and the milestones last. Completed items
+// are marked done, items in progress active, and blocked items critical.
+// Items whose dates can't be parsed are left out.
+func (s *StoredRoadmap) MermaidGantt() string {
+	var b strings.Builder
+	b.WriteString("gantt\n")
+	fmt.Fprintf(&b, "    title %s\n", mermaidText(s.Roadmap.Name))
+	b.WriteString("    dateFormat YYYY-MM-DD\n")
+
+	var teams []string
+	byTeam := make(map[string][]string)
+	for i := range s.Roadmap.Items {
+		item := &s.Roadmap.Items[i]
+		start, end, err := item.ItemSpan()
+		if err != nil {
+			continue
+		}
+		task := mermaidText(item.Name) + " :"
+		if tag, ok := ganttTags[item.Status]; ok {
+			task += tag + ", "
+		}
+		task += start.Format("2006-01-02") + ", " + end.Format("2006-01-02")
+		if _, ok := byTeam[item.Team]; !ok {
+			teams = append(teams, item.Team)
+		}
+		byTeam[item.Team] = append(byTeam[item.Team], task)
+	}
+	sort.SliceStable(teams, func(i, j int) bool { return teams[i] == "" && teams[j] != "" })
+
+	for _, team := range teams {
+		section := team
+		if section == "" {
+			section = s.Roadmap.Name
+		}
+		fmt.Fprintf(&b, "    section %s\n", mermaidText(section))
+		for _, task := range byTeam[team] {
+			fmt.Fprintf(&b, "    %s\n", task)
+		}
+	}
+
+	if len(s.Roadmap.Milestones) > 0 {
+		b.WriteString("    section Milestones\n")
+		for _, milestone := range s.Roadmap.Milestones {
+			date, _, err := ParsePeriod(milestone.Date)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(&b, "    %s :milestone, %s, 0d\n", mermaidText(milestone.Name), date.Format("2006-01-02"))
+		}
+	}
+	return b.String()
+}
+
+// Mermaid renders the graph as a Mermaid flowchart. Each roadmap is a
+// subgraph, items are filled by status, and external dependencies are thick
+// links labelled with their criticality. Items outside the requested
+// roadmaps have a dashed outline. Links point from the item that depends to
+// the item it depends on, as in the graph.
+func (g DependencyGraph) Mermaid() string {
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+
+	// Mermaid IDs can't contain the colons of node IDs, so nodes are
+	// numbered in order
+	ids := make(map[string]string, len(g.Nodes))
+	byStatus := make(map[RoadmapStatus][]string)
+	var outside []string
+	for i, cluster := 0, 0; i < len(g.Nodes); cluster++ {
+		roadmapID := g.Nodes[i].RoadmapID
+		fmt.Fprintf(&b, "    subgraph r%d [\"%s\"]\n", cluster, mermaidLabel(g.Nodes[i].RoadmapName))
+		for ; i < len(g.Nodes) && g.Nodes[i].RoadmapID == roadmapID; i++ {
+			node := g.Nodes[i]
+			id := fmt.Sprintf("n%d", i)
+			ids[node.ID] = id
+			fmt.Fprintf(&b, "        %s[\"%s\"]\n", id, mermaidLabel(node.Label))
+			byStatus[node.Status] = append(byStatus[node.Status], id)
+			if !node.InScope {
+				outside = append(outside, id)
+			}
+		}
+		b.WriteString("    end\n")
+	}
+
+	for _, edge := range g.Edges {
+		switch {
+		case edge.Type != EdgeExternal:
+			fmt.Fprintf(&b, "    %s --> %s\n", ids[edge.Source], ids[edge.Target])
+		case edge.Criticality != "":
+			fmt.Fprintf(&b, "    %s ==>|%s| %s\n", ids[edge.Source], mermaidLabel(edge.Criticality), ids[edge.Target])
+		default:
+			fmt.Fprintf(&b, "    %s ==> %s\n", ids[edge.Source], ids[edge.Target])
+		}
+	}
+
+	for _, status := range []RoadmapStatus{StatusPlanned, StatusInProgress, StatusCompleted, StatusBlocked} {
+		if len(byStatus[status]) == 0 {
+			continue
+		}
+		class := strings.ReplaceAll(string(status), "-", "")
+		fmt.Fprintf(&b, "    classDef %s fill:%s\n", class, statusFill[status])
+		fmt.Fprintf(&b, "    class %s %s\n", strings.Join(byStatus[status], ","), class)
+	}
+	if len(outside) > 0 {
+		b.WriteString("    classDef outside stroke-dasharray: 5 5\n")
+		fmt.Fprintf(&b, "    class %s outside\n", strings.Join(outside, ","))
+	}
+	return b.String()
+}
+
+// mermaidText makes a gantt title, section, or task name safe to use: colons
+// would end a task name and #s and semicolons start comments and entities
+func mermaidText(s string) string {
+	return strings.NewReplacer(":", " -", "#", "", ";", ",", "\n", " ").Replace(s)
+}
+
+// mermaidLabel makes text safe inside a quoted flowchart label
+func mermaidLabel(s string) string {
+	return strings.NewReplacer(`"`, "#quot;", "\n", " ", "|", "#124;").Replace(s)
+}
@@ -0,0 +1,126 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Objective is a goal, such as an OKR, that items contribute to. Roadmaps
+// that declare an objective with the same ID share it.
+type Objective struct {
+	ID          string   `yaml:"id" json:"id"`
+	Name        string   `yaml:"name" json:"name"`
+	Description string   `yaml:"description,omitempty" json:"description,omitempty"`
+	KeyResults  []string `yaml:"key_results,omitempty" json:"key_results,omitempty"`
+}
+
+// Validate checks if an objective has all required fields
+func (o *Objective) Validate() error {
+	if o.ID == "" {
+		return fmt.Errorf("objective id is required")
+	}
+	if o.Name == "" {
+		return fmt.Errorf("objective name is required")
+	}
+	for _, kr := range o.KeyResults {
+		if kr == "" {
+			return fmt.Errorf("objective key results must not be empty")
+		}
+	}
+	return nil
+}
+
+// ObjectiveItem is an item that contributes to an objective
+type ObjectiveItem struct {
+	RoadmapID   string        `json:"roadmap_id"`
+	RoadmapName string        `json:"roadmap_name"`
+	ServiceLine string        `json:"service_line"`
+	ItemID      string        `json:"item_id"`
+	ItemName    string        `json:"item_name"`
+	Status      RoadmapStatus `json:"status"`
+	Progress    int           `json:"progress"`
+}
+
+// ObjectiveSummary is an objective with the items across all roadmaps that
+// contribute to it
+type ObjectiveSummary struct {
+	Objective
+	// Roadmaps are the IDs of the roadmaps that declare the objective
+	Roadmaps     []string              `json:"roadmaps"`
+	Items        []ObjectiveItem       `json:"items"`
+	StatusCounts map[RoadmapStatus]int `json:"status_counts"`
+	// Completion is the done percentage of the items together, weighted by
+	// duration as in Roadmap.Completion
+	Completion int `json:"completion"`
+	// Status is completed once every item is, blocked if any item is,
+	// in-progress once any item has started, and planned otherwise
+	Status RoadmapStatus `json:"status"`
+}
+
+// GroupObjectives collects the objectives declared by the roadmaps, ordered by
+// ID, with the items that contribute to each. The name, description, and key
+// results are those of the first roadmap that declares the objective.
+func GroupObjectives(roadmaps []*StoredRoadmap) []ObjectiveSummary {
+	byID := make(map[string]*ObjectiveSummary)
+	combined := make(map[string]*Roadmap)
+	for _, rm := range roadmaps {
+		for _, objective := range rm.Roadmap.Objectives {
+			summary, ok := byID[objective.ID]
+			if !ok {
+				summary = &ObjectiveSummary{
+					Objective:    objective,
+					Roadmaps:     []string{},
+					Items:        []ObjectiveItem{},
+					StatusCounts: make(map[RoadmapStatus]int),
+				}
+				byID[objective.ID] = summary
+				combined[objective.ID] = &Roadmap{}
+			}
+			summary.Roadmaps = append(summary.Roadmaps, rm.ID)
+		}
+	}
+
+	for _, rm := range roadmaps {
+		for i := range rm.Roadmap.Items {
+			item := &rm.Roadmap.Items[i]
+			summary, ok := byID[item.Objective]
+			if !ok {
+				continue
+			}
+			summary.Items = append(summary.Items, ObjectiveItem{
+				RoadmapID:   rm.ID,
+				RoadmapName: rm.Roadmap.Name,
+				ServiceLine: rm.Roadmap.ServiceLine,
+				ItemID:      item.ID,
+				ItemName:    item.Name,
+				Status:      item.Status,
+				Progress:    item.EffectiveProgress(),
+			})
+			summary.StatusCounts[item.Status]++
+			combined[item.Objective].Items = append(combined[item.Objective].Items, *item)
+		}
+	}
+
+	result := make([]ObjectiveSummary, 0, len(byID))
+	for id, summary := range byID {
+		summary.Completion = combined[id].Completion()
+		summary.Status = aggregateStatus(summary.StatusCounts, len(summary.Items))
+		result = append(result, *summary)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result
+}
+
+// aggregateStatus rolls the status counts of a group of items up into one
+func aggregateStatus(counts map[RoadmapStatus]int, items int) RoadmapStatus {
+	switch {
+	case items > 0 && counts[StatusCompleted] == items:
+		return StatusCompleted
+	case counts[StatusBlocked] > 0:
+		return StatusBlocked
+	case counts[StatusInProgress] > 0 || counts[StatusCompleted] > 0:
+		return StatusInProgress
+	default:
+		return StatusPlanned
+	}
+}
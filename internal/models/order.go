@@ -0,0 +1,110 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+)
+
+// OrderedItem is an item in a dependency order
+type OrderedItem struct {
+	ID     string        `json:"id"`
+	Name   string        `json:"name"`
+	Status RoadmapStatus `json:"status"`
+	// DependsOn are the IDs of the items it waits for, all in earlier groups
+	DependsOn []string `json:"depends_on,omitempty"`
+}
+
+// OrderGroup is a set of items that can be worked on in parallel once the
+// groups before it are done
+type OrderGroup struct {
+	Level int           `json:"level"`
+	Items []OrderedItem `json:"items"`
+}
+
+// ItemOrder is a roadmap's items in an order that respects their dependencies
+type ItemOrder struct {
+	RoadmapID   string `json:"roadmap_id"`
+	RoadmapName string `json:"roadmap_name"`
+	// Order is every item ID, each after all the items it depends on
+	Order  []string     `json:"order"`
+	Groups []OrderGroup `json:"groups"`
+}
+
+// CycleError is returned when items can't be ordered because their
+// dependencies loop
+type CycleError struct {
+	Cycles []DependencyCycle
+}
+
+func (e *CycleError) Error() string {
+	return fmt.Sprintf("dependencies form %d cycle(s)", len(e.Cycles))
+}
+
+// Order sorts the roadmap's items so that each comes after the items it
+// depends on. Items are grouped by how long a chain of dependencies leads up
+// to them: the first group depends on nothing, and each later group only on
+// items in groups before it. Within a group items keep their roadmap order.
+// External dependencies only count when they point back into this roadmap. If
+// the dependencies loop, a *CycleError lists the cycles.
+func (s *StoredRoadmap) Order() (ItemOrder, error) {
+	g := newDependencyGraph([]*StoredRoadmap{s})
+	levels, ok := g.levels()
+	if !ok {
+		return ItemOrder{}, &CycleError{Cycles: FindDependencyCycles([]*StoredRoadmap{s})}
+	}
+
+	order := ItemOrder{RoadmapID: s.ID, RoadmapName: s.Roadmap.Name, Order: []string{}, Groups: []OrderGroup{}}
+	for level, nodes := range levels {
+		group := OrderGroup{Level: level, Items: make([]OrderedItem, 0, len(nodes))}
+		for _, n := range nodes {
+			item := g.items[n]
+			entry := OrderedItem{ID: item.ID, Name: item.Name, Status: item.Status}
+			for _, edge := range g.edges[n] {
+				if id := g.nodes[edge.to].ItemID; !containsString(entry.DependsOn, id) {
+					entry.DependsOn = append(entry.DependsOn, id)
+				}
+			}
+			order.Order = append(order.Order, item.ID)
+			group.Items = append(group.Items, entry)
+		}
+		order.Groups = append(order.Groups, group)
+	}
+	return order, nil
+}
+
+// levels groups the nodes by the length of the longest chain of dependencies
+// leading up to them, each group in node order. It reports false if the
+// dependencies loop, as some nodes can then never be placed.
+func (g *dependencyGraph) levels() ([][]int, bool) {
+	waiting := make([]int, len(g.nodes))
+	dependents := make([][]int, len(g.nodes))
+	var ready []int
+	for from, edges := range g.edges {
+		waiting[from] = len(edges)
+		for _, edge := range edges {
+			dependents[edge.to] = append(dependents[edge.to], from)
+		}
+		if len(edges) == 0 {
+			ready = append(ready, from)
+		}
+	}
+
+	var levels [][]int
+	placed := 0
+	for len(ready) > 0 {
+		levels = append(levels, ready)
+		placed += len(ready)
+		var next []int
+		for _, n := range ready {
+			for _, dependent := range dependents[n] {
+				waiting[dependent]--
+				if waiting[dependent] == 0 {
+					next = append(next, dependent)
+				}
+			}
+		}
+		sort.Ints(next)
+		ready = next
+	}
+	return levels, placed == len(g.nodes)
+}
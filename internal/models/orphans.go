@@ -0,0 +1,189 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Actions a dependency fix suggests
+const (
+	FixRetarget = "retarget"
+	FixRestore  = "restore"
+	FixRemove   = "remove"
+)
+
+// DependencyFix is a suggested way to repair an orphaned dependency
+type DependencyFix struct {
+	Action      string `json:"action"`
+	Description string `json:"description"`
+	// Dependency is what to replace the dependency with, for retarget
+	Dependency *ExternalDependency `json:"dependency,omitempty"`
+	// RoadmapID is the roadmap to restore from the trash, for restore
+	RoadmapID string `json:"roadmap_id,omitempty"`
+}
+
+// OrphanedDependency is an external dependency that no longer resolves,
+// usually because the roadmap or item it points at was deleted
+type OrphanedDependency struct {
+	RoadmapID   string `json:"roadmap_id"`
+	RoadmapName string `json:"roadmap_name"`
+	ItemID      string `json:"item_id"`
+	ItemName    string `json:"item_name"`
+	// Index is the dependency's position in the item's external_dependencies
+	Index       int                `json:"index"`
+	Dependency  ExternalDependency `json:"dependency"`
+	Error       string             `json:"error"`
+	Suggestions []DependencyFix    `json:"suggestions,omitempty"`
+}
+
+// FindOrphanedDependencies lists the external dependencies of the roadmaps
+// that don't resolve against them, with suggested fixes: pointing it at a
+// roadmap, item, or milestone whose ID or name differs only in case, or at the roadmap of
+// that name when the roadmap_id is wrong; restoring the roadmap if it is in
+// trashed; and, always last, removing it.
+func FindOrphanedDependencies(roadmaps []*StoredRoadmap, trashed []*StoredRoadmap) []OrphanedDependency {
+	byName := make(map[string]*StoredRoadmap)
+	byID := make(map[string]*StoredRoadmap)
+	for _, rm := range roadmaps {
+		byName[rm.Roadmap.Name] = rm
+		byID[rm.ID] = rm
+	}
+
+	orphans := []OrphanedDependency{}
+	for _, rm := range roadmaps {
+		for _, item := range rm.Roadmap.Items {
+			for i, dep := range item.ExternalDependencies {
+				target := byName[dep.RoadmapName]
+				if dep.RoadmapID != "" {
+					target = byID[dep.RoadmapID]
+				}
+				if target != nil && dep.resolves(&target.Roadmap) {
+					continue
+				}
+
+				orphan := OrphanedDependency{
+					RoadmapID:   rm.ID,
+					RoadmapName: rm.Roadmap.Name,
+					ItemID:      item.ID,
+					ItemName:    item.Name,
+					Index:       i,
+					Dependency:  dep,
+				}
+				switch {
+				case target != nil && dep.ItemName != "":
+					matches := target.Roadmap.itemsNamed(dep.ItemName)
+					orphan.Error = fmt.Sprintf("no item named '%s' in roadmap '%s'", dep.ItemName, target.Roadmap.Name)
+					if len(matches) > 1 {
+						orphan.Error = fmt.Sprintf("item name '%s' is ambiguous in roadmap '%s'", dep.ItemName, target.Roadmap.Name)
+					}
+					orphan.Suggestions = namedItemFixes(dep, matches)
+				case target != nil && dep.Milestone != "":
+					orphan.Error = fmt.Sprintf("milestone '%s' not found in roadmap '%s'", dep.Milestone, target.Roadmap.Name)
+					orphan.Suggestions = milestoneFixes(dep, target)
+				case target != nil:
+					orphan.Error = fmt.Sprintf("item '%s' not found in roadmap '%s'", dep.ItemID, target.Roadmap.Name)
+					orphan.Suggestions = itemFixes(dep, target)
+				case dep.RoadmapID != "":
+					orphan.Error = fmt.Sprintf("roadmap with ID '%s' not found", dep.RoadmapID)
+					orphan.Suggestions = roadmapFixes(dep, roadmaps, trashed)
+				default:
+					orphan.Error = fmt.Sprintf("roadmap named '%s' not found", dep.RoadmapName)
+					orphan.Suggestions = roadmapFixes(dep, roadmaps, trashed)
+				}
+				orphan.Suggestions = append(orphan.Suggestions, DependencyFix{
+					Action:      FixRemove,
+					Description: "Remove the dependency",
+				})
+				orphans = append(orphans, orphan)
+			}
+		}
+	}
+	return orphans
+}
+
+// itemFixes suggests items of the target roadmap whose ID matches the missing
+// one apart from case
+func itemFixes(dep ExternalDependency, target *StoredRoadmap) []DependencyFix {
+	var fixes []DependencyFix
+	for _, item := range target.Roadmap.Items {
+		if strings.EqualFold(item.ID, dep.ItemID) {
+			fixed := dep
+			fixed.ItemID = item.ID
+			fixes = append(fixes, DependencyFix{
+				Action:      FixRetarget,
+				Description: fmt.Sprintf("Depend on item '%s' (%s) instead", item.ID, item.Name),
+				Dependency:  &fixed,
+			})
+		}
+	}
+	return fixes
+}
+
+// namedItemFixes suggests depending by ID on each of the items that share
+// the name an ambiguous dependency gives
+func namedItemFixes(dep ExternalDependency, matches []*RoadmapItem) []DependencyFix {
+	var fixes []DependencyFix
+	for _, item := range matches {
+		fixed := dep
+		fixed.ItemName, fixed.ItemID = "", item.ID
+		fixes = append(fixes, DependencyFix{
+			Action:      FixRetarget,
+			Description: fmt.Sprintf("Depend on item '%s' (%s) instead", item.ID, item.Name),
+			Dependency:  &fixed,
+		})
+	}
+	return fixes
+}
+
+// milestoneFixes suggests milestones of the target roadmap whose ID matches
+// the missing one apart from case
+func milestoneFixes(dep ExternalDependency, target *StoredRoadmap) []DependencyFix {
+	var fixes []DependencyFix
+	for _, milestone := range target.Roadmap.Milestones {
+		if strings.EqualFold(milestone.ID, dep.Milestone) {
+			fixed := dep
+			fixed.Milestone = milestone.ID
+			fixes = append(fixes, DependencyFix{
+				Action:      FixRetarget,
+				Description: fmt.Sprintf("Depend on milestone '%s' (%s) instead", milestone.ID, milestone.Name),
+				Dependency:  &fixed,
+			})
+		}
+	}
+	return fixes
+}
+
+// roadmapFixes suggests live roadmaps the dependency may have meant, and
+// trashed ones it pointed at that could be restored
+func roadmapFixes(dep ExternalDependency, roadmaps []*StoredRoadmap, trashed []*StoredRoadmap) []DependencyFix {
+	var fixes []DependencyFix
+	for _, rm := range roadmaps {
+		matches := strings.EqualFold(rm.Roadmap.Name, dep.RoadmapName)
+		if dep.RoadmapID != "" {
+			matches = matches || strings.EqualFold(rm.ID, dep.RoadmapID)
+		}
+		if !matches || !dep.resolves(&rm.Roadmap) {
+			continue
+		}
+		fixed := dep
+		fixed.RoadmapName = rm.Roadmap.Name
+		if dep.RoadmapID != "" {
+			fixed.RoadmapID = rm.ID
+		}
+		fixes = append(fixes, DependencyFix{
+			Action:      FixRetarget,
+			Description: fmt.Sprintf("Depend on roadmap '%s' (%s) instead", rm.Roadmap.Name, rm.ID),
+			Dependency:  &fixed,
+		})
+	}
+	for _, rm := range trashed {
+		if rm.ID == dep.RoadmapID || (dep.RoadmapID == "" && rm.Roadmap.Name == dep.RoadmapName) {
+			fixes = append(fixes, DependencyFix{
+				Action:      FixRestore,
+				Description: fmt.Sprintf("Restore roadmap '%s' (%s) from the trash", rm.Roadmap.Name, rm.ID),
+				RoadmapID:   rm.ID,
+			})
+		}
+	}
+	return fixes
+}
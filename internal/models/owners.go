@@ -0,0 +1,75 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxNameLength bounds item owners and teams and roadmap categories and
+// portfolios
+const maxNameLength = 100
+
+// validateName checks an optional one-line name such as an item owner
+func validateName(field, name string) error {
+	if name == "" {
+		return nil
+	}
+	if strings.TrimSpace(name) != name {
+		return fmt.Errorf("%s must not start or end with spaces", field)
+	}
+	if utf8.RuneCountInString(name) > maxNameLength {
+		return fmt.Errorf("%s must be at most %d characters", field, maxNameLength)
+	}
+	if strings.IndexFunc(name, unicode.IsControl) != -1 {
+		return fmt.Errorf("%s must not contain control characters", field)
+	}
+	return nil
+}
+
+// AssignedTo reports whether the item's owner or team is name
+func (r *RoadmapItem) AssignedTo(name string) bool {
+	return name != "" && (r.Owner == name || r.Team == name)
+}
+
+// OwnerCount reports how much work is assigned to an item owner or team
+type OwnerCount struct {
+	Name string `json:"name"`
+	// Items is the number of items assigned
+	Items int `json:"items"`
+	// Roadmaps is the number of roadmaps with at least one such item
+	Roadmaps int `json:"roadmaps"`
+}
+
+// Assignments lists the item owners and teams
+type Assignments struct {
+	Owners []OwnerCount `json:"owners"`
+	Teams  []OwnerCount `json:"teams"`
+}
+
+// CountAssignments counts the items assigned to each owner and each team in
+// the roadmaps, most assigned first and then by name
+func CountAssignments(roadmaps []*StoredRoadmap) Assignments {
+	owners := countUsage(roadmaps, func(item *RoadmapItem) []string { return nonEmpty(item.Owner) })
+	teams := countUsage(roadmaps, func(item *RoadmapItem) []string { return nonEmpty(item.Team) })
+
+	result := Assignments{
+		Owners: make([]OwnerCount, len(owners)),
+		Teams:  make([]OwnerCount, len(teams)),
+	}
+	for i, u := range owners {
+		result.Owners[i] = OwnerCount{Name: u.value, Items: u.items, Roadmaps: u.roadmaps}
+	}
+	for i, u := range teams {
+		result.Teams[i] = OwnerCount{Name: u.value, Items: u.items, Roadmaps: u.roadmaps}
+	}
+	return result
+}
+
+func nonEmpty(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return []string{value}
+}
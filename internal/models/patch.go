@@ -0,0 +1,125 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// ApplyMergePatch applies a JSON merge patch (RFC 7386) to the roadmap and
+// returns the patched copy. The receiver is left untouched.
+//
+// As an extension, "items" may be given as an object keyed by item ID instead
+// of an array. Each value is then merged into the matching item, null removes
+// the item, and an unknown ID appends a new item with that ID.
+func (r *Roadmap) ApplyMergePatch(patch []byte) (*Roadmap, error) {
+	var patchDoc interface{}
+	if err := json.Unmarshal(patch, &patchDoc); err != nil {
+		return nil, fmt.Errorf("invalid patch: %w", err)
+	}
+	patchObj, ok := patchDoc.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid patch: must be a JSON object")
+	}
+
+	// Round-trip the roadmap through JSON to get a generic document
+	current, err := json.Marshal(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode roadmap: %w", err)
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(current, &doc); err != nil {
+		return nil, fmt.Errorf("failed to decode roadmap: %w", err)
+	}
+
+	// Handle the keyed items form before the generic merge
+	if itemPatches, ok := patchObj["items"].(map[string]interface{}); ok {
+		items, err := mergeItemPatches(doc["items"], itemPatches)
+		if err != nil {
+			return nil, err
+		}
+		doc["items"] = items
+		delete(patchObj, "items")
+	}
+
+	merged := mergePatch(doc, patchObj)
+
+	data, err := json.Marshal(merged)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode patched roadmap: %w", err)
+	}
+	var patched Roadmap
+	if err := json.Unmarshal(data, &patched); err != nil {
+		return nil, fmt.Errorf("invalid patch: %w", err)
+	}
+
+	return &patched, nil
+}
+
+// mergePatch merges patch into target following RFC 7386
+func mergePatch(target, patch interface{}) interface{} {
+	patchObj, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObj, ok := target.(map[string]interface{})
+	if !ok {
+		targetObj = make(map[string]interface{})
+	}
+
+	for key, value := range patchObj {
+		if value == nil {
+			delete(targetObj, key)
+		} else {
+			targetObj[key] = mergePatch(targetObj[key], value)
+		}
+	}
+
+	return targetObj
+}
+
+// mergeItemPatches applies per-item merge patches keyed by item ID
+func mergeItemPatches(items interface{}, patches map[string]interface{}) ([]interface{}, error) {
+	list, _ := items.([]interface{})
+
+	// Apply patches in a stable order so appended items are deterministic
+	ids := make([]string, 0, len(patches))
+	for id := range patches {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		itemPatch := patches[id]
+
+		index := -1
+		for i, item := range list {
+			if obj, ok := item.(map[string]interface{}); ok && obj["id"] == id {
+				index = i
+				break
+			}
+		}
+
+		if itemPatch == nil {
+			if index == -1 {
+				return nil, fmt.Errorf("item %s not found", id)
+			}
+			list = append(list[:index], list[index+1:]...)
+			continue
+		}
+
+		if _, ok := itemPatch.(map[string]interface{}); !ok {
+			return nil, fmt.Errorf("patch for item %s must be an object", id)
+		}
+
+		if index == -1 {
+			newItem := mergePatch(map[string]interface{}{"id": id}, itemPatch)
+			list = append(list, newItem)
+		} else {
+			list[index] = mergePatch(list[index], itemPatch)
+		}
+	}
+
+	return list, nil
+}
@@ -0,0 +1,157 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Responsibility is a stakeholder's part in a roadmap, as in a RACI matrix
+type Responsibility string
+
+const (
+	ResponsibilityResponsible Responsibility = "responsible"
+	ResponsibilityAccountable Responsibility = "accountable"
+	ResponsibilityConsulted   Responsibility = "consulted"
+	ResponsibilityInformed    Responsibility = "informed"
+)
+
+// ValidateResponsibility checks if a responsibility is valid
+func ValidateResponsibility(responsibility string) error {
+	switch Responsibility(responsibility) {
+	case ResponsibilityResponsible, ResponsibilityAccountable, ResponsibilityConsulted, ResponsibilityInformed:
+		return nil
+	default:
+		return fmt.Errorf("invalid responsibility '%s' (must be responsible, accountable, consulted, or informed)", responsibility)
+	}
+}
+
+// Stakeholder is someone with a part in a roadmap beyond its owner
+type Stakeholder struct {
+	Name string `yaml:"name" json:"name"`
+	// Role is their job or function, such as "Security lead"
+	Role           string         `yaml:"role,omitempty" json:"role,omitempty"`
+	Responsibility Responsibility `yaml:"responsibility" json:"responsibility"`
+}
+
+// Validate checks the name, role, and responsibility of a stakeholder
+func (s *Stakeholder) Validate() error {
+	if s.Name == "" {
+		return fmt.Errorf("stakeholder name is required")
+	}
+	if err := validateName("stakeholder name", s.Name); err != nil {
+		return err
+	}
+	if err := validateName("stakeholder role", s.Role); err != nil {
+		return err
+	}
+	return ValidateResponsibility(string(s.Responsibility))
+}
+
+// validateStakeholders checks every stakeholder, that each is listed once, and
+// that at most one is accountable
+func validateStakeholders(stakeholders []Stakeholder) []error {
+	var errs []error
+	names := make(map[string]bool, len(stakeholders))
+	accountable := 0
+	for i := range stakeholders {
+		stakeholder := &stakeholders[i]
+		if err := stakeholder.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("stakeholder %d: %w", i, err))
+		}
+		if names[stakeholder.Name] {
+			errs = append(errs, fmt.Errorf("duplicate stakeholder: %s", stakeholder.Name))
+		}
+		names[stakeholder.Name] = true
+		if stakeholder.Responsibility == ResponsibilityAccountable {
+			accountable++
+		}
+	}
+	if accountable > 1 {
+		errs = append(errs, fmt.Errorf("only one stakeholder can be accountable"))
+	}
+	return errs
+}
+
+// PersonRoadmap is how a person is involved in one roadmap
+type PersonRoadmap struct {
+	RoadmapID   string `json:"roadmap_id"`
+	RoadmapName string `json:"roadmap_name"`
+	// Owner is set for the roadmap's owner and each of its owners
+	Owner bool `json:"owner,omitempty"`
+	// Role and Responsibility are set for stakeholders
+	Role           string         `json:"role,omitempty"`
+	Responsibility Responsibility `json:"responsibility,omitempty"`
+	// Items is the number of items they own
+	Items int `json:"items,omitempty"`
+}
+
+// Person is everyone named as a roadmap owner, stakeholder, or item owner,
+// with the roadmaps they are involved in. Email and Slack are the first given
+// for them in a roadmap's owners.
+type Person struct {
+	Name     string          `json:"name"`
+	Email    string          `json:"email,omitempty"`
+	Slack    string          `json:"slack,omitempty"`
+	Roadmaps []PersonRoadmap `json:"roadmaps"`
+}
+
+// ListPeople collects the people named by the roadmaps, by name, each with
+// their roadmaps in the order given
+func ListPeople(roadmaps []*StoredRoadmap) []Person {
+	byName := make(map[string]*Person)
+	for _, rm := range roadmaps {
+		involved := make(map[string]*PersonRoadmap)
+		var order []string
+		entry := func(name string) *PersonRoadmap {
+			if e, ok := involved[name]; ok {
+				return e
+			}
+			e := &PersonRoadmap{RoadmapID: rm.ID, RoadmapName: rm.Roadmap.Name}
+			involved[name] = e
+			order = append(order, name)
+			return e
+		}
+
+		if rm.Roadmap.Owner != "" {
+			entry(rm.Roadmap.Owner).Owner = true
+		}
+		for _, owner := range rm.Roadmap.Owners {
+			entry(owner.Name).Owner = true
+		}
+		for _, stakeholder := range rm.Roadmap.Stakeholders {
+			e := entry(stakeholder.Name)
+			e.Role = stakeholder.Role
+			e.Responsibility = stakeholder.Responsibility
+		}
+		for i := range rm.Roadmap.Items {
+			if owner := rm.Roadmap.Items[i].Owner; owner != "" {
+				entry(owner).Items++
+			}
+		}
+
+		for _, name := range order {
+			person, ok := byName[name]
+			if !ok {
+				person = &Person{Name: name}
+				byName[name] = person
+			}
+			person.Roadmaps = append(person.Roadmaps, *involved[name])
+		}
+		for _, owner := range rm.Roadmap.Owners {
+			person := byName[owner.Name]
+			if person.Email == "" {
+				person.Email = owner.Email
+			}
+			if person.Slack == "" {
+				person.Slack = owner.Slack
+			}
+		}
+	}
+
+	people := make([]Person, 0, len(byName))
+	for _, person := range byName {
+		people = append(people, *person)
+	}
+	sort.Slice(people, func(i, j int) bool { return people[i].Name < people[j].Name })
+	return people
+}
@@ -0,0 +1,167 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParsePeriod converts an item date such as "2025-Q2", "2025-06", "2025-06-15",
+// or "2025" into the half-open time range [start, end) it covers. Quarters are
+// fiscal: the fiscal year starts on July 1 of the previous calendar year, so
+// 2026-Q1 is July to September 2025 and 2026-Q3 is January to March 2026.
+func ParsePeriod(value string) (time.Time, time.Time, error) {
+	value = strings.TrimSpace(value)
+
+	if year, quarter, ok := strings.Cut(value, "-Q"); ok {
+		y, err := strconv.Atoi(year)
+		q, qErr := strconv.Atoi(quarter)
+		if err != nil || qErr != nil || len(year) != 4 || len(quarter) != 1 || q < 1 || q > 4 {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid quarter: %s", value)
+		}
+		start := time.Date(y-1, time.July+time.Month((q-1)*3), 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 3, 0), nil
+	}
+
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, t.AddDate(0, 0, 1), nil
+	}
+	if t, err := time.Parse("2006-01", value); err == nil {
+		return t, t.AddDate(0, 1, 0), nil
+	}
+	if t, err := time.Parse("2006", value); err == nil {
+		return t, t.AddDate(1, 0, 0), nil
+	}
+
+	return time.Time{}, time.Time{}, fmt.Errorf("invalid date: %s (expected YYYY-Qn, YYYY-MM-DD, YYYY-MM, or YYYY)", value)
+}
+
+// fiscalQuarter formats the fiscal quarter containing t, e.g. 2026-Q1 for
+// August 2025
+func fiscalQuarter(t time.Time) string {
+	year, month := t.Year(), int(t.Month())
+	if month >= int(time.July) {
+		year++
+	}
+	return fmt.Sprintf("%04d-Q%d", year, (month+5)%12/3+1)
+}
+
+// ItemSpan returns the time range covered by an item, from the beginning of
+// its start period to the end of its end period
+func (r *RoadmapItem) ItemSpan() (time.Time, time.Time, error) {
+	start, _, err := ParsePeriod(r.Start)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("item %s start: %w", r.ID, err)
+	}
+	_, end, err := ParsePeriod(r.End)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("item %s end: %w", r.ID, err)
+	}
+	return start, end, nil
+}
+
+// ItemDates are the calendar days an item covers, worked out from its start
+// and end, which may be quarters, months, or years
+type ItemDates struct {
+	// StartDate is the first day of the start period
+	StartDate string `json:"start_date"`
+	// EndDate is the last day of the end period, inclusive
+	EndDate string `json:"end_date"`
+}
+
+// Dates returns the first and last day covered by the item
+func (r *RoadmapItem) Dates() (ItemDates, error) {
+	start, end, err := r.ItemSpan()
+	if err != nil {
+		return ItemDates{}, err
+	}
+	return ItemDates{
+		StartDate: start.Format("2006-01-02"),
+		EndDate:   end.AddDate(0, 0, -1).Format("2006-01-02"),
+	}, nil
+}
+
+// MarshalJSON encodes the item with its computed start_date and end_date
+// next to the start and end as written, so clients can draw quarter-aligned
+// bars without parsing quarters themselves. The computed dates are left out
+// if the item's dates don't parse. Items with deliverables also get their
+// deliverables_done and deliverables_total.
+func (r RoadmapItem) MarshalJSON() ([]byte, error) {
+	type plain RoadmapItem
+	out := struct {
+		plain
+		*ItemDates
+		*DeliverableCount
+	}{plain: plain(r)}
+	if dates, err := r.Dates(); err == nil {
+		out.ItemDates = &dates
+	}
+	if len(r.Deliverables) > 0 {
+		count := r.CountDeliverables()
+		out.DeliverableCount = &count
+	}
+	return json.Marshal(out)
+}
+
+// ShiftPeriod moves an item date by a number of months, keeping its format.
+// Quarters can only move by whole quarters and years by whole years.
+func ShiftPeriod(value string, months int) (string, error) {
+	value = strings.TrimSpace(value)
+	start, _, err := ParsePeriod(value)
+	if err != nil {
+		return "", err
+	}
+
+	if strings.Contains(value, "-Q") {
+		if months%3 != 0 {
+			return "", fmt.Errorf("cannot shift quarter %s by %d months (must be a multiple of 3)", value, months)
+		}
+		return fiscalQuarter(start.AddDate(0, months, 0)), nil
+	}
+
+	switch len(value) {
+	case len("2006-01-02"):
+		// Clamp to the end of the month instead of overflowing into the next
+		target := time.Date(start.Year(), start.Month()+time.Month(months), 1, 0, 0, 0, 0, time.UTC)
+		day := start.Day()
+		if last := target.AddDate(0, 1, -1).Day(); day > last {
+			day = last
+		}
+		return target.AddDate(0, 0, day-1).Format("2006-01-02"), nil
+	case len("2006-01"):
+		return start.AddDate(0, months, 0).Format("2006-01"), nil
+	default:
+		if months%12 != 0 {
+			return "", fmt.Errorf("cannot shift year %s by %d months (must be a multiple of 12)", value, months)
+		}
+		return start.AddDate(0, months, 0).Format("2006"), nil
+	}
+}
+
+// ShiftDates moves the start and end of every item, and every milestone
+// date, by a number of months
+func (r *Roadmap) ShiftDates(months int) error {
+	for i := range r.Items {
+		item := &r.Items[i]
+		start, err := ShiftPeriod(item.Start, months)
+		if err != nil {
+			return fmt.Errorf("item %s start: %w", item.ID, err)
+		}
+		end, err := ShiftPeriod(item.End, months)
+		if err != nil {
+			return fmt.Errorf("item %s end: %w", item.ID, err)
+		}
+		item.Start, item.End = start, end
+	}
+	for i := range r.Milestones {
+		milestone := &r.Milestones[i]
+		date, err := ShiftPeriod(milestone.Date, months)
+		if err != nil {
+			return fmt.Errorf("milestone %s date: %w", milestone.ID, err)
+		}
+		milestone.Date = date
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParsePeriodFiscalQuarters(t *testing.T) {
+	tests := []struct {
+		value       string
+		first, last string
+	}{
+		{"2026-Q1", "2025-07-01", "2025-09-30"},
+		{"2026-Q2", "2025-10-01", "2025-12-31"},
+		{"2026-Q3", "2026-01-01", "2026-03-31"},
+		{"2026-Q4", "2026-04-01", "2026-06-30"},
+	}
+	for _, tt := range tests {
+		start, end, err := ParsePeriod(tt.value)
+		if err != nil {
+			t.Fatalf("ParsePeriod(%q): %v", tt.value, err)
+		}
+		first, last := start.Format("2006-01-02"), end.AddDate(0, 0, -1).Format("2006-01-02")
+		if first != tt.first || last != tt.last {
+			t.Errorf("ParsePeriod(%q) = %s..%s, want %s..%s", tt.value, first, last, tt.first, tt.last)
+		}
+	}
+}
+
+func TestFiscalQuarter(t *testing.T) {
+	tests := map[string]string{
+		"2025-07-01": "2026-Q1",
+		"2025-09-30": "2026-Q1",
+		"2025-12-31": "2026-Q2",
+		"2026-01-01": "2026-Q3",
+		"2026-06-30": "2026-Q4",
+	}
+	for date, want := range tests {
+		day, _ := time.Parse("2006-01-02", date)
+		if got := fiscalQuarter(day); got != want {
+			t.Errorf("fiscalQuarter(%s) = %s, want %s", date, got, want)
+		}
+	}
+}
+
+func TestParsePeriodInvalidQuarter(t *testing.T) {
+	for _, value := range []string{"2026-Q0", "2026-Q5", "2026-Q01", "26-Q1"} {
+		if _, _, err := ParsePeriod(value); err == nil {
+			t.Errorf("ParsePeriod(%q) succeeded, want an error", value)
+		}
+	}
+}
+
+func TestShiftPeriodFiscalQuarter(t *testing.T) {
+	tests := []struct {
+		value  string
+		months int
+		want   string
+	}{
+		{"2026-Q1", 3, "2026-Q2"},
+		{"2026-Q4", 3, "2027-Q1"},
+		{"2026-Q1", -3, "2025-Q4"},
+	}
+	for _, tt := range tests {
+		got, err := ShiftPeriod(tt.value, tt.months)
+		if err != nil {
+			t.Fatalf("ShiftPeriod(%q, %d): %v", tt.value, tt.months, err)
+		}
+		if got != tt.want {
+			t.Errorf("ShiftPeriod(%q, %d) = %s, want %s", tt.value, tt.months, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package models
+
+import "sort"
+
+// Portfolio is a group of related roadmaps with rollup stats
+type Portfolio struct {
+	// Name is empty for the roadmaps without a portfolio
+	Name         string                `json:"name"`
+	ServiceLines []string              `json:"service_lines"`
+	Categories   []string              `json:"categories"`
+	ItemCount    int                   `json:"item_count"`
+	StatusCounts map[RoadmapStatus]int `json:"status_counts"`
+	// Completion is the done percentage of all the items together, weighted
+	// by duration as in Roadmap.Completion
+	Completion int              `json:"completion"`
+	Roadmaps   []RoadmapSummary `json:"roadmaps"`
+}
+
+// GroupPortfolios groups the roadmaps by portfolio, ordered by name with the
+// roadmaps without a portfolio last. Roadmaps keep their order within a group.
+func GroupPortfolios(roadmaps []*StoredRoadmap) []Portfolio {
+	byName := make(map[string]*Portfolio)
+	combined := make(map[string]*Roadmap)
+	var names []string
+	for _, rm := range roadmaps {
+		name := rm.Roadmap.Portfolio
+		portfolio, ok := byName[name]
+		if !ok {
+			portfolio = &Portfolio{
+				Name:         name,
+				ServiceLines: []string{},
+				Categories:   []string{},
+				StatusCounts: make(map[RoadmapStatus]int),
+				Roadmaps:     []RoadmapSummary{},
+			}
+			byName[name] = portfolio
+			combined[name] = &Roadmap{}
+			names = append(names, name)
+		}
+
+		summary := rm.Summary()
+		portfolio.Roadmaps = append(portfolio.Roadmaps, summary)
+		portfolio.ItemCount += summary.ItemCount
+		for status, count := range summary.StatusCounts {
+			portfolio.StatusCounts[status] += count
+		}
+		if !containsString(portfolio.ServiceLines, rm.Roadmap.ServiceLine) {
+			portfolio.ServiceLines = append(portfolio.ServiceLines, rm.Roadmap.ServiceLine)
+		}
+		if rm.Roadmap.Category != "" && !containsString(portfolio.Categories, rm.Roadmap.Category) {
+			portfolio.Categories = append(portfolio.Categories, rm.Roadmap.Category)
+		}
+		combined[name].Items = append(combined[name].Items, rm.Roadmap.Items...)
+	}
+
+	sort.Slice(names, func(i, j int) bool {
+		if names[i] == "" || names[j] == "" {
+			return names[j] == ""
+		}
+		return names[i] < names[j]
+	})
+	result := make([]Portfolio, len(names))
+	for i, name := range names {
+		portfolio := byName[name]
+		portfolio.Completion = combined[name].Completion()
+		sort.Strings(portfolio.ServiceLines)
+		sort.Strings(portfolio.Categories)
+		result[i] = *portfolio
+	}
+	return result
+}
@@ -0,0 +1,41 @@
+package models
+
+import "math"
+
+// EffectiveProgress returns the item's progress percentage, worked out from
+// its deliverables if ProgressFromDeliverables is set. Items without one
+// count as done when completed and not started otherwise.
+func (r *RoadmapItem) EffectiveProgress() int {
+	if r.ProgressFromDeliverables {
+		return r.deliverablesProgress()
+	}
+	if r.Progress != nil {
+		return *r.Progress
+	}
+	if r.Status == StatusCompleted {
+		return 100
+	}
+	return 0
+}
+
+// Completion returns the percentage of the roadmap's work that is done: the
+// progress of its items averaged with each item weighted by its duration, so a
+// finished two-quarter item counts twice as much as a finished one-quarter
+// item. Items whose dates can't be parsed are left out.
+func (r *Roadmap) Completion() int {
+	var total, done float64
+	for i := range r.Items {
+		item := &r.Items[i]
+		start, end, err := item.ItemSpan()
+		if err != nil || !end.After(start) {
+			continue
+		}
+		weight := end.Sub(start).Hours()
+		total += weight
+		done += weight * float64(item.EffectiveProgress()) / 100
+	}
+	if total == 0 {
+		return 0
+	}
+	return int(math.Round(done / total * 100))
+}
@@ -0,0 +1,169 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// maxOccurrences bounds how many items a recurrence can expand to
+const maxOccurrences = 100
+
+// recurrenceMonths is how far apart each interval puts occurrences
+var recurrenceMonths = map[string]int{
+	"month":   1,
+	"quarter": 3,
+	"year":    12,
+}
+
+// Recurrence repeats an item at a fixed interval, e.g. a quarterly compliance
+// review. The item with the rule is the first occurrence; ExpandRecurrences
+// adds the others.
+type Recurrence struct {
+	// Every is month, quarter, or year
+	Every string `yaml:"every" json:"every"`
+	// Count is the number of occurrences including the first, and Until the
+	// period the last occurrence may start in; exactly one is set
+	Count int    `yaml:"count,omitempty" json:"count,omitempty"`
+	Until string `yaml:"until,omitempty" json:"until,omitempty"`
+}
+
+// Validate checks the interval and that the rule ends
+func (rec *Recurrence) Validate() error {
+	if _, ok := recurrenceMonths[rec.Every]; !ok {
+		return fmt.Errorf("invalid recurrence every '%s' (must be month, quarter, or year)", rec.Every)
+	}
+	if (rec.Count == 0) == (rec.Until == "") {
+		return fmt.Errorf("recurrence needs exactly one of count or until")
+	}
+	if rec.Count < 0 || rec.Count > maxOccurrences {
+		return fmt.Errorf("recurrence count must be between 1 and %d", maxOccurrences)
+	}
+	if rec.Until != "" {
+		if _, _, err := ParsePeriod(rec.Until); err != nil {
+			return fmt.Errorf("recurrence until: %w", err)
+		}
+	}
+	return nil
+}
+
+// occurrences returns copies of item for its second and later occurrences,
+// with IDs of the form {id}-2, {id}-3, and so on
+func (rec *Recurrence) occurrences(item *RoadmapItem) ([]RoadmapItem, error) {
+	var until int64
+	if rec.Until != "" {
+		_, end, err := ParsePeriod(rec.Until)
+		if err != nil {
+			return nil, err
+		}
+		until = end.Unix()
+	}
+
+	var items []RoadmapItem
+	step := recurrenceMonths[rec.Every]
+	for n := 2; ; n++ {
+		if rec.Count > 0 && n > rec.Count {
+			break
+		}
+		start, err := ShiftPeriod(item.Start, step*(n-1))
+		if err != nil {
+			return nil, err
+		}
+		if rec.Until != "" {
+			from, _, err := ParsePeriod(start)
+			if err != nil {
+				return nil, err
+			}
+			if from.Unix() >= until {
+				break
+			}
+		}
+		if n > maxOccurrences {
+			return nil, fmt.Errorf("recurrence expands to more than %d occurrences", maxOccurrences)
+		}
+		end, err := ShiftPeriod(item.End, step*(n-1))
+		if err != nil {
+			return nil, err
+		}
+
+		occurrence := copyItem(*item)
+		occurrence.ID = item.ID + "-" + strconv.Itoa(n)
+		occurrence.Start = start
+		occurrence.End = end
+		occurrence.Status = StatusPlanned
+		occurrence.Progress = nil
+		occurrence.StatusChangedAt = nil
+		occurrence.StatusHistory = nil
+		occurrence.Baseline = nil
+		occurrence.Recurrence = nil
+		occurrence.RecurrenceOf = item.ID
+		items = append(items, occurrence)
+	}
+	return items, nil
+}
+
+// ExpandRecurrences replaces the occurrences generated from each recurring
+// item with ones built from its current rule, so editing the rule or the
+// item regenerates them. An occurrence that already existed keeps its status,
+// progress, and baseline. Occurrences whose item no longer recurs are removed.
+func (r *Roadmap) ExpandRecurrences() error {
+	previous := make(map[string]*RoadmapItem)
+	for i := range r.Items {
+		if r.Items[i].RecurrenceOf != "" {
+			previous[r.Items[i].ID] = &r.Items[i]
+		}
+	}
+	if len(previous) == 0 && !r.hasRecurrence() {
+		return nil
+	}
+
+	used := make(map[string]bool, len(r.Items))
+	for _, item := range r.Items {
+		if item.RecurrenceOf == "" {
+			used[item.ID] = true
+		}
+	}
+
+	expanded := make([]RoadmapItem, 0, len(r.Items))
+	for i := range r.Items {
+		item := &r.Items[i]
+		if item.RecurrenceOf != "" {
+			continue
+		}
+		expanded = append(expanded, *item)
+		if item.Recurrence == nil {
+			continue
+		}
+		if err := item.Recurrence.Validate(); err != nil {
+			return fmt.Errorf("item %s: %w", item.ID, err)
+		}
+		occurrences, err := item.Recurrence.occurrences(item)
+		if err != nil {
+			return fmt.Errorf("item %s: recurrence: %w", item.ID, err)
+		}
+		for _, occurrence := range occurrences {
+			if used[occurrence.ID] {
+				return fmt.Errorf("item %s: recurrence generates id %s, which is already used", item.ID, occurrence.ID)
+			}
+			used[occurrence.ID] = true
+			if old, ok := previous[occurrence.ID]; ok && old.RecurrenceOf == item.ID {
+				occurrence.Status = old.Status
+				occurrence.Progress = old.Progress
+				occurrence.StatusChangedAt = old.StatusChangedAt
+				occurrence.StatusHistory = old.StatusHistory
+				occurrence.Baseline = old.Baseline
+			}
+			expanded = append(expanded, occurrence)
+		}
+	}
+	r.Items = expanded
+	return nil
+}
+
+func (r *Roadmap) hasRecurrence() bool {
+	for i := range r.Items {
+		if r.Items[i].Recurrence != nil {
+			return true
+		}
+	}
+	return false
+}
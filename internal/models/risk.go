@@ -0,0 +1,166 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+)
+
+// RiskLevel is how likely an item is to slip or fail
+type RiskLevel string
+
+const (
+	RiskLow    RiskLevel = "low"
+	RiskMedium RiskLevel = "medium"
+	RiskHigh   RiskLevel = "high"
+)
+
+// rank orders risk levels, with 0 for an unset or unknown level
+func (l RiskLevel) rank() int {
+	switch l {
+	case RiskLow:
+		return 1
+	case RiskMedium:
+		return 2
+	case RiskHigh:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// ValidateRiskLevel checks if a risk level is valid
+func ValidateRiskLevel(level string) error {
+	if RiskLevel(level).rank() == 0 {
+		return fmt.Errorf("invalid risk '%s' (must be low, medium, or high)", level)
+	}
+	return nil
+}
+
+// RiskDependency is an item that a risky item depends on
+type RiskDependency struct {
+	RoadmapID   string `json:"roadmap_id,omitempty"`
+	RoadmapName string `json:"roadmap_name"`
+	ItemID      string `json:"item_id"`
+	// ItemName, Status, and Risk are left empty if the item doesn't exist
+	ItemName string        `json:"item_name,omitempty"`
+	Status   RoadmapStatus `json:"status,omitempty"`
+	Risk     RiskLevel     `json:"risk,omitempty"`
+	Found    bool          `json:"found"`
+}
+
+// RiskEntry is an item in the risk report
+type RiskEntry struct {
+	RoadmapID    string           `json:"roadmap_id"`
+	RoadmapName  string           `json:"roadmap_name"`
+	ServiceLine  string           `json:"service_line"`
+	ItemID       string           `json:"item_id"`
+	ItemName     string           `json:"item_name"`
+	Status       RoadmapStatus    `json:"status"`
+	Start        string           `json:"start"`
+	End          string           `json:"end"`
+	Owner        string           `json:"owner,omitempty"`
+	Team         string           `json:"team,omitempty"`
+	Risk         RiskLevel        `json:"risk"`
+	RiskNotes    string           `json:"risk_notes,omitempty"`
+	Dependencies []RiskDependency `json:"dependencies"`
+}
+
+// ReportRisks lists the items of the roadmaps that are at least as risky as
+// minimum and not yet completed, with the internal and external dependencies
+// of each. Entries are ordered by risk, highest first, and then by start date.
+func ReportRisks(roadmaps []*StoredRoadmap, minimum RiskLevel) []RiskEntry {
+	byID := make(map[string]*StoredRoadmap, len(roadmaps))
+	byName := make(map[string]*StoredRoadmap, len(roadmaps))
+	for _, rm := range roadmaps {
+		byID[rm.ID] = rm
+		byName[rm.Roadmap.Name] = rm
+	}
+
+	entries := []RiskEntry{}
+	for _, rm := range roadmaps {
+		for i := range rm.Roadmap.Items {
+			item := &rm.Roadmap.Items[i]
+			if item.Risk.rank() < minimum.rank() || item.Status == StatusCompleted {
+				continue
+			}
+
+			entry := RiskEntry{
+				RoadmapID:    rm.ID,
+				RoadmapName:  rm.Roadmap.Name,
+				ServiceLine:  rm.Roadmap.ServiceLine,
+				ItemID:       item.ID,
+				ItemName:     item.Name,
+				Status:       item.Status,
+				Start:        item.Start,
+				End:          item.End,
+				Owner:        item.Owner,
+				Team:         item.Team,
+				Risk:         item.Risk,
+				RiskNotes:    item.RiskNotes,
+				Dependencies: []RiskDependency{},
+			}
+			for _, depID := range item.Dependencies {
+				entry.Dependencies = append(entry.Dependencies, riskDependency(rm, depID))
+			}
+			for _, dep := range item.ExternalDependencies {
+				target := byID[dep.RoadmapID]
+				if dep.RoadmapID == "" {
+					target = byName[dep.RoadmapName]
+				}
+				if target == nil {
+					entry.Dependencies = append(entry.Dependencies, RiskDependency{RoadmapID: dep.RoadmapID, RoadmapName: dep.RoadmapName, ItemID: dep.Target()})
+					continue
+				}
+				if dep.Milestone != "" || dep.Completion {
+					entry.Dependencies = append(entry.Dependencies, riskTargetDependency(target, dep))
+					continue
+				}
+				itemID := dep.ItemID
+				if item := dep.TargetItem(&target.Roadmap); item != nil {
+					itemID = item.ID
+				}
+				entry.Dependencies = append(entry.Dependencies, riskDependency(target, itemID))
+			}
+			entries = append(entries, entry)
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Risk != entries[j].Risk {
+			return entries[i].Risk.rank() > entries[j].Risk.rank()
+		}
+		a, _, errA := ParsePeriod(entries[i].Start)
+		b, _, errB := ParsePeriod(entries[j].Start)
+		return errA == nil && errB == nil && a.Before(b)
+	})
+	return entries
+}
+
+// riskDependency describes the item of rm with the given ID
+func riskDependency(rm *StoredRoadmap, itemID string) RiskDependency {
+	dep := RiskDependency{RoadmapID: rm.ID, RoadmapName: rm.Roadmap.Name, ItemID: itemID}
+	if item := rm.Roadmap.item(itemID); item != nil {
+		dep.ItemName = item.Name
+		dep.Status = item.Status
+		dep.Risk = item.Risk
+		dep.Found = true
+	}
+	return dep
+}
+
+// riskTargetDependency describes a milestone of rm, or its completion, that
+// dep depends on; ItemID is dep's target and Status is rolled up from the
+// items delivering it
+func riskTargetDependency(rm *StoredRoadmap, dep ExternalDependency) RiskDependency {
+	target := RiskDependency{RoadmapID: rm.ID, RoadmapName: rm.Roadmap.Name, ItemID: dep.Target()}
+	if !dep.resolves(&rm.Roadmap) {
+		return target
+	}
+	target.ItemName = rm.Roadmap.Name
+	if milestone := rm.Roadmap.milestone(dep.Milestone); milestone != nil {
+		target.ItemName = milestone.Name
+	}
+	target.Status = dep.targetStatus(&rm.Roadmap)
+	target.Found = true
+	return target
+}
@@ -1,8 +1,13 @@
 package models
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 // RoadmapStatus represents the status of a roadmap item
@@ -25,13 +30,24 @@ func ValidateStatus(status string) error {
 	}
 }
 
-// ExternalDependency represents a dependency on an item in another roadmap
+// ExternalDependency represents a dependency on an item or milestone of
+// another roadmap, or on the whole of it
 type ExternalDependency struct {
 	RoadmapName string `yaml:"roadmap" json:"roadmap"`
 	RoadmapID   string `yaml:"roadmap_id,omitempty" json:"roadmap_id,omitempty"`
-	ItemID      string `yaml:"item" json:"item"`
+	ItemID      string `yaml:"item,omitempty" json:"item"`
+	// ItemName names the item instead of its ID, for authors who don't know
+	// it; it resolves when exactly one item has that name, apart from case
+	ItemName string `yaml:"item_name,omitempty" json:"item_name,omitempty"`
+	// Milestone is the ID of a milestone of the roadmap, and Completion is
+	// set to depend on the whole roadmap, either instead of an item
+	Milestone   string `yaml:"milestone,omitempty" json:"milestone,omitempty"`
+	Completion  bool   `yaml:"completion,omitempty" json:"completion,omitempty"`
 	Reason      string `yaml:"reason,omitempty" json:"reason,omitempty"`
 	Criticality string `yaml:"criticality,omitempty" json:"criticality,omitempty"`
+	// Orphaned is set by an annotating prune to why the dependency stopped
+	// resolving, so the author can fix or remove it
+	Orphaned string `yaml:"orphaned,omitempty" json:"orphaned,omitempty"`
 }
 
 // RoadmapItem represents a single item on a roadmap
@@ -45,6 +61,62 @@ type RoadmapItem struct {
 	Notes                string               `yaml:"notes,omitempty" json:"notes,omitempty"`
 	Dependencies         []string             `yaml:"dependencies,omitempty" json:"dependencies,omitempty"`
 	ExternalDependencies []ExternalDependency `yaml:"external_dependencies,omitempty" json:"external_dependencies,omitempty"`
+	// Owner is the person and Team the squad the item is assigned to
+	Owner string `yaml:"owner,omitempty" json:"owner,omitempty"`
+	Team  string `yaml:"team,omitempty" json:"team,omitempty"`
+	// Archived items are left out of responses unless asked for, but still
+	// count as dependencies
+	Archived bool `yaml:"archived,omitempty" json:"archived,omitempty"`
+	// Allocations are the people the item takes from roadmap teams while it
+	// runs
+	Allocations []Allocation `yaml:"allocations,omitempty" json:"allocations,omitempty"`
+	// Custom holds organization-specific metadata such as a cost center or
+	// epic link; keys must pass ValidateCustomKey
+	Custom map[string]string `yaml:"custom,omitempty" json:"custom,omitempty"`
+	// Progress is how much of the item is done, 0-100; see EffectiveProgress
+	// for items without one
+	Progress *int `yaml:"progress,omitempty" json:"progress,omitempty"`
+	// Deliverables are the item's checklist; with ProgressFromDeliverables
+	// the item's progress is the share of them that are done
+	Deliverables             []Deliverable `yaml:"deliverables,omitempty" json:"deliverables,omitempty"`
+	ProgressFromDeliverables bool          `yaml:"progress_from_deliverables,omitempty" json:"progress_from_deliverables,omitempty"`
+	// Effort is the estimated work in the item, if known
+	Effort *Effort `yaml:"effort,omitempty" json:"effort,omitempty"`
+	// Budget and ActualCost are amounts in the roadmap's currency
+	Budget     *float64 `yaml:"budget,omitempty" json:"budget,omitempty"`
+	ActualCost *float64 `yaml:"actual_cost,omitempty" json:"actual_cost,omitempty"`
+	// Risk is how likely the item is to slip, and RiskNotes why
+	Risk      RiskLevel `yaml:"risk,omitempty" json:"risk,omitempty"`
+	RiskNotes string    `yaml:"risk_notes,omitempty" json:"risk_notes,omitempty"`
+	// Confidence is how firmly the item is planned
+	Confidence Confidence `yaml:"confidence,omitempty" json:"confidence,omitempty"`
+	// Type classifies the work, e.g. feature or tech-debt; one of ItemTypes
+	Type string `yaml:"type,omitempty" json:"type,omitempty"`
+	// Tags label the item for filtering; they are kept in NormalizeTag form
+	Tags []string `yaml:"tags,omitempty" json:"tags,omitempty"`
+	// Links point to related pages such as design docs or tracker epics
+	Links []Link `yaml:"links,omitempty" json:"links,omitempty"`
+	// Color and Icon are display hints for the visualizer, so that a work
+	// stream looks the same across teams; Color is a hex color such as
+	// #1976d2 and Icon one of Icons
+	Color string `yaml:"color,omitempty" json:"color,omitempty"`
+	Icon  string `yaml:"icon,omitempty" json:"icon,omitempty"`
+	// Recurrence repeats the item; RecurrenceOf is set on the occurrences
+	// generated from it to the ID of the recurring item
+	Recurrence   *Recurrence `yaml:"recurrence,omitempty" json:"recurrence,omitempty"`
+	RecurrenceOf string      `yaml:"recurrence_of,omitempty" json:"recurrence_of,omitempty"`
+	// Milestone is the ID of the roadmap milestone the item delivers, if any
+	Milestone string `yaml:"milestone,omitempty" json:"milestone,omitempty"`
+	// Objective is the ID of the roadmap objective the item contributes to,
+	// if any
+	Objective string `yaml:"objective,omitempty" json:"objective,omitempty"`
+	// StatusChangedAt is when the status last changed through the API
+	StatusChangedAt *time.Time `yaml:"status_changed_at,omitempty" json:"status_changed_at,omitempty"`
+	// StatusHistory lists the status changes made through the API, oldest
+	// first
+	StatusHistory []StatusChange `yaml:"status_history,omitempty" json:"status_history,omitempty"`
+	// Baseline is the start and end the item was first stored with
+	Baseline *Baseline `yaml:"baseline,omitempty" json:"baseline,omitempty"`
 }
 
 // Validate checks if a roadmap item has all required fields
@@ -61,78 +133,408 @@ func (r *RoadmapItem) Validate() error {
 	if r.End == "" {
 		return fmt.Errorf("item end is required")
 	}
+	start, _, err := ParsePeriod(r.Start)
+	if err != nil {
+		return fmt.Errorf("item start: %w", err)
+	}
+	_, end, err := ParsePeriod(r.End)
+	if err != nil {
+		return fmt.Errorf("item end: %w", err)
+	}
+	if !end.After(start) {
+		return fmt.Errorf("item end %s is before its start %s", r.End, r.Start)
+	}
+	if r.Baseline != nil {
+		if _, _, err := ParsePeriod(r.Baseline.Start); err != nil {
+			return fmt.Errorf("item baseline start: %w", err)
+		}
+		if _, _, err := ParsePeriod(r.Baseline.End); err != nil {
+			return fmt.Errorf("item baseline end: %w", err)
+		}
+	}
 	if err := ValidateStatus(string(r.Status)); err != nil {
 		return err
 	}
 
+	if err := validateName("item owner", r.Owner); err != nil {
+		return err
+	}
+	if err := validateName("item team", r.Team); err != nil {
+		return err
+	}
+	for _, key := range r.CustomKeys() {
+		if err := ValidateCustomKey(key); err != nil {
+			return err
+		}
+		if utf8.RuneCountInString(r.Custom[key]) > maxCustomValueLength {
+			return fmt.Errorf("custom field %s must be at most %d characters", key, maxCustomValueLength)
+		}
+	}
+	if r.Progress != nil && (*r.Progress < 0 || *r.Progress > 100) {
+		return fmt.Errorf("item progress must be between 0 and 100")
+	}
+	if err := r.validateDeliverables(); err != nil {
+		return err
+	}
+	if r.Effort != nil {
+		if err := r.Effort.Validate(); err != nil {
+			return err
+		}
+	}
+	if r.Budget != nil {
+		if err := validateAmount("item budget", *r.Budget); err != nil {
+			return err
+		}
+	}
+	if r.ActualCost != nil {
+		if err := validateAmount("item actual_cost", *r.ActualCost); err != nil {
+			return err
+		}
+	}
+	if r.Risk != "" {
+		if err := ValidateRiskLevel(string(r.Risk)); err != nil {
+			return err
+		}
+	}
+	if r.Confidence != "" {
+		if err := ValidateConfidence(string(r.Confidence)); err != nil {
+			return err
+		}
+	}
+	if r.Type != "" {
+		if err := ValidateItemType(r.Type); err != nil {
+			return err
+		}
+	}
+	if r.Color != "" {
+		if err := ValidateColor(r.Color); err != nil {
+			return err
+		}
+	}
+	if r.Icon != "" {
+		if err := ValidateIcon(r.Icon); err != nil {
+			return err
+		}
+	}
+
+	if err := validateTags(r.Tags); err != nil {
+		return err
+	}
+	if r.Recurrence != nil {
+		if r.RecurrenceOf != "" {
+			return fmt.Errorf("an occurrence of a recurring item cannot recur itself")
+		}
+		if err := r.Recurrence.Validate(); err != nil {
+			return err
+		}
+	}
+
+	for i := range r.Links {
+		if err := r.Links[i].Validate(); err != nil {
+			return fmt.Errorf("link %d: %w", i, err)
+		}
+	}
+
+	allocated := make(map[string]bool, len(r.Allocations))
+	for i := range r.Allocations {
+		if err := r.Allocations[i].Validate(); err != nil {
+			return fmt.Errorf("allocation %d: %w", i, err)
+		}
+		if allocated[r.Allocations[i].Team] {
+			return fmt.Errorf("duplicate allocation for team %s", r.Allocations[i].Team)
+		}
+		allocated[r.Allocations[i].Team] = true
+	}
+
 	// Validate external dependencies structure
-	for i, extDep := range r.ExternalDependencies {
-		if extDep.RoadmapName == "" && extDep.RoadmapID == "" {
-			return fmt.Errorf("external dependency %d: either roadmap name or roadmap_id is required", i)
-		}
-		if extDep.ItemID == "" {
-			return fmt.Errorf("external dependency %d: item id is required", i)
-		}
-		// Validate criticality if provided
-		if extDep.Criticality != "" {
-			switch extDep.Criticality {
-			case "low", "medium", "high", "critical":
-				// valid
-			default:
-				return fmt.Errorf("external dependency %d: invalid criticality '%s' (must be low, medium, high, or critical)", i, extDep.Criticality)
-			}
+	for i := range r.ExternalDependencies {
+		if err := r.ExternalDependencies[i].Validate(); err != nil {
+			return fmt.Errorf("external dependency %d: %w", i, err)
 		}
 	}
 
 	return nil
 }
 
+// Validate checks that an external dependency names a roadmap and exactly one
+// thing in it, and has a valid criticality if any. Whether it resolves is
+// checked against the stored roadmaps by ValidateExternalDependencies.
+func (d *ExternalDependency) Validate() error {
+	if d.RoadmapName == "" && d.RoadmapID == "" {
+		return fmt.Errorf("either roadmap name or roadmap_id is required")
+	}
+	targets := 0
+	for _, set := range []bool{d.ItemID != "", d.ItemName != "", d.Milestone != "", d.Completion} {
+		if set {
+			targets++
+		}
+	}
+	if targets != 1 {
+		return fmt.Errorf("exactly one of item, item_name, milestone, or completion is required")
+	}
+	// Validate criticality if provided
+	if d.Criticality != "" {
+		switch d.Criticality {
+		case "low", "medium", "high", "critical":
+			// valid
+		default:
+			return fmt.Errorf("invalid criticality '%s' (must be low, medium, high, or critical)", d.Criticality)
+		}
+	}
+	return nil
+}
+
+// Milestone is a key date on a roadmap. Unlike an item it has no duration,
+// so the timeline draws it as a single point.
+type Milestone struct {
+	ID          string `yaml:"id" json:"id"`
+	Name        string `yaml:"name" json:"name"`
+	Date        string `yaml:"date" json:"date"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+}
+
+// Validate checks if a milestone has all required fields and a valid date
+func (m *Milestone) Validate() error {
+	if m.ID == "" {
+		return fmt.Errorf("milestone id is required")
+	}
+	if m.Name == "" {
+		return fmt.Errorf("milestone name is required")
+	}
+	if m.Date == "" {
+		return fmt.Errorf("milestone date is required")
+	}
+	if _, _, err := ParsePeriod(m.Date); err != nil {
+		return fmt.Errorf("milestone date: %w", err)
+	}
+	return nil
+}
+
+// StampStatusChanges sets StatusChangedAt on every item whose status differs
+// from the item with the same ID in previous, and adds the change by author to
+// its StatusHistory. Items whose status is unchanged keep the time from
+// previous, so replacing an item doesn't lose it. Every item keeps the history
+// from previous, whatever it was sent with; new items start theirs with the
+// status they were added with.
+func (r *Roadmap) StampStatusChanges(previous *Roadmap, at time.Time, author string) {
+	before := make(map[string]*RoadmapItem, len(previous.Items))
+	for i := range previous.Items {
+		before[previous.Items[i].ID] = &previous.Items[i]
+	}
+
+	for i := range r.Items {
+		item := &r.Items[i]
+		old, ok := before[item.ID]
+		if !ok {
+			item.StatusHistory = []StatusChange{{To: item.Status, At: at, Author: author}}
+			continue
+		}
+		item.StatusHistory = old.StatusHistory
+		if old.Status != item.Status {
+			changed := at
+			item.StatusChangedAt = &changed
+			item.StatusHistory = append(append([]StatusChange(nil), old.StatusHistory...),
+				StatusChange{From: old.Status, To: item.Status, At: at, Author: author})
+		} else if item.StatusChangedAt == nil {
+			item.StatusChangedAt = old.StatusChangedAt
+		}
+	}
+}
+
 // Roadmap represents a complete roadmap
 type Roadmap struct {
-	Name        string         `yaml:"name" json:"name"`
-	ServiceLine string         `yaml:"service_line" json:"service_line"`
-	Owner       string         `yaml:"owner,omitempty" json:"owner,omitempty"`
-	Notes       string         `yaml:"notes,omitempty" json:"notes,omitempty"`
-	Items       []RoadmapItem  `yaml:"items" json:"items"`
+	Name        string `yaml:"name" json:"name"`
+	ServiceLine string `yaml:"service_line" json:"service_line"`
+	Owner       string `yaml:"owner,omitempty" json:"owner,omitempty"`
+	// Owners are the people who own the roadmap, with their contact details;
+	// they may be used instead of, or as well as, Owner
+	Owners []Contact `yaml:"owners,omitempty" json:"owners,omitempty"`
+	// Archived roadmaps are left out of listings unless asked for
+	Archived bool `yaml:"archived,omitempty" json:"archived,omitempty"`
+	// Stakeholders are the other people with a part in the roadmap
+	Stakeholders []Stakeholder `yaml:"stakeholders,omitempty" json:"stakeholders,omitempty"`
+	// Portfolio groups related roadmaps, e.g. across service lines, and
+	// Category says what kind of roadmap this is
+	Portfolio string `yaml:"portfolio,omitempty" json:"portfolio,omitempty"`
+	Category  string `yaml:"category,omitempty" json:"category,omitempty"`
+	// Tags label the roadmap as a whole; they are kept in NormalizeTag form
+	Tags []string `yaml:"tags,omitempty" json:"tags,omitempty"`
+	// Currency is the ISO 4217 code of the item budgets and costs
+	Currency   string      `yaml:"currency,omitempty" json:"currency,omitempty"`
+	Notes      string      `yaml:"notes,omitempty" json:"notes,omitempty"`
+	Milestones []Milestone `yaml:"milestones,omitempty" json:"milestones,omitempty"`
+	Objectives []Objective `yaml:"objectives,omitempty" json:"objectives,omitempty"`
+	// Teams are the teams items allocate people from; see ReportCapacity
+	Teams []Team        `yaml:"teams,omitempty" json:"teams,omitempty"`
+	Items []RoadmapItem `yaml:"items" json:"items"`
 }
 
 // Validate checks if a roadmap has all required fields and valid items
 func (r *Roadmap) Validate() error {
+	if errs := r.ValidationErrors(); len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+// ValidationErrors returns every problem Validate would report, in the order
+// it would find them, so all of them can be fixed in one pass
+func (r *Roadmap) ValidationErrors() []error {
+	var errs []error
 	if r.Name == "" {
-		return fmt.Errorf("roadmap name is required")
+		errs = append(errs, fmt.Errorf("roadmap name is required"))
 	}
 	if r.ServiceLine == "" {
-		return fmt.Errorf("service_line is required")
+		errs = append(errs, fmt.Errorf("service_line is required"))
+	}
+	if err := validateName("portfolio", r.Portfolio); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validateName("category", r.Category); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validateTags(r.Tags); err != nil {
+		errs = append(errs, err)
+	}
+	if r.Currency != "" {
+		if err := ValidateCurrency(r.Currency); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	ownerNames := make(map[string]bool, len(r.Owners))
+	for i := range r.Owners {
+		if err := r.Owners[i].Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("owner %d: %w", i, err))
+		}
+		if ownerNames[r.Owners[i].Name] {
+			errs = append(errs, fmt.Errorf("duplicate owner: %s", r.Owners[i].Name))
+		}
+		ownerNames[r.Owners[i].Name] = true
 	}
+	errs = append(errs, validateStakeholders(r.Stakeholders)...)
 	if len(r.Items) == 0 {
-		return fmt.Errorf("roadmap must have at least one item")
+		errs = append(errs, fmt.Errorf("roadmap must have at least one item"))
+	}
+
+	// Validate each milestone
+	milestoneIDs := make(map[string]bool)
+	for i, milestone := range r.Milestones {
+		if err := milestone.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("milestone %d: %w", i, err))
+		}
+		if milestoneIDs[milestone.ID] {
+			errs = append(errs, fmt.Errorf("duplicate milestone id: %s", milestone.ID))
+		}
+		milestoneIDs[milestone.ID] = true
+	}
+
+	// Validate each team
+	teamNames := make(map[string]bool)
+	for i := range r.Teams {
+		team := &r.Teams[i]
+		if err := team.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("team %d: %w", i, err))
+		}
+		if teamNames[team.Name] {
+			errs = append(errs, fmt.Errorf("duplicate team: %s", team.Name))
+		}
+		teamNames[team.Name] = true
+	}
+
+	// Validate each objective
+	objectiveIDs := make(map[string]bool)
+	for i, objective := range r.Objectives {
+		if err := objective.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("objective %d: %w", i, err))
+		}
+		if objectiveIDs[objective.ID] {
+			errs = append(errs, fmt.Errorf("duplicate objective id: %s", objective.ID))
+		}
+		objectiveIDs[objective.ID] = true
 	}
 
 	// Validate each item
 	itemIDs := make(map[string]bool)
 	for i, item := range r.Items {
 		if err := item.Validate(); err != nil {
-			return fmt.Errorf("item %d: %w", i, err)
+			errs = append(errs, fmt.Errorf("item %d: %w", i, err))
 		}
 		// Check for duplicate IDs
 		if itemIDs[item.ID] {
-			return fmt.Errorf("duplicate item id: %s", item.ID)
+			errs = append(errs, fmt.Errorf("duplicate item id: %s", item.ID))
 		}
 		itemIDs[item.ID] = true
 	}
 
-	// Validate dependencies reference existing items
+	// Validate dependencies and milestones reference existing entries, and
+	// that no item is over before the work it depends on has started
 	for _, item := range r.Items {
 		for _, depID := range item.Dependencies {
 			if !itemIDs[depID] {
-				return fmt.Errorf("item %s: dependency %s does not exist", item.ID, depID)
+				errs = append(errs, fmt.Errorf("item %s: dependency %s does not exist", item.ID, depID))
+				continue
+			}
+			if err := checkDependencyOrder(&item, r.item(depID)); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		if item.RecurrenceOf != "" {
+			if source := r.item(item.RecurrenceOf); source == nil || source.Recurrence == nil {
+				errs = append(errs, fmt.Errorf("item %s: recurrence_of %s is not a recurring item", item.ID, item.RecurrenceOf))
+			}
+		}
+		if item.Milestone != "" && !milestoneIDs[item.Milestone] {
+			errs = append(errs, fmt.Errorf("item %s: milestone %s does not exist", item.ID, item.Milestone))
+		}
+		if item.Objective != "" && !objectiveIDs[item.Objective] {
+			errs = append(errs, fmt.Errorf("item %s: objective %s does not exist", item.ID, item.Objective))
+		}
+		for _, allocation := range item.Allocations {
+			if allocation.Team != "" && !teamNames[allocation.Team] {
+				errs = append(errs, fmt.Errorf("item %s: team %s does not exist", item.ID, allocation.Team))
 			}
 		}
 	}
 
+	return errs
+}
+
+// item returns the first item with the given ID
+func (r *Roadmap) item(id string) *RoadmapItem {
+	for i := range r.Items {
+		if r.Items[i].ID == id {
+			return &r.Items[i]
+		}
+	}
 	return nil
 }
 
+// checkDependencyOrder reports an item that ends before its dependency
+// starts. Items with dates that don't parse are skipped; Validate reports those.
+func checkDependencyOrder(item, dep *RoadmapItem) error {
+	_, end, err := item.ItemSpan()
+	if err != nil {
+		return nil
+	}
+	depStart, _, err := dep.ItemSpan()
+	if err != nil {
+		return nil
+	}
+	if !end.After(depStart) {
+		return fmt.Errorf("item %s: ends (%s) before its dependency %s starts (%s)", item.ID, item.End, dep.ID, dep.Start)
+	}
+	return nil
+}
+
+// ContentHash returns a SHA-256 hex digest of the roadmap content. It is
+// computed from the parsed roadmap, so formatting and comments in the source
+// YAML don't affect it.
+func (r *Roadmap) ContentHash() string {
+	data, _ := json.Marshal(r) // a Roadmap always marshals
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
 // RoadmapFile represents the top-level structure of a roadmap YAML file
 type RoadmapFile struct {
 	Roadmap Roadmap `yaml:"roadmap" json:"roadmap"`
@@ -140,11 +542,83 @@ type RoadmapFile struct {
 
 // StoredRoadmap represents a roadmap as stored in the system
 type StoredRoadmap struct {
-	ID          string    `json:"id"`
-	Roadmap     Roadmap   `json:"roadmap"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
-	FileName    string    `json:"file_name"`
+	ID        string     `json:"id"`
+	Roadmap   Roadmap    `json:"roadmap"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	FileName  string     `json:"file_name"`
+	Revision  int        `json:"revision"`
+	CreatedBy string     `json:"created_by,omitempty"`
+	UpdatedBy string     `json:"updated_by,omitempty"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+// CurrentRevision returns the revision number of the stored content. Roadmaps
+// stored before revisions existed report 0 but are treated as revision 1.
+func (s *StoredRoadmap) CurrentRevision() int {
+	if s.Revision == 0 {
+		return 1
+	}
+	return s.Revision
+}
+
+// RoadmapSummary is a lightweight view of a stored roadmap for listings
+type RoadmapSummary struct {
+	ID           string                `json:"id"`
+	Name         string                `json:"name"`
+	ServiceLine  string                `json:"service_line"`
+	Owner        string                `json:"owner,omitempty"`
+	Owners       []Contact             `json:"owners,omitempty"`
+	Portfolio    string                `json:"portfolio,omitempty"`
+	Category     string                `json:"category,omitempty"`
+	Tags         []string              `json:"tags,omitempty"`
+	FileName     string                `json:"file_name"`
+	CreatedAt    time.Time             `json:"created_at"`
+	UpdatedAt    time.Time             `json:"updated_at"`
+	Revision     int                   `json:"revision"`
+	ItemCount    int                   `json:"item_count"`
+	StatusCounts map[RoadmapStatus]int `json:"status_counts"`
+	// Completion is the roadmap's done percentage; see Roadmap.Completion
+	Completion int `json:"completion"`
+	// Health is the roadmap's health now; see Roadmap.Health
+	Health      Health `json:"health"`
+	ContentHash string `json:"content_hash"`
+}
+
+// Summary returns the listing view of a stored roadmap
+func (s *StoredRoadmap) Summary() RoadmapSummary {
+	counts := make(map[RoadmapStatus]int)
+	for _, item := range s.Roadmap.Items {
+		counts[item.Status]++
+	}
+
+	return RoadmapSummary{
+		ID:           s.ID,
+		Name:         s.Roadmap.Name,
+		ServiceLine:  s.Roadmap.ServiceLine,
+		Owner:        s.Roadmap.Owner,
+		Owners:       s.Roadmap.Owners,
+		Portfolio:    s.Roadmap.Portfolio,
+		Category:     s.Roadmap.Category,
+		Tags:         s.Roadmap.Tags,
+		FileName:     s.FileName,
+		CreatedAt:    s.CreatedAt,
+		UpdatedAt:    s.UpdatedAt,
+		Revision:     s.CurrentRevision(),
+		ItemCount:    len(s.Roadmap.Items),
+		StatusCounts: counts,
+		Completion:   s.Roadmap.Completion(),
+		Health:       s.Roadmap.Health(time.Now()),
+		ContentHash:  s.Roadmap.ContentHash(),
+	}
+}
+
+// Revision is a snapshot of a roadmap's content at one point in its history
+type Revision struct {
+	Number    int       `json:"revision"`
+	Roadmap   Roadmap   `json:"roadmap"`
+	CreatedAt time.Time `json:"created_at"`
+	Author    string    `json:"author,omitempty"`
 }
 
 // ExternalDependencyValidation represents validation result for an external dependency
@@ -152,87 +626,184 @@ type ExternalDependencyValidation struct {
 	Valid          bool   `json:"valid"`
 	RoadmapItemID  string `json:"roadmap_item_id"`
 	DependencyDesc string `json:"dependency_desc"`
-	Error          string `json:"error,omitempty"`
+	// RoadmapID is the roadmap declaring the dependency, when it is stored
+	RoadmapID   string `json:"roadmap_id,omitempty"`
+	Criticality string `json:"criticality,omitempty"`
+	Error       string `json:"error,omitempty"`
+	// ResolvedItemID is the ID of the item a dependency by item_name
+	// resolved to
+	ResolvedItemID string `json:"resolved_item_id,omitempty"`
+	// DateConflict is set when the dependency resolves but ends after the
+	// item that depends on it starts; Severity follows its criticality
+	DateConflict string `json:"date_conflict,omitempty"`
+	Severity     string `json:"severity,omitempty"`
+	// Suggestions are the roadmap names, roadmap IDs, item IDs, or milestone
+	// IDs closest to the one that wasn't found, for fixing typos
+	Suggestions []string `json:"suggestions,omitempty"`
 }
 
 // ValidateExternalDependencies validates all external dependencies across roadmaps
 func ValidateExternalDependencies(roadmaps []StoredRoadmap) []ExternalDependencyValidation {
 	var results []ExternalDependencyValidation
 
-	// Build lookup maps
-	roadmapsByName := make(map[string]*StoredRoadmap)
-	roadmapsByID := make(map[string]*StoredRoadmap)
-	itemsByRoadmap := make(map[string]map[string]bool)
+	targets := newDependencyTargets(roadmaps)
+	for _, rm := range roadmaps {
+		for _, validation := range targets.validate(&rm.Roadmap) {
+			validation.RoadmapID = rm.ID
+			results = append(results, validation)
+		}
+	}
+
+	return results
+}
+
+// ValidateRoadmapDependencies validates the external dependencies of a single
+// roadmap, which need not be stored yet, against the given roadmaps
+func ValidateRoadmapDependencies(roadmap *Roadmap, against []StoredRoadmap) []ExternalDependencyValidation {
+	return newDependencyTargets(against).validate(roadmap)
+}
+
+// ValidateItemDependency validates one external dependency of an item of the
+// roadmap, which need not be on the item yet, against the given roadmaps
+func ValidateItemDependency(stored *StoredRoadmap, item *RoadmapItem, dep ExternalDependency, against []StoredRoadmap) ExternalDependencyValidation {
+	validation := newDependencyTargets(against).check(&stored.Roadmap, item, dep)
+	validation.RoadmapID = stored.ID
+	return validation
+}
+
+// dependencyTargets looks up the roadmaps and items that external
+// dependencies can point at
+type dependencyTargets struct {
+	byName map[string]*StoredRoadmap
+	byID   map[string]*StoredRoadmap
+	items  map[*StoredRoadmap]map[string]bool
+}
+
+func newDependencyTargets(roadmaps []StoredRoadmap) *dependencyTargets {
+	t := &dependencyTargets{
+		byName: make(map[string]*StoredRoadmap),
+		byID:   make(map[string]*StoredRoadmap),
+		items:  make(map[*StoredRoadmap]map[string]bool),
+	}
 
 	for i := range roadmaps {
 		rm := &roadmaps[i]
-		roadmapsByName[rm.Roadmap.Name] = rm
-		roadmapsByID[rm.ID] = rm
-		itemsByRoadmap[rm.ID] = make(map[string]bool)
+		t.byName[rm.Roadmap.Name] = rm
+		if rm.ID != "" {
+			t.byID[rm.ID] = rm
+		}
+		t.items[rm] = make(map[string]bool)
 		for _, item := range rm.Roadmap.Items {
-			itemsByRoadmap[rm.ID][item.ID] = true
+			t.items[rm][item.ID] = true
 		}
 	}
 
-	// Validate each external dependency
-	for _, rm := range roadmaps {
-		for _, item := range rm.Roadmap.Items {
-			for _, extDep := range item.ExternalDependencies {
-				validation := ExternalDependencyValidation{
-					RoadmapItemID:  fmt.Sprintf("%s:%s", rm.Roadmap.Name, item.ID),
-					DependencyDesc: fmt.Sprintf("%s:%s", extDep.RoadmapName, extDep.ItemID),
-					Valid:          false,
-				}
-
-				// Find the target roadmap
-				var targetRoadmap *StoredRoadmap
-				if extDep.RoadmapID != "" {
-					targetRoadmap = roadmapsByID[extDep.RoadmapID]
-					if targetRoadmap == nil {
-						validation.Error = fmt.Sprintf("roadmap with ID '%s' not found", extDep.RoadmapID)
-						results = append(results, validation)
-						continue
-					}
-				} else {
-					targetRoadmap = roadmapsByName[extDep.RoadmapName]
-					if targetRoadmap == nil {
-						validation.Error = fmt.Sprintf("roadmap named '%s' not found", extDep.RoadmapName)
-						results = append(results, validation)
-						continue
-					}
-				}
-
-				// Check if the target item exists
-				if !itemsByRoadmap[targetRoadmap.ID][extDep.ItemID] {
-					validation.Error = fmt.Sprintf("item '%s' not found in roadmap '%s'", extDep.ItemID, targetRoadmap.Roadmap.Name)
-					results = append(results, validation)
-					continue
-				}
-
-				validation.Valid = true
-				results = append(results, validation)
-			}
+	return t
+}
+
+// validate checks each external dependency of the roadmap's items
+func (t *dependencyTargets) validate(roadmap *Roadmap) []ExternalDependencyValidation {
+	var results []ExternalDependencyValidation
+
+	for i := range roadmap.Items {
+		item := &roadmap.Items[i]
+		for _, extDep := range item.ExternalDependencies {
+			results = append(results, t.check(roadmap, item, extDep))
 		}
 	}
 
 	return results
 }
 
-// GetExternalDependents returns all items that depend on items in the given roadmap
-func GetExternalDependents(roadmapID string, allRoadmaps []StoredRoadmap) []struct {
+// check validates one external dependency of an item of the roadmap
+func (t *dependencyTargets) check(roadmap *Roadmap, item *RoadmapItem, extDep ExternalDependency) ExternalDependencyValidation {
+	validation := ExternalDependencyValidation{
+		RoadmapItemID:  fmt.Sprintf("%s:%s", roadmap.Name, item.ID),
+		DependencyDesc: fmt.Sprintf("%s:%s", extDep.RoadmapName, extDep.Target()),
+		Criticality:    extDep.Criticality,
+		Valid:          false,
+	}
+
+	// Find the target roadmap
+	var targetRoadmap *StoredRoadmap
+	if extDep.RoadmapID != "" {
+		targetRoadmap = t.byID[extDep.RoadmapID]
+		if targetRoadmap == nil {
+			validation.Error = fmt.Sprintf("roadmap with ID '%s' not found", extDep.RoadmapID)
+			validation.Suggestions = nearestMatches(extDep.RoadmapID, sortedKeys(t.byID))
+			return validation
+		}
+	} else {
+		targetRoadmap = t.byName[extDep.RoadmapName]
+		if targetRoadmap == nil {
+			validation.Error = fmt.Sprintf("roadmap named '%s' not found", extDep.RoadmapName)
+			validation.Suggestions = nearestMatches(extDep.RoadmapName, sortedKeys(t.byName))
+			return validation
+		}
+	}
+
+	// Check if the target milestone or item exists
+	if extDep.Milestone != "" {
+		if targetRoadmap.Roadmap.milestone(extDep.Milestone) == nil {
+			milestones := make([]string, 0, len(targetRoadmap.Roadmap.Milestones))
+			for _, milestone := range targetRoadmap.Roadmap.Milestones {
+				milestones = append(milestones, milestone.ID)
+			}
+			validation.Error = fmt.Sprintf("milestone '%s' not found in roadmap '%s'", extDep.Milestone, targetRoadmap.Roadmap.Name)
+			validation.Suggestions = nearestMatches(extDep.Milestone, milestones)
+			return validation
+		}
+	} else if extDep.ItemName != "" {
+		matches := targetRoadmap.Roadmap.itemsNamed(extDep.ItemName)
+		switch len(matches) {
+		case 0:
+			names := make([]string, 0, len(targetRoadmap.Roadmap.Items))
+			for _, item := range targetRoadmap.Roadmap.Items {
+				names = append(names, item.Name)
+			}
+			validation.Error = fmt.Sprintf("no item named '%s' in roadmap '%s'", extDep.ItemName, targetRoadmap.Roadmap.Name)
+			validation.Suggestions = nearestMatches(extDep.ItemName, names)
+			return validation
+		case 1:
+			validation.ResolvedItemID = matches[0].ID
+		default:
+			ids := make([]string, len(matches))
+			for i, match := range matches {
+				ids[i] = match.ID
+			}
+			validation.Error = fmt.Sprintf("item name '%s' is ambiguous in roadmap '%s': items %s all have it (give the item ID instead)",
+				extDep.ItemName, targetRoadmap.Roadmap.Name, strings.Join(ids, ", "))
+			validation.Suggestions = ids
+			return validation
+		}
+	} else if !extDep.Completion && !t.items[targetRoadmap][extDep.ItemID] {
+		validation.Error = fmt.Sprintf("item '%s' not found in roadmap '%s'", extDep.ItemID, targetRoadmap.Roadmap.Name)
+		validation.Suggestions = nearestMatches(extDep.ItemID, sortedKeys(t.items[targetRoadmap]))
+		return validation
+	}
+
+	validation.Valid = true
+	if conflict := externalDateConflict(item, extDep, &targetRoadmap.Roadmap); conflict != "" {
+		validation.DateConflict = conflict
+		validation.Severity = DependencySeverity(extDep.Criticality)
+	}
+	return validation
+}
+
+// ExternalDependent is an item in another roadmap that depends on an item of
+// a roadmap. Its fields have no JSON names, so they keep the Go field names
+// the dependents endpoint has always returned.
+type ExternalDependent struct {
 	RoadmapID   string
 	RoadmapName string
 	ItemID      string
 	ItemName    string
-	DependsOn   string // The item ID in the target roadmap
-} {
-	var dependents []struct {
-		RoadmapID   string
-		RoadmapName string
-		ItemID      string
-		ItemName    string
-		DependsOn   string
-	}
+	DependsOn   string // The target in the target roadmap, as ExternalDependency.Target
+}
+
+// GetExternalDependents returns all items that depend on items in the given roadmap
+func GetExternalDependents(roadmapID string, allRoadmaps []StoredRoadmap) []ExternalDependent {
+	var dependents []ExternalDependent
 
 	// Find the target roadmap
 	var targetRoadmap *StoredRoadmap
@@ -251,28 +822,30 @@ func GetExternalDependents(roadmapID string, allRoadmaps []StoredRoadmap) []stru
 		if rm.ID == roadmapID {
 			continue // Skip the roadmap itself
 		}
-		for _, item := range rm.Roadmap.Items {
-			for _, extDep := range item.ExternalDependencies {
-				// Check if this dependency points to our target roadmap
-				if extDep.RoadmapID == roadmapID ||
-					extDep.RoadmapName == targetRoadmap.Roadmap.Name {
-					dependents = append(dependents, struct {
-						RoadmapID   string
-						RoadmapName string
-						ItemID      string
-						ItemName    string
-						DependsOn   string
-					}{
-						RoadmapID:   rm.ID,
-						RoadmapName: rm.Roadmap.Name,
-						ItemID:      item.ID,
-						ItemName:    item.Name,
-						DependsOn:   extDep.ItemID,
-					})
-				}
+		dependents = append(dependents, rm.dependentsOn(roadmapID, targetRoadmap.Roadmap.Name)...)
+	}
+
+	return dependents
+}
+
+// dependentsOn returns the items of the roadmap with an external dependency
+// on the roadmap with the given ID or name
+func (s *StoredRoadmap) dependentsOn(roadmapID, roadmapName string) []ExternalDependent {
+	var dependents []ExternalDependent
+	for _, item := range s.Roadmap.Items {
+		for _, extDep := range item.ExternalDependencies {
+			// Check if this dependency points to our target roadmap
+			if extDep.RoadmapID == roadmapID ||
+				extDep.RoadmapName == roadmapName {
+				dependents = append(dependents, ExternalDependent{
+					RoadmapID:   s.ID,
+					RoadmapName: s.Roadmap.Name,
+					ItemID:      item.ID,
+					ItemName:    item.Name,
+					DependsOn:   extDep.Target(),
+				})
 			}
 		}
 	}
-
 	return dependents
 }
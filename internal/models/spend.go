@@ -0,0 +1,124 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+)
+
+// currencyPattern matches ISO 4217 codes such as USD or EUR
+var currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// maxAmount bounds budgets and costs, well within exact float64 cents
+const maxAmount = 1e12
+
+// ValidateCurrency checks that a currency is a three-letter ISO 4217 code
+func ValidateCurrency(currency string) error {
+	if !currencyPattern.MatchString(currency) {
+		return fmt.Errorf("invalid currency '%s' (must be a three-letter code such as USD)", currency)
+	}
+	return nil
+}
+
+// validateAmount checks that a money amount is not negative and has no more
+// than two decimal places
+func validateAmount(field string, amount float64) error {
+	if math.IsNaN(amount) || amount < 0 || amount > maxAmount {
+		return fmt.Errorf("%s must be between 0 and %.0f", field, maxAmount)
+	}
+	if cents := amount * 100; math.Abs(cents-math.Round(cents)) > 1e-6 {
+		return fmt.Errorf("%s must have at most two decimal places", field)
+	}
+	return nil
+}
+
+// SpendTotal is the budget and actual cost of a group of items in one
+// currency
+type SpendTotal struct {
+	// ID is set for roadmaps
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name"`
+	// Currency is empty for roadmaps that don't set one
+	Currency   string  `json:"currency,omitempty"`
+	Budget     float64 `json:"budget"`
+	ActualCost float64 `json:"actual_cost"`
+	// Remaining is the budget less the actual cost; negative when over
+	Remaining float64 `json:"remaining"`
+	// Items is the number of items with a budget or an actual cost
+	Items int `json:"items"`
+}
+
+// SpendReport rolls item budgets and costs up for finance reviews
+type SpendReport struct {
+	Roadmaps []SpendTotal `json:"roadmaps"`
+	// ServiceLines has one total per service line and currency, since
+	// amounts in different currencies aren't added together
+	ServiceLines []SpendTotal `json:"service_lines"`
+}
+
+// ReportSpend totals the budgets and actual costs of the items in the
+// roadmaps, each list by actual cost, largest first, and then by name and
+// currency
+func ReportSpend(roadmaps []*StoredRoadmap) SpendReport {
+	type groupKey struct{ name, currency string }
+	serviceLines := make(map[groupKey]*SpendTotal)
+	report := SpendReport{Roadmaps: make([]SpendTotal, 0, len(roadmaps))}
+
+	for _, rm := range roadmaps {
+		currency := rm.Roadmap.Currency
+		total := SpendTotal{ID: rm.ID, Name: rm.Roadmap.Name, Currency: currency}
+		key := groupKey{rm.Roadmap.ServiceLine, currency}
+		serviceLine, ok := serviceLines[key]
+		if !ok {
+			serviceLine = &SpendTotal{Name: key.name, Currency: key.currency}
+			serviceLines[key] = serviceLine
+		}
+		for i := range rm.Roadmap.Items {
+			item := &rm.Roadmap.Items[i]
+			if item.Budget == nil && item.ActualCost == nil {
+				continue
+			}
+			total.add(item)
+			serviceLine.add(item)
+		}
+		report.Roadmaps = append(report.Roadmaps, total)
+	}
+
+	report.ServiceLines = make([]SpendTotal, 0, len(serviceLines))
+	for _, group := range serviceLines {
+		report.ServiceLines = append(report.ServiceLines, *group)
+	}
+	for _, totals := range [][]SpendTotal{report.Roadmaps, report.ServiceLines} {
+		for i := range totals {
+			totals[i].Budget = roundCents(totals[i].Budget)
+			totals[i].ActualCost = roundCents(totals[i].ActualCost)
+			totals[i].Remaining = roundCents(totals[i].Budget - totals[i].ActualCost)
+		}
+		sort.Slice(totals, func(i, j int) bool {
+			if totals[i].ActualCost != totals[j].ActualCost {
+				return totals[i].ActualCost > totals[j].ActualCost
+			}
+			if totals[i].Name != totals[j].Name {
+				return totals[i].Name < totals[j].Name
+			}
+			return totals[i].Currency < totals[j].Currency
+		})
+	}
+	return report
+}
+
+// add counts an item's budget and cost in the total
+func (t *SpendTotal) add(item *RoadmapItem) {
+	t.Items++
+	if item.Budget != nil {
+		t.Budget += *item.Budget
+	}
+	if item.ActualCost != nil {
+		t.ActualCost += *item.ActualCost
+	}
+}
+
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
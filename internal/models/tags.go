@@ -0,0 +1,133 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// NormalizeTag returns the canonical form of a tag: lower case, with runs of
+// spaces and underscores replaced by a single hyphen, so "Data Platform" and
+// "data_platform" both become "data-platform"
+func NormalizeTag(tag string) string {
+	words := strings.FieldsFunc(strings.ToLower(tag), func(r rune) bool {
+		return unicode.IsSpace(r) || r == '_'
+	})
+	return strings.Join(words, "-")
+}
+
+// NormalizeTags puts the tags of the roadmap and its items in NormalizeTag
+// form, dropping any that become duplicates. Tags with nothing to keep, such
+// as "  ", are left for Validate to reject.
+func (r *Roadmap) NormalizeTags() {
+	r.Tags = normalizeTags(r.Tags)
+	for i := range r.Items {
+		r.Items[i].Tags = normalizeTags(r.Items[i].Tags)
+	}
+}
+
+func normalizeTags(tags []string) []string {
+	if len(tags) == 0 {
+		return tags
+	}
+	seen := make(map[string]bool, len(tags))
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if n := NormalizeTag(tag); n != "" {
+			tag = n
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			normalized = append(normalized, tag)
+		}
+	}
+	return normalized
+}
+
+// validateTags checks that every tag has a normalized form and is used once
+func validateTags(tags []string) error {
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		if NormalizeTag(tag) == "" {
+			return fmt.Errorf("tags must not be empty")
+		}
+		if seen[tag] {
+			return fmt.Errorf("duplicate tag: %s", tag)
+		}
+		seen[tag] = true
+	}
+	return nil
+}
+
+// HasTag reports whether the item carries the tag
+func (r *RoadmapItem) HasTag(tag string) bool {
+	for _, t := range r.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// TagCount reports how often a tag is used
+type TagCount struct {
+	Tag string `json:"tag"`
+	// Items is the number of items with the tag
+	Items int `json:"items"`
+	// Roadmaps is the number of roadmaps with at least one such item
+	Roadmaps int `json:"roadmaps"`
+}
+
+// CountTags counts the tags of every item in the roadmaps, most used first
+// and then by name
+func CountTags(roadmaps []*StoredRoadmap) []TagCount {
+	usages := countUsage(roadmaps, func(item *RoadmapItem) []string { return item.Tags })
+	result := make([]TagCount, len(usages))
+	for i, u := range usages {
+		result[i] = TagCount{Tag: u.value, Items: u.items, Roadmaps: u.roadmaps}
+	}
+	return result
+}
+
+// usage is how many items and roadmaps a value appears in
+type usage struct {
+	value    string
+	items    int
+	roadmaps int
+}
+
+// countUsage counts the values that values returns for each item of the
+// roadmaps, most used first and then by value
+func countUsage(roadmaps []*StoredRoadmap, values func(*RoadmapItem) []string) []usage {
+	counts := make(map[string]*usage)
+	for _, rm := range roadmaps {
+		inRoadmap := make(map[string]bool)
+		for i := range rm.Roadmap.Items {
+			for _, value := range values(&rm.Roadmap.Items[i]) {
+				count, ok := counts[value]
+				if !ok {
+					count = &usage{value: value}
+					counts[value] = count
+				}
+				count.items++
+				if !inRoadmap[value] {
+					count.roadmaps++
+					inRoadmap[value] = true
+				}
+			}
+		}
+	}
+
+	result := make([]usage, 0, len(counts))
+	for _, count := range counts {
+		result = append(result, *count)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].items != result[j].items {
+			return result[i].items > result[j].items
+		}
+		return result[i].value < result[j].value
+	})
+	return result
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	r := Roadmap{
+		Tags:  []string{"Data Platform", "data_platform", "Q3"},
+		Items: []RoadmapItem{{Tags: []string{" Tech  Debt ", "tech-debt"}}, {}},
+	}
+	r.NormalizeTags()
+
+	if want := []string{"data-platform", "q3"}; !reflect.DeepEqual(r.Tags, want) {
+		t.Errorf("roadmap tags = %q, want %q", r.Tags, want)
+	}
+	if want := []string{"tech-debt"}; !reflect.DeepEqual(r.Items[0].Tags, want) {
+		t.Errorf("item tags = %q, want %q", r.Items[0].Tags, want)
+	}
+	if r.Items[1].Tags != nil {
+		t.Errorf("untagged item tags = %q, want nil", r.Items[1].Tags)
+	}
+}
+
+func TestValidateTagsRejectsEmpty(t *testing.T) {
+	r := Roadmap{Tags: []string{" _ "}}
+	r.NormalizeTags()
+	if err := validateTags(r.Tags); err == nil {
+		t.Errorf("validateTags(%q) succeeded, want an error", r.Tags)
+	}
+}
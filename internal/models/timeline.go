@@ -0,0 +1,71 @@
+package models
+
+import "time"
+
+// TimelineRow is an item on a monthly timeline
+type TimelineRow struct {
+	ItemID string
+	Name   string
+	Status RoadmapStatus
+	// Fill is the item's color for its status, as in DOT graphs
+	Fill string
+	// Start and End are the time range the item covers, as from ItemSpan
+	Start time.Time
+	End   time.Time
+	// Months is whether the item runs in each month of the timeline
+	Months []bool
+}
+
+// Timeline lays a roadmap's items out by month, as a Gantt chart
+type Timeline struct {
+	// Months are the first days of the months from the earliest start of an
+	// item to the latest end
+	Months []time.Time
+	Rows   []TimelineRow
+}
+
+// MonthlyTimeline lays the roadmap's items out over the months they run in,
+// in roadmap order. Items whose dates can't be parsed are left out.
+func (s *StoredRoadmap) MonthlyTimeline() Timeline {
+	type span struct {
+		item       *RoadmapItem
+		start, end time.Time
+	}
+	var spans []span
+	var first, last time.Time
+	for i := range s.Roadmap.Items {
+		item := &s.Roadmap.Items[i]
+		start, end, err := item.ItemSpan()
+		if err != nil {
+			continue
+		}
+		if first.IsZero() || start.Before(first) {
+			first = start
+		}
+		if end.After(last) {
+			last = end
+		}
+		spans = append(spans, span{item, start, end})
+	}
+
+	var timeline Timeline
+	if len(spans) == 0 {
+		return timeline
+	}
+	for month := time.Date(first.Year(), first.Month(), 1, 0, 0, 0, 0, time.UTC); month.Before(last); month = month.AddDate(0, 1, 0) {
+		timeline.Months = append(timeline.Months, month)
+	}
+
+	for _, sp := range spans {
+		fill, ok := statusFill[sp.item.Status]
+		if !ok {
+			fill = "#ffffff"
+		}
+		row := TimelineRow{ItemID: sp.item.ID, Name: sp.item.Name, Status: sp.item.Status, Fill: fill, Start: sp.start, End: sp.end, Months: make([]bool, len(timeline.Months))}
+		for i, month := range timeline.Months {
+			row.Months[i] = sp.start.Before(month.AddDate(0, 1, 0)) && sp.end.After(month)
+		}
+		timeline.Rows = append(timeline.Rows, row)
+	}
+	return timeline
+}
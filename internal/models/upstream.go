@@ -0,0 +1,89 @@
+package models
+
+// DependencyTree is an item and, recursively, the items it depends on
+type DependencyTree struct {
+	DependencyNode
+	Status RoadmapStatus `json:"status"`
+	// Type is how its dependent depends on it, internal or external; empty
+	// for the item the tree was asked for
+	Type        string `json:"type,omitempty"`
+	Criticality string `json:"criticality,omitempty"`
+	// Cycle is set when the item is already further up this branch; its
+	// dependencies are not listed again
+	Cycle bool `json:"cycle,omitempty"`
+	// Repeat is set when the item's dependencies are listed where it first
+	// appears in the tree rather than here
+	Repeat bool `json:"repeat,omitempty"`
+	// Truncated is set when the depth limit stopped the tree before the
+	// item's dependencies
+	Truncated    bool             `json:"truncated,omitempty"`
+	Dependencies []DependencyTree `json:"dependencies,omitempty"`
+}
+
+// UpstreamTree is everything an item depends on, directly or through other
+// items, up to a depth
+type UpstreamTree struct {
+	Depth int `json:"depth"`
+	// Total is the number of distinct items in the tree below the root
+	Total int            `json:"total"`
+	Tree  DependencyTree `json:"tree"`
+}
+
+// Upstream walks the internal and external dependencies of an item in the
+// roadmaps, depth levels deep, and reports false if there is no such item.
+// Each item's dependencies are listed once, where it first appears; later
+// appearances are marked Repeat and items that loop back are marked Cycle.
+// External dependencies that don't resolve are left out.
+func Upstream(roadmaps []*StoredRoadmap, roadmapID, itemID string, depth int) (UpstreamTree, bool) {
+	g := newDependencyGraph(roadmaps)
+	root, ok := g.index[roadmapID+":"+itemID]
+	if !ok {
+		return UpstreamTree{}, false
+	}
+
+	onPath := make([]bool, len(g.nodes))
+	expanded := make([]bool, len(g.nodes))
+	found := make(map[int]bool)
+
+	var walk func(n, level int) DependencyTree
+	walk = func(n, level int) DependencyTree {
+		tree := DependencyTree{DependencyNode: g.nodes[n], Status: g.items[n].Status}
+		if len(g.edges[n]) == 0 {
+			return tree
+		}
+		switch {
+		case onPath[n]:
+			tree.Cycle = true
+			return tree
+		case expanded[n]:
+			tree.Repeat = true
+			return tree
+		case level == depth:
+			tree.Truncated = true
+			return tree
+		}
+
+		onPath[n], expanded[n] = true, true
+		listed := make(map[int]bool)
+		for _, edge := range g.edges[n] {
+			if listed[edge.to] {
+				continue
+			}
+			listed[edge.to] = true
+			found[edge.to] = true
+			dep := walk(edge.to, level+1)
+			dep.Type = EdgeInternal
+			if edge.external {
+				dep.Type = EdgeExternal
+				dep.Criticality = edge.criticality
+			}
+			tree.Dependencies = append(tree.Dependencies, dep)
+		}
+		onPath[n] = false
+		return tree
+	}
+
+	tree := walk(root, 0)
+	delete(found, root)
+	return UpstreamTree{Depth: depth, Total: len(found), Tree: tree}, true
+}
@@ -0,0 +1,193 @@
+package openapi
+
+import (
+	"reflect"
+	"roadmap-visualizer/internal/models"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Schema is an OpenAPI 3 schema object
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+	OneOf                []*Schema          `json:"oneOf,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+}
+
+// enums lists the allowed values of named string types
+var enums = map[reflect.Type][]string{
+	reflect.TypeOf(models.RoadmapStatus("")): {
+		string(models.StatusPlanned),
+		string(models.StatusInProgress),
+		string(models.StatusCompleted),
+		string(models.StatusBlocked),
+	},
+	reflect.TypeOf(models.RiskLevel("")): {
+		string(models.RiskLow),
+		string(models.RiskMedium),
+		string(models.RiskHigh),
+	},
+	reflect.TypeOf(models.Confidence("")): {
+		string(models.ConfidenceCommitted),
+		string(models.ConfidenceLikely),
+		string(models.ConfidenceExploratory),
+	},
+	reflect.TypeOf(models.Responsibility("")): {
+		string(models.ResponsibilityResponsible),
+		string(models.ResponsibilityAccountable),
+		string(models.ResponsibilityConsulted),
+		string(models.ResponsibilityInformed),
+	},
+	reflect.TypeOf(models.Health("")): {
+		string(models.HealthOnTrack),
+		string(models.HealthAtRisk),
+		string(models.HealthLate),
+	},
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// generator builds schemas from Go types, collecting named structs and enums
+// as components so they are described once and referenced everywhere else
+type generator struct {
+	components map[string]*Schema
+}
+
+func newGenerator() *generator {
+	return &generator{components: make(map[string]*Schema)}
+}
+
+// ref returns a reference to the component schema for v's type, generating it
+// on first use
+func (g *generator) ref(v interface{}) *Schema {
+	return g.schemaFor(reflect.TypeOf(v))
+}
+
+// schemaFor returns the schema of a Go type as encoding/json would encode it
+func (g *generator) schemaFor(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == timeType {
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+	if values, ok := enums[t]; ok {
+		if _, done := g.components[t.Name()]; !done {
+			g.components[t.Name()] = &Schema{Type: "string", Enum: values}
+		}
+		return componentRef(t.Name())
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: g.schemaFor(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schemaFor(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		if _, done := g.components[t.Name()]; !done {
+			// Reserve the name first so recursive types terminate
+			g.components[t.Name()] = &Schema{}
+			*g.components[t.Name()] = *g.structSchema(t)
+		}
+		return componentRef(t.Name())
+	default:
+		return &Schema{}
+	}
+}
+
+// structSchema describes a struct's JSON fields. Fields without omitempty
+// are required; embedded structs contribute their fields directly.
+func (g *generator) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			for embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				inner := g.structSchema(embedded)
+				for k, v := range inner.Properties {
+					schema.Properties[k] = v
+				}
+				schema.Required = append(schema.Required, inner.Required...)
+				continue
+			}
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		prop := g.schemaFor(field.Type)
+		if field.Type.Kind() == reflect.Ptr && prop.Ref == "" {
+			prop.Nullable = true
+		}
+		schema.Properties[name] = prop
+
+		if !strings.Contains(opts, "omitempty") && field.Type.Kind() != reflect.Ptr {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+
+	return schema
+}
+
+// componentRef returns a reference to a schema under components/schemas
+func componentRef(name string) *Schema {
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// object builds an inline object schema whose properties are all required
+func object(properties map[string]*Schema) *Schema {
+	schema := &Schema{Type: "object", Properties: properties}
+	for name := range properties {
+		schema.Required = append(schema.Required, name)
+	}
+	sort.Strings(schema.Required)
+	return schema
+}
+
+// arrayOf builds an array schema
+func arrayOf(items *Schema) *Schema {
+	return &Schema{Type: "array", Items: items}
+}
@@ -0,0 +1,1148 @@
+// Package openapi builds the OpenAPI 3 description of the HTTP API. Schemas
+// for request and response bodies are generated from the models package, so
+// they stay in step with what the handlers encode.
+package openapi
+
+import (
+	"roadmap-visualizer/internal/apierror"
+	"roadmap-visualizer/internal/models"
+	"roadmap-visualizer/internal/search"
+	"roadmap-visualizer/internal/servicelines"
+	"roadmap-visualizer/internal/storage"
+	"roadmap-visualizer/internal/webhooks"
+)
+
+// Document is an OpenAPI 3 document
+type Document struct {
+	OpenAPI    string                `json:"openapi"`
+	Info       Info                  `json:"info"`
+	Paths      map[string]PathItem   `json:"paths"`
+	Components Components            `json:"components"`
+	Security   []map[string][]string `json:"security,omitempty"`
+	Tags       []Tag                 `json:"tags,omitempty"`
+}
+
+// Info describes the API
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// Tag groups operations
+type Tag struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// Components holds the reusable schemas
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// SecurityScheme describes how requests authenticate
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+	Description  string `json:"description,omitempty"`
+}
+
+// PathItem maps lowercase HTTP methods to operations
+type PathItem map[string]*Operation
+
+// Operation is a single API operation
+type Operation struct {
+	OperationID string              `json:"operationId"`
+	Summary     string              `json:"summary"`
+	Description string              `json:"description,omitempty"`
+	Tags        []string            `json:"tags,omitempty"`
+	Parameters  []*Parameter        `json:"parameters,omitempty"`
+	RequestBody *RequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]Response `json:"responses"`
+}
+
+// Parameter is a path, query, or header parameter
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// RequestBody describes the body of a request
+type RequestBody struct {
+	Description string               `json:"description,omitempty"`
+	Required    bool                 `json:"required"`
+	Content     map[string]MediaType `json:"content"`
+}
+
+// MediaType holds the schema for one content type
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Response describes one response status
+type Response struct {
+	Description string               `json:"description"`
+	Headers     map[string]Header    `json:"headers,omitempty"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// Header describes a response header
+type Header struct {
+	Description string  `json:"description,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// Tags used to group operations
+const (
+	tagRoadmaps     = "roadmaps"
+	tagRevisions    = "revisions"
+	tagDependencies = "dependencies"
+	tagTrash        = "trash"
+	tagSearch       = "search"
+	tagGraphQL      = "graphql"
+	tagWebhooks     = "webhooks"
+	tagAdmin        = "admin"
+	tagReports      = "reports"
+	tagServiceLines = "service-lines"
+	tagImport       = "import"
+)
+
+// operation builds an Operation with chained helpers
+type operation struct {
+	*Operation
+}
+
+func newOperation(id, tag, summary string) operation {
+	return operation{&Operation{
+		OperationID: id,
+		Summary:     summary,
+		Tags:        []string{tag},
+		Responses:   make(map[string]Response),
+	}}
+}
+
+func (o operation) describe(description string) operation {
+	o.Description = description
+	return o
+}
+
+func (o operation) param(p *Parameter) operation {
+	o.Parameters = append(o.Parameters, p)
+	return o
+}
+
+func (o operation) body(contentType string, schema *Schema, description string) operation {
+	o.RequestBody = &RequestBody{
+		Description: description,
+		Required:    true,
+		Content:     map[string]MediaType{contentType: {Schema: schema}},
+	}
+	return o
+}
+
+// optionalBody documents a request body that may be left out
+func (o operation) optionalBody(contentType string, schema *Schema, description string) operation {
+	o = o.body(contentType, schema, description)
+	o.RequestBody.Required = false
+	return o
+}
+
+// alsoBody accepts another content type for the request body
+func (o operation) alsoBody(contentType string, schema *Schema) operation {
+	o.RequestBody.Content[contentType] = MediaType{Schema: schema}
+	return o
+}
+
+func (o operation) respond(status, description, contentType string, schema *Schema) operation {
+	response := Response{Description: description}
+	if contentType != "" {
+		response.Content = map[string]MediaType{contentType: {Schema: schema}}
+	}
+	o.Responses[status] = response
+	return o
+}
+
+// also adds another content type to a documented response
+func (o operation) also(status, contentType string, schema *Schema) operation {
+	o.Responses[status].Content[contentType] = MediaType{Schema: schema}
+	return o
+}
+
+func (o operation) json(status, description string, schema *Schema) operation {
+	return o.respond(status, description, "application/json", schema)
+}
+
+// fail documents an error response; errors share the Error envelope
+func (o operation) fail(status, description string) operation {
+	return o.json(status, description, componentRef("Error"))
+}
+
+// withETag adds the ETag header to a documented response
+func (o operation) withETag(status string) operation {
+	response := o.Responses[status]
+	response.Headers = map[string]Header{
+		"ETag": {Description: "Quoted revision number of the roadmap", Schema: &Schema{Type: "string"}},
+	}
+	o.Responses[status] = response
+	return o
+}
+
+// withLastModified adds the Last-Modified header to a documented response
+func (o operation) withLastModified(status string) operation {
+	response := o.Responses[status]
+	if response.Headers == nil {
+		response.Headers = make(map[string]Header)
+	}
+	response.Headers["Last-Modified"] = Header{Description: "When the content last changed", Schema: &Schema{Type: "string"}}
+	o.Responses[status] = response
+	return o
+}
+
+func pathParam(name, description string, schema *Schema) *Parameter {
+	return &Parameter{Name: name, In: "path", Description: description, Required: true, Schema: schema}
+}
+
+func queryParam(name, description string, schema *Schema) *Parameter {
+	return &Parameter{Name: name, In: "query", Description: description, Schema: schema}
+}
+
+func headerParam(name, description string) *Parameter {
+	return &Parameter{Name: name, In: "header", Description: description, Schema: &Schema{Type: "string"}}
+}
+
+func enum(values ...string) *Schema {
+	return &Schema{Type: "string", Enum: values}
+}
+
+// apiVersion is the version of the API described by the document
+const apiVersion = "1.0.0"
+
+// Build returns the OpenAPI document for the server's API
+func Build() *Document {
+	g := newGenerator()
+
+	stored := g.ref(models.StoredRoadmap{})
+	summary := g.ref(models.RoadmapSummary{})
+	roadmapFile := g.ref(models.RoadmapFile{})
+	revision := g.ref(models.Revision{})
+	item := g.ref(models.RoadmapItem{})
+	extDep := g.ref(models.ExternalDependency{})
+	validation := g.ref(models.ExternalDependencyValidation{})
+	reindex := g.ref(storage.ReindexResult{})
+	match := g.ref(search.Match{})
+	webhook := g.ref(webhooks.Webhook{})
+	serviceLine := g.ref(servicelines.ServiceLine{})
+	serviceLineSummary := g.ref(servicelines.Summary{})
+	delivery := g.ref(webhooks.Delivery{})
+	comment := g.ref(models.Comment{})
+	g.ref(apierror.Error{}) // referenced by every fail response
+
+	// Added by RoadmapItem.MarshalJSON, and ignored in requests
+	g.components["RoadmapItem"].Properties["start_date"] = &Schema{Type: "string", Format: "date", Description: "First day of the start period, computed by the server"}
+	g.components["RoadmapItem"].Properties["end_date"] = &Schema{Type: "string", Format: "date", Description: "Last day of the end period, computed by the server"}
+	g.components["RoadmapItem"].Properties["deliverables_done"] = &Schema{Type: "integer", Description: "Number of deliverables done, for items with deliverables"}
+	g.components["RoadmapItem"].Properties["deliverables_total"] = &Schema{Type: "integer", Description: "Number of deliverables, for items with deliverables"}
+
+	// Display hints are plain strings in Go
+	g.components["RoadmapItem"].Properties["color"] = &Schema{Type: "string", Pattern: "^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$", Description: "Hex color to draw the item with"}
+	g.components["RoadmapItem"].Properties["icon"] = &Schema{Type: "string", Enum: models.Icons, Description: "Icon to draw the item with"}
+	g.components["RoadmapItem"].Properties["type"] = &Schema{Type: "string", Enum: models.ItemTypes, Description: "Kind of work, from the types the server is configured with"}
+
+	// Contact details are checked by the server
+	g.components["Contact"].Properties["email"] = &Schema{Type: "string", Format: "email"}
+	g.components["Contact"].Properties["slack"] = &Schema{Type: "string", Pattern: "^[@#][a-z0-9][a-z0-9._-]{0,79}$", Description: "Slack member handle (@jane.doe) or channel (#identity-team)"}
+
+	// So are the recurrence interval and the currency
+	g.components["Recurrence"].Properties["every"] = &Schema{Type: "string", Enum: []string{"month", "quarter", "year"}, Description: "Interval between occurrences"}
+	g.components["Roadmap"].Properties["currency"] = &Schema{Type: "string", Pattern: "^[A-Z]{3}$", Description: "ISO 4217 code of the item budgets and costs"}
+
+	createResult := &Schema{AllOf: []*Schema{stored, object(map[string]*Schema{
+		"duplicate": {Type: "boolean", Description: "Set when an existing roadmap with identical content was returned"},
+	})}}
+
+	commentBody := object(map[string]*Schema{
+		"body": {Type: "string", Description: "Comment text, at most 10000 characters"},
+	})
+
+	health := g.ref(models.Health(""))
+	itemHealth := &Schema{Type: "object", AdditionalProperties: health, Description: "Health of each item, keyed by item ID"}
+	effectiveStatus := &Schema{Type: "object", AdditionalProperties: g.ref(models.EffectiveStatus{}), Description: "Effective status of each item, keyed by item ID"}
+	listed := &Schema{AllOf: []*Schema{stored, object(map[string]*Schema{
+		"completion":       {Type: "integer", Description: "Percentage of the roadmap's work done, with items weighted by duration"},
+		"health":           health,
+		"item_health":      itemHealth,
+		"effective_status": effectiveStatus,
+	})}}
+	got := &Schema{AllOf: []*Schema{stored, object(map[string]*Schema{
+		"health":              health,
+		"item_health":         itemHealth,
+		"effective_status":    effectiveStatus,
+		"blocked_by_external": g.ref(models.ExternalExposure{}),
+	})}}
+
+	issue := arrayOf(object(map[string]*Schema{
+		"document": {Type: "integer", Description: "1-based position of the document in the payload"},
+		"roadmap":  {Type: "string"},
+		"item":     {Type: "string", Description: "Item ID, for dependency warnings"},
+		"message":  {Type: "string"},
+	}))
+	validationReport := object(map[string]*Schema{
+		"valid":    {Type: "boolean"},
+		"roadmaps": {Type: "integer", Description: "Number of documents parsed"},
+		"errors":   issue,
+		"warnings": issue,
+	})
+
+	batchResult := object(map[string]*Schema{
+		"count":      {Type: "integer"},
+		"duplicates": {Type: "integer"},
+		"roadmaps":   arrayOf(createResult),
+	})
+	formUpload := object(map[string]*Schema{
+		"files": {
+			Type:        "array",
+			Items:       &Schema{Type: "string", Format: "binary"},
+			Description: "YAML files, named after their parts; fields without a file are ignored",
+		},
+	})
+
+	id := pathParam("id", "Roadmap ID", &Schema{Type: "string"})
+	itemID := pathParam("itemID", "Item ID within the roadmap", &Schema{Type: "string"})
+	revisionNumber := pathParam("n", "Revision number", &Schema{Type: "integer", Format: "int32"})
+	webhookID := pathParam("id", "Webhook ID", &Schema{Type: "string"})
+	serviceLineName := pathParam("name", "Service line name", &Schema{Type: "string"})
+	ifMatch := headerParam("If-Match", "ETag from a previous GET; required unless the server runs with REQUIRE_IF_MATCH=false")
+	ifModifiedSince := headerParam("If-Modified-Since", "Return 304 if nothing changed since this HTTP date; ignored when If-None-Match is sent")
+	author := headerParam("X-Author", "Recorded as the author of the resulting revision; ignored when authentication is enabled")
+	commentAuthor := headerParam("X-Author", "Recorded as the author of the comment; ignored when authentication is enabled")
+	fileName := headerParam("X-File-Name", "Original file name of the upload")
+	withArchived := queryParam("include_archived", "Also return archived items, and for listings archived roadmaps", enum("true"))
+	onDuplicate := queryParam("on_duplicate", "What to do when the upload matches a stored roadmap", enum("return", "reject", "allow"))
+	renderPage := queryParam("page", "Page size (default a4)", enum("a4", "a3", "letter", "legal", "tabloid"))
+	renderOrientation := queryParam("orientation", "Page orientation (default landscape)", enum("landscape", "portrait"))
+
+	paths := map[string]PathItem{
+		"/api/v1/roadmaps": {
+			"get": newOperation("listRoadmaps", tagRoadmaps, "List roadmaps").
+				describe("Returns full roadmaps, or summaries with view=summary. Filters are combined with AND. custom.<key>=<value> matches roadmaps with an item whose custom field has that value; several custom parameters must all match the same item.").
+				param(queryParam("view", "Response shape", enum("full", "summary"))).
+				param(queryParam("service_line", "Exact service line", &Schema{Type: "string"})).
+				param(queryParam("owner", "Roadmaps owned by, or with an item assigned to, this owner or team", &Schema{Type: "string"})).
+				param(queryParam("status", "Roadmaps with at least one item in this status", componentRef("RoadmapStatus"))).
+				param(queryParam("confidence", "Roadmaps with at least one item of this confidence", componentRef("Confidence"))).
+				param(queryParam("type", "Roadmaps with at least one item of this type", &Schema{Type: "string", Enum: models.ItemTypes})).
+				param(queryParam("health", "Roadmaps with this health now", componentRef("Health"))).
+				param(withArchived).
+				param(queryParam("from", "Roadmaps with an item ending after this date (YYYY-Qn, YYYY-MM-DD, YYYY-MM, or YYYY)", &Schema{Type: "string"})).
+				param(queryParam("to", "Roadmaps with an item starting before the end of this date", &Schema{Type: "string"})).
+				param(queryParam("portfolio", "Exact portfolio", &Schema{Type: "string"})).
+				param(queryParam("category", "Exact category", &Schema{Type: "string"})).
+				param(queryParam("tag", "Roadmaps with this tag, or with at least one item with it", &Schema{Type: "string"})).
+				param(queryParam("sort", "Sort field", enum(storage.SortByName, storage.SortByCreatedAt, storage.SortByUpdatedAt, storage.SortByServiceLine))).
+				param(queryParam("order", "Sort direction", enum("asc", "desc"))).
+				param(headerParam("If-None-Match", "Return 304 if the listing still has this ETag, which changes with the query, the date, and every roadmap")).
+				json("200", "Full roadmaps, or summaries when view=summary", &Schema{OneOf: []*Schema{arrayOf(listed), arrayOf(summary)}}).
+				withETag("200").
+				respond("304", "Not modified", "", nil).
+				fail("400", "Invalid view, filter, or sort").Operation,
+			"post": newOperation("createRoadmap", tagRoadmaps, "Upload a roadmap").
+				describe("The roadmap may also be sent as JSON of the same schema, or as Markdown with text/markdown: a level-1 heading naming the roadmap and a level-2 heading per item, each followed by a fenced yaml block of its other fields, with the text under a heading as the roadmap's notes or the item's description. A multipart/form-data upload may carry several files, each with one or more documents, and is answered like a batch upload.").
+				param(onDuplicate).param(fileName).param(author).
+				body("application/x-yaml", roadmapFile, "A single roadmap document").
+				alsoBody("application/json", roadmapFile).
+				alsoBody("text/markdown", &Schema{Type: "string", Description: "A roadmap written as Markdown"}).
+				alsoBody("multipart/form-data", formUpload).
+				json("201", "Roadmap created, or the batch result for a multipart upload", &Schema{OneOf: []*Schema{createResult, batchResult}}).
+				json("200", "Identical roadmap already stored (on_duplicate=return)", createResult).
+				fail("400", "Invalid roadmap").
+				fail("409", "Identical roadmap already stored (on_duplicate=reject)").
+				fail("413", "Request body exceeds the upload size limit").Operation,
+			"delete": newOperation("deleteRoadmaps", tagRoadmaps, "Delete several roadmaps").
+				describe("Deletes the listed roadmaps, or every roadmap in service_line. Soft delete and force apply as for single deletes, except that items of the roadmaps being deleted don't count as dependents; If-Match is not checked.").
+				param(queryParam("service_line", "Delete every roadmap in this service line instead of listing ids", &Schema{Type: "string"})).
+				param(queryParam("permanent", "Delete immediately even when soft delete is enabled", enum("true"))).
+				param(queryParam("force", "Delete roadmaps even though items in other roadmaps depend on them", enum("true"))).
+				optionalBody("application/json", object(map[string]*Schema{
+					"ids": arrayOf(&Schema{Type: "string"}),
+				}), "IDs of the roadmaps to delete").
+				json("200", "Outcome for each roadmap", object(map[string]*Schema{
+					"deleted": {Type: "integer"},
+					"failed":  {Type: "integer"},
+					"results": arrayOf(object(map[string]*Schema{
+						"id":      {Type: "string"},
+						"deleted": {Type: "boolean"},
+						"error":   {Type: "string", Description: "Why the roadmap was not deleted"},
+						"dependents": {Type: "array", Description: "Items in other roadmaps that kept the roadmap from being deleted", Items: object(map[string]*Schema{
+							"RoadmapID":   {Type: "string"},
+							"RoadmapName": {Type: "string"},
+							"ItemID":      {Type: "string"},
+							"ItemName":    {Type: "string"},
+							"DependsOn":   {Type: "string"},
+						})},
+					})),
+				})).
+				fail("400", "Invalid body, or neither or both of ids and service_line given").
+				fail("413", "Request body exceeds the upload size limit").Operation,
+		},
+		"/api/v1/roadmaps/batch": {
+			"post": newOperation("createRoadmaps", tagRoadmaps, "Upload several roadmaps").
+				describe("Accepts multiple YAML documents separated by ---, a JSON array of documents, or a multipart/form-data upload of one or more YAML files.").
+				param(onDuplicate).param(fileName).param(author).
+				body("application/x-yaml", &Schema{Type: "string", Description: "Roadmap documents separated by ---"}, "One or more roadmap documents").
+				alsoBody("application/json", arrayOf(roadmapFile)).
+				alsoBody("multipart/form-data", formUpload).
+				json("201", "Roadmaps created", batchResult).
+				fail("400", "Invalid roadmap file").
+				fail("409", "A roadmap matches a stored or earlier roadmap (on_duplicate=reject)").
+				fail("413", "Request body exceeds the upload size limit").Operation,
+		},
+		"/api/v1/roadmaps/merge": {
+			"post": newOperation("mergeRoadmaps", tagRoadmaps, "Merge two roadmaps into a new one").
+				describe("The first roadmap supplies the service line, owner, and notes; the originals are kept. Dependencies between the two roadmaps become internal dependencies.").
+				param(author).
+				body("application/json", object(map[string]*Schema{
+					"ids":          {Type: "array", Items: &Schema{Type: "string"}, Description: "The two roadmaps to merge"},
+					"name":         {Type: "string", Description: "Name of the merged roadmap"},
+					"service_line": {Type: "string", Description: "Service line of the merged roadmap (default: the first roadmap's)"},
+					"on_conflict":  {Type: "string", Enum: []string{models.MergeConflictFail, models.MergeConflictRename, models.MergeConflictSkip}, Description: "What to do with an item ID used by both roadmaps: fail, give the second roadmap's item a numbered ID, or keep only the first's"},
+				}), "Roadmaps to merge and the name of the result").
+				json("201", "The merged roadmap", &Schema{AllOf: []*Schema{stored, object(map[string]*Schema{
+					"renamed_items": {Type: "object", AdditionalProperties: &Schema{Type: "string"}, Description: "New IDs of renamed items of the second roadmap, keyed by old ID"},
+				})}}).withETag("201").
+				fail("400", "Invalid request or resulting roadmap").
+				fail("404", "Roadmap not found").
+				fail("409", "An item ID is used by both roadmaps (on_conflict=fail)").Operation,
+		},
+		"/api/v1/roadmaps/validate": {
+			"post": newOperation("validateRoadmaps", tagRoadmaps, "Validate roadmaps without storing them").
+				describe("Dry run for CI: checks one or more YAML documents, including external dependencies against stored roadmaps and the rest of the payload. Unresolved dependencies and content identical to a stored roadmap are warnings.").
+				body("application/x-yaml", &Schema{Type: "string", Description: "Roadmap documents separated by ---"}, "One or more roadmap documents").
+				json("200", "All documents are valid; warnings may still be reported", validationReport).
+				json("422", "At least one document has errors", validationReport).
+				fail("413", "Request body exceeds the upload size limit").Operation,
+		},
+		"/api/v1/roadmaps/export": {
+			"get": newOperation("exportRoadmaps", tagRoadmaps, "Export every roadmap").
+				describe("format=csv has a row for every item of every roadmap, with the columns of a single roadmap's CSV export; archived items are left out unless include_archived=true.").
+				param(queryParam("format", "Archive format", enum("zip", "yaml", "csv"))).param(withArchived).
+				respond("200", "Zip with manifest.json, roadmaps/{id}.yaml, and metadata/{id}.json, multi-document YAML when format=yaml, or CSV when format=csv",
+					"application/zip", &Schema{Type: "string", Format: "binary"}).
+				also("200", "application/x-yaml", &Schema{Type: "string"}).
+				also("200", "text/csv", &Schema{Type: "string"}).
+				fail("400", "Invalid format").Operation,
+		},
+		"/api/v1/roadmaps/calendar.ics": {
+			"get": newOperation("getRoadmapsCalendar", tagRoadmaps, "Subscribe to every roadmap as a calendar").
+				describe("An iCalendar feed with an all-day event for each item, from the first to the last day it covers, and for each milestone, on the last day of its date. With owner, only the roadmaps owned by that owner and the items assigned to them or their team are included; a roadmap they own contributes its milestones too. Archived roadmaps and items are left out unless include_archived=true.").
+				param(queryParam("owner", "Roadmap owner, or item owner or team", &Schema{Type: "string"})).param(withArchived).
+				respond("200", "The calendar", "text/calendar", &Schema{Type: "string"}).Operation,
+		},
+		"/api/v1/roadmaps/{id}": {
+			"get": newOperation("getRoadmap", tagRoadmaps, "Get a roadmap").
+				param(id).param(withArchived).
+				describe("Archived items are left out unless include_archived=true. health and item_health are worked out from the item statuses, dates, and dependencies at the time of the request: an item that isn't completed is late once its end has passed, and at-risk while blocked, if still planned after its start, or if an item it depends on is blocked or late and not archived. The roadmap takes the worst health of its items that aren't archived. effective_status is also worked out at the time of the request: an item that isn't completed is effectively blocked when an internal or external dependency isn't completed and its end has passed, with the dependencies holding it up and the chain from the first of them to the root cause. blocked_by_external lists the items that aren't completed and wait for external dependencies that aren't completed either, counted by criticality. As these depend on other roadmaps and the date, the roadmap is always sent in full; the ETag is its revision, for If-Match.").
+				json("200", "The roadmap", got).withETag("200").
+				fail("404", "Roadmap not found").Operation,
+			"patch": newOperation("patchRoadmap", tagRoadmaps, "Update a roadmap").
+				describe("Applies a JSON merge patch. items may be an object keyed by item ID to edit single items; null removes an item.").
+				param(id).param(ifMatch).param(author).
+				body("application/merge-patch+json", &Schema{Type: "object"}, "JSON merge patch of the roadmap").
+				json("200", "The updated roadmap", stored).withETag("200").
+				fail("400", "Invalid patch or resulting roadmap").
+				fail("404", "Roadmap not found").
+				fail("412", "If-Match does not match the current revision").
+				fail("413", "Request body exceeds the upload size limit").
+				fail("428", "If-Match header is required").Operation,
+			"delete": newOperation("deleteRoadmap", tagRoadmaps, "Delete a roadmap").
+				describe("Moves the roadmap to the trash when soft delete is enabled. Refused while items in other roadmaps depend on it, unless force=true.").
+				param(id).param(ifMatch).
+				param(queryParam("permanent", "Delete immediately even when soft delete is enabled", enum("true"))).
+				param(queryParam("force", "Delete even though items in other roadmaps depend on it", enum("true"))).
+				respond("204", "Deleted", "", nil).
+				fail("404", "Roadmap not found").
+				fail("409", "Items in other roadmaps depend on the roadmap; details.dependents lists them").
+				fail("412", "If-Match does not match the current revision").
+				fail("428", "If-Match header is required").Operation,
+		},
+		"/api/v1/roadmaps/{id}/items": {
+			"get": newOperation("listItems", tagRoadmaps, "List the items of a roadmap").
+				param(id).param(withArchived).
+				json("200", "The roadmap's items", arrayOf(item)).withETag("200").
+				fail("404", "Roadmap not found").Operation,
+			"post": newOperation("createItem", tagRoadmaps, "Add an item to a roadmap").
+				describe("The whole roadmap is validated with the new item before it is stored as a new revision.").
+				param(id).param(ifMatch).param(author).
+				body("application/json", item, "The new item").
+				json("201", "The added item", item).withETag("201").
+				fail("400", "Invalid item or resulting roadmap").
+				fail("404", "Roadmap not found").
+				fail("409", "An item with this ID already exists").
+				fail("412", "If-Match does not match the current revision").
+				fail("428", "If-Match header is required").Operation,
+		},
+		"/api/v1/roadmaps/{id}/items/{itemID}": {
+			"get": newOperation("getItem", tagRoadmaps, "Get one item of a roadmap").
+				param(id).param(itemID).
+				json("200", "The item", item).withETag("200").
+				fail("404", "Roadmap or item not found").Operation,
+			"put": newOperation("replaceItem", tagRoadmaps, "Replace one item of a roadmap").
+				describe("The body may leave out the item ID but must not change it. The whole roadmap is validated before it is stored as a new revision.").
+				param(id).param(itemID).param(ifMatch).param(author).
+				body("application/json", item, "The replacement item").
+				json("200", "The replaced item", item).withETag("200").
+				fail("400", "Invalid item or resulting roadmap").
+				fail("404", "Roadmap or item not found").
+				fail("412", "If-Match does not match the current revision").
+				fail("428", "If-Match header is required").Operation,
+			"delete": newOperation("deleteItem", tagRoadmaps, "Remove one item from a roadmap").
+				describe("Fails while other items of the roadmap still depend on the item.").
+				param(id).param(itemID).param(ifMatch).param(author).
+				respond("204", "Removed", "", nil).withETag("204").
+				fail("400", "Resulting roadmap is invalid").
+				fail("404", "Roadmap or item not found").
+				fail("412", "If-Match does not match the current revision").
+				fail("428", "If-Match header is required").Operation,
+		},
+		"/api/v1/roadmaps/{id}/items/{itemID}/status": {
+			"patch": newOperation("setItemStatus", tagRoadmaps, "Change the status of an item").
+				describe("Records the time of the change in status_changed_at and adds it to status_history. Setting the current status again stores nothing.").
+				param(id).param(itemID).param(ifMatch).param(author).
+				body("application/json", object(map[string]*Schema{"status": componentRef("RoadmapStatus")}), "The new status").
+				json("200", "The updated item", item).withETag("200").
+				fail("400", "Invalid status").
+				fail("404", "Roadmap or item not found").
+				fail("412", "If-Match does not match the current revision").
+				fail("428", "If-Match header is required").Operation,
+		},
+		"/api/v1/roadmaps/{id}/items/{itemID}/dependencies": {
+			"get": newOperation("getItemDependencies", tagDependencies, "Get the items an item depends on").
+				describe("A tree of the item's internal and external dependencies. With transitive=true their dependencies are followed in turn: each item's dependencies are listed once, where it first appears (later appearances have repeat set), items that loop back have cycle set, and items at the depth limit with dependencies of their own have truncated set. External dependencies that don't resolve are left out.").
+				param(id).param(itemID).
+				param(queryParam("transitive", "Follow dependencies of dependencies", enum("true"))).
+				param(queryParam("depth", "Levels to follow with transitive=true, 1-50 (default 10)", &Schema{Type: "integer"})).
+				json("200", "Dependency tree", g.ref(models.UpstreamTree{})).
+				fail("400", "Invalid depth").
+				fail("404", "Roadmap or item not found").Operation,
+		},
+		"/api/v1/roadmaps/{id}/items/{itemID}/external-dependencies": {
+			"post": newOperation("addExternalDependency", tagDependencies, "Add an external dependency to an item").
+				describe("The dependency is checked against the stored roadmaps first; one that doesn't resolve is refused with 422 and its validation in details, with suggestions for near matches. The validation is returned with the updated item, so a date_conflict shows at once.").
+				param(id).param(itemID).param(ifMatch).param(author).
+				body("application/json", g.ref(models.ExternalDependency{}), "The dependency").
+				json("201", "The updated item and the dependency's validation", object(map[string]*Schema{
+					"item":       item,
+					"validation": g.ref(models.ExternalDependencyValidation{}),
+				})).withETag("201").
+				fail("400", "Invalid dependency").
+				fail("404", "Roadmap or item not found").
+				fail("409", "The item already has this dependency").
+				fail("412", "If-Match does not match the current revision").
+				fail("422", "The dependency doesn't resolve").
+				fail("428", "If-Match header is required").Operation,
+			"delete": newOperation("removeExternalDependency", tagDependencies, "Remove an external dependency from an item").
+				describe("Removes the item's dependencies on the given target of the roadmap named by roadmap or roadmap_id.").
+				param(id).param(itemID).param(ifMatch).param(author).
+				param(queryParam("roadmap", "Name of the roadmap depended on", &Schema{Type: "string"})).
+				param(queryParam("roadmap_id", "ID of the roadmap depended on", &Schema{Type: "string"})).
+				param(queryParam("item", "Item depended on", &Schema{Type: "string"})).
+				param(queryParam("item_name", "Name of the item depended on, as given in the dependency", &Schema{Type: "string"})).
+				param(queryParam("milestone", "Milestone depended on", &Schema{Type: "string"})).
+				param(queryParam("completion", "The whole roadmap is depended on", enum("true"))).
+				respond("204", "Removed", "", nil).withETag("204").
+				fail("400", "Invalid dependency").
+				fail("404", "Roadmap, item, or dependency not found").
+				fail("412", "If-Match does not match the current revision").
+				fail("428", "If-Match header is required").Operation,
+		},
+		"/api/v1/roadmaps/{id}/items/{itemID}/history": {
+			"get": newOperation("getItemHistory", tagRoadmaps, "Get the status history of an item").
+				describe("Status changes made through the API, oldest first, each with who made it. The first entry of an item added through the API has no from. cycle_time_days is the time from first going in progress to last being completed, for completed items whose history has both.").
+				param(id).param(itemID).
+				json("200", "Status history", object(map[string]*Schema{
+					"item_id":         {Type: "string"},
+					"status":          componentRef("RoadmapStatus"),
+					"history":         arrayOf(g.ref(models.StatusChange{})),
+					"cycle_time_days": {Type: "number"},
+				})).withETag("200").
+				fail("404", "Roadmap or item not found").Operation,
+		},
+		"/api/v1/roadmaps/{id}/comments": {
+			"get": newOperation("listComments", tagRoadmaps, "List comments on a roadmap").
+				describe("Comments on the roadmap and on each of its items, oldest first. Comments on an item have item_id set.").
+				param(id).
+				json("200", "Comments", arrayOf(comment)).
+				fail("404", "Roadmap not found").Operation,
+			"post": newOperation("addComment", tagRoadmaps, "Comment on a roadmap").
+				describe("Comments are stored alongside the roadmap, not in its content, so adding one doesn't create a revision.").
+				param(id).param(commentAuthor).
+				body("application/json", commentBody, "The comment").
+				json("201", "The stored comment", comment).
+				fail("400", "Missing or too long body").
+				fail("404", "Roadmap not found").
+				fail("413", "Request body too large").Operation,
+		},
+		"/api/v1/roadmaps/{id}/items/{itemID}/comments": {
+			"get": newOperation("listItemComments", tagRoadmaps, "List comments on an item").
+				param(id).param(itemID).
+				json("200", "Comments, oldest first", arrayOf(comment)).
+				fail("404", "Roadmap or item not found").Operation,
+			"post": newOperation("addItemComment", tagRoadmaps, "Comment on an item").
+				param(id).param(itemID).param(commentAuthor).
+				body("application/json", commentBody, "The comment").
+				json("201", "The stored comment", comment).
+				fail("400", "Missing or too long body").
+				fail("404", "Roadmap or item not found").
+				fail("413", "Request body too large").Operation,
+		},
+		"/api/v1/roadmaps/{id}/rename": {
+			"post": newOperation("renameRoadmap", tagRoadmaps, "Rename a roadmap").
+				describe("External dependencies that refer to the roadmap by name are rewritten in every other roadmap. Each change is a new revision; if any write fails, the ones already made are reverted.").
+				param(id).param(ifMatch).param(author).
+				body("application/json", object(map[string]*Schema{"name": {Type: "string"}}), "The new name").
+				json("200", "The renamed roadmap and the IDs of rewritten dependents", g.ref(storage.RenameResult{})).withETag("200").
+				fail("400", "Missing name").
+				fail("404", "Roadmap not found").
+				fail("409", "Another roadmap already has the name").
+				fail("412", "If-Match does not match, or a roadmap changed during the rename").
+				fail("428", "If-Match header is required").Operation,
+		},
+		"/api/v1/roadmaps/{id}/clone": {
+			"post": newOperation("cloneRoadmap", tagRoadmaps, "Copy a roadmap").
+				describe("Stores a copy under a new name and ID. shift_months moves every item date; quarters move only by multiples of 3 months and years by multiples of 12.").
+				param(id).param(author).
+				optionalBody("application/json", object(map[string]*Schema{
+					"name":         {Type: "string", Description: "Name of the copy (default: original name with \" (copy)\")"},
+					"shift_months": {Type: "integer", Description: "Months to move every item's start and end by"},
+				}), "Name and date offset of the copy").
+				json("201", "The new roadmap", stored).withETag("201").
+				fail("400", "Invalid body or date shift").
+				fail("404", "Roadmap not found").Operation,
+		},
+		"/api/v1/roadmaps/{id}/yaml": {
+			"get": newOperation("getRoadmapYAML", tagRoadmaps, "Download a roadmap as YAML").
+				describe("Returns the stored YAML document as an attachment named {id}.yaml.").
+				param(id).param(headerParam("If-None-Match", "Return 304 if the roadmap still has this ETag")).param(ifModifiedSince).
+				respond("200", "The roadmap YAML", "application/x-yaml", &Schema{Type: "string"}).withETag("200").withLastModified("200").
+				respond("304", "Not modified", "", nil).
+				fail("404", "Roadmap not found").Operation,
+		},
+		"/api/v1/roadmaps/{id}/restore": {
+			"post": newOperation("restoreRoadmap", tagTrash, "Restore a roadmap from the trash").
+				param(id).
+				json("200", "The restored roadmap", stored).
+				fail("404", "Roadmap not found in trash").Operation,
+		},
+		"/api/v1/roadmaps/{id}/revisions": {
+			"get": newOperation("listRevisions", tagRevisions, "List revisions").
+				param(id).
+				json("200", "Revision history without content", object(map[string]*Schema{
+					"roadmap_id": {Type: "string"},
+					"count":      {Type: "integer"},
+					"revisions": arrayOf(&Schema{Type: "object", Required: []string{"revision", "created_at"}, Properties: map[string]*Schema{
+						"revision":   {Type: "integer"},
+						"created_at": {Type: "string", Format: "date-time"},
+						"author":     {Type: "string"},
+					}}),
+				})).
+				fail("404", "Roadmap not found").Operation,
+		},
+		"/api/v1/roadmaps/{id}/revisions/{n}": {
+			"get": newOperation("getRevision", tagRevisions, "Get a revision").
+				param(id).param(revisionNumber).
+				json("200", "The revision", revision).
+				fail("404", "Revision not found").Operation,
+		},
+		"/api/v1/roadmaps/{id}/revisions/{n}/restore": {
+			"post": newOperation("restoreRevision", tagRevisions, "Restore a revision").
+				describe("Stores the revision's content as a new revision.").
+				param(id).param(revisionNumber).param(ifMatch).param(author).
+				json("200", "The updated roadmap", stored).withETag("200").
+				fail("404", "Roadmap or revision not found").
+				fail("412", "If-Match does not match the current revision").
+				fail("428", "If-Match header is required").Operation,
+		},
+		"/api/v1/roadmaps/{id}/dependencies": {
+			"get": newOperation("getRoadmapDependencies", tagDependencies, "List a roadmap's external dependencies").
+				param(id).
+				json("200", "Items with external dependencies", object(map[string]*Schema{
+					"roadmap_id":   {Type: "string"},
+					"roadmap_name": {Type: "string"},
+					"dependencies": arrayOf(object(map[string]*Schema{
+						"item_id":               {Type: "string"},
+						"item_name":             {Type: "string"},
+						"external_dependencies": arrayOf(extDep),
+					})),
+				})).
+				fail("404", "Roadmap not found").Operation,
+		},
+		"/api/v1/roadmaps/{id}/slippage": {
+			"get": newOperation("getSlippage", tagRoadmaps, "Report schedule slippage").
+				describe("Days each item's start and end have moved from its baseline, the dates it was first stored with; positive is later. Items without a baseline are left out.").
+				param(id).
+				param(queryParam("slipped", "Only items that now end later than their baseline", &Schema{Type: "boolean"})).
+				json("200", "Slippage per item", g.ref(models.SlippageReport{})).withETag("200").
+				fail("404", "Roadmap not found").Operation,
+		},
+		"/api/v1/roadmaps/{id}/order": {
+			"get": newOperation("getOrder", tagDependencies, "Order a roadmap's items by their dependencies").
+				describe("Every item comes after the items it depends on. Groups hold items that can run in parallel: the first depends on nothing, and each later group only on earlier ones.").
+				param(id).param(withArchived).
+				json("200", "Items in dependency order", g.ref(models.ItemOrder{})).withETag("200").
+				fail("404", "Roadmap not found").
+				fail("409", "The dependencies form a cycle; details lists the cycles").Operation,
+		},
+		"/api/v1/roadmaps/{id}/critical-path": {
+			"get": newOperation("getCriticalPath", tagDependencies, "Find a roadmap's critical path and item slack").
+				describe("Dependencies are treated as finish-to-start and items keep their durations. Slack is how many days an item's end can move before it delays a dependent item or the roadmap's end; items without slack are critical.").
+				param(id).param(withArchived).
+				json("200", "Critical path and slack per item", g.ref(models.CriticalPath{})).withETag("200").
+				fail("404", "Roadmap not found").
+				fail("409", "The dependencies form a cycle; details lists the cycles").Operation,
+		},
+		"/api/v1/roadmaps/{id}/export.csv": {
+			"get": newOperation("getRoadmapCSV", tagRoadmaps, "Export a roadmap's items as CSV").
+				describe("One row per item under a header row: roadmap_id, roadmap, service_line, item_id, item, status, start, end, start_date, end_date, team, owner, milestone, dependencies, external_dependencies. start_date and end_date are the days the item covers; dependencies lists the item IDs depended on and external_dependencies each external dependency as roadmap:target with its criticality in parentheses, both separated by semicolons. Cells starting with =, +, -, or @ are prefixed with ' so spreadsheets don't run them as formulas.").
+				param(id).param(withArchived).
+				respond("200", "The items as CSV, as an attachment named {id}.csv", "text/csv", &Schema{Type: "string"}).withETag("200").
+				fail("404", "Roadmap not found").Operation,
+		},
+		"/api/v1/roadmaps/{id}/export.xlsx": {
+			"get": newOperation("getRoadmapXLSX", tagRoadmaps, "Export a roadmap as an Excel workbook").
+				describe("The Items sheet has the columns of the CSV export. The Timeline sheet has a row per item and a column per month from the earliest start to the latest end, with the months each item runs in shaded by its status; items whose dates don't parse are left out of it.").
+				param(id).param(withArchived).
+				respond("200", "The workbook, as an attachment named {id}.xlsx", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", &Schema{Type: "string", Format: "binary"}).withETag("200").
+				fail("404", "Roadmap not found").Operation,
+		},
+		"/api/v1/roadmaps/{id}/export.md": {
+			"get": newOperation("getRoadmapMarkdown", tagRoadmaps, "Export a roadmap as Markdown").
+				describe("A heading with the service line, owner, and notes, then a section per status (in progress, blocked, planned, completed) or per fiscal quarter in which items start, with the unscheduled last, then the milestones. Each item's internal and external dependencies are called out in a quote under it.").
+				param(id).param(withArchived).
+				param(queryParam("group_by", "How to group the items", enum(models.MarkdownByStatus, models.MarkdownByQuarter))).
+				respond("200", "The roadmap in Markdown", "text/markdown", &Schema{Type: "string"}).withETag("200").
+				fail("400", "Invalid group_by").
+				fail("404", "Roadmap not found").Operation,
+		},
+		"/api/v1/roadmaps/{id}/calendar.ics": {
+			"get": newOperation("getRoadmapCalendar", tagRoadmaps, "Get a roadmap as a calendar").
+				describe("The roadmap's items and milestones as all-day iCalendar events, as in the all-roadmaps feed, filtered by owner in the same way.").
+				param(id).param(withArchived).
+				param(queryParam("owner", "Item owner or team", &Schema{Type: "string"})).
+				respond("200", "The calendar", "text/calendar", &Schema{Type: "string"}).withETag("200").
+				fail("404", "Roadmap not found").Operation,
+		},
+		"/api/v1/roadmaps/{id}/render.png": {
+			"get": newOperation("renderRoadmapPNG", tagRoadmaps, "Render a roadmap as a PNG image").
+				describe("A timeline for slides to embed by URL: a bar per item from its start to its end, colored by status, a diamond per milestone on the last day of its date, and a red line on today. The image is as wide as the page at 144 dpi, and taller than it if the items need more room.").
+				param(id).param(withArchived).param(renderPage).param(renderOrientation).
+				respond("200", "The timeline", "image/png", &Schema{Type: "string", Format: "binary"}).withETag("200").
+				fail("400", "Invalid page or orientation").
+				fail("404", "Roadmap not found").Operation,
+		},
+		"/api/v1/roadmaps/{id}/render.pdf": {
+			"get": newOperation("renderRoadmapPDF", tagRoadmaps, "Render a roadmap as a PDF document").
+				describe("The timeline of render.png on pages of the given size, as many as the items need, each headed by the roadmap's name and the months.").
+				param(id).param(withArchived).param(renderPage).param(renderOrientation).
+				respond("200", "The timeline", "application/pdf", &Schema{Type: "string", Format: "binary"}).withETag("200").
+				fail("400", "Invalid page or orientation").
+				fail("404", "Roadmap not found").Operation,
+		},
+		"/api/v1/roadmaps/{id}/mermaid": {
+			"get": newOperation("getRoadmapMermaid", tagRoadmaps, "Get a roadmap as a Mermaid gantt chart").
+				describe("A section per team, items without a team first under the roadmap's name, then the milestones. Completed items are done, items in progress active, and blocked items critical.").
+				param(id).param(withArchived).
+				respond("200", "The gantt chart in Mermaid syntax", "text/plain", &Schema{Type: "string"}).withETag("200").
+				fail("404", "Roadmap not found").Operation,
+		},
+		"/api/v1/roadmaps/{id}/graph.dot": {
+			"get": newOperation("getRoadmapGraphDOT", tagDependencies, "Get a roadmap's dependency graph as Graphviz DOT").
+				describe("The roadmap's items and dependencies, with the items of other roadmaps joined to it by a dependency in their own clusters with a dashed outline.").
+				param(id).param(withArchived).
+				respond("200", "The graph in DOT syntax", "text/vnd.graphviz", &Schema{Type: "string"}).
+				fail("404", "Roadmap not found").Operation,
+		},
+		"/api/v1/roadmaps/{id}/dependents": {
+			"get": newOperation("getRoadmapDependents", tagDependencies, "List items in other roadmaps that depend on a roadmap").
+				param(id).
+				json("200", "Dependent items", object(map[string]*Schema{
+					"roadmap_id":   {Type: "string"},
+					"roadmap_name": {Type: "string"},
+					"count":        {Type: "integer"},
+					"dependents": arrayOf(object(map[string]*Schema{
+						"RoadmapID":   {Type: "string"},
+						"RoadmapName": {Type: "string"},
+						"ItemID":      {Type: "string"},
+						"ItemName":    {Type: "string"},
+						"DependsOn":   {Type: "string", Description: "Item ID in this roadmap"},
+					})),
+				})).
+				fail("404", "Roadmap not found").Operation,
+		},
+		"/api/v1/dependencies/validate": {
+			"get": newOperation("validateDependencies", tagDependencies, "Validate every external dependency").
+				describe("Dependencies that resolve but end after the item depending on them starts have date_conflict set, with a severity from their criticality: error for critical and high, warning for medium or none, info for low. Internal dependencies are checked for the same overlap. When a lead time policy is configured, external dependencies that end fewer than the policy's days for their criticality before the item starts are listed as warnings in lead_time_violations. The filters narrow everything to the dependencies declared by the roadmaps and of the criticality given, which still resolve against every roadmap, so a CI job can gate on critical breakage alone.").
+				param(queryParam("roadmap_id", "Only dependencies declared by this roadmap", &Schema{Type: "string"})).
+				param(queryParam("service_line", "Only dependencies declared by roadmaps in this service line", &Schema{Type: "string"})).
+				param(queryParam("min_criticality", "Only external dependencies at least this critical; internal dependencies and those without a criticality are left out", enum("low", "medium", "high", "critical"))).
+				param(queryParam("only_invalid", "List only dependencies that don't resolve in results; the counts still cover all of them", enum("true"))).
+				json("200", "Validation results", object(map[string]*Schema{
+					"total":                {Type: "integer"},
+					"valid":                {Type: "integer"},
+					"invalid":              {Type: "integer"},
+					"results":              arrayOf(validation),
+					"date_conflicts":       {Type: "integer", Description: "External and internal dependencies with a date conflict"},
+					"internal_conflicts":   arrayOf(g.ref(models.DependencyDateConflict{})),
+					"lead_times":           {Type: "object", AdditionalProperties: &Schema{Type: "integer"}, Description: "Minimum days between a dependency's end and the dependent item's start, by criticality"},
+					"lead_time_violations": arrayOf(g.ref(models.LeadTimeViolation{})),
+				})).
+				fail("400", "Invalid min_criticality").
+				fail("404", "Roadmap not found").Operation,
+		},
+		"/api/v1/dependencies/cycles": {
+			"get": newOperation("findDependencyCycles", tagDependencies, "Find dependency cycles across roadmaps").
+				describe("Each cycle is a path of roadmap_id:item_id nodes; each item depends on the next and the last on the first.").
+				param(queryParam("cross_roadmap", "Only list cycles that span more than one roadmap", enum("true"))).
+				json("200", "Dependency cycles", object(map[string]*Schema{
+					"total":         {Type: "integer"},
+					"cross_roadmap": {Type: "integer"},
+					"cycles":        arrayOf(g.ref(models.DependencyCycle{})),
+				})).Operation,
+		},
+		"/api/v1/dependencies/graph": {
+			"get": newOperation("getDependencyGraph", tagDependencies, "Get the dependency graph of all roadmaps").
+				describe("Items are nodes with roadmap_id:item_id IDs; every dependency is an edge from the item that depends to the item it depends on. Items outside the requested roadmaps are included, with in_scope false, when an edge joins them to one inside. format=cytoscape returns {\"elements\": {\"nodes\": [{\"data\": node}], \"edges\": [{\"data\": edge}]}} instead.").
+				param(queryParam("service_line", "Only draw roadmaps in this service line", &Schema{Type: "string"})).
+				param(queryParam("roadmaps", "Only draw these roadmaps, as comma-separated IDs", &Schema{Type: "string"})).
+				param(queryParam("format", "Return Cytoscape.js elements", enum("cytoscape"))).
+				param(withArchived).
+				json("200", "Nodes and edges", g.ref(models.DependencyGraph{})).
+				fail("400", "Invalid format").
+				fail("404", "A listed roadmap was not found").Operation,
+		},
+		"/api/v1/dependencies/graph.dot": {
+			"get": newOperation("getDependencyGraphDOT", tagDependencies, "Get the dependency graph as Graphviz DOT").
+				describe("Each roadmap is a cluster, items are filled by status, and external dependencies are bold edges labelled with their criticality. Items outside the requested roadmaps have a dashed outline.").
+				param(queryParam("service_line", "Only draw roadmaps in this service line", &Schema{Type: "string"})).
+				param(queryParam("roadmaps", "Only draw these roadmaps, as comma-separated IDs", &Schema{Type: "string"})).
+				param(withArchived).
+				respond("200", "The graph in DOT syntax", "text/vnd.graphviz", &Schema{Type: "string"}).
+				fail("404", "A listed roadmap was not found").Operation,
+		},
+		"/api/v1/dependencies/mermaid": {
+			"get": newOperation("getDependencyGraphMermaid", tagDependencies, "Get the dependency graph as a Mermaid flowchart").
+				describe("Each roadmap is a subgraph, items are filled by status, and external dependencies are thick links labelled with their criticality. Items outside the requested roadmaps have a dashed outline.").
+				param(queryParam("service_line", "Only draw roadmaps in this service line", &Schema{Type: "string"})).
+				param(queryParam("roadmaps", "Only draw these roadmaps, as comma-separated IDs", &Schema{Type: "string"})).
+				param(withArchived).
+				respond("200", "The flowchart in Mermaid syntax", "text/plain", &Schema{Type: "string"}).
+				fail("404", "A listed roadmap was not found").Operation,
+		},
+		"/api/v1/dependencies/orphans": {
+			"get": newOperation("listOrphanedDependencies", tagDependencies, "List external dependencies that no longer resolve").
+				describe("Usually left behind when the roadmap or item a dependency points at is deleted. Each orphan has suggested fixes: retarget it at a roadmap or item whose ID or name differs only in case, or at the roadmap of that name when the roadmap_id is wrong; restore the roadmap from the trash; or, always last, remove it.").
+				param(queryParam("roadmap_id", "Only list orphans of this roadmap", &Schema{Type: "string"})).
+				json("200", "Orphaned dependencies", object(map[string]*Schema{
+					"total":   {Type: "integer"},
+					"orphans": arrayOf(g.ref(models.OrphanedDependency{})),
+				})).Operation,
+		},
+		"/api/v1/dependencies/orphans/prune": {
+			"post": newOperation("pruneOrphanedDependencies", tagDependencies, "Remove or annotate orphaned dependencies").
+				describe("Each changed roadmap is written as a new revision. With annotate, orphaned dependencies get an orphaned field giving the reason, and those already annotated are left alone. If any write fails, the writes already made are reverted.").
+				body("application/json", object(map[string]*Schema{
+					"action":      {Type: "string", Enum: []string{"remove", "annotate"}},
+					"roadmap_ids": arrayOf(&Schema{Type: "string"}),
+				}), "What to do with the orphans, and optionally which roadmaps to prune").
+				json("200", "What was pruned", g.ref(storage.PruneResult{})).
+				fail("400", "Invalid request").
+				fail("412", "A roadmap was modified during the prune").Operation,
+		},
+		"/api/v1/dependencies/risks": {
+			"get": newOperation("scoreDependencyRisks", tagDependencies, "Score the risk of every external dependency").
+				describe("Scores run from 0 to 100, highest first: 100 times the criticality weight (critical 1, high 0.75, medium or unset 0.5, low 0.25) times a mix of slack between the items (40%), the status of the item depended on (35%), and the health of its roadmap (25%). Dependencies on or of completed items score 0; unresolved ones score 100.").
+				param(queryParam("service_line", "Only score dependencies of roadmaps in this service line", &Schema{Type: "string"})).
+				json("200", "Dependencies by risk", arrayOf(g.ref(models.DependencyRisk{}))).Operation,
+		},
+		"/api/v1/dependencies/summary": {
+			"get": newOperation("summarizeDependencies", tagDependencies, "Roll up the health of external dependencies").
+				describe("Validation results, date conflicts by severity, internal date conflicts, lead time violations, and criticality counts of the external dependencies declared by each roadmap, totalled by service line and overall, to drive a dependencies dashboard. Dependencies that don't resolve are unresolved, or acknowledged once an annotating prune has marked them orphaned. Dependencies resolve against every roadmap.").
+				param(queryParam("service_line", "Only count roadmaps in this service line", &Schema{Type: "string"})).
+				param(withArchived).
+				json("200", "Dependency health", g.ref(models.DependencySummary{})).Operation,
+		},
+		"/api/v1/analysis/impact": {
+			"post": newOperation("analyzeImpact", tagDependencies, "Find the items a slipping item would push back").
+				describe("Items across all roadmaps that would start before a dependency ends if the item ended at new_end; each is pushed back, keeping its duration, which can push back the items that depend on it in turn. Only the delay added by the slip counts. Nothing is changed.").
+				body("application/json", object(map[string]*Schema{
+					"roadmap_id": {Type: "string"},
+					"item_id":    {Type: "string"},
+					"new_end":    {Type: "string", Description: "Hypothetical end of the item, in any item date format"},
+				}), "The item and its hypothetical end").
+				json("200", "Impacted items by roadmap and criticality", g.ref(models.ImpactAnalysis{})).
+				fail("400", "Invalid request").
+				fail("404", "Roadmap or item not found").Operation,
+		},
+		"/api/v1/trash": {
+			"get": newOperation("listTrash", tagTrash, "List soft-deleted roadmaps").
+				json("200", "Roadmaps in the trash", arrayOf(stored)).Operation,
+		},
+		"/api/v1/trash/{id}": {
+			"delete": newOperation("purgeRoadmap", tagTrash, "Permanently delete a roadmap from the trash").
+				param(id).
+				respond("204", "Purged", "", nil).
+				fail("404", "Roadmap not found in trash").Operation,
+		},
+		"/api/v1/search": {
+			"get": newOperation("search", tagSearch, "Search roadmaps and items").
+				describe("Every word of q must appear in the same field; words match as prefixes.").
+				param(&Parameter{Name: "q", In: "query", Required: true, Schema: &Schema{Type: "string"}}).
+				param(queryParam("limit", "Maximum number of results (default 50)", &Schema{Type: "integer"})).
+				param(queryParam("tag", "Only matches in items with this tag", &Schema{Type: "string"})).
+				json("200", "Matches", object(map[string]*Schema{
+					"query":   {Type: "string"},
+					"count":   {Type: "integer"},
+					"results": arrayOf(match),
+				})).
+				fail("400", "Missing q or invalid limit").Operation,
+		},
+		"/api/v1/tags": {
+			"get": newOperation("listTags", tagSearch, "List item tags").
+				describe("Every tag used by an item, with the number of items and roadmaps using it, most used first.").
+				param(queryParam("service_line", "Count only roadmaps in this service line", &Schema{Type: "string"})).
+				param(queryParam("owner", "Count only roadmaps owned by, or with an item assigned to, this owner or team", &Schema{Type: "string"})).
+				json("200", "Tag counts", arrayOf(g.ref(models.TagCount{}))).Operation,
+		},
+		"/api/v1/people": {
+			"get": newOperation("listPeople", tagSearch, "List people").
+				describe("Everyone named as a roadmap owner, stakeholder, or item owner, by name, with how they are involved in each roadmap.").
+				param(queryParam("service_line", "Only roadmaps in this service line", &Schema{Type: "string"})).
+				json("200", "People", arrayOf(g.ref(models.Person{}))).Operation,
+		},
+		"/api/v1/owners": {
+			"get": newOperation("listOwners", tagSearch, "List item owners and teams").
+				describe("Every item owner and team, with the number of items and roadmaps assigned to them, most assigned first.").
+				param(queryParam("service_line", "Count only roadmaps in this service line", &Schema{Type: "string"})).
+				json("200", "Owner and team counts", g.ref(models.Assignments{})).Operation,
+		},
+		"/api/v1/portfolios": {
+			"get": newOperation("listPortfolios", tagReports, "List portfolios").
+				describe("Roadmaps grouped by portfolio, by name with roadmaps without a portfolio last under an empty name. Each group has the service lines and categories of its roadmaps, item and status counts, and the completion of all its items together.").
+				param(queryParam("service_line", "Only roadmaps in this service line", &Schema{Type: "string"})).
+				param(queryParam("category", "Only roadmaps in this category", &Schema{Type: "string"})).
+				param(queryParam("tag", "Only roadmaps with this tag, or with an item with it", &Schema{Type: "string"})).
+				json("200", "Portfolios", arrayOf(g.ref(models.Portfolio{}))).Operation,
+		},
+		"/api/v1/objectives": {
+			"get": newOperation("listObjectives", tagReports, "List objectives").
+				describe("Every objective declared by a roadmap, by ID, with the items across all roadmaps that contribute to it, their status counts and completion, and an aggregate status: completed once every item is, blocked if any item is, in-progress once any item has started, and planned otherwise. Roadmaps that declare an objective with the same ID share it.").
+				param(queryParam("service_line", "Only roadmaps in this service line", &Schema{Type: "string"})).
+				json("200", "Objectives", arrayOf(g.ref(models.ObjectiveSummary{}))).Operation,
+		},
+		"/api/v1/reports/effort": {
+			"get": newOperation("effortReport", tagReports, "Total effort estimates").
+				describe("Item effort in person-weeks per roadmap, service line, and owner, largest first. Days count as 1/5 of a week, months as 52/12 weeks, and t-shirt sizes XS, S, M, L, and XL as 1, 2, 4, 8, and 16 weeks. Items are grouped by owner, then team, then roadmap owner.").
+				param(queryParam("service_line", "Count only roadmaps in this service line", &Schema{Type: "string"})).
+				json("200", "Effort totals", g.ref(models.EffortReport{})).Operation,
+		},
+		"/api/v1/reports/risks": {
+			"get": newOperation("riskReport", tagReports, "List risky items").
+				describe("Items at or above the risk level that are not completed, across all roadmaps, highest risk and earliest start first. Each lists its internal and external dependencies; dependencies that don't resolve have found set to false.").
+				param(queryParam("level", "Lowest risk to include (default high)", componentRef("RiskLevel"))).
+				param(queryParam("service_line", "Only items in roadmaps of this service line", &Schema{Type: "string"})).
+				json("200", "Risky items", arrayOf(g.ref(models.RiskEntry{}))).
+				fail("400", "Invalid level").Operation,
+		},
+		"/api/v1/reports/spend": {
+			"get": newOperation("spendReport", tagReports, "Total budgets and costs").
+				describe("Item budgets and actual costs per roadmap, and per service line and currency, largest actual cost first. Amounts in different currencies are never added together; roadmaps without a currency are totalled under an empty one.").
+				param(queryParam("service_line", "Count only roadmaps in this service line", &Schema{Type: "string"})).
+				json("200", "Spend totals", g.ref(models.SpendReport{})).Operation,
+		},
+		"/api/v1/reports/cycle-time": {
+			"get": newOperation("cycleTimeReport", tagReports, "Summarize cycle times").
+				describe("Average and median days completed items took from first going in progress to being completed, per roadmap and service line, slowest first. Only items whose status history has both changes count.").
+				param(queryParam("service_line", "Count only roadmaps in this service line", &Schema{Type: "string"})).
+				json("200", "Cycle times", g.ref(models.CycleTimeReport{})).Operation,
+		},
+		"/api/v1/reports/capacity": {
+			"get": newOperation("capacityReport", tagReports, "Compare team allocations with capacity").
+				describe("For every team declared by a roadmap, by name, the full-time people its items' allocations take in each calendar month an item overlaps, against the team's capacity for that month. Months where the allocations exceed capacity are flagged. A team declared by several roadmaps is shared, with the capacity of the first.").
+				param(queryParam("service_line", "Count only roadmaps in this service line", &Schema{Type: "string"})).
+				param(queryParam("over_allocated", "Only list over-allocated months", enum("true"))).
+				json("200", "Team loads", arrayOf(g.ref(models.TeamLoad{}))).Operation,
+		},
+		"/api/v1/reports/dependency-matrix": {
+			"get": newOperation("dependencyMatrixReport", tagReports, "Count the dependencies between every pair of roadmaps").
+				describe("An N×N matrix of the roadmaps, ordered by ID: cells[i][j] counts the items of roadmaps[i] with an external dependency on an item of roadmaps[j], split by criticality. Each roadmap also has the totals of its row (depends_on) and its column (depended_on_by). Dependencies that don't resolve, or resolve to a roadmap outside the matrix, aren't counted.").
+				param(queryParam("service_line", "Only include roadmaps in this service line, on both axes", &Schema{Type: "string"})).
+				param(withArchived).
+				json("200", "Dependency matrix", g.ref(models.DependencyMatrix{})).Operation,
+		},
+		"/api/v1/reports/types": {
+			"get": newOperation("typeReport", tagReports, "Break items down by type").
+				describe("The number and share of items of each type per roadmap and service line, by name, to show the mix of feature and maintenance work. Every configured type is listed, then any other types found, then the untyped items under an empty type.").
+				param(queryParam("service_line", "Count only roadmaps in this service line", &Schema{Type: "string"})).
+				json("200", "Type breakdowns", g.ref(models.TypeReport{})).Operation,
+		},
+		"/graphql": {
+			"post": newOperation("graphql", tagGraphQL, "Run a GraphQL query").
+				describe("Roadmaps, items, and external dependencies as a graph. Use introspection for the schema.").
+				body("application/json", &Schema{Type: "object", Required: []string{"query"}, Properties: map[string]*Schema{
+					"query":         {Type: "string"},
+					"operationName": {Type: "string"},
+					"variables":     {Type: "object"},
+				}}, "GraphQL request").
+				json("200", "GraphQL result with data and errors", &Schema{Type: "object", Properties: map[string]*Schema{
+					"data":   {Type: "object", Nullable: true},
+					"errors": arrayOf(&Schema{Type: "object"}),
+				}}).
+				fail("400", "Missing query").Operation,
+		},
+		"/api/v1/import/jira": {
+			"post": newOperation("importJira", tagImport, "Import a roadmap from Jira").
+				describe("Searches the Jira site set by JIRA_URL and stores the issues found as a new roadmap, one item per issue with the issue key as its ID. An item runs through its sprints, or else up to the release date of its last fix version, or else up to its due date; issues with none of these are skipped. Status categories map to planned, in-progress, and completed, and statuses named like Blocked to blocked. Blocks links between imported issues become dependencies. Skipped issues and dropped links are listed in warnings.").
+				param(author).
+				body("application/json", &Schema{Type: "object", Required: []string{"service_line"}, Properties: map[string]*Schema{
+					"project":      {Type: "string", Description: "Key of the project whose epics to import"},
+					"jql":          {Type: "string", Description: "JQL selecting the issues to import instead (default: the project's epics in rank order)"},
+					"name":         {Type: "string", Description: "Name of the roadmap (default: the project key; required with only jql)"},
+					"service_line": {Type: "string", Description: "Service line of the roadmap"},
+				}}, "What to import").
+				json("201", "The imported roadmap", &Schema{AllOf: []*Schema{stored, object(map[string]*Schema{
+					"issues":    {Type: "integer", Description: "Number of issues the search returned"},
+					"truncated": {Type: "boolean", Description: "Whether more issues matched than JIRA_MAX_ISSUES"},
+					"warnings":  {Type: "array", Items: &Schema{Type: "string"}, Description: "Issues skipped and dependencies dropped"},
+				})}}).withETag("201").
+				fail("400", "Invalid request or JQL query").
+				fail("413", "Request body exceeds the upload size limit").
+				fail("422", "The imported roadmap is invalid").
+				fail("502", "Jira could not be searched").
+				fail("503", "Jira import is not configured").Operation,
+		},
+		"/api/v1/service-lines": {
+			"get": newOperation("listServiceLines", tagServiceLines, "List service lines").
+				describe("Registered service lines and any others that roadmaps use, by name, with the roadmaps, item and status counts, and completion of each. Unregistered ones have registered set to false.").
+				json("200", "Service lines", arrayOf(serviceLineSummary)).Operation,
+			"post": newOperation("createServiceLine", tagServiceLines, "Register a service line").
+				describe("With STRICT_SERVICE_LINES enabled, roadmaps can only be stored in registered service lines.").
+				body("application/json", &Schema{Type: "object", Required: []string{"name"}, Properties: map[string]*Schema{
+					"name":        {Type: "string", Description: "At most 100 characters, without slashes"},
+					"description": {Type: "string"},
+				}}, "Service line").
+				json("201", "Service line registered", serviceLine).
+				fail("400", "Invalid name").
+				fail("409", "Already registered").Operation,
+		},
+		"/api/v1/service-lines/{name}": {
+			"get": newOperation("getServiceLine", tagServiceLines, "Get a service line").
+				param(serviceLineName).
+				json("200", "The service line with its stats", serviceLineSummary).
+				fail("404", "Neither registered nor used by a roadmap").Operation,
+			"delete": newOperation("deleteServiceLine", tagServiceLines, "Remove a service line").
+				param(serviceLineName).
+				respond("204", "Removed", "", nil).
+				fail("404", "Service line not registered").
+				fail("409", "Roadmaps still use the service line").Operation,
+		},
+		"/api/v1/service-lines/{name}/rename": {
+			"post": newOperation("renameServiceLine", tagServiceLines, "Rename a service line").
+				describe("Moves every roadmap in the service line to the new name, each as a new revision, and registers the new name. The old name need not be registered, so a misspelled service line can be folded into an existing one. If any roadmap can't be updated, the changes already made are reverted.").
+				param(serviceLineName).param(author).
+				body("application/json", object(map[string]*Schema{"name": {Type: "string"}}), "New name").
+				json("200", "The renamed service line and the IDs of the roadmaps moved", g.ref(servicelines.RenameResult{})).
+				fail("400", "Invalid name").
+				fail("404", "Neither registered nor used by a roadmap").
+				fail("409", "Both names are registered").
+				fail("412", "A roadmap was modified during the rename").Operation,
+		},
+		"/api/v1/webhooks": {
+			"get": newOperation("listWebhooks", tagWebhooks, "List registered webhooks").
+				json("200", "Webhooks, oldest first", arrayOf(webhook)).Operation,
+			"post": newOperation("createWebhook", tagWebhooks, "Register a webhook").
+				describe("Events are POSTed as JSON. When a secret is set, X-Webhook-Signature holds sha256= and the hex HMAC-SHA256 of the X-Webhook-Timestamp value, a dot, and the body.").
+				body("application/json", &Schema{Type: "object", Required: []string{"url"}, Properties: map[string]*Schema{
+					"url":    {Type: "string", Format: "uri"},
+					"secret": {Type: "string", Description: "Key used to sign deliveries"},
+					"events": {Type: "array", Items: enum(webhooks.EventTypes...), Description: "Event types to deliver; empty means all"},
+				}}, "Webhook registration").
+				json("201", "Webhook registered", webhook).
+				fail("400", "Invalid URL or event").Operation,
+		},
+		"/api/v1/webhooks/{id}": {
+			"get": newOperation("getWebhook", tagWebhooks, "Get a webhook").
+				param(webhookID).
+				json("200", "The webhook", webhook).
+				fail("404", "Webhook not found").Operation,
+			"delete": newOperation("deleteWebhook", tagWebhooks, "Delete a webhook").
+				param(webhookID).
+				respond("204", "Deleted", "", nil).
+				fail("404", "Webhook not found").Operation,
+		},
+		"/api/v1/webhooks/{id}/deliveries": {
+			"get": newOperation("listWebhookDeliveries", tagWebhooks, "List recent deliveries of a webhook").
+				describe("Failed attempts are retried with exponential backoff; each attempt is recorded.").
+				param(webhookID).
+				json("200", "Deliveries, newest first", arrayOf(delivery)).
+				fail("404", "Webhook not found").Operation,
+		},
+		"/api/v1/admin/backup": {
+			"post": newOperation("backup", tagAdmin, "Download a backup of every roadmap and its revisions").
+				respond("200", "tar.gz archive", "application/gzip", &Schema{Type: "string", Format: "binary"}).Operation,
+		},
+		"/api/v1/admin/restore": {
+			"post": newOperation("restoreBackup", tagAdmin, "Restore a backup").
+				body("application/gzip", &Schema{Type: "string", Format: "binary"}, "Archive produced by backup").
+				json("200", "Restored roadmaps", object(map[string]*Schema{
+					"backup_created_at": {Type: "string", Format: "date-time"},
+					"restored":          {Type: "integer"},
+					"ids":               arrayOf(&Schema{Type: "string"}),
+				})).
+				fail("400", "Invalid backup").Operation,
+		},
+		"/api/v1/admin/reindex": {
+			"post": newOperation("reindex", tagAdmin, "Sync with YAML files changed outside the API").
+				json("200", "Changes applied", reindex).
+				fail("501", "Not supported by the storage driver").Operation,
+		},
+	}
+
+	return &Document{
+		OpenAPI: "3.0.3",
+		Info: Info{
+			Title:       "Roadmap Visualizer API",
+			Description: "Upload, version, and query service line roadmaps. The unversioned /api/... paths are deprecated aliases of /api/v1/... and answer with a Deprecation header.",
+			Version:     apiVersion,
+		},
+		Paths: paths,
+		Components: Components{
+			Schemas: g.components,
+			SecuritySchemes: map[string]*SecurityScheme{
+				"bearerAuth": {
+					Type:         "http",
+					Scheme:       "bearer",
+					BearerFormat: "JWT",
+					Description:  "OIDC token, required when the server runs with OIDC_ISSUER_URL set",
+				},
+			},
+		},
+		// Authentication is optional and depends on server configuration
+		Security: []map[string][]string{{}, {"bearerAuth": {}}},
+		Tags: []Tag{
+			{Name: tagRoadmaps, Description: "Upload, list, and edit roadmaps"},
+			{Name: tagRevisions, Description: "Revision history"},
+			{Name: tagDependencies, Description: "Cross-roadmap dependencies"},
+			{Name: tagTrash, Description: "Soft-deleted roadmaps"},
+			{Name: tagSearch, Description: "Full-text search"},
+			{Name: tagGraphQL, Description: "Graph queries across roadmaps"},
+			{Name: tagWebhooks, Description: "Outbound event notifications"},
+			{Name: tagAdmin, Description: "Backup, restore, and reindex"},
+			{Name: tagReports, Description: "Rollups across roadmaps for planning"},
+			{Name: tagServiceLines, Description: "Registered service lines"},
+			{Name: tagImport, Description: "Roadmaps imported from other tools"},
+		},
+	}
+}
@@ -0,0 +1,67 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"roadmap-visualizer/internal/models"
+)
+
+// DecodeRoadmapJSON parses a JSON roadmap document read from r, with the same
+// schema as a YAML one, into a Roadmap struct
+func DecodeRoadmapJSON(r io.Reader) (*models.Roadmap, error) {
+	var roadmapFile models.RoadmapFile
+
+	err := json.NewDecoder(r).Decode(&roadmapFile)
+	if err == io.EOF {
+		return nil, fmt.Errorf("failed to parse JSON: document is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+
+	// Normalize tags, expand recurring items, and validate the parsed roadmap
+	roadmapFile.Roadmap.NormalizeTags()
+	if err := roadmapFile.Roadmap.ExpandRecurrences(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	if err := roadmapFile.Roadmap.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	return &roadmapFile.Roadmap, nil
+}
+
+// DecodeMultipleRoadmapsJSON parses a JSON array of roadmap documents read
+// from r into a slice of Roadmap structs
+func DecodeMultipleRoadmapsJSON(r io.Reader) ([]*models.Roadmap, error) {
+	var roadmapFiles []models.RoadmapFile
+
+	err := json.NewDecoder(r).Decode(&roadmapFiles)
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+
+	roadmaps := make([]*models.Roadmap, 0, len(roadmapFiles))
+	for i := range roadmapFiles {
+		roadmap := &roadmapFiles[i].Roadmap
+
+		// Normalize tags, expand recurring items, and validate the parsed roadmap
+		roadmap.NormalizeTags()
+		err := roadmap.ExpandRecurrences()
+		if err == nil {
+			err = roadmap.Validate()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("validation failed for roadmap %d (%s): %w", i+1, roadmap.Name, err)
+		}
+
+		roadmaps = append(roadmaps, roadmap)
+	}
+
+	if len(roadmaps) == 0 {
+		return nil, fmt.Errorf("no roadmaps found in file")
+	}
+
+	return roadmaps, nil
+}
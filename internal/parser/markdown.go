@@ -0,0 +1,184 @@
+package parser
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"roadmap-visualizer/internal/models"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// markdownSection is the roadmap heading or an item heading with what
+// follows it up to the next one
+type markdownSection struct {
+	title string
+	line  int
+	// metadata is the first fenced yaml block, or nil if there is none
+	metadata []byte
+	text     []string
+}
+
+// DecodeRoadmapMarkdown parses a roadmap written as Markdown, read from r. The
+// document has one level-1 heading naming the roadmap and a level-2 heading
+// for each item. A fenced yaml block (or one without a language) after a
+// heading holds its other fields, as they would be written in YAML; the first
+// one is read as metadata and any others are kept as text. The rest of the
+// text under the roadmap heading becomes its notes, and under an item heading
+// the item's description, unless the metadata sets them.
+//
+//	# Platform 2026
+//
+//	```yaml
+//	service_line: Platform
+//	```
+//
+//	## Migrate to the new cluster
+//
+//	```yaml
+//	id: migrate
+//	start: 2026-Q1
+//	end: 2026-Q2
+//	status: planned
+//	```
+func DecodeRoadmapMarkdown(r io.Reader) (*models.Roadmap, error) {
+	sections, err := splitMarkdown(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Markdown: %w", err)
+	}
+
+	head := sections[0]
+	var roadmap models.Roadmap
+	if err := yaml.Unmarshal(head.metadata, &roadmap); err != nil {
+		return nil, fmt.Errorf("failed to parse Markdown: metadata of roadmap '%s' (line %d): %w", head.title, head.line, err)
+	}
+	if len(roadmap.Items) > 0 {
+		return nil, fmt.Errorf("failed to parse Markdown: items of roadmap '%s' must be level-2 headings, not metadata", head.title)
+	}
+	roadmap.Name = head.title
+	if roadmap.Notes == "" {
+		roadmap.Notes = markdownText(head.text)
+	}
+
+	for _, section := range sections[1:] {
+		var item models.RoadmapItem
+		if err := yaml.Unmarshal(section.metadata, &item); err != nil {
+			return nil, fmt.Errorf("failed to parse Markdown: metadata of item '%s' (line %d): %w", section.title, section.line, err)
+		}
+		item.Name = section.title
+		if item.Description == "" {
+			item.Description = markdownText(section.text)
+		}
+		roadmap.Items = append(roadmap.Items, item)
+	}
+
+	// Normalize tags, expand recurring items, and validate the parsed roadmap
+	roadmap.NormalizeTags()
+	if err := roadmap.ExpandRecurrences(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	if err := roadmap.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	return &roadmap, nil
+}
+
+// splitMarkdown splits a document into the roadmap's section, first, and an
+// item section for each level-2 heading. Headings inside fenced blocks don't
+// count, and deeper headings are part of the text.
+func splitMarkdown(r io.Reader) ([]*markdownSection, error) {
+	var sections []*markdownSection
+	var current *markdownSection
+	var fence string
+	var block []string
+	metadataBlock := false
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+
+		// Inside a fenced block, until the fence that closes it
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" {
+				if metadataBlock {
+					current.metadata = []byte(strings.Join(block, "\n"))
+				} else {
+					current.text = append(current.text, line)
+				}
+				fence, block, metadataBlock = "", nil, false
+				continue
+			}
+			if metadataBlock {
+				block = append(block, line)
+			} else {
+				current.text = append(current.text, line)
+			}
+			continue
+		}
+
+		if title, ok := strings.CutPrefix(trimmed, "# "); ok {
+			if current != nil {
+				return nil, fmt.Errorf("line %d: a document holds one roadmap, but '%s' is a second level-1 heading", n, strings.TrimSpace(title))
+			}
+			current = &markdownSection{title: strings.TrimSpace(title), line: n}
+			sections = append(sections, current)
+			continue
+		}
+		if title, ok := strings.CutPrefix(trimmed, "## "); ok {
+			if current == nil {
+				return nil, fmt.Errorf("line %d: item '%s' comes before the roadmap's level-1 heading", n, strings.TrimSpace(title))
+			}
+			current = &markdownSection{title: strings.TrimSpace(title), line: n}
+			sections = append(sections, current)
+			continue
+		}
+
+		if current == nil {
+			if trimmed != "" {
+				return nil, fmt.Errorf("line %d: expected the roadmap's level-1 heading first", n)
+			}
+			continue
+		}
+
+		if marker := fenceMarker(trimmed); marker != "" {
+			fence = marker
+			language := strings.TrimSpace(strings.TrimLeft(trimmed, marker[:1]))
+			metadataBlock = current.metadata == nil && (language == "" || language == "yaml" || language == "yml")
+			if !metadataBlock {
+				current.text = append(current.text, line)
+			}
+			continue
+		}
+		current.text = append(current.text, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if fence != "" {
+		return nil, fmt.Errorf("fenced block under '%s' is not closed", current.title)
+	}
+	if len(sections) == 0 {
+		return nil, fmt.Errorf("no level-1 heading naming the roadmap")
+	}
+	return sections, nil
+}
+
+// fenceMarker returns the ``` or ~~~ run opening a fenced block, or ""
+func fenceMarker(line string) string {
+	for _, c := range []string{"`", "~"} {
+		if strings.HasPrefix(line, c+c+c) {
+			return line[:len(line)-len(strings.TrimLeft(line, c))]
+		}
+	}
+	return ""
+}
+
+// markdownText joins the lines of text under a heading, without the blank
+// lines around them
+func markdownText(lines []string) string {
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
@@ -11,13 +11,27 @@ import (
 
 // ParseRoadmap parses a YAML byte slice into a Roadmap struct
 func ParseRoadmap(data []byte) (*models.Roadmap, error) {
+	return DecodeRoadmap(bytes.NewReader(data))
+}
+
+// DecodeRoadmap parses the first YAML document read from r into a Roadmap
+// struct, without buffering the whole input first
+func DecodeRoadmap(r io.Reader) (*models.Roadmap, error) {
 	var roadmapFile models.RoadmapFile
 
-	if err := yaml.Unmarshal(data, &roadmapFile); err != nil {
+	err := yaml.NewDecoder(r).Decode(&roadmapFile)
+	if err == io.EOF {
+		return nil, fmt.Errorf("failed to parse YAML: document is empty")
+	}
+	if err != nil {
 		return nil, fmt.Errorf("failed to parse YAML: %w", err)
 	}
 
-	// Validate the parsed roadmap
+	// Normalize tags, expand recurring items, and validate the parsed roadmap
+	roadmapFile.Roadmap.NormalizeTags()
+	if err := roadmapFile.Roadmap.ExpandRecurrences(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
 	if err := roadmapFile.Roadmap.Validate(); err != nil {
 		return nil, fmt.Errorf("validation failed: %w", err)
 	}
@@ -28,10 +42,16 @@ func ParseRoadmap(data []byte) (*models.Roadmap, error) {
 // ParseMultipleRoadmaps parses a YAML file containing multiple roadmap documents
 // separated by --- into a slice of Roadmap structs
 func ParseMultipleRoadmaps(data []byte) ([]*models.Roadmap, error) {
+	return DecodeMultipleRoadmaps(bytes.NewReader(data))
+}
+
+// DecodeMultipleRoadmaps parses roadmap documents separated by --- as they
+// are read from r
+func DecodeMultipleRoadmaps(r io.Reader) ([]*models.Roadmap, error) {
 	var roadmaps []*models.Roadmap
 
 	// Create a YAML decoder to handle multiple documents
-	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder := yaml.NewDecoder(r)
 
 	for {
 		var roadmapFile models.RoadmapFile
@@ -46,8 +66,13 @@ func ParseMultipleRoadmaps(data []byte) ([]*models.Roadmap, error) {
 			return nil, fmt.Errorf("failed to parse YAML document %d: %w", len(roadmaps)+1, err)
 		}
 
-		// Validate the parsed roadmap
-		if err := roadmapFile.Roadmap.Validate(); err != nil {
+		// Normalize tags, expand recurring items, and validate the parsed roadmap
+		roadmapFile.Roadmap.NormalizeTags()
+		err = roadmapFile.Roadmap.ExpandRecurrences()
+		if err == nil {
+			err = roadmapFile.Roadmap.Validate()
+		}
+		if err != nil {
 			return nil, fmt.Errorf("validation failed for roadmap %d (%s): %w", len(roadmaps)+1, roadmapFile.Roadmap.Name, err)
 		}
 
@@ -61,6 +86,30 @@ func ParseMultipleRoadmaps(data []byte) ([]*models.Roadmap, error) {
 	return roadmaps, nil
 }
 
+// DecodeDocuments reads every roadmap document from r without validating
+// them. On a syntax error it returns the documents decoded so far along with
+// the error, which names the failing document.
+func DecodeDocuments(r io.Reader) ([]*models.Roadmap, error) {
+	var roadmaps []*models.Roadmap
+
+	decoder := yaml.NewDecoder(r)
+	for {
+		var roadmapFile models.RoadmapFile
+
+		err := decoder.Decode(&roadmapFile)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return roadmaps, fmt.Errorf("failed to parse YAML document %d: %w", len(roadmaps)+1, err)
+		}
+
+		roadmaps = append(roadmaps, &roadmapFile.Roadmap)
+	}
+
+	return roadmaps, nil
+}
+
 // SerializeRoadmap converts a Roadmap to YAML bytes
 func SerializeRoadmap(roadmap *models.Roadmap) ([]byte, error) {
 	roadmapFile := models.RoadmapFile{
@@ -0,0 +1,188 @@
+package render
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// PDF draws the chart as a PDF document of pages of the given size, with as
+// many pages as the rows need, each with the chart's title and month labels.
+// Text is set in Helvetica, which PDF readers provide, so no font is embedded.
+func PDF(w io.Writer, chart Chart, page Page) error {
+	perPage := rowsPerPage(page)
+	pages := max(1, (len(chart.Rows)+perPage-1)/perPage)
+
+	var contents [][]byte
+	for i := 0; i < pages; i++ {
+		rows := chart.Rows[min(i*perPage, len(chart.Rows)):min((i+1)*perPage, len(chart.Rows))]
+		note := ""
+		if pages > 1 {
+			note = fmt.Sprintf("page %d of %d", i+1, pages)
+		}
+		c := &pdfCanvas{height: page.Height}
+		drawChart(c, &chart, page.Width, rows, note)
+
+		var stream bytes.Buffer
+		zw := zlib.NewWriter(&stream)
+		zw.Write(c.b.Bytes())
+		if err := zw.Close(); err != nil {
+			return err
+		}
+		contents = append(contents, stream.Bytes())
+	}
+
+	// Objects 1 to 4 are the catalog, the page tree, and the fonts; each page
+	// is followed by its content stream, and the document info comes last
+	var objects []string
+	kids := make([]string, pages)
+	for i := range kids {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+	objects = append(objects,
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), pages),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>",
+	)
+	for i, content := range contents {
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+				number(page.Width), number(page.Height), 6+2*i),
+			fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", len(content), content),
+		)
+	}
+	objects = append(objects, fmt.Sprintf("<< /Title %s /Producer (roadmap-visualizer) >>", pdfString(chart.Title)))
+
+	var b bytes.Buffer
+	b.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = b.Len()
+		fmt.Fprintf(&b, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+	xref := b.Len()
+	fmt.Fprintf(&b, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&b, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&b, "trailer\n<< /Size %d /Root 1 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, len(objects), xref)
+
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// pdfCanvas draws into a page's content stream, whose y axis grows upward
+// from the bottom of the page
+type pdfCanvas struct {
+	b      bytes.Buffer
+	height float64
+}
+
+func (c *pdfCanvas) rect(x, y, w, h float64, fill string) {
+	fmt.Fprintf(&c.b, "%s rg %s %s %s %s re f\n", pdfColor(fill), number(x), number(c.height-y-h), number(w), number(h))
+}
+
+func (c *pdfCanvas) line(x0, y0, x1, y1, width float64, color string) {
+	fmt.Fprintf(&c.b, "%s RG %s w %s %s m %s %s l S\n", pdfColor(color), number(width),
+		number(x0), number(c.height-y0), number(x1), number(c.height-y1))
+}
+
+func (c *pdfCanvas) polygon(points []point, fill string) {
+	fmt.Fprintf(&c.b, "%s rg", pdfColor(fill))
+	for i, p := range points {
+		op := "l"
+		if i == 0 {
+			op = "m"
+		}
+		fmt.Fprintf(&c.b, " %s %s %s", number(p.x), number(c.height-p.y), op)
+	}
+	c.b.WriteString(" h f\n")
+}
+
+func (c *pdfCanvas) text(x, y, size float64, bold bool, color, s string) {
+	if s == "" {
+		return
+	}
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	fmt.Fprintf(&c.b, "BT /%s %s Tf %s rg %s %s Td %s Tj ET\n", font, number(size), pdfColor(color),
+		number(x), number(c.height-y), pdfString(s))
+}
+
+func (c *pdfCanvas) textWidth(s string, size float64, bold bool) float64 {
+	widths := helveticaWidths
+	if bold {
+		widths = helveticaBoldWidths
+	}
+	total := 0
+	for _, ch := range s {
+		if ch >= ' ' && ch <= '~' {
+			total += widths[ch-' ']
+		} else {
+			total += 556
+		}
+	}
+	return float64(total) * size / 1000
+}
+
+// number formats a coordinate with at most two decimals
+func number(v float64) string {
+	s := strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.2f", v), "0"), ".")
+	if s == "-0" {
+		return "0"
+	}
+	return s
+}
+
+func pdfColor(s string) string {
+	r, g, b := parseColor(s)
+	return fmt.Sprintf("%s %s %s", number(float64(r)/255), number(float64(g)/255), number(float64(b)/255))
+}
+
+// pdfString encodes s as a literal string in WinAnsiEncoding, which matches
+// Latin-1 from 0xA0 up; other characters become ?
+func pdfString(s string) string {
+	var b strings.Builder
+	b.WriteByte('(')
+	for _, ch := range s {
+		switch {
+		case ch == '(' || ch == ')' || ch == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(ch)
+		case ch >= ' ' && ch <= '~':
+			b.WriteRune(ch)
+		case ch >= 0xa0 && ch <= 0xff:
+			fmt.Fprintf(&b, "\\%03o", ch)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	b.WriteByte(')')
+	return b.String()
+}
+
+// Advance widths of the printable ASCII characters in thousandths of the
+// font size, from the Adobe font metrics of Helvetica and Helvetica-Bold
+var (
+	helveticaWidths = [...]int{
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+		1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+		333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+		556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+	}
+	helveticaBoldWidths = [...]int{
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+		975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+		333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+		611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+	}
+)
@@ -0,0 +1,110 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+)
+
+// pngScale is the pixels per point of PNG images, 144 dpi
+const pngScale = 2
+
+// PNG draws the chart as a PNG image as wide as the page and as tall as the
+// page or the rows, whichever is taller
+func PNG(w io.Writer, chart Chart, page Page) error {
+	height := math.Max(page.Height, chartTop+float64(max(len(chart.Rows), 1))*rowHeight+margin)
+	img := image.NewRGBA(image.Rect(0, 0, pixels(page.Width), pixels(height)))
+	draw.Draw(img, img.Bounds(), image.White, image.Point{}, draw.Src)
+
+	drawChart(&raster{img}, &chart, page.Width, chart.Rows, "")
+	return png.Encode(w, img)
+}
+
+// raster is a canvas of pixels
+type raster struct {
+	img *image.RGBA
+}
+
+func pixels(v float64) int {
+	return int(math.Round(v * pngScale))
+}
+
+func rgba(s string) color.RGBA {
+	r, g, b := parseColor(s)
+	return color.RGBA{r, g, b, 0xff}
+}
+
+func (r *raster) rect(x, y, w, h float64, fill string) {
+	bounds := image.Rect(pixels(x), pixels(y), max(pixels(x+w), pixels(x)+1), max(pixels(y+h), pixels(y)+1))
+	draw.Draw(r.img, bounds, &image.Uniform{rgba(fill)}, image.Point{}, draw.Src)
+}
+
+func (r *raster) line(x0, y0, x1, y1, width float64, color string) {
+	if x0 == x1 {
+		r.rect(x0-width/2, math.Min(y0, y1), width, math.Abs(y1-y0), color)
+	} else {
+		r.rect(math.Min(x0, x1), y0-width/2, math.Abs(x1-x0), width, color)
+	}
+}
+
+// polygon fills a convex polygon a row of pixels at a time
+func (r *raster) polygon(points []point, fill string) {
+	top, bottom := math.Inf(1), math.Inf(-1)
+	for _, p := range points {
+		top, bottom = math.Min(top, p.y), math.Max(bottom, p.y)
+	}
+	c := rgba(fill)
+	for py := pixels(top); py < pixels(bottom); py++ {
+		y := (float64(py) + 0.5) / pngScale
+		from, to := math.Inf(1), math.Inf(-1)
+		for i, a := range points {
+			b := points[(i+1)%len(points)]
+			if (a.y <= y) == (b.y <= y) {
+				continue
+			}
+			x := a.x + (y-a.y)*(b.x-a.x)/(b.y-a.y)
+			from, to = math.Min(from, x), math.Max(to, x)
+		}
+		for px := pixels(from); px < pixels(to); px++ {
+			r.img.SetRGBA(px, py, c)
+		}
+	}
+}
+
+// glyphScale is how many pixels square each dot of a glyph is drawn at
+func glyphScale(size float64) int {
+	return max(1, int(math.Round(size*pngScale/8)))
+}
+
+func (r *raster) text(x, y, size float64, bold bool, color, s string) {
+	k := glyphScale(size)
+	c := rgba(color)
+	px, baseline := pixels(x), pixels(y)
+	for _, ch := range s {
+		rows := glyph(ch)
+		for row, bits := range rows {
+			for col := 0; col < glyphWidth; col++ {
+				if bits&(1<<(glyphWidth-1-col)) == 0 {
+					continue
+				}
+				dot := image.Rect(px+col*k, baseline-(glyphHeight-row)*k, px+(col+1)*k, baseline-(glyphHeight-row-1)*k)
+				if bold {
+					dot.Max.X++
+				}
+				draw.Draw(r.img, dot, &image.Uniform{c}, image.Point{}, draw.Src)
+			}
+		}
+		px += (glyphWidth + 1) * k
+	}
+}
+
+func (r *raster) textWidth(s string, size float64, bold bool) float64 {
+	n := len([]rune(s))
+	if n == 0 {
+		return 0
+	}
+	return float64((n*(glyphWidth+1)-1)*glyphScale(size)) / pngScale
+}
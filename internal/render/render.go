@@ -0,0 +1,224 @@
+// Package render draws a roadmap timeline, a Gantt chart of bars by date, as
+// a PNG image or a PDF document, without depending on a graphics library.
+package render
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Chart is a timeline of labelled rows. It spans whole months, from the
+// earliest start of a row to the latest end.
+type Chart struct {
+	Title    string
+	Subtitle string
+	Rows     []Row
+	// Legend explains the fill colors, shown beside the title
+	Legend []LegendEntry
+	// Today is marked with a line when it falls in the chart
+	Today time.Time
+}
+
+// Row is a bar from Start up to End, or with Milestone set a diamond on the
+// day starting at Start. Fill is a color such as #a5d6a7.
+type Row struct {
+	Label     string
+	Start     time.Time
+	End       time.Time
+	Fill      string
+	Milestone bool
+}
+
+// LegendEntry is a color swatch and what it means
+type LegendEntry struct {
+	Label string
+	Fill  string
+}
+
+// Page is a page size in points (1/72 inch)
+type Page struct {
+	Width  float64
+	Height float64
+}
+
+// Pages are the page sizes by name, in portrait orientation
+var Pages = map[string]Page{
+	"a3":      {842, 1191},
+	"a4":      {595, 842},
+	"legal":   {612, 1008},
+	"letter":  {612, 792},
+	"tabloid": {792, 1224},
+}
+
+// Landscape turns the page on its side
+func (p Page) Landscape() Page {
+	return Page{Width: p.Height, Height: p.Width}
+}
+
+// Layout, in points
+const (
+	margin       = 36.0
+	titleSize    = 16.0
+	fontSize     = 8.0
+	monthsHeight = 16.0
+	rowHeight    = 16.0
+	barHeight    = 10.0
+	// chartTop is where the first row starts, below the title, subtitle,
+	// and month labels
+	chartTop = margin + 40 + monthsHeight
+)
+
+const (
+	textColor    = "#212121"
+	mutedColor   = "#616161"
+	gridColor    = "#d6d6d6"
+	shadeColor   = "#f5f5f5"
+	todayColor   = "#e53935"
+	outlineColor = "#424242"
+)
+
+// canvas is a surface to draw on, with y growing downward from the top left
+// of the page. Lines are horizontal or vertical.
+type canvas interface {
+	rect(x, y, w, h float64, fill string)
+	line(x0, y0, x1, y1, width float64, color string)
+	polygon(points []point, fill string)
+	// text draws s with its baseline at y
+	text(x, y, size float64, bold bool, color, s string)
+	textWidth(s string, size float64, bold bool) float64
+}
+
+type point struct {
+	x, y float64
+}
+
+// rowsPerPage is how many rows fit on a page under the chart's header
+func rowsPerPage(page Page) int {
+	return max(1, int((page.Height-chartTop-margin)/rowHeight))
+}
+
+// span is the months the chart covers
+func (c *Chart) span() (time.Time, time.Time) {
+	var first, last time.Time
+	for _, row := range c.Rows {
+		if first.IsZero() || row.Start.Before(first) {
+			first = row.Start
+		}
+		if row.End.After(last) {
+			last = row.End
+		}
+	}
+	if first.IsZero() {
+		first, last = c.Today, c.Today
+	}
+
+	start := time.Date(first.Year(), first.Month(), 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(last.Year(), last.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if end.Before(last) || !end.After(start) {
+		end = end.AddDate(0, 1, 0)
+	}
+	return start, end
+}
+
+// drawChart lays the chart's header and the given rows out on a page width
+// wide. note is added to the subtitle, e.g. to number the pages.
+func drawChart(c canvas, chart *Chart, width float64, rows []Row, note string) {
+	start, end := chart.span()
+	labelWidth := math.Min(220, (width-2*margin)*0.3)
+	left, right := margin+labelWidth, width-margin
+	bottom := chartTop + float64(max(len(rows), 1))*rowHeight
+	x := func(t time.Time) float64 {
+		return left + (right-left)*t.Sub(start).Hours()/end.Sub(start).Hours()
+	}
+
+	// The legend is right-aligned beside the title, which is cut short to fit
+	titleRight := right
+	for i := len(chart.Legend) - 1; i >= 0; i-- {
+		entry := chart.Legend[i]
+		titleRight -= c.textWidth(entry.Label, fontSize, false)
+		c.text(titleRight, margin+12, fontSize, false, mutedColor, entry.Label)
+		titleRight -= 12
+		c.rect(titleRight, margin+4, 9, 9, entry.Fill)
+		titleRight -= 10
+	}
+	c.text(margin, margin+14, titleSize, true, textColor, fit(c, chart.Title, titleSize, true, titleRight-margin-12))
+	subtitle := chart.Subtitle
+	if note != "" {
+		subtitle = strings.TrimPrefix(subtitle+" - "+note, " - ")
+	}
+	c.text(margin, margin+30, fontSize, false, mutedColor, fit(c, subtitle, fontSize, false, right-margin))
+
+	for i := 1; i < len(rows); i += 2 {
+		c.rect(margin, chartTop+float64(i)*rowHeight, right-margin, rowHeight, shadeColor)
+	}
+
+	// A line at the start of every month, labelled as often as the labels fit
+	months := 0
+	for month := start; month.Before(end); month = month.AddDate(0, 1, 0) {
+		months++
+	}
+	monthWidth := (right - left) / float64(months)
+	every := 12
+	for _, n := range []int{1, 2, 3, 6} {
+		if monthWidth*float64(n) >= c.textWidth("Sep 2026", fontSize, false)+4 {
+			every = n
+			break
+		}
+	}
+	for i, month := 0, start; month.Before(end); i, month = i+1, month.AddDate(0, 1, 0) {
+		c.line(x(month), chartTop-monthsHeight, x(month), bottom, 0.5, gridColor)
+		if i%every != 0 {
+			continue
+		}
+		label := month.Format("Jan")
+		if i == 0 || month.Month() == time.January {
+			label = month.Format("Jan 2006")
+		}
+		c.text(x(month)+2, chartTop-5, fontSize, false, mutedColor, fit(c, label, fontSize, false, monthWidth*float64(every)-3))
+	}
+	c.line(right, chartTop-monthsHeight, right, bottom, 0.5, gridColor)
+	c.line(margin, chartTop, right, chartTop, 0.5, outlineColor)
+	c.line(margin, bottom, right, bottom, 0.5, gridColor)
+
+	for i, row := range rows {
+		y := chartTop + float64(i)*rowHeight
+		c.text(margin+4, y+rowHeight-4.5, fontSize, false, textColor, fit(c, row.Label, fontSize, false, labelWidth-8))
+		if row.Milestone {
+			cx, cy, r := x(row.Start.Add(12*time.Hour)), y+rowHeight/2, barHeight/2+1
+			c.polygon([]point{{cx, cy - r}, {cx + r, cy}, {cx, cy + r}, {cx - r, cy}}, row.Fill)
+			continue
+		}
+		x0, x1 := x(row.Start), x(row.End)
+		c.rect(x0, y+(rowHeight-barHeight)/2, math.Max(x1-x0, 1), barHeight, row.Fill)
+	}
+
+	if !chart.Today.Before(start) && chart.Today.Before(end) {
+		c.line(x(chart.Today), chartTop-monthsHeight, x(chart.Today), bottom, 1, todayColor)
+	}
+}
+
+// fit cuts s short with "..." so it is at most width wide
+func fit(c canvas, s string, size float64, bold bool, width float64) string {
+	if c.textWidth(s, size, bold) <= width {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 && c.textWidth(string(runes)+"...", size, bold) > width {
+		runes = runes[:len(runes)-1]
+	}
+	if len(runes) == 0 {
+		return ""
+	}
+	return strings.TrimRight(string(runes), " ") + "..."
+}
+
+// parseColor reads a color such as #a5d6a7 into its red, green, and blue
+// components, or black if it isn't one
+func parseColor(s string) (r, g, b uint8) {
+	if _, err := fmt.Sscanf(s, "#%02x%02x%02x", &r, &g, &b); err != nil || len(s) != 7 {
+		return 0, 0, 0
+	}
+	return r, g, b
+}
@@ -0,0 +1,135 @@
+// Package requestlog assigns every HTTP request an ID and writes a structured
+// access log line for it. The ID is returned in the X-Request-ID header and
+// appended to plain-text error responses, so a failure reported by a user can
+// be matched to its log line.
+package requestlog
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Header carries the request ID. An ID sent by the client or a proxy is kept
+// if it is reasonable; otherwise a new one is generated.
+const Header = "X-Request-ID"
+
+// maxIDLength bounds IDs accepted from clients
+const maxIDLength = 128
+
+type idKey struct{}
+
+// ID returns the request ID stored in the context, or "" outside a request
+func ID(ctx context.Context) string {
+	id, _ := ctx.Value(idKey{}).(string)
+	return id
+}
+
+// Middleware assigns request IDs and logs method, path, status, latency, and
+// body sizes of every request to logger
+func Middleware(next http.Handler, logger *slog.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		id := r.Header.Get(Header)
+		if !validID(id) {
+			id = uuid.NewString()
+		}
+		w.Header().Set(Header, id)
+
+		rec := &recorder{ResponseWriter: w, id: id, status: http.StatusOK}
+		next.ServeHTTP(rec, r.WithContext(context.WithValue(r.Context(), idKey{}, id)))
+
+		level := slog.LevelInfo
+		if rec.status >= 500 {
+			level = slog.LevelError
+		}
+		logger.LogAttrs(r.Context(), level, "request",
+			slog.String("request_id", id),
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.Int("status", rec.status),
+			slog.Float64("duration_ms", float64(time.Since(start).Microseconds())/1000),
+			slog.Int64("request_bytes", max(r.ContentLength, 0)),
+			slog.Int64("response_bytes", rec.bytes),
+			slog.String("remote_addr", r.RemoteAddr),
+		)
+	})
+}
+
+// validID accepts IDs of letters, digits, and -_.:/ up to maxIDLength
+func validID(id string) bool {
+	if id == "" || len(id) > maxIDLength {
+		return false
+	}
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case strings.ContainsRune("-_.:/", c):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// recorder captures the status and size of a response, and appends the
+// request ID to plain-text error bodies such as those written by http.Error
+type recorder struct {
+	http.ResponseWriter
+	id          string
+	status      int
+	bytes       int64
+	wroteHeader bool
+	errorText   bool
+}
+
+func (rec *recorder) WriteHeader(status int) {
+	if rec.wroteHeader {
+		return
+	}
+	rec.wroteHeader = true
+	rec.status = status
+	rec.errorText = status >= 400 && strings.HasPrefix(rec.Header().Get("Content-Type"), "text/plain")
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+func (rec *recorder) Write(p []byte) (int, error) {
+	if !rec.wroteHeader {
+		rec.WriteHeader(http.StatusOK)
+	}
+
+	if rec.errorText {
+		// Only the first write is tagged; http.Error writes its message at once
+		rec.errorText = false
+		tagged := fmt.Sprintf("%s (request ID: %s)\n", bytes.TrimRight(p, "\n"), rec.id)
+		n, err := rec.ResponseWriter.Write([]byte(tagged))
+		rec.bytes += int64(n)
+		if err != nil {
+			return 0, err
+		}
+		return len(p), nil
+	}
+
+	n, err := rec.ResponseWriter.Write(p)
+	rec.bytes += int64(n)
+	return n, err
+}
+
+// Flush lets streaming handlers flush through the recorder
+func (rec *recorder) Flush() {
+	if flusher, ok := rec.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController
+func (rec *recorder) Unwrap() http.ResponseWriter {
+	return rec.ResponseWriter
+}
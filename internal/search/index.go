@@ -0,0 +1,304 @@
+package search
+
+import (
+	"roadmap-visualizer/internal/models"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Searchable fields
+const (
+	FieldRoadmapName     = "roadmap_name"
+	FieldRoadmapNotes    = "roadmap_notes"
+	FieldItemName        = "item_name"
+	FieldItemDescription = "item_description"
+	FieldItemNotes       = "item_notes"
+	FieldItemTags        = "item_tags"
+)
+
+// fieldRank orders matches so names come before longer text
+var fieldRank = map[string]int{
+	FieldRoadmapName:     0,
+	FieldItemName:        1,
+	FieldItemDescription: 2,
+	FieldRoadmapNotes:    3,
+	FieldItemNotes:       4,
+	FieldItemTags:        5,
+}
+
+// snippetRadius is how many characters of context a snippet keeps on each
+// side of the first match
+const snippetRadius = 40
+
+// Match is one field of a roadmap or item that contains every query term
+type Match struct {
+	RoadmapID   string `json:"roadmap_id"`
+	RoadmapName string `json:"roadmap_name"`
+	ItemID      string `json:"item_id,omitempty"`
+	ItemName    string `json:"item_name,omitempty"`
+	Field       string `json:"field"`
+	Snippet     string `json:"snippet"`
+}
+
+// document is a single indexed field
+type document struct {
+	roadmapID   string
+	roadmapName string
+	itemID      string
+	itemName    string
+	position    int // item position in the roadmap, -1 for roadmap fields
+	field       string
+	text        string
+	tags        []string // tags of the item, for filtering
+}
+
+// version identifies the stored content a roadmap was indexed from
+type version struct {
+	revision  int
+	updatedAt time.Time
+}
+
+// Index is an inverted index from lowercase tokens to the roadmap and item
+// fields that contain them. It is safe for concurrent use.
+type Index struct {
+	mu       sync.RWMutex
+	docs     map[int]*document
+	postings map[string]map[int]bool
+	// byRoadmap lists the document IDs of each roadmap so it can be removed
+	byRoadmap map[string][]int
+	versions  map[string]version
+	nextDoc   int
+}
+
+// NewIndex creates an empty index
+func NewIndex() *Index {
+	return &Index{
+		docs:      make(map[int]*document),
+		postings:  make(map[string]map[int]bool),
+		byRoadmap: make(map[string][]int),
+		versions:  make(map[string]version),
+	}
+}
+
+// Sync brings the index in line with the given roadmaps, the full current
+// contents of storage. Only roadmaps that are new or whose revision or update
+// time changed are re-tokenized, and roadmaps that are gone are removed.
+func (idx *Index) Sync(roadmaps []*models.StoredRoadmap) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	live := make(map[string]bool, len(roadmaps))
+	for _, rm := range roadmaps {
+		live[rm.ID] = true
+		v, ok := idx.versions[rm.ID]
+		if ok && v.revision == rm.CurrentRevision() && v.updatedAt.Equal(rm.UpdatedAt) {
+			continue
+		}
+		idx.add(rm)
+	}
+
+	for id := range idx.versions {
+		if !live[id] {
+			idx.remove(id)
+		}
+	}
+}
+
+// add indexes a roadmap; the caller holds mu
+func (idx *Index) add(stored *models.StoredRoadmap) {
+	idx.remove(stored.ID)
+
+	rm := &stored.Roadmap
+	idx.addDoc(&document{roadmapID: stored.ID, roadmapName: rm.Name, position: -1, field: FieldRoadmapName, text: rm.Name})
+	idx.addDoc(&document{roadmapID: stored.ID, roadmapName: rm.Name, position: -1, field: FieldRoadmapNotes, text: rm.Notes})
+	for i, item := range rm.Items {
+		base := document{roadmapID: stored.ID, roadmapName: rm.Name, itemID: item.ID, itemName: item.Name, position: i, tags: item.Tags}
+		for field, text := range map[string]string{
+			FieldItemName:        item.Name,
+			FieldItemDescription: item.Description,
+			FieldItemNotes:       item.Notes,
+			FieldItemTags:        strings.Join(item.Tags, " "),
+		} {
+			doc := base
+			doc.field = field
+			doc.text = text
+			idx.addDoc(&doc)
+		}
+	}
+
+	idx.versions[stored.ID] = version{revision: stored.CurrentRevision(), updatedAt: stored.UpdatedAt}
+}
+
+// addDoc indexes one field; empty fields are skipped
+func (idx *Index) addDoc(doc *document) {
+	tokens := tokenize(doc.text)
+	if len(tokens) == 0 {
+		return
+	}
+
+	id := idx.nextDoc
+	idx.nextDoc++
+	idx.docs[id] = doc
+	idx.byRoadmap[doc.roadmapID] = append(idx.byRoadmap[doc.roadmapID], id)
+
+	for _, token := range tokens {
+		docs, ok := idx.postings[token]
+		if !ok {
+			docs = make(map[int]bool)
+			idx.postings[token] = docs
+		}
+		docs[id] = true
+	}
+}
+
+// remove drops a roadmap's documents; the caller holds mu
+func (idx *Index) remove(roadmapID string) {
+	for _, id := range idx.byRoadmap[roadmapID] {
+		for _, token := range tokenize(idx.docs[id].text) {
+			delete(idx.postings[token], id)
+			if len(idx.postings[token]) == 0 {
+				delete(idx.postings, token)
+			}
+		}
+		delete(idx.docs, id)
+	}
+	delete(idx.byRoadmap, roadmapID)
+	delete(idx.versions, roadmapID)
+}
+
+// Search returns fields containing every term of the query, ranked by field
+// (roadmap names first) and then by roadmap and item order. Each term matches
+// as a prefix, so "migr" finds "migration". If tag is set, only fields of items
+// with that tag match. At most limit matches are returned when limit is positive.
+func (idx *Index) Search(query, tag string, limit int) []Match {
+	terms := tokenize(query)
+	if len(terms) == 0 {
+		return []Match{}
+	}
+
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	var candidates map[int]bool
+	for _, term := range terms {
+		found := make(map[int]bool)
+		for token, docs := range idx.postings {
+			if !strings.HasPrefix(token, term) {
+				continue
+			}
+			for id := range docs {
+				if candidates == nil || candidates[id] {
+					found[id] = true
+				}
+			}
+		}
+		candidates = found
+		if len(candidates) == 0 {
+			return []Match{}
+		}
+	}
+
+	docs := make([]*document, 0, len(candidates))
+	for id := range candidates {
+		if tag != "" && !containsTag(idx.docs[id].tags, tag) {
+			continue
+		}
+		docs = append(docs, idx.docs[id])
+	}
+	sort.Slice(docs, func(i, j int) bool {
+		a, b := docs[i], docs[j]
+		if fieldRank[a.field] != fieldRank[b.field] {
+			return fieldRank[a.field] < fieldRank[b.field]
+		}
+		if a.roadmapID != b.roadmapID {
+			return a.roadmapID < b.roadmapID
+		}
+		return a.position < b.position
+	})
+	if limit > 0 && len(docs) > limit {
+		docs = docs[:limit]
+	}
+
+	matches := make([]Match, len(docs))
+	for i, doc := range docs {
+		matches[i] = Match{
+			RoadmapID:   doc.roadmapID,
+			RoadmapName: doc.roadmapName,
+			ItemID:      doc.itemID,
+			ItemName:    doc.itemName,
+			Field:       doc.field,
+			Snippet:     snippet(doc.text, terms[0]),
+		}
+	}
+
+	return matches
+}
+
+// tokenize splits text into unique lowercase words
+func tokenize(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	seen := make(map[string]bool, len(words))
+	tokens := words[:0]
+	for _, word := range words {
+		if !seen[word] {
+			seen[word] = true
+			tokens = append(tokens, word)
+		}
+	}
+	return tokens
+}
+
+// snippet returns the text around the first occurrence of term, trimmed to
+// whole words and marked with ellipses where it was cut
+func snippet(text, term string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	runes := []rune(text)
+	if len(runes) <= 2*snippetRadius {
+		return text
+	}
+
+	// Lowercase rune by rune so offsets line up with the original text
+	lower := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+	}
+	lowerText := string(lower)
+	center := 0
+	if at := strings.Index(lowerText, term); at > 0 {
+		center = utf8.RuneCountInString(lowerText[:at])
+	}
+
+	start := max(center-snippetRadius, 0)
+	end := min(center+len([]rune(term))+snippetRadius, len(runes))
+	for start > 0 && runes[start-1] != ' ' {
+		start--
+	}
+	for end < len(runes) && runes[end] != ' ' {
+		end++
+	}
+
+	result := strings.TrimSpace(string(runes[start:end]))
+	if start > 0 {
+		result = "..." + result
+	}
+	if end < len(runes) {
+		result += "..."
+	}
+	return result
+}
+
+func containsTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
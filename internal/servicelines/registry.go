@@ -0,0 +1,201 @@
+package servicelines
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Registry holds the registered service lines
+type Registry struct {
+	// path is the JSON file registrations are saved to; empty keeps them in
+	// memory only
+	path string
+
+	mu    sync.RWMutex
+	lines map[string]*ServiceLine
+}
+
+// NewRegistry creates a registry, loading saved service lines from path
+func NewRegistry(path string) (*Registry, error) {
+	reg := &Registry{path: path, lines: make(map[string]*ServiceLine)}
+	if err := reg.load(); err != nil {
+		return nil, err
+	}
+	return reg, nil
+}
+
+// load reads saved service lines; a missing file means none are registered
+func (reg *Registry) load() error {
+	if reg.path == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(reg.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read service lines: %w", err)
+	}
+
+	var saved []*ServiceLine
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", reg.path, err)
+	}
+	for _, line := range saved {
+		reg.lines[line.Name] = line
+	}
+	return nil
+}
+
+// save writes the registered service lines atomically. Callers hold reg.mu.
+func (reg *Registry) save() error {
+	if reg.path == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(reg.sorted(), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(reg.path), 0755); err != nil {
+		return fmt.Errorf("failed to create service line directory: %w", err)
+	}
+	tmp := reg.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write service lines: %w", err)
+	}
+	if err := os.Rename(tmp, reg.path); err != nil {
+		return fmt.Errorf("failed to write service lines: %w", err)
+	}
+	return nil
+}
+
+// sorted returns copies of the service lines by name. Callers hold reg.mu.
+func (reg *Registry) sorted() []*ServiceLine {
+	lines := make([]*ServiceLine, 0, len(reg.lines))
+	for _, line := range reg.lines {
+		copied := *line
+		lines = append(lines, &copied)
+	}
+	sort.Slice(lines, func(i, j int) bool { return lines[i].Name < lines[j].Name })
+	return lines
+}
+
+// Create validates and registers a new service line
+func (reg *Registry) Create(name, description string) (*ServiceLine, error) {
+	if err := ValidateName(name); err != nil {
+		return nil, err
+	}
+	line := &ServiceLine{Name: name, Description: description, CreatedAt: time.Now().UTC()}
+
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	if _, ok := reg.lines[name]; ok {
+		return nil, ErrExists
+	}
+	reg.lines[name] = line
+	if err := reg.save(); err != nil {
+		delete(reg.lines, name)
+		return nil, err
+	}
+	copied := *line
+	return &copied, nil
+}
+
+// List returns every registered service line by name
+func (reg *Registry) List() []*ServiceLine {
+	reg.mu.RLock()
+	defer reg.mu.RUnlock()
+	return reg.sorted()
+}
+
+// Get returns one registered service line
+func (reg *Registry) Get(name string) (*ServiceLine, error) {
+	reg.mu.RLock()
+	defer reg.mu.RUnlock()
+
+	line, ok := reg.lines[name]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	copied := *line
+	return &copied, nil
+}
+
+// Has reports whether the service line is registered
+func (reg *Registry) Has(name string) bool {
+	reg.mu.RLock()
+	defer reg.mu.RUnlock()
+	_, ok := reg.lines[name]
+	return ok
+}
+
+// rename moves a registration to a new name, keeping its description and
+// creation time; an unregistered oldName registers newName if it isn't
+// already. The returned function puts both names back as they were.
+func (reg *Registry) rename(oldName, newName string) (func() error, error) {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	oldLine, hadOld := reg.lines[oldName]
+	newLine, hadNew := reg.lines[newName]
+	if hadOld && hadNew {
+		return nil, ErrExists
+	}
+	restore := func() {
+		delete(reg.lines, oldName)
+		delete(reg.lines, newName)
+		if hadOld {
+			reg.lines[oldName] = oldLine
+		}
+		if hadNew {
+			reg.lines[newName] = newLine
+		}
+	}
+
+	if hadOld {
+		renamed := *oldLine
+		renamed.Name = newName
+		delete(reg.lines, oldName)
+		reg.lines[newName] = &renamed
+	} else if !hadNew {
+		reg.lines[newName] = &ServiceLine{Name: newName, CreatedAt: time.Now().UTC()}
+	}
+	if err := reg.save(); err != nil {
+		restore()
+		return nil, err
+	}
+
+	undo := func() error {
+		reg.mu.Lock()
+		defer reg.mu.Unlock()
+		restore()
+		return reg.save()
+	}
+	return undo, nil
+}
+
+// Delete removes a registration
+func (reg *Registry) Delete(name string) error {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	line, ok := reg.lines[name]
+	if !ok {
+		return ErrNotFound
+	}
+	delete(reg.lines, name)
+	if err := reg.save(); err != nil {
+		reg.lines[name] = line
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package servicelines
+
+import (
+	"fmt"
+	"roadmap-visualizer/internal/models"
+	"roadmap-visualizer/internal/storage"
+)
+
+// RenameResult reports what a rename changed
+type RenameResult struct {
+	ServiceLine *ServiceLine `json:"service_line"`
+	// Roadmaps are the IDs of the roadmaps moved to the new name
+	Roadmaps []string `json:"roadmaps"`
+}
+
+// Rename renames a service line and moves every roadmap in it to the new
+// name, each as a new revision. The old name need not be registered, so a
+// misspelled service line can be folded into the right one; either way the
+// new name ends up registered. If any write fails, the writes already made
+// and the registration change are reverted.
+func Rename(s storage.Storage, reg *Registry, oldName, newName, author string) (*RenameResult, error) {
+	if err := ValidateName(newName); err != nil {
+		return nil, err
+	}
+
+	roadmaps, err := s.List(storage.ListFilter{ServiceLine: oldName})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list roadmaps: %w", err)
+	}
+	if len(roadmaps) == 0 && !reg.Has(oldName) {
+		return nil, ErrNotFound
+	}
+
+	result := &RenameResult{Roadmaps: []string{}}
+	if newName == oldName {
+		roadmaps = nil // only registering an unregistered name
+	}
+	if newName != oldName || !reg.Has(oldName) {
+		// The new name is registered first so Strict accepts the updates
+		undo, err := reg.rename(oldName, newName)
+		if err != nil {
+			return nil, err
+		}
+
+		var applied []*models.StoredRoadmap
+		for _, rm := range roadmaps {
+			roadmap := rm.Roadmap
+			roadmap.ServiceLine = newName
+			updated, err := s.Update(rm.ID, &roadmap, author, rm.CurrentRevision())
+			if err != nil {
+				err = fmt.Errorf("failed to update roadmap %s: %w", rm.ID, err)
+				for i, done := range applied {
+					if _, rerr := s.Update(done.ID, &roadmaps[i].Roadmap, author, done.CurrentRevision()); rerr != nil {
+						err = fmt.Errorf("%w; failed to revert roadmap %s: %v", err, done.ID, rerr)
+					}
+				}
+				if uerr := undo(); uerr != nil {
+					err = fmt.Errorf("%w; failed to revert service line: %v", err, uerr)
+				}
+				return nil, err
+			}
+			applied = append(applied, updated)
+			result.Roadmaps = append(result.Roadmaps, rm.ID)
+		}
+	}
+
+	result.ServiceLine, err = reg.Get(newName)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
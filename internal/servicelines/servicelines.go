@@ -0,0 +1,52 @@
+// Package servicelines keeps the registered service lines, so roadmaps can
+// be checked against them instead of each spelling a service line its own way.
+package servicelines
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxNameLength bounds service line names
+const maxNameLength = 100
+
+// ErrNotFound is returned for a service line that is neither registered nor
+// used by any roadmap
+var ErrNotFound = errors.New("service line not found")
+
+// ErrExists is returned when registering or renaming to a name that is
+// already registered
+var ErrExists = errors.New("service line already registered")
+
+// ErrUnknown is returned by Strict when a roadmap names a service line that
+// isn't registered
+var ErrUnknown = errors.New("unknown service line")
+
+// ServiceLine is a registered service line
+type ServiceLine struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// ValidateName checks a service line name. Names are used in URL paths, so
+// they must not contain a slash.
+func ValidateName(name string) error {
+	if name == "" {
+		return fmt.Errorf("service line name is required")
+	}
+	if strings.TrimSpace(name) != name {
+		return fmt.Errorf("service line name must not start or end with spaces")
+	}
+	if utf8.RuneCountInString(name) > maxNameLength {
+		return fmt.Errorf("service line name must be at most %d characters", maxNameLength)
+	}
+	if strings.ContainsRune(name, '/') || strings.IndexFunc(name, unicode.IsControl) != -1 {
+		return fmt.Errorf("service line name must not contain slashes or control characters")
+	}
+	return nil
+}
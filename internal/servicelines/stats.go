@@ -0,0 +1,65 @@
+package servicelines
+
+import (
+	"roadmap-visualizer/internal/models"
+	"sort"
+)
+
+// Summary is a service line with stats over the roadmaps in it. Service lines
+// that roadmaps use without being registered are listed too, so misspelled
+// ones can be found and renamed.
+type Summary struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Registered  bool   `json:"registered"`
+	// Roadmaps lists the IDs of the roadmaps in the service line
+	Roadmaps     []string                     `json:"roadmaps"`
+	ItemCount    int                          `json:"item_count"`
+	StatusCounts map[models.RoadmapStatus]int `json:"status_counts"`
+	// Completion is the done percentage of all the items together, weighted
+	// by duration as in Roadmap.Completion
+	Completion int `json:"completion"`
+}
+
+// Summarize returns the registered service lines and those used by the
+// roadmaps, by name
+func Summarize(registered []*ServiceLine, roadmaps []*models.StoredRoadmap) []Summary {
+	byName := make(map[string]*Summary)
+	combined := make(map[string]*models.Roadmap)
+	group := func(name string) *Summary {
+		summary, ok := byName[name]
+		if !ok {
+			summary = &Summary{
+				Name:         name,
+				Roadmaps:     []string{},
+				StatusCounts: make(map[models.RoadmapStatus]int),
+			}
+			byName[name] = summary
+			combined[name] = &models.Roadmap{}
+		}
+		return summary
+	}
+
+	for _, line := range registered {
+		summary := group(line.Name)
+		summary.Description = line.Description
+		summary.Registered = true
+	}
+	for _, rm := range roadmaps {
+		summary := group(rm.Roadmap.ServiceLine)
+		summary.Roadmaps = append(summary.Roadmaps, rm.ID)
+		summary.ItemCount += len(rm.Roadmap.Items)
+		for _, item := range rm.Roadmap.Items {
+			summary.StatusCounts[item.Status]++
+		}
+		combined[summary.Name].Items = append(combined[summary.Name].Items, rm.Roadmap.Items...)
+	}
+
+	result := make([]Summary, 0, len(byName))
+	for name, summary := range byName {
+		summary.Completion = combined[name].Completion()
+		result = append(result, *summary)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
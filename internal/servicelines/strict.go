@@ -0,0 +1,55 @@
+package servicelines
+
+import (
+	"fmt"
+	"roadmap-visualizer/internal/models"
+	"roadmap-visualizer/internal/storage"
+)
+
+// Strict wraps a Storage and refuses to store a roadmap whose service line
+// isn't registered, returning ErrUnknown. Roadmaps already stored are only
+// checked when next updated; restores from the trash or a backup and
+// reindexes aren't checked.
+type Strict struct {
+	storage.Storage
+	registry *Registry
+}
+
+// NewStrict wraps store, checking service lines against reg
+func NewStrict(store storage.Storage, reg *Registry) *Strict {
+	return &Strict{Storage: store, registry: reg}
+}
+
+// CheckRoadmap returns ErrUnknown unless the roadmap's service line is
+// registered
+func (s *Strict) CheckRoadmap(roadmap *models.Roadmap) error {
+	if !s.registry.Has(roadmap.ServiceLine) {
+		return fmt.Errorf("%w: %s", ErrUnknown, roadmap.ServiceLine)
+	}
+	return nil
+}
+
+// Create stores a roadmap in a registered service line
+func (s *Strict) Create(roadmap *models.Roadmap, originalFileName, author string) (*models.StoredRoadmap, error) {
+	if err := s.CheckRoadmap(roadmap); err != nil {
+		return nil, err
+	}
+	return s.Storage.Create(roadmap, originalFileName, author)
+}
+
+// Update stores a new revision in a registered service line
+func (s *Strict) Update(id string, roadmap *models.Roadmap, author string, ifRevision int) (*models.StoredRoadmap, error) {
+	if err := s.CheckRoadmap(roadmap); err != nil {
+		return nil, err
+	}
+	return s.Storage.Update(id, roadmap, author, ifRevision)
+}
+
+// Reindex syncs a backend that supports it
+func (s *Strict) Reindex() (*storage.ReindexResult, error) {
+	reindexer, ok := s.Storage.(storage.Reindexer)
+	if !ok {
+		return nil, storage.ErrReindexNotSupported
+	}
+	return reindexer.Reindex()
+}
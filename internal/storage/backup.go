@@ -0,0 +1,233 @@
+package storage
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"roadmap-visualizer/internal/models"
+	"roadmap-visualizer/internal/parser"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// backupVersion is the archive format written by WriteBackup
+const backupVersion = 1
+
+// BackupManifest describes a backup archive
+type BackupManifest struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	Roadmaps  int       `json:"roadmaps"`
+}
+
+// BackupRecord is one roadmap and its revision history read from a backup
+type BackupRecord struct {
+	Roadmap   *models.StoredRoadmap
+	Revisions []*models.Revision
+}
+
+// WriteBackup streams every live roadmap and its revisions to w as a tar.gz
+// archive. The archive is independent of the storage backend, so a backup
+// taken from one driver can be restored into another. Layout:
+//
+//	manifest.json
+//	roadmaps/{id}/roadmap.json       stored roadmap with metadata
+//	roadmaps/{id}/roadmap.yaml       readable copy, ignored on restore
+//	roadmaps/{id}/revisions/{n}.json revision history
+func WriteBackup(s Storage, w io.Writer) error {
+	roadmaps, err := s.List(ListFilter{})
+	if err != nil {
+		return fmt.Errorf("failed to list roadmaps: %w", err)
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	now := time.Now()
+
+	manifest := BackupManifest{
+		Version:   backupVersion,
+		CreatedAt: now,
+		Roadmaps:  len(roadmaps),
+	}
+	if err := writeTarJSON(tw, "manifest.json", manifest, now); err != nil {
+		return err
+	}
+
+	for _, rm := range roadmaps {
+		dir := path.Join("roadmaps", rm.ID)
+
+		if err := writeTarJSON(tw, path.Join(dir, "roadmap.json"), rm, rm.UpdatedAt); err != nil {
+			return err
+		}
+
+		yamlData, err := parser.SerializeRoadmap(&rm.Roadmap)
+		if err != nil {
+			return fmt.Errorf("failed to serialize roadmap %s: %w", rm.ID, err)
+		}
+		if err := writeTarFile(tw, path.Join(dir, "roadmap.yaml"), yamlData, rm.UpdatedAt); err != nil {
+			return err
+		}
+
+		revisions, err := s.ListRevisions(rm.ID)
+		if err != nil {
+			return fmt.Errorf("failed to list revisions of %s: %w", rm.ID, err)
+		}
+		for _, rev := range revisions {
+			name := path.Join(dir, "revisions", fmt.Sprintf("%d.json", rev.Number))
+			if err := writeTarJSON(tw, name, rev, rev.CreatedAt); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to finish archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("failed to finish archive: %w", err)
+	}
+
+	return nil
+}
+
+// ReadBackup parses a tar.gz archive written by WriteBackup and validates
+// every roadmap in it. Nothing is written to storage.
+func ReadBackup(r io.Reader) (*BackupManifest, []*BackupRecord, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("backup is not a gzip archive: %w", err)
+	}
+	defer gz.Close()
+
+	var manifest *BackupManifest
+	records := make(map[string]*BackupRecord)
+	record := func(id string) *BackupRecord {
+		if records[id] == nil {
+			records[id] = &BackupRecord{}
+		}
+		return records[id]
+	}
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read archive: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		name := path.Clean(header.Name)
+		if name == "manifest.json" {
+			manifest = &BackupManifest{}
+			if err := json.NewDecoder(tr).Decode(manifest); err != nil {
+				return nil, nil, fmt.Errorf("failed to parse manifest: %w", err)
+			}
+			continue
+		}
+
+		parts := strings.Split(name, "/")
+		if len(parts) < 3 || parts[0] != "roadmaps" {
+			continue
+		}
+		id := parts[1]
+
+		switch {
+		case len(parts) == 3 && parts[2] == "roadmap.json":
+			var stored models.StoredRoadmap
+			if err := json.NewDecoder(tr).Decode(&stored); err != nil {
+				return nil, nil, fmt.Errorf("roadmap %s: failed to parse roadmap.json: %w", id, err)
+			}
+			record(id).Roadmap = &stored
+		case len(parts) == 4 && parts[2] == "revisions" && path.Ext(parts[3]) == ".json":
+			if _, err := strconv.Atoi(strings.TrimSuffix(parts[3], ".json")); err != nil {
+				continue
+			}
+			var rev models.Revision
+			if err := json.NewDecoder(tr).Decode(&rev); err != nil {
+				return nil, nil, fmt.Errorf("roadmap %s: failed to parse %s: %w", id, name, err)
+			}
+			record(id).Revisions = append(record(id).Revisions, &rev)
+		}
+	}
+
+	if manifest == nil {
+		return nil, nil, fmt.Errorf("backup is missing manifest.json")
+	}
+	if manifest.Version != backupVersion {
+		return nil, nil, fmt.Errorf("unsupported backup version %d", manifest.Version)
+	}
+
+	ids := make([]string, 0, len(records))
+	for id := range records {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	result := make([]*BackupRecord, 0, len(ids))
+	for _, id := range ids {
+		rec := records[id]
+		if rec.Roadmap == nil {
+			return nil, nil, fmt.Errorf("roadmap %s: missing roadmap.json", id)
+		}
+		if rec.Roadmap.ID != id {
+			return nil, nil, fmt.Errorf("roadmap %s: roadmap.json has id %q", id, rec.Roadmap.ID)
+		}
+		if err := rec.Roadmap.Roadmap.Validate(); err != nil {
+			return nil, nil, fmt.Errorf("roadmap %s: %w", id, err)
+		}
+		sort.Slice(rec.Revisions, func(i, j int) bool {
+			return rec.Revisions[i].Number < rec.Revisions[j].Number
+		})
+		result = append(result, rec)
+	}
+
+	return manifest, result, nil
+}
+
+// RestoreBackup imports every record, replacing any roadmap with the same ID.
+// Roadmaps that are not in the backup are left untouched.
+func RestoreBackup(s Storage, records []*BackupRecord) error {
+	for _, rec := range records {
+		if err := s.Import(rec.Roadmap, rec.Revisions); err != nil {
+			return fmt.Errorf("failed to restore roadmap %s: %w", rec.Roadmap.ID, err)
+		}
+	}
+	return nil
+}
+
+// writeTarJSON adds a JSON-encoded file to the archive
+func writeTarJSON(tw *tar.Writer, name string, v interface{}, modTime time.Time) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize %s: %w", name, err)
+	}
+	return writeTarFile(tw, name, data, modTime)
+}
+
+// writeTarFile adds a regular file to the archive
+func writeTarFile(tw *tar.Writer, name string, data []byte, modTime time.Time) error {
+	header := &tar.Header{
+		Name:     name,
+		Mode:     0644,
+		Size:     int64(len(data)),
+		ModTime:  modTime,
+		Typeflag: tar.TypeReg,
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	if _, err := tw.Write(data); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package storage
+
+import (
+	"roadmap-visualizer/internal/models"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// newComment returns a copy of comment with a new ID and the current time
+func newComment(comment *models.Comment) *models.Comment {
+	created := *comment
+	created.ID = uuid.NewString()
+	created.CreatedAt = time.Now().UTC()
+	return &created
+}
+
+// sortComments orders comments oldest first
+func sortComments(comments []*models.Comment) {
+	sort.SliceStable(comments, func(i, j int) bool {
+		return comments[i].CreatedAt.Before(comments[j].CreatedAt)
+	})
+}
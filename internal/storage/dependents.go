@@ -0,0 +1,375 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"roadmap-visualizer/internal/models"
+	"sort"
+	"sync"
+	"time"
+)
+
+// dependentEntry is one external dependency of an indexed roadmap
+type dependentEntry struct {
+	targetID   string
+	targetName string
+	dependent  models.ExternalDependent
+}
+
+// DependentsIndex wraps a Storage and keeps a reverse index of external
+// dependencies, from the roadmaps depended on to the items depending on them,
+// so dependents can be found without loading every roadmap.
+//
+// Backends that are a DependentsFinder, SQLite and Postgres, already keep the
+// index in their external_dependencies table, so it persists and is shared by
+// every replica; the wrapper only queries it. For the other backends the
+// index is held in memory, built from storage at startup and updated after
+// each change made through it; changes made to the wrapped storage directly
+// must be passed to Reindexed. Changes made by another process sharing the
+// backend, such as a second replica on the same S3 bucket, don't reach it.
+// With MaxAge set, an index older than that is rebuilt from storage before it
+// answers, so it is at most that stale.
+type DependentsIndex struct {
+	Storage
+	// MaxAge is how long an in-memory index is trusted before it is rebuilt;
+	// zero trusts it until the process exits
+	MaxAge time.Duration
+
+	// finder is the wrapped storage when it keeps the index itself
+	finder DependentsFinder
+
+	mu sync.RWMutex
+	// built is when the index was last rebuilt
+	built time.Time
+	// names is the name of each live roadmap, by ID
+	names map[string]string
+	// entries is the external dependencies of each live roadmap, by ID
+	entries map[string][]dependentEntry
+	// byID and byName are the IDs of the roadmaps with an external
+	// dependency on a roadmap ID or name
+	byID   map[string]map[string]bool
+	byName map[string]map[string]bool
+}
+
+// NewDependentsIndex wraps store and indexes the roadmaps already in it,
+// unless store keeps the index itself
+func NewDependentsIndex(store Storage) (*DependentsIndex, error) {
+	idx := &DependentsIndex{Storage: store}
+	if finder, ok := store.(DependentsFinder); ok {
+		idx.finder = finder
+		return idx, nil
+	}
+	if err := idx.Rebuild(); err != nil {
+		return nil, err
+	}
+	return idx, nil
+}
+
+// Rebuild indexes every live roadmap from scratch. It does nothing when the
+// wrapped storage keeps the index itself.
+func (idx *DependentsIndex) Rebuild() error {
+	if idx.finder != nil {
+		return nil
+	}
+	roadmaps, err := idx.Storage.List(ListFilter{})
+	if err != nil {
+		return err
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.built = time.Now()
+	idx.names = make(map[string]string, len(roadmaps))
+	idx.entries = make(map[string][]dependentEntry, len(roadmaps))
+	idx.byID = make(map[string]map[string]bool)
+	idx.byName = make(map[string]map[string]bool)
+	for _, rm := range roadmaps {
+		idx.add(rm)
+	}
+	return nil
+}
+
+// Dependents returns the items of other roadmaps with an external dependency
+// on the roadmap, by its ID or current name, ordered by roadmap ID and then
+// as listed. It returns nothing for a roadmap that isn't live.
+func (idx *DependentsIndex) Dependents(roadmapID string) ([]models.ExternalDependent, error) {
+	if idx.finder != nil {
+		return idx.finder.Dependents(roadmapID)
+	}
+	if idx.expired() {
+		if err := idx.Rebuild(); err != nil {
+			return nil, err
+		}
+	}
+
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	name, ok := idx.names[roadmapID]
+	if !ok {
+		return nil, nil
+	}
+	var sources []string
+	for source := range idx.byID[roadmapID] {
+		sources = append(sources, source)
+	}
+	for source := range idx.byName[name] {
+		if !idx.byID[roadmapID][source] {
+			sources = append(sources, source)
+		}
+	}
+	sort.Strings(sources)
+
+	var dependents []models.ExternalDependent
+	for _, source := range sources {
+		if source == roadmapID {
+			continue
+		}
+		for _, entry := range idx.entries[source] {
+			if entry.targetID == roadmapID || entry.targetName == name {
+				dependents = append(dependents, entry.dependent)
+			}
+		}
+	}
+	return dependents, nil
+}
+
+// expired reports whether the index is older than MaxAge
+func (idx *DependentsIndex) expired() bool {
+	if idx.MaxAge <= 0 {
+		return false
+	}
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return time.Since(idx.built) > idx.MaxAge
+}
+
+// Create stores a roadmap and indexes it
+func (idx *DependentsIndex) Create(roadmap *models.Roadmap, originalFileName, author string) (*models.StoredRoadmap, error) {
+	stored, err := idx.Storage.Create(roadmap, originalFileName, author)
+	if err == nil {
+		idx.refresh(stored.ID)
+	}
+	return stored, err
+}
+
+// Update stores a new revision and reindexes the roadmap
+func (idx *DependentsIndex) Update(id string, roadmap *models.Roadmap, author string, ifRevision int) (*models.StoredRoadmap, error) {
+	stored, err := idx.Storage.Update(id, roadmap, author, ifRevision)
+	if err == nil {
+		idx.refresh(id)
+	}
+	return stored, err
+}
+
+// Delete removes a roadmap and drops it from the index
+func (idx *DependentsIndex) Delete(id string) error {
+	err := idx.Storage.Delete(id)
+	if err == nil {
+		idx.refresh(id)
+	}
+	return err
+}
+
+// Trash soft-deletes a roadmap and drops it from the index
+func (idx *DependentsIndex) Trash(id string) error {
+	err := idx.Storage.Trash(id)
+	if err == nil {
+		idx.refresh(id)
+	}
+	return err
+}
+
+// Restore brings a roadmap back from the trash and indexes it again
+func (idx *DependentsIndex) Restore(id string) (*models.StoredRoadmap, error) {
+	stored, err := idx.Storage.Restore(id)
+	if err == nil {
+		idx.refresh(id)
+	}
+	return stored, err
+}
+
+// Import stores a roadmap under its existing ID and reindexes it
+func (idx *DependentsIndex) Import(stored *models.StoredRoadmap, revisions []*models.Revision) error {
+	err := idx.Storage.Import(stored, revisions)
+	if err == nil {
+		idx.refresh(stored.ID)
+	}
+	return err
+}
+
+// Reindex syncs a backend that supports it and reindexes what changed
+func (idx *DependentsIndex) Reindex() (*ReindexResult, error) {
+	reindexer, ok := idx.Storage.(Reindexer)
+	if !ok {
+		return nil, ErrReindexNotSupported
+	}
+	result, err := reindexer.Reindex()
+	if err != nil {
+		return nil, err
+	}
+	idx.Reindexed(result)
+	return result, nil
+}
+
+// Reindexed updates the index with the changes found by a reindex that ran
+// on the wrapped storage directly, such as the data directory watcher
+func (idx *DependentsIndex) Reindexed(result *ReindexResult) {
+	if result == nil || !result.Changed() {
+		return
+	}
+	for _, ids := range [][]string{result.Added, result.Updated, result.Removed} {
+		for _, id := range ids {
+			idx.refresh(id)
+		}
+	}
+}
+
+// refresh reindexes a roadmap as it now is in storage, dropping it if it is
+// no longer live. Storage that keeps the index itself has already done so.
+func (idx *DependentsIndex) refresh(id string) {
+	if idx.finder != nil {
+		return
+	}
+	stored, err := idx.Storage.Get(id)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		// Leave the roadmap as it was indexed; the next change to it will
+		// try again
+		return
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.remove(id)
+	if err == nil {
+		idx.add(stored)
+	}
+}
+
+// add indexes a roadmap; the caller holds mu
+func (idx *DependentsIndex) add(stored *models.StoredRoadmap) {
+	idx.names[stored.ID] = stored.Roadmap.Name
+	for _, item := range stored.Roadmap.Items {
+		for _, extDep := range item.ExternalDependencies {
+			idx.entries[stored.ID] = append(idx.entries[stored.ID], dependentEntry{
+				targetID:   extDep.RoadmapID,
+				targetName: extDep.RoadmapName,
+				dependent: models.ExternalDependent{
+					RoadmapID:   stored.ID,
+					RoadmapName: stored.Roadmap.Name,
+					ItemID:      item.ID,
+					ItemName:    item.Name,
+					DependsOn:   extDep.Target(),
+				},
+			})
+			addSource(idx.byID, extDep.RoadmapID, stored.ID)
+			addSource(idx.byName, extDep.RoadmapName, stored.ID)
+		}
+	}
+}
+
+// remove drops a roadmap from the index; the caller holds mu
+func (idx *DependentsIndex) remove(id string) {
+	for _, entry := range idx.entries[id] {
+		removeSource(idx.byID, entry.targetID, id)
+		removeSource(idx.byName, entry.targetName, id)
+	}
+	delete(idx.entries, id)
+	delete(idx.names, id)
+}
+
+// addSource records that source depends on target
+func addSource(index map[string]map[string]bool, target, source string) {
+	if target == "" {
+		return
+	}
+	if index[target] == nil {
+		index[target] = make(map[string]bool)
+	}
+	index[target][source] = true
+}
+
+// removeSource forgets that source depends on target
+func removeSource(index map[string]map[string]bool, target, source string) {
+	delete(index[target], source)
+	if len(index[target]) == 0 {
+		delete(index, target)
+	}
+}
+
+// DependentsError is returned by CheckDelete when items in other roadmaps
+// depend on the roadmap being deleted
+type DependentsError struct {
+	Dependents []models.ExternalDependent
+}
+
+func (e *DependentsError) Error() string {
+	return fmt.Sprintf("%d items in other roadmaps depend on this roadmap", len(e.Dependents))
+}
+
+// FindDependents returns the items of other roadmaps that depend on the
+// roadmap, from index, or by loading every roadmap in store when there is no
+// index
+func FindDependents(store Storage, index *DependentsIndex, id string) ([]models.ExternalDependent, error) {
+	if index != nil {
+		return index.Dependents(id)
+	}
+	roadmaps, err := store.List(ListFilter{})
+	if err != nil {
+		return nil, err
+	}
+	return GetExternalDependents(id, roadmaps), nil
+}
+
+// CheckDelete returns a *DependentsError if items in other roadmaps depend on
+// the roadmap, other than items of the roadmaps in with, which are being
+// deleted along with it. Dependents are found as by FindDependents.
+func CheckDelete(store Storage, index *DependentsIndex, id string, with map[string]bool) error {
+	dependents, err := FindDependents(store, index, id)
+	if err != nil {
+		return err
+	}
+	var remaining []models.ExternalDependent
+	for _, dependent := range dependents {
+		if !with[dependent.RoadmapID] {
+			remaining = append(remaining, dependent)
+		}
+	}
+	if len(remaining) > 0 {
+		return &DependentsError{Dependents: remaining}
+	}
+	return nil
+}
+
+// dependentColumns and dependentTables select the dependents of a roadmap
+// from the external_dependencies table of the SQL backends, joined to the
+// live roadmap and the item each dependency belongs to
+const (
+	dependentColumns = `d.roadmap_id, r.name, d.item_id, i.name, d.target_item, d.target_item_name, d.target_milestone, d.target_completion`
+	dependentTables  = `external_dependencies d
+		 JOIN roadmaps r ON r.id = d.roadmap_id AND r.deleted_at IS NULL
+		 JOIN items i ON i.roadmap_id = d.roadmap_id AND i.item_id = d.item_id`
+)
+
+// readDependents reads and closes rows selected with dependentColumns
+func readDependents(rows *sql.Rows) ([]models.ExternalDependent, error) {
+	defer rows.Close()
+
+	var dependents []models.ExternalDependent
+	for rows.Next() {
+		var dependent models.ExternalDependent
+		var target models.ExternalDependency
+		if err := rows.Scan(&dependent.RoadmapID, &dependent.RoadmapName, &dependent.ItemID, &dependent.ItemName,
+			&target.ItemID, &target.ItemName, &target.Milestone, &target.Completion); err != nil {
+			return nil, fmt.Errorf("failed to read dependent: %w", err)
+		}
+		dependent.DependsOn = target.Target()
+		dependents = append(dependents, dependent)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read dependents: %w", err)
+	}
+
+	return dependents, nil
+}
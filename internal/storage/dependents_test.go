@@ -0,0 +1,109 @@
+package storage
+
+import (
+	"path/filepath"
+	"roadmap-visualizer/internal/models"
+	"testing"
+	"time"
+)
+
+func dependencyRoadmaps() (*models.Roadmap, *models.Roadmap) {
+	platform := &models.Roadmap{Name: "Platform", ServiceLine: "Infra", Items: []models.RoadmapItem{
+		{ID: "auth", Name: "Auth", Status: "planned"},
+	}}
+	payments := &models.Roadmap{Name: "Payments", ServiceLine: "Commerce", Items: []models.RoadmapItem{
+		{ID: "checkout", Name: "Checkout", Status: "planned", ExternalDependencies: []models.ExternalDependency{
+			{RoadmapName: "Platform", ItemID: "auth"},
+		}},
+	}}
+	return platform, payments
+}
+
+func TestDependentsIndexSharedBackend(t *testing.T) {
+	shared := NewMemoryStorage()
+	replicaA, err := NewDependentsIndex(shared)
+	if err != nil {
+		t.Fatal(err)
+	}
+	replicaB, err := NewDependentsIndex(shared)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	platform, payments := dependencyRoadmaps()
+	stored, err := replicaA.Create(platform, "platform.yaml", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := replicaB.Create(payments, "payments.yaml", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	// Replica A never saw the dependent roadmap, so without a maximum age its
+	// index stays as it was
+	dependents, err := replicaA.Dependents(stored.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dependents) != 0 {
+		t.Fatalf("dependents before expiry = %v, want none", dependents)
+	}
+
+	replicaA.MaxAge = time.Nanosecond
+	time.Sleep(time.Millisecond)
+	dependents, err = replicaA.Dependents(stored.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dependents) != 1 || dependents[0].ItemID != "checkout" {
+		t.Errorf("dependents after expiry = %v, want checkout", dependents)
+	}
+}
+
+func TestDependentsIndexStoredInSQLite(t *testing.T) {
+	shared, err := NewSQLiteStorage(filepath.Join(t.TempDir(), "roadmaps.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer shared.Close()
+	replicaA, err := NewDependentsIndex(shared)
+	if err != nil {
+		t.Fatal(err)
+	}
+	replicaB, err := NewDependentsIndex(shared)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	platform, payments := dependencyRoadmaps()
+	stored, err := replicaA.Create(platform, "platform.yaml", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	payments.Items[0].ExternalDependencies = append(payments.Items[0].ExternalDependencies,
+		models.ExternalDependency{RoadmapID: stored.ID, Milestone: "ga"})
+	dependent, err := replicaB.Create(payments, "payments.yaml", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The index lives in the database, so replica A sees replica B's roadmap
+	// straight away, by name and by ID
+	dependents, err := replicaA.Dependents(stored.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dependents) != 2 || dependents[0].DependsOn != "auth" || dependents[1].DependsOn != "milestone:ga" {
+		t.Fatalf("dependents = %v, want auth and milestone:ga of checkout", dependents)
+	}
+	if dependents[0].RoadmapName != "Payments" || dependents[0].ItemName != "Checkout" {
+		t.Errorf("dependent = %+v, want Payments' Checkout", dependents[0])
+	}
+
+	if err := replicaB.Trash(dependent.ID); err != nil {
+		t.Fatal(err)
+	}
+	if dependents, err = replicaA.Dependents(stored.ID); err != nil || len(dependents) != 0 {
+		t.Errorf("dependents after trashing = %v, %v; want none", dependents, err)
+	}
+}
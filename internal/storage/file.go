@@ -1,22 +1,37 @@
 package storage
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"hash/fnv"
 	"os"
 	"path/filepath"
 	"roadmap-visualizer/internal/models"
 	"roadmap-visualizer/internal/parser"
+	"sort"
+	"strings"
 	"sync"
 	"time"
-
-	"github.com/google/uuid"
 )
 
+// lockStripes is the number of locks roadmap IDs are spread across
+const lockStripes = 64
+
 // FileStorage implements file-based storage for roadmaps
 type FileStorage struct {
 	dataDir string
-	mu      sync.RWMutex
+
+	// locks serialize operations on the same roadmap while letting
+	// operations on different roadmaps run concurrently; see lockFor
+	locks [lockStripes]sync.RWMutex
+	// reindexMu allows only one reindex at a time
+	reindexMu sync.Mutex
+
+	// index caches the metadata JSON of every live roadmap by ID so Get and
+	// List don't touch the disk. It is kept current by apply and guarded by mu.
+	index map[string][]byte
+	mu    sync.RWMutex
 }
 
 // NewFileStorage creates a new file storage instance
@@ -26,9 +41,13 @@ func NewFileStorage(dataDir string) (*FileStorage, error) {
 		return nil, fmt.Errorf("failed to create data directory: %w", err)
 	}
 
-	// Create subdirectories for YAML and metadata
+	// Create subdirectories for YAML, metadata, revision history, trash, and the write journal
 	yamlDir := filepath.Join(dataDir, "yaml")
 	metaDir := filepath.Join(dataDir, "meta")
+	revisionsDir := filepath.Join(dataDir, "revisions")
+	trashYAMLDir := filepath.Join(dataDir, "trash", "yaml")
+	trashMetaDir := filepath.Join(dataDir, "trash", "meta")
+	journalDir := filepath.Join(dataDir, "journal")
 
 	if err := os.MkdirAll(yamlDir, 0755); err != nil {
 		return nil, fmt.Errorf("failed to create yaml directory: %w", err)
@@ -36,18 +55,91 @@ func NewFileStorage(dataDir string) (*FileStorage, error) {
 	if err := os.MkdirAll(metaDir, 0755); err != nil {
 		return nil, fmt.Errorf("failed to create meta directory: %w", err)
 	}
+	if err := os.MkdirAll(revisionsDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create revisions directory: %w", err)
+	}
+	if err := os.MkdirAll(trashYAMLDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create trash yaml directory: %w", err)
+	}
+	if err := os.MkdirAll(trashMetaDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create trash meta directory: %w", err)
+	}
+	if err := os.MkdirAll(journalDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create journal directory: %w", err)
+	}
 
-	return &FileStorage{
+	fs := &FileStorage{
 		dataDir: dataDir,
-	}, nil
+		index:   make(map[string][]byte),
+	}
+
+	// Finish any writes interrupted by a crash before serving requests
+	if err := fs.recoverJournal(); err != nil {
+		return nil, fmt.Errorf("failed to recover storage: %w", err)
+	}
+
+	if err := fs.loadIndex(); err != nil {
+		return nil, err
+	}
+
+	return fs, nil
 }
 
-// Create stores a new roadmap
-func (fs *FileStorage) Create(roadmap *models.Roadmap, originalFileName string) (*models.StoredRoadmap, error) {
+// loadIndex reads every metadata file into the in-memory index
+func (fs *FileStorage) loadIndex() error {
+	metaDir := filepath.Join(fs.dataDir, "meta")
+	entries, err := os.ReadDir(metaDir)
+	if err != nil {
+		return fmt.Errorf("failed to read metadata directory: %w", err)
+	}
+
+	index := make(map[string][]byte, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" || isTempFile(entry.Name()) {
+			continue
+		}
+
+		metaData, err := os.ReadFile(filepath.Join(metaDir, entry.Name()))
+		if err != nil {
+			continue // Skip files we can't read
+		}
+		if !json.Valid(metaData) {
+			continue // Skip files we can't parse
+		}
+
+		index[strings.TrimSuffix(entry.Name(), ".json")] = metaData
+	}
+
 	fs.mu.Lock()
-	defer fs.mu.Unlock()
+	fs.index = index
+	fs.mu.Unlock()
+
+	return nil
+}
+
+// Create stores a new roadmap
+func (fs *FileStorage) Create(roadmap *models.Roadmap, originalFileName, author string) (*models.StoredRoadmap, error) {
+	// Claim a free ID and keep its lock held until the files are written
+	var lock *sync.RWMutex
+	id, err := newID(roadmap.Name, func(candidate string) (bool, error) {
+		l := fs.lockFor(candidate)
+		l.Lock()
+		if fs.idTaken(candidate) {
+			l.Unlock()
+			return true, nil
+		}
+		lock = l
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if lock == nil {
+		lock = fs.lockFor(id)
+		lock.Lock()
+	}
+	defer lock.Unlock()
 
-	id := uuid.New().String()
 	now := time.Now()
 
 	stored := &models.StoredRoadmap{
@@ -56,6 +148,9 @@ func (fs *FileStorage) Create(roadmap *models.Roadmap, originalFileName string)
 		CreatedAt: now,
 		UpdatedAt: now,
 		FileName:  originalFileName,
+		Revision:  1,
+		CreatedBy: author,
+		UpdatedBy: author,
 	}
 
 	// Serialize roadmap to YAML
@@ -64,23 +159,27 @@ func (fs *FileStorage) Create(roadmap *models.Roadmap, originalFileName string)
 		return nil, fmt.Errorf("failed to serialize roadmap: %w", err)
 	}
 
-	// Write YAML file
-	yamlPath := filepath.Join(fs.dataDir, "yaml", fmt.Sprintf("%s.yaml", id))
-	if err := os.WriteFile(yamlPath, yamlData, 0644); err != nil {
-		return nil, fmt.Errorf("failed to write yaml file: %w", err)
-	}
-
-	// Write metadata file
 	metaData, err := json.Marshal(stored)
 	if err != nil {
 		return nil, fmt.Errorf("failed to serialize metadata: %w", err)
 	}
 
-	metaPath := filepath.Join(fs.dataDir, "meta", fmt.Sprintf("%s.json", id))
-	if err := os.WriteFile(metaPath, metaData, 0644); err != nil {
-		// Clean up YAML file if metadata write fails
-		os.Remove(yamlPath)
-		return nil, fmt.Errorf("failed to write metadata file: %w", err)
+	revision, err := revisionWrite(stored)
+	if err != nil {
+		return nil, err
+	}
+
+	// Write YAML, metadata, and the first revision as one journaled operation
+	err = fs.commit(&journalEntry{
+		ID: id,
+		Writes: []journalWrite{
+			{Path: yamlFile(id), Data: yamlData},
+			{Path: metaFile(id), Data: metaData},
+			revision,
+		},
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return stored, nil
@@ -91,11 +190,54 @@ func (fs *FileStorage) Get(id string) (*models.StoredRoadmap, error) {
 	fs.mu.RLock()
 	defer fs.mu.RUnlock()
 
+	metaData, ok := fs.index[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	var stored models.StoredRoadmap
+	if err := json.Unmarshal(metaData, &stored); err != nil {
+		return nil, fmt.Errorf("failed to parse metadata: %w", err)
+	}
+
+	return &stored, nil
+}
+
+// List returns the stored roadmaps that match the filter, ordered by creation time
+func (fs *FileStorage) List(filter ListFilter) ([]*models.StoredRoadmap, error) {
+	fs.mu.RLock()
+	defer fs.mu.RUnlock()
+
+	roadmaps := make([]*models.StoredRoadmap, 0, len(fs.index))
+	for _, metaData := range fs.index {
+		var stored models.StoredRoadmap
+		if err := json.Unmarshal(metaData, &stored); err != nil {
+			continue // Skip entries we can't parse
+		}
+		if !filter.Matches(&stored) {
+			continue
+		}
+		roadmaps = append(roadmaps, &stored)
+	}
+
+	sort.Slice(roadmaps, func(i, j int) bool {
+		return roadmaps[i].CreatedAt.Before(roadmaps[j].CreatedAt)
+	})
+
+	return roadmaps, nil
+}
+
+// Update replaces the roadmap content for an existing ID
+func (fs *FileStorage) Update(id string, roadmap *models.Roadmap, author string, ifRevision int) (*models.StoredRoadmap, error) {
+	lock := fs.lockFor(id)
+	lock.Lock()
+	defer lock.Unlock()
+
 	metaPath := filepath.Join(fs.dataDir, "meta", fmt.Sprintf("%s.json", id))
 	metaData, err := os.ReadFile(metaPath)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return nil, fmt.Errorf("roadmap not found")
+			return nil, ErrNotFound
 		}
 		return nil, fmt.Errorf("failed to read metadata: %w", err)
 	}
@@ -105,18 +247,128 @@ func (fs *FileStorage) Get(id string) (*models.StoredRoadmap, error) {
 		return nil, fmt.Errorf("failed to parse metadata: %w", err)
 	}
 
+	if err := checkRevision(&stored, ifRevision); err != nil {
+		return nil, err
+	}
+
+	var writes []journalWrite
+
+	// Roadmaps stored before revisions existed get their current content
+	// recorded as revision 1 so it isn't lost from the history
+	if stored.Revision == 0 {
+		stored.Revision = 1
+		legacy, err := revisionWrite(&stored)
+		if err != nil {
+			return nil, err
+		}
+		writes = append(writes, legacy)
+	}
+
+	stored.Roadmap = *roadmap
+	stored.UpdatedAt = time.Now()
+	stored.Revision++
+	stored.UpdatedBy = author
+
+	// Serialize roadmap to YAML
+	yamlData, err := parser.SerializeRoadmap(roadmap)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize roadmap: %w", err)
+	}
+
+	newMetaData, err := json.Marshal(&stored)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize metadata: %w", err)
+	}
+
+	revision, err := revisionWrite(&stored)
+	if err != nil {
+		return nil, err
+	}
+
+	writes = append(writes,
+		journalWrite{Path: yamlFile(id), Data: yamlData},
+		journalWrite{Path: metaFile(id), Data: newMetaData},
+		revision,
+	)
+	if err := fs.commit(&journalEntry{ID: id, Writes: writes}); err != nil {
+		return nil, err
+	}
+
 	return &stored, nil
 }
 
-// List returns all stored roadmaps
-func (fs *FileStorage) List() ([]*models.StoredRoadmap, error) {
-	fs.mu.RLock()
-	defer fs.mu.RUnlock()
+// Delete removes a roadmap by ID
+func (fs *FileStorage) Delete(id string) error {
+	lock := fs.lockFor(id)
+	lock.Lock()
+	defer lock.Unlock()
 
-	metaDir := filepath.Join(fs.dataDir, "meta")
-	entries, err := os.ReadDir(metaDir)
+	metaPath := filepath.Join(fs.dataDir, metaFile(id))
+
+	// Check if metadata exists
+	if _, err := os.Stat(metaPath); os.IsNotExist(err) {
+		return ErrNotFound
+	}
+
+	// Remove the metadata first so a partial delete never lists the roadmap
+	return fs.commit(&journalEntry{
+		ID:      id,
+		Removes: []string{metaFile(id), yamlFile(id), revisionDir(id), commentDir(id)},
+	})
+}
+
+// Trash moves a roadmap's files into the trash directory
+func (fs *FileStorage) Trash(id string) error {
+	lock := fs.lockFor(id)
+	lock.Lock()
+	defer lock.Unlock()
+
+	metaPath := filepath.Join(fs.dataDir, metaFile(id))
+
+	metaData, err := os.ReadFile(metaPath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read metadata directory: %w", err)
+		if os.IsNotExist(err) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to read metadata: %w", err)
+	}
+
+	yamlData, err := os.ReadFile(filepath.Join(fs.dataDir, yamlFile(id)))
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read yaml file: %w", err)
+	}
+
+	var stored models.StoredRoadmap
+	if err := json.Unmarshal(metaData, &stored); err != nil {
+		return fmt.Errorf("failed to parse metadata: %w", err)
+	}
+
+	now := time.Now()
+	stored.DeletedAt = &now
+
+	trashMetaData, err := json.Marshal(&stored)
+	if err != nil {
+		return fmt.Errorf("failed to serialize metadata: %w", err)
+	}
+
+	return fs.commit(&journalEntry{
+		ID: id,
+		Writes: []journalWrite{
+			{Path: trashYAMLFile(id), Data: yamlData},
+			{Path: trashMetaFile(id), Data: trashMetaData},
+		},
+		Removes: []string{metaFile(id), yamlFile(id)},
+	})
+}
+
+// ListTrash returns all roadmaps in the trash directory
+func (fs *FileStorage) ListTrash() ([]*models.StoredRoadmap, error) {
+	// Trash files are only ever replaced atomically, so no lock is needed
+	// to read a consistent copy of each
+	trashMetaDir := filepath.Join(fs.dataDir, "trash", "meta")
+	entries, err := os.ReadDir(trashMetaDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trash directory: %w", err)
 	}
 
 	var roadmaps []*models.StoredRoadmap
@@ -125,8 +377,7 @@ func (fs *FileStorage) List() ([]*models.StoredRoadmap, error) {
 			continue
 		}
 
-		metaPath := filepath.Join(metaDir, entry.Name())
-		metaData, err := os.ReadFile(metaPath)
+		metaData, err := os.ReadFile(filepath.Join(trashMetaDir, entry.Name()))
 		if err != nil {
 			continue // Skip files we can't read
 		}
@@ -142,31 +393,540 @@ func (fs *FileStorage) List() ([]*models.StoredRoadmap, error) {
 	return roadmaps, nil
 }
 
-// Delete removes a roadmap by ID
-func (fs *FileStorage) Delete(id string) error {
-	fs.mu.Lock()
-	defer fs.mu.Unlock()
+// Restore moves a roadmap's files out of the trash directory
+func (fs *FileStorage) Restore(id string) (*models.StoredRoadmap, error) {
+	lock := fs.lockFor(id)
+	lock.Lock()
+	defer lock.Unlock()
 
-	yamlPath := filepath.Join(fs.dataDir, "yaml", fmt.Sprintf("%s.yaml", id))
-	metaPath := filepath.Join(fs.dataDir, "meta", fmt.Sprintf("%s.json", id))
+	metaData, err := os.ReadFile(filepath.Join(fs.dataDir, trashMetaFile(id)))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNotInTrash
+		}
+		return nil, fmt.Errorf("failed to read trash metadata: %w", err)
+	}
 
-	// Check if metadata exists
+	yamlData, err := os.ReadFile(filepath.Join(fs.dataDir, trashYAMLFile(id)))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read trash yaml file: %w", err)
+	}
+
+	var stored models.StoredRoadmap
+	if err := json.Unmarshal(metaData, &stored); err != nil {
+		return nil, fmt.Errorf("failed to parse trash metadata: %w", err)
+	}
+	stored.DeletedAt = nil
+
+	newMetaData, err := json.Marshal(&stored)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize metadata: %w", err)
+	}
+
+	err = fs.commit(&journalEntry{
+		ID: id,
+		Writes: []journalWrite{
+			{Path: yamlFile(id), Data: yamlData},
+			{Path: metaFile(id), Data: newMetaData},
+		},
+		Removes: []string{trashMetaFile(id), trashYAMLFile(id)},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &stored, nil
+}
+
+// Purge permanently removes a trashed roadmap and its revisions
+func (fs *FileStorage) Purge(id string) error {
+	lock := fs.lockFor(id)
+	lock.Lock()
+	defer lock.Unlock()
+
+	if _, err := os.Stat(filepath.Join(fs.dataDir, trashMetaFile(id))); os.IsNotExist(err) {
+		return ErrNotInTrash
+	}
+
+	return fs.commit(&journalEntry{
+		ID:      id,
+		Removes: []string{trashMetaFile(id), trashYAMLFile(id), revisionDir(id), commentDir(id)},
+	})
+}
+
+// Import stores a roadmap and its revision history under its existing ID
+func (fs *FileStorage) Import(stored *models.StoredRoadmap, revisions []*models.Revision) error {
+	lock := fs.lockFor(stored.ID)
+	lock.Lock()
+	defer lock.Unlock()
+
+	imported := *stored
+	imported.DeletedAt = nil
+
+	yamlData, err := parser.SerializeRoadmap(&imported.Roadmap)
+	if err != nil {
+		return fmt.Errorf("failed to serialize roadmap: %w", err)
+	}
+
+	metaData, err := json.Marshal(&imported)
+	if err != nil {
+		return fmt.Errorf("failed to serialize metadata: %w", err)
+	}
+
+	writes := []journalWrite{
+		{Path: yamlFile(imported.ID), Data: yamlData},
+		{Path: metaFile(imported.ID), Data: metaData},
+	}
+	for _, rev := range revisions {
+		data, err := json.Marshal(rev)
+		if err != nil {
+			return fmt.Errorf("failed to serialize revision: %w", err)
+		}
+		path := filepath.Join(revisionDir(imported.ID), fmt.Sprintf("%d.json", rev.Number))
+		writes = append(writes, journalWrite{Path: path, Data: data})
+	}
+
+	return fs.commit(&journalEntry{
+		ID:      imported.ID,
+		Writes:  writes,
+		Removes: []string{trashMetaFile(imported.ID), trashYAMLFile(imported.ID), revisionDir(imported.ID), commentDir(imported.ID)},
+	})
+}
+
+// ListRevisions returns the revision history of a roadmap, oldest first
+func (fs *FileStorage) ListRevisions(id string) ([]*models.Revision, error) {
+	lock := fs.lockFor(id)
+	lock.RLock()
+	defer lock.RUnlock()
+
+	metaPath := filepath.Join(fs.dataDir, "meta", fmt.Sprintf("%s.json", id))
 	if _, err := os.Stat(metaPath); os.IsNotExist(err) {
-		return fmt.Errorf("roadmap not found")
+		return nil, ErrNotFound
+	}
+
+	revisionDir := filepath.Join(fs.dataDir, "revisions", id)
+	entries, err := os.ReadDir(revisionDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read revisions directory: %w", err)
+	}
+
+	var revisions []*models.Revision
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(revisionDir, entry.Name()))
+		if err != nil {
+			continue // Skip files we can't read
+		}
+
+		var revision models.Revision
+		if err := json.Unmarshal(data, &revision); err != nil {
+			continue // Skip files we can't parse
+		}
+
+		revisions = append(revisions, &revision)
 	}
 
-	// Delete both files
-	if err := os.Remove(yamlPath); err != nil && !os.IsNotExist(err) {
-		return fmt.Errorf("failed to delete yaml file: %w", err)
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Number < revisions[j].Number
+	})
+
+	return revisions, nil
+}
+
+// GetRevision returns a single revision of a roadmap
+func (fs *FileStorage) GetRevision(id string, revision int) (*models.Revision, error) {
+	lock := fs.lockFor(id)
+	lock.RLock()
+	defer lock.RUnlock()
+
+	revisionPath := filepath.Join(fs.dataDir, "revisions", id, fmt.Sprintf("%d.json", revision))
+	data, err := os.ReadFile(revisionPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrRevisionNotFound
+		}
+		return nil, fmt.Errorf("failed to read revision: %w", err)
 	}
 
-	if err := os.Remove(metaPath); err != nil {
-		return fmt.Errorf("failed to delete metadata file: %w", err)
+	var rev models.Revision
+	if err := json.Unmarshal(data, &rev); err != nil {
+		return nil, fmt.Errorf("failed to parse revision: %w", err)
 	}
 
-	return nil
+	return &rev, nil
+}
+
+// AddComment stores a comment on a live roadmap as a file under comments/{id}
+func (fs *FileStorage) AddComment(id string, comment *models.Comment) (*models.Comment, error) {
+	lock := fs.lockFor(id)
+	lock.Lock()
+	defer lock.Unlock()
+
+	if _, err := os.Stat(filepath.Join(fs.dataDir, metaFile(id))); os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+
+	created := newComment(comment)
+	data, err := json.Marshal(created)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize comment: %w", err)
+	}
+
+	err = fs.commit(&journalEntry{
+		ID:     id,
+		Writes: []journalWrite{{Path: filepath.Join(commentDir(id), created.ID+".json"), Data: data}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// ListComments returns the comments on a live roadmap, oldest first
+func (fs *FileStorage) ListComments(id string) ([]*models.Comment, error) {
+	lock := fs.lockFor(id)
+	lock.RLock()
+	defer lock.RUnlock()
+
+	if _, err := os.Stat(filepath.Join(fs.dataDir, metaFile(id))); os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+
+	dir := filepath.Join(fs.dataDir, commentDir(id))
+	entries, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read comments directory: %w", err)
+	}
+
+	comments := []*models.Comment{}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" || isTempFile(entry.Name()) {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			continue // Skip files we can't read
+		}
+
+		var comment models.Comment
+		if err := json.Unmarshal(data, &comment); err != nil {
+			continue // Skip files we can't parse
+		}
+
+		comments = append(comments, &comment)
+	}
+
+	sortComments(comments)
+	return comments, nil
+}
+
+// Reindex brings metadata in line with the yaml directory after files were
+// added, edited, or removed by hand or by a git sync. New files are registered
+// with their name without the extension as the roadmap ID, edited files are
+// recorded as a new revision, and roadmaps whose file is gone are deleted.
+// YAML files are never rewritten. Files that fail to parse or validate, or
+// whose name is a reserved ID, are reported and skipped, keeping any
+// previously stored content.
+func (fs *FileStorage) Reindex() (*ReindexResult, error) {
+	// Roadmaps are locked one at a time as they're synced, so API requests
+	// keep being served during a reindex
+	fs.reindexMu.Lock()
+	defer fs.reindexMu.Unlock()
+
+	yamlDir := filepath.Join(fs.dataDir, "yaml")
+	entries, err := os.ReadDir(yamlDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read yaml directory: %w", err)
+	}
+
+	result := &ReindexResult{
+		Added:   []string{},
+		Updated: []string{},
+		Removed: []string{},
+		Errors:  []ReindexError{},
+	}
+
+	present := make(map[string]bool)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || filepath.Ext(name) != ".yaml" || isTempFile(name) {
+			continue
+		}
+
+		id := strings.TrimSuffix(name, ".yaml")
+		if id == "" || strings.HasPrefix(id, ".") {
+			continue
+		}
+		present[id] = true
+		if reservedIDs[id] {
+			result.Errors = append(result.Errors, ReindexError{
+				File:  name,
+				Error: fmt.Sprintf("%q is reserved for the /api/roadmaps/%s route; rename the file", id, id),
+			})
+			continue
+		}
+
+		added, updated, err := fs.reindexYAML(id, name)
+		if err != nil {
+			result.Errors = append(result.Errors, ReindexError{File: name, Error: err.Error()})
+			continue
+		}
+		if added {
+			result.Added = append(result.Added, id)
+		}
+		if updated {
+			result.Updated = append(result.Updated, id)
+		}
+	}
+
+	// Metadata without a YAML file means the file was removed
+	metaEntries, err := os.ReadDir(filepath.Join(fs.dataDir, "meta"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read metadata directory: %w", err)
+	}
+	for _, entry := range metaEntries {
+		name := entry.Name()
+		if entry.IsDir() || filepath.Ext(name) != ".json" || isTempFile(name) {
+			continue
+		}
+
+		id := strings.TrimSuffix(name, ".json")
+		if present[id] {
+			continue
+		}
+
+		removed, err := fs.removeOrphan(id)
+		if err != nil {
+			result.Errors = append(result.Errors, ReindexError{File: name, Error: err.Error()})
+			continue
+		}
+		if removed {
+			result.Removed = append(result.Removed, id)
+		}
+	}
+
+	return result, nil
 }
 
+// reindexYAML registers or syncs a single YAML file under the roadmap's lock
+func (fs *FileStorage) reindexYAML(id, name string) (added, updated bool, err error) {
+	lock := fs.lockFor(id)
+	lock.Lock()
+	defer lock.Unlock()
+
+	// The roadmap may have been deleted or trashed since the directory was listed
+	if _, err := os.Stat(filepath.Join(fs.dataDir, yamlFile(id))); os.IsNotExist(err) {
+		return false, false, nil
+	}
+
+	metaData, err := os.ReadFile(filepath.Join(fs.dataDir, metaFile(id)))
+	if os.IsNotExist(err) {
+		if _, err := fs.registerYAML(id, name); err != nil {
+			return false, false, err
+		}
+		return true, false, nil
+	}
+	if err != nil {
+		return false, false, err
+	}
+
+	var stored models.StoredRoadmap
+	if err := json.Unmarshal(metaData, &stored); err != nil {
+		return false, false, fmt.Errorf("failed to parse metadata: %w", err)
+	}
+
+	changed, err := fs.syncYAML(&stored)
+	if err != nil {
+		return false, false, err
+	}
+
+	return false, changed, nil
+}
+
+// removeOrphan deletes a roadmap whose YAML file was removed from disk
+func (fs *FileStorage) removeOrphan(id string) (bool, error) {
+	lock := fs.lockFor(id)
+	lock.Lock()
+	defer lock.Unlock()
+
+	// The roadmap may have been created or removed since the directory was listed
+	if _, err := os.Stat(filepath.Join(fs.dataDir, yamlFile(id))); err == nil {
+		return false, nil
+	}
+	if _, err := os.Stat(filepath.Join(fs.dataDir, metaFile(id))); os.IsNotExist(err) {
+		return false, nil
+	}
+
+	err := fs.commit(&journalEntry{
+		ID:      id,
+		Removes: []string{metaFile(id), revisionDir(id), commentDir(id)},
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// idTaken reports whether a live roadmap, a trashed roadmap, or a YAML file
+// not yet reindexed already uses id. The caller must hold the lock for id.
+func (fs *FileStorage) idTaken(id string) bool {
+	for _, path := range []string{metaFile(id), yamlFile(id), trashMetaFile(id)} {
+		if _, err := os.Stat(filepath.Join(fs.dataDir, path)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// lockFor returns the lock guarding a roadmap's files. IDs are hashed onto a
+// fixed set of locks, so unrelated roadmaps rarely contend and the lock set
+// never grows.
+func (fs *FileStorage) lockFor(id string) *sync.RWMutex {
+	h := fnv.New32a()
+	h.Write([]byte(id))
+	return &fs.locks[h.Sum32()%lockStripes]
+}
+
+// syncYAML records a new revision when a roadmap's YAML file no longer
+// matches its stored content, and reports whether it did
+func (fs *FileStorage) syncYAML(stored *models.StoredRoadmap) (bool, error) {
+	data, err := os.ReadFile(filepath.Join(fs.dataDir, yamlFile(stored.ID)))
+	if err != nil {
+		return false, fmt.Errorf("failed to read yaml file: %w", err)
+	}
+
+	roadmap, err := parser.ParseRoadmap(data)
+	if err != nil {
+		return false, err
+	}
+
+	current, err := json.Marshal(&stored.Roadmap)
+	if err != nil {
+		return false, fmt.Errorf("failed to serialize roadmap: %w", err)
+	}
+	onDisk, err := json.Marshal(roadmap)
+	if err != nil {
+		return false, fmt.Errorf("failed to serialize roadmap: %w", err)
+	}
+	if bytes.Equal(current, onDisk) {
+		return false, nil
+	}
+
+	var writes []journalWrite
+
+	// Roadmaps stored before revisions existed get their current content
+	// recorded as revision 1 so it isn't lost from the history
+	if stored.Revision == 0 {
+		stored.Revision = 1
+		legacy, err := revisionWrite(stored)
+		if err != nil {
+			return false, err
+		}
+		writes = append(writes, legacy)
+	}
+
+	stored.Roadmap = *roadmap
+	stored.UpdatedAt = time.Now()
+	stored.Revision++
+	stored.UpdatedBy = ""
+
+	metaData, err := json.Marshal(stored)
+	if err != nil {
+		return false, fmt.Errorf("failed to serialize metadata: %w", err)
+	}
+
+	revision, err := revisionWrite(stored)
+	if err != nil {
+		return false, err
+	}
+
+	writes = append(writes, journalWrite{Path: metaFile(stored.ID), Data: metaData}, revision)
+	if err := fs.commit(&journalEntry{ID: stored.ID, Writes: writes}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// registerYAML writes metadata and revision 1 for an existing YAML file
+func (fs *FileStorage) registerYAML(id, name string) (*models.StoredRoadmap, error) {
+	yamlPath := filepath.Join(fs.dataDir, yamlFile(id))
+	data, err := os.ReadFile(yamlPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read yaml file: %w", err)
+	}
+
+	roadmap, err := parser.ParseRoadmap(data)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(yamlPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat yaml file: %w", err)
+	}
+
+	stored := &models.StoredRoadmap{
+		ID:        id,
+		Roadmap:   *roadmap,
+		CreatedAt: info.ModTime(),
+		UpdatedAt: info.ModTime(),
+		FileName:  name,
+		Revision:  1,
+	}
+
+	metaData, err := json.Marshal(stored)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize metadata: %w", err)
+	}
+
+	revision, err := revisionWrite(stored)
+	if err != nil {
+		return nil, err
+	}
+
+	// The YAML file itself is not rewritten so it stays byte-for-byte what was synced
+	err = fs.commit(&journalEntry{
+		ID: id,
+		Writes: []journalWrite{
+			{Path: metaFile(id), Data: metaData},
+			revision,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return stored, nil
+}
+
+// revisionWrite returns the journal write that records the current content
+// of a stored roadmap as a revision
+func revisionWrite(stored *models.StoredRoadmap) (journalWrite, error) {
+	data, err := json.Marshal(revisionOf(stored))
+	if err != nil {
+		return journalWrite{}, fmt.Errorf("failed to serialize revision: %w", err)
+	}
+
+	path := filepath.Join(revisionDir(stored.ID), fmt.Sprintf("%d.json", stored.Revision))
+	return journalWrite{Path: path, Data: data}, nil
+}
+
+// Paths of a roadmap's files, relative to the data directory
+
+func yamlFile(id string) string      { return filepath.Join("yaml", id+".yaml") }
+func metaFile(id string) string      { return filepath.Join("meta", id+".json") }
+func trashYAMLFile(id string) string { return filepath.Join("trash", "yaml", id+".yaml") }
+func trashMetaFile(id string) string { return filepath.Join("trash", "meta", id+".json") }
+func revisionDir(id string) string   { return filepath.Join("revisions", id) }
+func commentDir(id string) string    { return filepath.Join("comments", id) }
+
 // ValidateExternalDependencies validates all external dependencies across roadmaps
 func ValidateExternalDependencies(roadmaps []*models.StoredRoadmap) []models.ExternalDependencyValidation {
 	// Convert to slice of values for models function
@@ -177,14 +937,29 @@ func ValidateExternalDependencies(roadmaps []*models.StoredRoadmap) []models.Ext
 	return models.ValidateExternalDependencies(rmValues)
 }
 
+// ValidateInternalDependencies checks the dates of internal dependencies across roadmaps
+func ValidateInternalDependencies(roadmaps []*models.StoredRoadmap) []models.DependencyDateConflict {
+	// Convert to slice of values for models function
+	rmValues := make([]models.StoredRoadmap, len(roadmaps))
+	for i, rm := range roadmaps {
+		rmValues[i] = *rm
+	}
+	return models.ValidateInternalDependencies(rmValues)
+}
+
+// ValidateLeadTimes checks external dependencies across roadmaps against the
+// lead time policy
+func ValidateLeadTimes(roadmaps []*models.StoredRoadmap) []models.LeadTimeViolation {
+	// Convert to slice of values for models function
+	rmValues := make([]models.StoredRoadmap, len(roadmaps))
+	for i, rm := range roadmaps {
+		rmValues[i] = *rm
+	}
+	return models.ValidateLeadTimes(rmValues)
+}
+
 // GetExternalDependents returns all items that depend on items in the given roadmap
-func GetExternalDependents(roadmapID string, allRoadmaps []*models.StoredRoadmap) []struct {
-	RoadmapID   string
-	RoadmapName string
-	ItemID      string
-	ItemName    string
-	DependsOn   string
-} {
+func GetExternalDependents(roadmapID string, allRoadmaps []*models.StoredRoadmap) []models.ExternalDependent {
 	// Convert to slice of values for models function
 	rmValues := make([]models.StoredRoadmap, len(allRoadmaps))
 	for i, rm := range allRoadmaps {
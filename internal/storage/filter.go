@@ -0,0 +1,239 @@
+package storage
+
+import (
+	"fmt"
+	"roadmap-visualizer/internal/models"
+	"time"
+)
+
+// ListFilter narrows the roadmaps returned by List. Empty fields match
+// everything, so the zero value lists every roadmap.
+type ListFilter struct {
+	ServiceLine string
+	// Owner matches roadmaps owned by, or with at least one item assigned
+	// to, this owner or team; see Roadmap.OwnedBy
+	Owner string
+	// Status matches roadmaps with at least one item in that status
+	Status models.RoadmapStatus
+	// Confidence matches roadmaps with at least one item of that confidence
+	Confidence models.Confidence
+	// Type matches roadmaps with at least one item of that type
+	Type string
+	// Health matches roadmaps whose health is this now; see Roadmap.Health
+	Health models.Health
+	// From and To match roadmaps with at least one item overlapping the
+	// range; either end may be left open. Both accept any item date format.
+	From string
+	To   string
+	// Portfolio and Category match the roadmap fields exactly
+	Portfolio string
+	Category  string
+	// Tag matches roadmaps carrying the tag, or with at least one item
+	// carrying it
+	Tag string
+	// ExcludeArchived leaves out archived roadmaps
+	ExcludeArchived bool
+	// Custom matches roadmaps with at least one item that has every one of
+	// these custom field values
+	Custom map[string]string
+}
+
+// Validate checks the status, confidence, type, health, custom field names, and date range
+// of the filter
+func (f ListFilter) Validate() error {
+	if f.Status != "" {
+		if err := models.ValidateStatus(string(f.Status)); err != nil {
+			return err
+		}
+	}
+	if f.Confidence != "" {
+		if err := models.ValidateConfidence(string(f.Confidence)); err != nil {
+			return err
+		}
+	}
+	if f.Type != "" {
+		if err := models.ValidateItemType(f.Type); err != nil {
+			return err
+		}
+	}
+	if f.Health != "" {
+		if err := models.ValidateHealth(string(f.Health)); err != nil {
+			return err
+		}
+	}
+
+	for key := range f.Custom {
+		if err := models.ValidateCustomKey(key); err != nil {
+			return err
+		}
+	}
+
+	from, to, err := f.dateRange()
+	if err != nil {
+		return err
+	}
+	if !from.IsZero() && !to.IsZero() && !from.Before(to) {
+		return fmt.Errorf("from must not be after to")
+	}
+
+	return nil
+}
+
+// dateRange returns the [from, to) bounds of the filter; a zero time means
+// that end is open
+func (f ListFilter) dateRange() (time.Time, time.Time, error) {
+	var from, to time.Time
+	var err error
+	if f.From != "" {
+		if from, _, err = models.ParsePeriod(f.From); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("from: %w", err)
+		}
+	}
+	if f.To != "" {
+		if _, to, err = models.ParsePeriod(f.To); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("to: %w", err)
+		}
+	}
+	return from, to, nil
+}
+
+// Matches reports whether a stored roadmap satisfies every field of the filter
+func (f ListFilter) Matches(stored *models.StoredRoadmap) bool {
+	if f.ServiceLine != "" && stored.Roadmap.ServiceLine != f.ServiceLine {
+		return false
+	}
+	if f.ExcludeArchived && stored.Roadmap.Archived {
+		return false
+	}
+	if f.Owner != "" && !stored.Roadmap.OwnedBy(f.Owner) && !hasItemAssignedTo(&stored.Roadmap, f.Owner) {
+		return false
+	}
+	if f.Status != "" && !hasItemStatus(&stored.Roadmap, f.Status) {
+		return false
+	}
+	if f.Confidence != "" && !hasItemConfidence(&stored.Roadmap, f.Confidence) {
+		return false
+	}
+	if f.Type != "" && !hasItemType(&stored.Roadmap, f.Type) {
+		return false
+	}
+	if f.Health != "" && stored.Roadmap.Health(time.Now()) != f.Health {
+		return false
+	}
+	if f.Portfolio != "" && stored.Roadmap.Portfolio != f.Portfolio {
+		return false
+	}
+	if f.Category != "" && stored.Roadmap.Category != f.Category {
+		return false
+	}
+	if f.Tag != "" && !containsTag(stored.Roadmap.Tags, f.Tag) && !hasItemTag(&stored.Roadmap, f.Tag) {
+		return false
+	}
+	if len(f.Custom) > 0 && !hasItemWithCustomFields(&stored.Roadmap, f.Custom) {
+		return false
+	}
+	if f.From != "" || f.To != "" {
+		from, to, err := f.dateRange()
+		if err != nil || !hasItemInRange(&stored.Roadmap, from, to) {
+			return false
+		}
+	}
+	return true
+}
+
+// hasItemStatus reports whether any item of the roadmap has the status
+func hasItemStatus(roadmap *models.Roadmap, status models.RoadmapStatus) bool {
+	for _, item := range roadmap.Items {
+		if item.Status == status {
+			return true
+		}
+	}
+	return false
+}
+
+// hasItemConfidence reports whether any item of the roadmap has the confidence
+func hasItemConfidence(roadmap *models.Roadmap, confidence models.Confidence) bool {
+	for i := range roadmap.Items {
+		if roadmap.Items[i].Confidence == confidence {
+			return true
+		}
+	}
+	return false
+}
+
+// hasItemType reports whether any item of the roadmap has the type
+func hasItemType(roadmap *models.Roadmap, itemType string) bool {
+	for i := range roadmap.Items {
+		if roadmap.Items[i].Type == itemType {
+			return true
+		}
+	}
+	return false
+}
+
+// hasItemAssignedTo reports whether any item of the roadmap is assigned to
+// the owner or team
+func hasItemAssignedTo(roadmap *models.Roadmap, name string) bool {
+	for i := range roadmap.Items {
+		if roadmap.Items[i].AssignedTo(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasItemTag reports whether any item of the roadmap has the tag
+func hasItemTag(roadmap *models.Roadmap, tag string) bool {
+	for i := range roadmap.Items {
+		if roadmap.Items[i].HasTag(tag) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// hasItemWithCustomFields reports whether any item of the roadmap has every
+// one of the custom field values
+func hasItemWithCustomFields(roadmap *models.Roadmap, fields map[string]string) bool {
+	for i := range roadmap.Items {
+		if roadmap.Items[i].HasCustomFields(fields) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasItemInRange reports whether any item of the roadmap overlaps [from, to).
+// Items whose dates can't be parsed never match.
+func hasItemInRange(roadmap *models.Roadmap, from, to time.Time) bool {
+	for _, item := range roadmap.Items {
+		start, end, err := item.ItemSpan()
+		if err != nil {
+			continue
+		}
+		if (from.IsZero() || end.After(from)) && (to.IsZero() || start.Before(to)) {
+			return true
+		}
+	}
+	return false
+}
+
+// filterRoadmaps keeps the roadmaps that match the filter, in order
+func filterRoadmaps(roadmaps []*models.StoredRoadmap, filter ListFilter) []*models.StoredRoadmap {
+	matched := roadmaps[:0]
+	for _, rm := range roadmaps {
+		if filter.Matches(rm) {
+			matched = append(matched, rm)
+		}
+	}
+	return matched
+}
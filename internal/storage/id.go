@@ -0,0 +1,80 @@
+package storage
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// maxSlugLength keeps generated IDs short enough for URLs and file names
+const maxSlugLength = 64
+
+// maxSlugAttempts bounds the numbered suffixes tried before falling back to a
+// random suffix
+const maxSlugAttempts = 1000
+
+// reservedIDs are the routes directly under /api/roadmaps/, kept out of IDs
+// so a roadmap's URL never reads as one of them. Routes after the ID are told
+// apart by their position, so they don't need reserving. calendar.ics can't
+// be a slug, but a file of that name can be dropped into the data directory.
+var reservedIDs = map[string]bool{
+	"batch":        true,
+	"calendar.ics": true,
+	"export":       true,
+	"merge":        true,
+	"validate":     true,
+}
+
+// Slugify turns a roadmap name into a URL-friendly ID, e.g.
+// "Platform Infra 2025" becomes "platform-infra-2025"
+func Slugify(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+
+	slug := strings.TrimSuffix(b.String(), "-")
+	if len(slug) > maxSlugLength {
+		slug = strings.TrimRight(slug[:maxSlugLength], "-")
+	}
+	if slug == "" {
+		slug = "roadmap"
+	}
+
+	return slug
+}
+
+// newID picks an ID for a roadmap named name: its slug if free, otherwise
+// the slug with the lowest free numbered suffix (-2, -3, ...). taken reports
+// whether a candidate is already used by a live or trashed roadmap.
+func newID(name string, taken func(id string) (bool, error)) (string, error) {
+	slug := Slugify(name)
+
+	for n := 1; n <= maxSlugAttempts; n++ {
+		candidate := slug
+		if n > 1 {
+			candidate = fmt.Sprintf("%s-%d", slug, n)
+		}
+		if reservedIDs[candidate] {
+			continue
+		}
+
+		used, err := taken(candidate)
+		if err != nil {
+			return "", err
+		}
+		if !used {
+			return candidate, nil
+		}
+	}
+
+	return fmt.Sprintf("%s-%s", slug, uuid.New().String()[:8]), nil
+}
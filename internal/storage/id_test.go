@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"roadmap-visualizer/internal/models"
+	"testing"
+)
+
+func TestNewIDSkipsReservedSegments(t *testing.T) {
+	for segment := range reservedIDs {
+		if Slugify(segment) != segment {
+			continue
+		}
+		id, err := newID(segment, func(string) (bool, error) { return false, nil })
+		if err != nil {
+			t.Fatalf("newID(%q): %v", segment, err)
+		}
+		if id != segment+"-2" {
+			t.Errorf("newID(%q) = %q, want %q", segment, id, segment+"-2")
+		}
+	}
+}
+
+func TestMemoryStorageCreateReservedName(t *testing.T) {
+	s := NewMemoryStorage()
+	for segment := range reservedIDs {
+		stored, err := s.Create(&models.Roadmap{Name: segment, ServiceLine: "Platform"}, segment+".yaml", "")
+		if err != nil {
+			t.Fatalf("create %q: %v", segment, err)
+		}
+		if stored.ID == segment {
+			t.Errorf("roadmap named %q got the reserved ID %q", segment, stored.ID)
+		}
+	}
+}
+
+func TestReindexSkipsReservedFileNames(t *testing.T) {
+	dir := t.TempDir()
+	fs, err := NewFileStorage(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	roadmap := []byte("roadmap:\n  name: Merge\n  service_line: Platform\n  items:\n    - {id: a, name: A, start: 2026-01-01, end: 2026-03-31, status: planned}\n")
+	for _, name := range []string{"merge.yaml", "merge-plan.yaml"} {
+		if err := os.WriteFile(filepath.Join(dir, "yaml", name), roadmap, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	result, err := fs.Reindex()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Added) != 1 || result.Added[0] != "merge-plan" {
+		t.Errorf("added = %v, want only merge-plan", result.Added)
+	}
+	if len(result.Errors) != 1 || result.Errors[0].File != "merge.yaml" {
+		t.Errorf("errors = %v, want merge.yaml reported", result.Errors)
+	}
+	if _, err := fs.Get("merge"); err != ErrNotFound {
+		t.Errorf("get merge: %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,189 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// journalEntry describes every file change needed to apply one FileStorage
+// operation. The entry is written to the journal directory before any of the
+// changes are made and removed once they all succeed, so an entry left behind
+// after a crash can be replayed on startup to finish the operation.
+type journalEntry struct {
+	ID      string         `json:"id"`
+	Writes  []journalWrite `json:"writes,omitempty"`
+	Removes []string       `json:"removes,omitempty"`
+}
+
+// journalWrite is a single file to write, with a path relative to the data directory
+type journalWrite struct {
+	Path string `json:"path"`
+	Data []byte `json:"data"`
+}
+
+// commit durably records the entry in the journal, applies it, and then
+// clears it from the journal. The caller must hold the lock for entry.ID.
+func (fs *FileStorage) commit(entry *journalEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to serialize journal entry: %w", err)
+	}
+
+	journalPath := filepath.Join(fs.dataDir, "journal", fmt.Sprintf("%s.json", entry.ID))
+	if err := writeFileAtomic(journalPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write journal entry: %w", err)
+	}
+
+	if err := fs.apply(entry); err != nil {
+		return err
+	}
+
+	if err := os.Remove(journalPath); err != nil {
+		return fmt.Errorf("failed to clear journal entry: %w", err)
+	}
+
+	return nil
+}
+
+// apply performs the file changes of a journal entry, removals first, and
+// keeps the in-memory index in step with the metadata files. Applying the
+// same entry twice has the same result as applying it once.
+func (fs *FileStorage) apply(entry *journalEntry) error {
+	for _, remove := range entry.Removes {
+		if err := os.RemoveAll(filepath.Join(fs.dataDir, remove)); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", remove, err)
+		}
+		if id, ok := metaFileID(remove); ok {
+			fs.mu.Lock()
+			delete(fs.index, id)
+			fs.mu.Unlock()
+		}
+	}
+
+	for _, write := range entry.Writes {
+		path := filepath.Join(fs.dataDir, write.Path)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", write.Path, err)
+		}
+		if err := writeFileAtomic(path, write.Data, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", write.Path, err)
+		}
+		if id, ok := metaFileID(write.Path); ok {
+			fs.mu.Lock()
+			fs.index[id] = write.Data
+			fs.mu.Unlock()
+		}
+	}
+
+	return nil
+}
+
+// recoverJournal replays journal entries left by interrupted operations and removes
+// temporary files from writes that never completed
+func (fs *FileStorage) recoverJournal() error {
+	journalDir := filepath.Join(fs.dataDir, "journal")
+	entries, err := os.ReadDir(journalDir)
+	if err != nil {
+		return fmt.Errorf("failed to read journal directory: %w", err)
+	}
+
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".json" {
+			continue
+		}
+
+		journalPath := filepath.Join(journalDir, e.Name())
+		data, err := os.ReadFile(journalPath)
+		if err != nil {
+			return fmt.Errorf("failed to read journal entry %s: %w", e.Name(), err)
+		}
+
+		var entry journalEntry
+		if err := json.Unmarshal(data, &entry); err != nil {
+			return fmt.Errorf("failed to parse journal entry %s: %w", e.Name(), err)
+		}
+
+		if err := fs.apply(&entry); err != nil {
+			return fmt.Errorf("failed to replay journal entry %s: %w", e.Name(), err)
+		}
+
+		if err := os.Remove(journalPath); err != nil {
+			return fmt.Errorf("failed to clear journal entry %s: %w", e.Name(), err)
+		}
+	}
+
+	return filepath.WalkDir(fs.dataDir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && isTempFile(d.Name()) {
+			if err := os.Remove(path); err != nil {
+				return fmt.Errorf("failed to remove temporary file %s: %w", path, err)
+			}
+		}
+		return nil
+	})
+}
+
+// writeFileAtomic writes data to a temporary file in the same directory and
+// renames it over path, so readers see either the old or the new contents
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Chmod(tmpPath, perm); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	return syncDir(dir)
+}
+
+// syncDir flushes a directory so a rename into it survives a crash
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
+
+// metaFileID returns the roadmap ID if path is a live metadata file
+func metaFileID(path string) (string, bool) {
+	dir, name := filepath.Split(path)
+	if filepath.Clean(dir) != "meta" || filepath.Ext(name) != ".json" {
+		return "", false
+	}
+	return strings.TrimSuffix(name, ".json"), true
+}
+
+// isTempFile reports whether name was created by writeFileAtomic
+func isTempFile(name string) bool {
+	return strings.HasPrefix(name, ".") && strings.Contains(name, ".tmp-")
+}
@@ -0,0 +1,319 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"roadmap-visualizer/internal/models"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemoryStorage keeps roadmaps in memory only. Nothing survives a restart,
+// which makes it suited to demos and tests.
+type MemoryStorage struct {
+	roadmaps  map[string]*models.StoredRoadmap
+	trash     map[string]*models.StoredRoadmap
+	revisions map[string][]*models.Revision
+	comments  map[string][]*models.Comment
+	mu        sync.RWMutex
+}
+
+// NewMemoryStorage creates an empty in-memory storage instance
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{
+		roadmaps:  make(map[string]*models.StoredRoadmap),
+		trash:     make(map[string]*models.StoredRoadmap),
+		revisions: make(map[string][]*models.Revision),
+		comments:  make(map[string][]*models.Comment),
+	}
+}
+
+// Create stores a new roadmap
+func (ms *MemoryStorage) Create(roadmap *models.Roadmap, originalFileName, author string) (*models.StoredRoadmap, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	id, err := newID(roadmap.Name, func(candidate string) (bool, error) {
+		_, live := ms.roadmaps[candidate]
+		_, trashed := ms.trash[candidate]
+		return live || trashed, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	stored := &models.StoredRoadmap{
+		ID:        id,
+		Roadmap:   *roadmap,
+		CreatedAt: now,
+		UpdatedAt: now,
+		FileName:  originalFileName,
+		Revision:  1,
+		CreatedBy: author,
+		UpdatedBy: author,
+	}
+
+	copied, err := cloneStoredRoadmap(stored)
+	if err != nil {
+		return nil, err
+	}
+	ms.roadmaps[stored.ID] = copied
+	ms.revisions[stored.ID] = []*models.Revision{revisionOf(copied)}
+
+	return stored, nil
+}
+
+// Get retrieves a roadmap by ID
+func (ms *MemoryStorage) Get(id string) (*models.StoredRoadmap, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	stored, ok := ms.roadmaps[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return cloneStoredRoadmap(stored)
+}
+
+// List returns the stored roadmaps that match the filter, ordered by creation time
+func (ms *MemoryStorage) List(filter ListFilter) ([]*models.StoredRoadmap, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	roadmaps := make([]*models.StoredRoadmap, 0, len(ms.roadmaps))
+	for _, stored := range ms.roadmaps {
+		if !filter.Matches(stored) {
+			continue
+		}
+		copied, err := cloneStoredRoadmap(stored)
+		if err != nil {
+			return nil, err
+		}
+		roadmaps = append(roadmaps, copied)
+	}
+
+	sort.Slice(roadmaps, func(i, j int) bool {
+		return roadmaps[i].CreatedAt.Before(roadmaps[j].CreatedAt)
+	})
+
+	return roadmaps, nil
+}
+
+// Update replaces the roadmap content for an existing ID
+func (ms *MemoryStorage) Update(id string, roadmap *models.Roadmap, author string, ifRevision int) (*models.StoredRoadmap, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	existing, ok := ms.roadmaps[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	if err := checkRevision(existing, ifRevision); err != nil {
+		return nil, err
+	}
+
+	updated := *existing
+	updated.Roadmap = *roadmap
+	updated.UpdatedAt = time.Now()
+	updated.Revision++
+	updated.UpdatedBy = author
+
+	copied, err := cloneStoredRoadmap(&updated)
+	if err != nil {
+		return nil, err
+	}
+	ms.roadmaps[id] = copied
+	ms.revisions[id] = append(ms.revisions[id], revisionOf(copied))
+
+	return &updated, nil
+}
+
+// Delete removes a roadmap by ID
+func (ms *MemoryStorage) Delete(id string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	if _, ok := ms.roadmaps[id]; !ok {
+		return ErrNotFound
+	}
+	delete(ms.roadmaps, id)
+	delete(ms.revisions, id)
+	delete(ms.comments, id)
+
+	return nil
+}
+
+// Trash moves a roadmap into the trash
+func (ms *MemoryStorage) Trash(id string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	stored, ok := ms.roadmaps[id]
+	if !ok {
+		return ErrNotFound
+	}
+
+	now := time.Now()
+	stored.DeletedAt = &now
+	ms.trash[id] = stored
+	delete(ms.roadmaps, id)
+
+	return nil
+}
+
+// ListTrash returns all roadmaps in the trash
+func (ms *MemoryStorage) ListTrash() ([]*models.StoredRoadmap, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	roadmaps := make([]*models.StoredRoadmap, 0, len(ms.trash))
+	for _, stored := range ms.trash {
+		copied, err := cloneStoredRoadmap(stored)
+		if err != nil {
+			return nil, err
+		}
+		roadmaps = append(roadmaps, copied)
+	}
+
+	return roadmaps, nil
+}
+
+// Restore moves a roadmap out of the trash
+func (ms *MemoryStorage) Restore(id string) (*models.StoredRoadmap, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	stored, ok := ms.trash[id]
+	if !ok {
+		return nil, ErrNotInTrash
+	}
+
+	stored.DeletedAt = nil
+	ms.roadmaps[id] = stored
+	delete(ms.trash, id)
+
+	return cloneStoredRoadmap(stored)
+}
+
+// Purge permanently removes a trashed roadmap and its revisions
+func (ms *MemoryStorage) Purge(id string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	if _, ok := ms.trash[id]; !ok {
+		return ErrNotInTrash
+	}
+	delete(ms.trash, id)
+	delete(ms.revisions, id)
+	delete(ms.comments, id)
+
+	return nil
+}
+
+// Import stores a roadmap and its revision history under its existing ID
+func (ms *MemoryStorage) Import(stored *models.StoredRoadmap, revisions []*models.Revision) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	copied, err := cloneStoredRoadmap(stored)
+	if err != nil {
+		return err
+	}
+	copied.DeletedAt = nil
+
+	history := make([]*models.Revision, 0, len(revisions))
+	for _, rev := range revisions {
+		copiedRev := *rev
+		history = append(history, &copiedRev)
+	}
+
+	delete(ms.trash, copied.ID)
+	ms.roadmaps[copied.ID] = copied
+	ms.revisions[copied.ID] = history
+	delete(ms.comments, copied.ID)
+
+	return nil
+}
+
+// ListRevisions returns the revision history of a roadmap, oldest first
+func (ms *MemoryStorage) ListRevisions(id string) ([]*models.Revision, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	if _, ok := ms.roadmaps[id]; !ok {
+		return nil, ErrNotFound
+	}
+
+	// Revisions are never modified once recorded, so sharing them is safe
+	revisions := make([]*models.Revision, len(ms.revisions[id]))
+	copy(revisions, ms.revisions[id])
+
+	return revisions, nil
+}
+
+// GetRevision returns a single revision of a roadmap
+func (ms *MemoryStorage) GetRevision(id string, revision int) (*models.Revision, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	for _, rev := range ms.revisions[id] {
+		if rev.Number == revision {
+			return rev, nil
+		}
+	}
+
+	return nil, ErrRevisionNotFound
+}
+
+// AddComment stores a comment on a live roadmap
+func (ms *MemoryStorage) AddComment(id string, comment *models.Comment) (*models.Comment, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	if _, ok := ms.roadmaps[id]; !ok {
+		return nil, ErrNotFound
+	}
+
+	created := newComment(comment)
+	stored := *created
+	ms.comments[id] = append(ms.comments[id], &stored)
+
+	return created, nil
+}
+
+// ListComments returns the comments on a live roadmap, oldest first
+func (ms *MemoryStorage) ListComments(id string) ([]*models.Comment, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	if _, ok := ms.roadmaps[id]; !ok {
+		return nil, ErrNotFound
+	}
+
+	comments := make([]*models.Comment, 0, len(ms.comments[id]))
+	for _, comment := range ms.comments[id] {
+		copied := *comment
+		comments = append(comments, &copied)
+	}
+
+	return comments, nil
+}
+
+// cloneStoredRoadmap deep-copies a stored roadmap so callers can't mutate
+// the stored state through shared slices
+func cloneStoredRoadmap(stored *models.StoredRoadmap) (*models.StoredRoadmap, error) {
+	data, err := json.Marshal(stored)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy roadmap: %w", err)
+	}
+
+	var copied models.StoredRoadmap
+	if err := json.Unmarshal(data, &copied); err != nil {
+		return nil, fmt.Errorf("failed to copy roadmap: %w", err)
+	}
+
+	return &copied, nil
+}
@@ -0,0 +1,11 @@
+ALTER TABLE roadmaps ADD COLUMN IF NOT EXISTS revision INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE roadmaps ADD COLUMN IF NOT EXISTS updated_by TEXT NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS roadmap_revisions (
+	roadmap_id TEXT NOT NULL REFERENCES roadmaps(id) ON DELETE CASCADE,
+	revision   INTEGER NOT NULL,
+	document   JSONB NOT NULL,
+	author     TEXT NOT NULL DEFAULT '',
+	created_at TIMESTAMPTZ NOT NULL,
+	PRIMARY KEY (roadmap_id, revision)
+);
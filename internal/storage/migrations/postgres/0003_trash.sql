@@ -0,0 +1,2 @@
+ALTER TABLE roadmaps ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+CREATE INDEX IF NOT EXISTS idx_roadmaps_deleted_at ON roadmaps(deleted_at);
@@ -0,0 +1 @@
+ALTER TABLE roadmaps ADD COLUMN IF NOT EXISTS created_by TEXT NOT NULL DEFAULT '';
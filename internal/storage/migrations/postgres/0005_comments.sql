@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS roadmap_comments (
+	id         TEXT PRIMARY KEY,
+	roadmap_id TEXT NOT NULL REFERENCES roadmaps(id) ON DELETE CASCADE,
+	item_id    TEXT NOT NULL DEFAULT '',
+	author     TEXT NOT NULL DEFAULT '',
+	body       TEXT NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_roadmap_comments_roadmap ON roadmap_comments(roadmap_id, created_at);
@@ -0,0 +1,2 @@
+ALTER TABLE external_dependencies ADD COLUMN IF NOT EXISTS target_milestone TEXT NOT NULL DEFAULT '';
+ALTER TABLE external_dependencies ADD COLUMN IF NOT EXISTS target_completion BOOLEAN NOT NULL DEFAULT FALSE;
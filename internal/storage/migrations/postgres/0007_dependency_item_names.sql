@@ -0,0 +1 @@
+ALTER TABLE external_dependencies ADD COLUMN IF NOT EXISTS target_item_name TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,38 @@
+CREATE TABLE IF NOT EXISTS roadmaps (
+	id           TEXT PRIMARY KEY,
+	name         TEXT NOT NULL,
+	service_line TEXT NOT NULL,
+	owner        TEXT NOT NULL DEFAULT '',
+	file_name    TEXT NOT NULL DEFAULT '',
+	document     TEXT NOT NULL,
+	created_at   TEXT NOT NULL,
+	updated_at   TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_roadmaps_service_line ON roadmaps(service_line);
+CREATE INDEX IF NOT EXISTS idx_roadmaps_owner ON roadmaps(owner);
+
+CREATE TABLE IF NOT EXISTS items (
+	roadmap_id  TEXT NOT NULL REFERENCES roadmaps(id) ON DELETE CASCADE,
+	item_id     TEXT NOT NULL,
+	position    INTEGER NOT NULL,
+	name        TEXT NOT NULL,
+	start_date  TEXT NOT NULL,
+	end_date    TEXT NOT NULL,
+	status      TEXT NOT NULL,
+	description TEXT NOT NULL DEFAULT '',
+	PRIMARY KEY (roadmap_id, item_id)
+);
+CREATE INDEX IF NOT EXISTS idx_items_status ON items(status);
+
+CREATE TABLE IF NOT EXISTS external_dependencies (
+	roadmap_id        TEXT NOT NULL REFERENCES roadmaps(id) ON DELETE CASCADE,
+	item_id           TEXT NOT NULL,
+	position          INTEGER NOT NULL,
+	target_roadmap    TEXT NOT NULL DEFAULT '',
+	target_roadmap_id TEXT NOT NULL DEFAULT '',
+	target_item       TEXT NOT NULL,
+	reason            TEXT NOT NULL DEFAULT '',
+	criticality       TEXT NOT NULL DEFAULT '',
+	PRIMARY KEY (roadmap_id, item_id, position)
+);
+CREATE INDEX IF NOT EXISTS idx_extdeps_target ON external_dependencies(target_roadmap, target_item);
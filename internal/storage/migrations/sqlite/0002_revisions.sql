@@ -0,0 +1,11 @@
+ALTER TABLE roadmaps ADD COLUMN revision INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE roadmaps ADD COLUMN updated_by TEXT NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS roadmap_revisions (
+	roadmap_id TEXT NOT NULL REFERENCES roadmaps(id) ON DELETE CASCADE,
+	revision   INTEGER NOT NULL,
+	document   TEXT NOT NULL,
+	author     TEXT NOT NULL DEFAULT '',
+	created_at TEXT NOT NULL,
+	PRIMARY KEY (roadmap_id, revision)
+);
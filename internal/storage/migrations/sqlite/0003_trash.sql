@@ -0,0 +1,2 @@
+ALTER TABLE roadmaps ADD COLUMN deleted_at TEXT;
+CREATE INDEX IF NOT EXISTS idx_roadmaps_deleted_at ON roadmaps(deleted_at);
@@ -0,0 +1 @@
+ALTER TABLE roadmaps ADD COLUMN created_by TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+ALTER TABLE external_dependencies ADD COLUMN target_milestone TEXT NOT NULL DEFAULT '';
+ALTER TABLE external_dependencies ADD COLUMN target_completion INTEGER NOT NULL DEFAULT 0;
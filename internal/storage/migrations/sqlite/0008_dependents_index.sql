@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_extdeps_target_id ON external_dependencies(target_roadmap_id);
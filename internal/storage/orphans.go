@@ -0,0 +1,116 @@
+package storage
+
+import (
+	"fmt"
+	"roadmap-visualizer/internal/models"
+)
+
+// Ways PruneOrphanedDependencies can deal with orphaned dependencies
+const (
+	PruneRemove   = "remove"
+	PruneAnnotate = "annotate"
+)
+
+// PruneResult reports what a prune changed
+type PruneResult struct {
+	Action string `json:"action"`
+	// Pruned are the dependencies removed or annotated, as they were found,
+	// without suggestions
+	Pruned []models.OrphanedDependency `json:"pruned"`
+	// Roadmaps are the IDs of the roadmaps written as a new revision
+	Roadmaps []string `json:"roadmaps"`
+}
+
+// PruneOrphanedDependencies removes the external dependencies that no longer
+// resolve, or with PruneAnnotate marks them Orphaned with the reason, leaving
+// alone those already marked. If roadmapIDs is not empty only those roadmaps
+// are pruned. Each changed roadmap is written as a new revision, only while
+// it is still at the revision that was read; if any write fails, the writes
+// already made are reverted.
+func PruneOrphanedDependencies(s Storage, action string, roadmapIDs []string, author string) (*PruneResult, error) {
+	if action != PruneRemove && action != PruneAnnotate {
+		return nil, fmt.Errorf("invalid action %q (must be %s or %s)", action, PruneRemove, PruneAnnotate)
+	}
+
+	all, err := s.List(ListFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list roadmaps: %w", err)
+	}
+	only := make(map[string]bool, len(roadmapIDs))
+	for _, id := range roadmapIDs {
+		only[id] = true
+	}
+
+	// Work out every change before writing anything
+	result := &PruneResult{Action: action, Pruned: []models.OrphanedDependency{}, Roadmaps: []string{}}
+	pruned := make(map[string]map[string][]models.OrphanedDependency)
+	for _, orphan := range models.FindOrphanedDependencies(all, nil) {
+		if len(only) > 0 && !only[orphan.RoadmapID] {
+			continue
+		}
+		if action == PruneAnnotate && orphan.Dependency.Orphaned != "" {
+			continue
+		}
+		orphan.Suggestions = nil
+		if pruned[orphan.RoadmapID] == nil {
+			pruned[orphan.RoadmapID] = make(map[string][]models.OrphanedDependency)
+			result.Roadmaps = append(result.Roadmaps, orphan.RoadmapID)
+		}
+		pruned[orphan.RoadmapID][orphan.ItemID] = append(pruned[orphan.RoadmapID][orphan.ItemID], orphan)
+		result.Pruned = append(result.Pruned, orphan)
+	}
+
+	var applied, before []*models.StoredRoadmap
+	for _, rm := range all {
+		items, ok := pruned[rm.ID]
+		if !ok {
+			continue
+		}
+		updated, err := s.Update(rm.ID, pruneRoadmap(&rm.Roadmap, items, action), author, rm.CurrentRevision())
+		if err != nil {
+			err = fmt.Errorf("failed to update roadmap %s: %w", rm.ID, err)
+			for i, done := range applied {
+				if _, rerr := s.Update(done.ID, &before[i].Roadmap, author, done.CurrentRevision()); rerr != nil {
+					err = fmt.Errorf("%w; failed to revert roadmap %s: %v", err, done.ID, rerr)
+				}
+			}
+			return nil, err
+		}
+		applied = append(applied, updated)
+		before = append(before, rm)
+	}
+
+	return result, nil
+}
+
+// pruneRoadmap returns a copy of the roadmap with the orphaned dependencies
+// of each item, by item ID, removed or annotated
+func pruneRoadmap(roadmap *models.Roadmap, orphans map[string][]models.OrphanedDependency, action string) *models.Roadmap {
+	pruned := *roadmap
+	pruned.Items = make([]models.RoadmapItem, len(roadmap.Items))
+
+	for i, item := range roadmap.Items {
+		if itemOrphans, ok := orphans[item.ID]; ok {
+			reasons := make(map[int]string, len(itemOrphans))
+			for _, orphan := range itemOrphans {
+				reasons[orphan.Index] = orphan.Error
+			}
+			var deps []models.ExternalDependency
+			for j, dep := range item.ExternalDependencies {
+				reason, orphaned := reasons[j]
+				switch {
+				case !orphaned:
+				case action == PruneRemove:
+					continue
+				default:
+					dep.Orphaned = reason
+				}
+				deps = append(deps, dep)
+			}
+			item.ExternalDependencies = deps
+		}
+		pruned.Items[i] = item
+	}
+
+	return &pruned
+}
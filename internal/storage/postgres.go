@@ -0,0 +1,664 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"roadmap-visualizer/internal/models"
+	"sort"
+	"time"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+//go:embed migrations/postgres/*.sql
+var postgresMigrations embed.FS
+
+// migrationLockID is the advisory lock key held while applying migrations,
+// so replicas starting at the same time don't race each other
+const migrationLockID = 72616470 // "rdmp"
+
+// PostgresConfig holds connection and pool settings for PostgresStorage
+type PostgresConfig struct {
+	URL             string
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+}
+
+// postgresRoadmapColumns is the column list read by scanPostgresRoadmap
+const postgresRoadmapColumns = `id, file_name, document, created_at, updated_at, revision, created_by, updated_by, deleted_at`
+
+// PostgresStorage implements storage for roadmaps in a PostgreSQL database
+type PostgresStorage struct {
+	db *sql.DB
+}
+
+// NewPostgresStorage connects to PostgreSQL and applies pending migrations
+func NewPostgresStorage(config PostgresConfig) (*PostgresStorage, error) {
+	if config.URL == "" {
+		return nil, fmt.Errorf("database URL is required")
+	}
+
+	db, err := sql.Open("pgx", config.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	if config.MaxOpenConns > 0 {
+		db.SetMaxOpenConns(config.MaxOpenConns)
+	}
+	if config.MaxIdleConns > 0 {
+		db.SetMaxIdleConns(config.MaxIdleConns)
+	}
+	if config.ConnMaxLifetime > 0 {
+		db.SetConnMaxLifetime(config.ConnMaxLifetime)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	if err := migratePostgres(ctx, db); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &PostgresStorage{
+		db: db,
+	}, nil
+}
+
+// migratePostgres applies embedded migrations that haven't been recorded yet
+func migratePostgres(ctx context.Context, db *sql.DB) error {
+	// Advisory locks are per-session, so pin a single connection
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, migrationLockID); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	defer conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, migrationLockID)
+
+	_, err = conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    TEXT PRIMARY KEY,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`)
+	if err != nil {
+		return fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+
+	names, err := fs.Glob(postgresMigrations, "migrations/postgres/*.sql")
+	if err != nil {
+		return fmt.Errorf("failed to list migrations: %w", err)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		version := name[len("migrations/postgres/") : len(name)-len(".sql")]
+
+		var applied bool
+		err := conn.QueryRowContext(ctx,
+			`SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = $1)`, version).Scan(&applied)
+		if err != nil {
+			return fmt.Errorf("failed to check migration %s: %w", version, err)
+		}
+		if applied {
+			continue
+		}
+
+		script, err := postgresMigrations.ReadFile(name)
+		if err != nil {
+			return fmt.Errorf("failed to read migration %s: %w", version, err)
+		}
+
+		tx, err := conn.BeginTx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("failed to begin migration %s: %w", version, err)
+		}
+		if _, err := tx.ExecContext(ctx, string(script)); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to apply migration %s: %w", version, err)
+		}
+		if _, err := tx.ExecContext(ctx, `INSERT INTO schema_migrations (version) VALUES ($1)`, version); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to record migration %s: %w", version, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("failed to commit migration %s: %w", version, err)
+		}
+	}
+
+	return nil
+}
+
+// Close closes the connection pool
+func (s *PostgresStorage) Close() error {
+	return s.db.Close()
+}
+
+// Create stores a new roadmap
+func (s *PostgresStorage) Create(roadmap *models.Roadmap, originalFileName, author string) (*models.StoredRoadmap, error) {
+	now := time.Now()
+	stored := &models.StoredRoadmap{
+		Roadmap:   *roadmap,
+		CreatedAt: now,
+		UpdatedAt: now,
+		FileName:  originalFileName,
+		Revision:  1,
+		CreatedBy: author,
+		UpdatedBy: author,
+	}
+
+	document, err := json.Marshal(roadmap)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize roadmap: %w", err)
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Serialize creates of the same name so two can't claim one ID
+	if _, err := tx.Exec(`SELECT pg_advisory_xact_lock(hashtext($1))`, Slugify(roadmap.Name)); err != nil {
+		return nil, fmt.Errorf("failed to lock roadmap id: %w", err)
+	}
+
+	// Deleted (trashed) rows still hold their ID, so they count as taken
+	stored.ID, err = newID(roadmap.Name, func(candidate string) (bool, error) {
+		var count int
+		err := tx.QueryRow(`SELECT COUNT(*) FROM roadmaps WHERE id = $1`, candidate).Scan(&count)
+		return count > 0, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to allocate roadmap id: %w", err)
+	}
+
+	_, err = tx.Exec(
+		`INSERT INTO roadmaps (id, name, service_line, owner, file_name, document, created_at, updated_at, revision, created_by, updated_by)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
+		stored.ID, roadmap.Name, roadmap.ServiceLine, roadmap.Owner, originalFileName,
+		string(document), now, now, stored.Revision, author, author,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert roadmap: %w", err)
+	}
+
+	if err := insertPostgresItems(tx, stored.ID, roadmap); err != nil {
+		return nil, err
+	}
+	if err := insertPostgresRevision(tx, stored.ID, stored.Revision, document, author, now); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit roadmap: %w", err)
+	}
+
+	return stored, nil
+}
+
+// Get retrieves a roadmap by ID
+func (s *PostgresStorage) Get(id string) (*models.StoredRoadmap, error) {
+	row := s.db.QueryRow(
+		`SELECT `+postgresRoadmapColumns+` FROM roadmaps WHERE id = $1 AND deleted_at IS NULL`, id)
+
+	stored, err := scanPostgresRoadmap(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return stored, nil
+}
+
+// List returns the stored roadmaps that match the filter. Service line and
+// status are matched in SQL; the date range is checked on the decoded
+// roadmaps because item dates are free-form strings, and so are owner, tags,
+// and confidence, since the items table doesn't hold those item fields.
+func (s *PostgresStorage) List(filter ListFilter) ([]*models.StoredRoadmap, error) {
+	query := `SELECT ` + postgresRoadmapColumns + ` FROM roadmaps WHERE deleted_at IS NULL`
+	var args []interface{}
+	if filter.ServiceLine != "" {
+		args = append(args, filter.ServiceLine)
+		query += fmt.Sprintf(` AND service_line = $%d`, len(args))
+	}
+	if filter.Status != "" {
+		args = append(args, string(filter.Status))
+		query += fmt.Sprintf(` AND EXISTS (SELECT 1 FROM items WHERE items.roadmap_id = roadmaps.id AND items.status = $%d)`, len(args))
+	}
+	query += ` ORDER BY created_at`
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query roadmaps: %w", err)
+	}
+	defer rows.Close()
+
+	var roadmaps []*models.StoredRoadmap
+	for rows.Next() {
+		stored, err := scanPostgresRoadmap(rows)
+		if err != nil {
+			continue // Skip rows we can't parse
+		}
+		roadmaps = append(roadmaps, stored)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read roadmaps: %w", err)
+	}
+
+	return filterRoadmaps(roadmaps, filter), nil
+}
+
+// Update replaces the roadmap content for an existing ID
+func (s *PostgresStorage) Update(id string, roadmap *models.Roadmap, author string, ifRevision int) (*models.StoredRoadmap, error) {
+	document, err := json.Marshal(roadmap)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize roadmap: %w", err)
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Lock the row so concurrent replicas assign distinct revision numbers
+	current, err := scanPostgresRoadmap(tx.QueryRow(
+		`SELECT `+postgresRoadmapColumns+` FROM roadmaps WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`, id))
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := checkRevision(current, ifRevision); err != nil {
+		return nil, err
+	}
+
+	// Roadmaps stored before revisions existed get their current content
+	// recorded as revision 1 so it isn't lost from the history
+	if current.Revision == 0 {
+		currentDoc, err := json.Marshal(current.Roadmap)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize roadmap: %w", err)
+		}
+		current.Revision = 1
+		if err := insertPostgresRevision(tx, id, 1, currentDoc, current.UpdatedBy, current.UpdatedAt); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	revision := current.Revision + 1
+	stored, err := scanPostgresRoadmap(tx.QueryRow(
+		`UPDATE roadmaps SET name = $1, service_line = $2, owner = $3, document = $4, updated_at = $5, revision = $6, updated_by = $7
+		 WHERE id = $8
+		 RETURNING `+postgresRoadmapColumns,
+		roadmap.Name, roadmap.ServiceLine, roadmap.Owner, string(document), now, revision, author, id,
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	// Replace the normalized rows
+	if _, err := tx.Exec(`DELETE FROM external_dependencies WHERE roadmap_id = $1`, id); err != nil {
+		return nil, fmt.Errorf("failed to clear external dependencies: %w", err)
+	}
+	if _, err := tx.Exec(`DELETE FROM items WHERE roadmap_id = $1`, id); err != nil {
+		return nil, fmt.Errorf("failed to clear items: %w", err)
+	}
+	if err := insertPostgresItems(tx, id, roadmap); err != nil {
+		return nil, err
+	}
+	if err := insertPostgresRevision(tx, id, revision, document, author, now); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit roadmap: %w", err)
+	}
+
+	return stored, nil
+}
+
+// Delete removes a roadmap by ID
+func (s *PostgresStorage) Delete(id string) error {
+	result, err := s.db.Exec(`DELETE FROM roadmaps WHERE id = $1 AND deleted_at IS NULL`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete roadmap: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// Trash soft-deletes a roadmap by setting deleted_at
+func (s *PostgresStorage) Trash(id string) error {
+	result, err := s.db.Exec(
+		`UPDATE roadmaps SET deleted_at = $1 WHERE id = $2 AND deleted_at IS NULL`, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to trash roadmap: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// ListTrash returns all soft-deleted roadmaps
+func (s *PostgresStorage) ListTrash() ([]*models.StoredRoadmap, error) {
+	rows, err := s.db.Query(
+		`SELECT ` + postgresRoadmapColumns + ` FROM roadmaps WHERE deleted_at IS NOT NULL ORDER BY deleted_at`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query trash: %w", err)
+	}
+	defer rows.Close()
+
+	var roadmaps []*models.StoredRoadmap
+	for rows.Next() {
+		stored, err := scanPostgresRoadmap(rows)
+		if err != nil {
+			continue // Skip rows we can't parse
+		}
+		roadmaps = append(roadmaps, stored)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read trash: %w", err)
+	}
+
+	return roadmaps, nil
+}
+
+// Restore clears deleted_at on a soft-deleted roadmap
+func (s *PostgresStorage) Restore(id string) (*models.StoredRoadmap, error) {
+	result, err := s.db.Exec(`UPDATE roadmaps SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore roadmap: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return nil, ErrNotInTrash
+	}
+
+	return s.Get(id)
+}
+
+// Purge permanently removes a soft-deleted roadmap; revisions and items cascade
+func (s *PostgresStorage) Purge(id string) error {
+	result, err := s.db.Exec(`DELETE FROM roadmaps WHERE id = $1 AND deleted_at IS NOT NULL`, id)
+	if err != nil {
+		return fmt.Errorf("failed to purge roadmap: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ErrNotInTrash
+	}
+
+	return nil
+}
+
+// Import stores a roadmap and its revision history under its existing ID
+func (s *PostgresStorage) Import(stored *models.StoredRoadmap, revisions []*models.Revision) error {
+	document, err := json.Marshal(&stored.Roadmap)
+	if err != nil {
+		return fmt.Errorf("failed to serialize roadmap: %w", err)
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Items, external dependencies, and revisions cascade with the roadmap row
+	if _, err := tx.Exec(`DELETE FROM roadmaps WHERE id = $1`, stored.ID); err != nil {
+		return fmt.Errorf("failed to replace roadmap: %w", err)
+	}
+
+	roadmap := &stored.Roadmap
+	_, err = tx.Exec(
+		`INSERT INTO roadmaps (id, name, service_line, owner, file_name, document, created_at, updated_at, revision, created_by, updated_by)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
+		stored.ID, roadmap.Name, roadmap.ServiceLine, roadmap.Owner, stored.FileName,
+		string(document), stored.CreatedAt, stored.UpdatedAt, stored.Revision, stored.CreatedBy, stored.UpdatedBy,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert roadmap: %w", err)
+	}
+
+	if err := insertPostgresItems(tx, stored.ID, roadmap); err != nil {
+		return err
+	}
+	for _, rev := range revisions {
+		revDoc, err := json.Marshal(&rev.Roadmap)
+		if err != nil {
+			return fmt.Errorf("failed to serialize revision: %w", err)
+		}
+		if err := insertPostgresRevision(tx, stored.ID, rev.Number, revDoc, rev.Author, rev.CreatedAt); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit roadmap: %w", err)
+	}
+
+	return nil
+}
+
+// ListRevisions returns the revision history of a roadmap, oldest first
+func (s *PostgresStorage) ListRevisions(id string) ([]*models.Revision, error) {
+	if _, err := s.Get(id); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(
+		`SELECT revision, document, author, created_at FROM roadmap_revisions
+		 WHERE roadmap_id = $1 ORDER BY revision`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query revisions: %w", err)
+	}
+	defer rows.Close()
+
+	var revisions []*models.Revision
+	for rows.Next() {
+		revision, err := scanPostgresRevision(rows)
+		if err != nil {
+			continue // Skip rows we can't parse
+		}
+		revisions = append(revisions, revision)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read revisions: %w", err)
+	}
+
+	return revisions, nil
+}
+
+// GetRevision returns a single revision of a roadmap
+func (s *PostgresStorage) GetRevision(id string, revision int) (*models.Revision, error) {
+	row := s.db.QueryRow(
+		`SELECT revision, document, author, created_at FROM roadmap_revisions
+		 WHERE roadmap_id = $1 AND revision = $2`, id, revision)
+
+	rev, err := scanPostgresRevision(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrRevisionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return rev, nil
+}
+
+// AddComment stores a comment on a live roadmap
+func (s *PostgresStorage) AddComment(id string, comment *models.Comment) (*models.Comment, error) {
+	created := newComment(comment)
+	result, err := s.db.Exec(
+		`INSERT INTO roadmap_comments (id, roadmap_id, item_id, author, body, created_at)
+		 SELECT $1, id, $2, $3, $4, $5 FROM roadmaps WHERE id = $6 AND deleted_at IS NULL`,
+		created.ID, created.ItemID, created.Author, created.Body, created.CreatedAt, id,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert comment: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return nil, ErrNotFound
+	}
+
+	return created, nil
+}
+
+// ListComments returns the comments on a live roadmap, oldest first
+func (s *PostgresStorage) ListComments(id string) ([]*models.Comment, error) {
+	if _, err := s.Get(id); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(
+		`SELECT id, item_id, author, body, created_at FROM roadmap_comments
+		 WHERE roadmap_id = $1 ORDER BY created_at, id`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query comments: %w", err)
+	}
+	defer rows.Close()
+
+	comments := []*models.Comment{}
+	for rows.Next() {
+		var comment models.Comment
+		if err := rows.Scan(&comment.ID, &comment.ItemID, &comment.Author, &comment.Body, &comment.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to read comment: %w", err)
+		}
+		comments = append(comments, &comment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read comments: %w", err)
+	}
+
+	return comments, nil
+}
+
+// Dependents returns the items of other live roadmaps with an external
+// dependency on the roadmap, by its ID or current name, from the
+// external_dependencies table. It returns nothing for a roadmap that isn't
+// live.
+func (s *PostgresStorage) Dependents(roadmapID string) ([]models.ExternalDependent, error) {
+	var name string
+	err := s.db.QueryRow(`SELECT name FROM roadmaps WHERE id = $1 AND deleted_at IS NULL`, roadmapID).Scan(&name)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get roadmap: %w", err)
+	}
+
+	rows, err := s.db.Query(`SELECT `+dependentColumns+` FROM `+dependentTables+`
+		 WHERE d.roadmap_id <> $1 AND (d.target_roadmap_id = $1 OR d.target_roadmap = $2)
+		 ORDER BY d.roadmap_id, i.position, d.position`, roadmapID, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query dependents: %w", err)
+	}
+	return readDependents(rows)
+}
+
+// insertPostgresItems writes the normalized item and external dependency rows
+func insertPostgresItems(tx *sql.Tx, roadmapID string, roadmap *models.Roadmap) error {
+	for i, item := range roadmap.Items {
+		_, err := tx.Exec(
+			`INSERT INTO items (roadmap_id, item_id, position, name, start_date, end_date, status, description)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+			roadmapID, item.ID, i, item.Name, item.Start, item.End, string(item.Status), item.Description,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to insert item %s: %w", item.ID, err)
+		}
+
+		for j, extDep := range item.ExternalDependencies {
+			_, err := tx.Exec(
+				`INSERT INTO external_dependencies
+				 (roadmap_id, item_id, position, target_roadmap, target_roadmap_id, target_item, target_item_name, target_milestone, target_completion, reason, criticality)
+				 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
+				roadmapID, item.ID, j, extDep.RoadmapName, extDep.RoadmapID, extDep.ItemID, extDep.ItemName, extDep.Milestone, extDep.Completion, extDep.Reason, extDep.Criticality,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to insert external dependency for item %s: %w", item.ID, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// insertPostgresRevision records a roadmap document as a numbered revision
+func insertPostgresRevision(tx *sql.Tx, roadmapID string, revision int, document []byte, author string, at time.Time) error {
+	_, err := tx.Exec(
+		`INSERT INTO roadmap_revisions (roadmap_id, revision, document, author, created_at) VALUES ($1, $2, $3, $4, $5)`,
+		roadmapID, revision, string(document), author, at,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert revision %d: %w", revision, err)
+	}
+
+	return nil
+}
+
+// scanPostgresRoadmap reads a roadmaps row into a StoredRoadmap
+func scanPostgresRoadmap(row rowScanner) (*models.StoredRoadmap, error) {
+	var stored models.StoredRoadmap
+	var document []byte
+	var deletedAt sql.NullTime
+
+	err := row.Scan(&stored.ID, &stored.FileName, &document, &stored.CreatedAt, &stored.UpdatedAt,
+		&stored.Revision, &stored.CreatedBy, &stored.UpdatedBy, &deletedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to read roadmap: %w", err)
+	}
+
+	if err := json.Unmarshal(document, &stored.Roadmap); err != nil {
+		return nil, fmt.Errorf("failed to parse roadmap document: %w", err)
+	}
+	if deletedAt.Valid {
+		stored.DeletedAt = &deletedAt.Time
+	}
+
+	return &stored, nil
+}
+
+// scanPostgresRevision reads a roadmap_revisions row into a Revision
+func scanPostgresRevision(row rowScanner) (*models.Revision, error) {
+	var revision models.Revision
+	var document []byte
+
+	if err := row.Scan(&revision.Number, &document, &revision.Author, &revision.CreatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to read revision: %w", err)
+	}
+
+	if err := json.Unmarshal(document, &revision.Roadmap); err != nil {
+		return nil, fmt.Errorf("failed to parse revision document: %w", err)
+	}
+
+	return &revision, nil
+}
@@ -0,0 +1,127 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"roadmap-visualizer/internal/models"
+	"strings"
+)
+
+// ErrNameTaken is returned by RenameRoadmap when another roadmap already has
+// the new name, which would make dependencies by name ambiguous
+var ErrNameTaken = errors.New("another roadmap already has this name")
+
+// RenameResult reports what a rename changed
+type RenameResult struct {
+	Roadmap *models.StoredRoadmap `json:"roadmap"`
+	// Dependents are the other roadmaps whose external dependencies were
+	// rewritten to the new name
+	Dependents []string `json:"dependents"`
+}
+
+// RenameRoadmap renames a roadmap and rewrites every external dependency that
+// refers to it by its old name, across all stored roadmaps. Each roadmap is
+// written as a new revision, and only while it is still at the revision that
+// was read; if any write fails, the writes already made are reverted, so
+// dependents are never left pointing at a name that no longer exists. If
+// ifRevision is non-zero the renamed roadmap must still be at that revision.
+func RenameRoadmap(s Storage, id, newName, author string, ifRevision int) (*RenameResult, error) {
+	newName = strings.TrimSpace(newName)
+	if newName == "" {
+		return nil, fmt.Errorf("roadmap name is required")
+	}
+
+	target, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkRevision(target, ifRevision); err != nil {
+		return nil, err
+	}
+	oldName := target.Roadmap.Name
+	if newName == oldName {
+		return &RenameResult{Roadmap: target, Dependents: []string{}}, nil
+	}
+
+	all, err := s.List(ListFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list roadmaps: %w", err)
+	}
+	for _, rm := range all {
+		if rm.ID != id && rm.Roadmap.Name == newName {
+			return nil, fmt.Errorf("%w: %s", ErrNameTaken, rm.ID)
+		}
+	}
+
+	// Work out every change before writing anything
+	type change struct {
+		before  *models.StoredRoadmap
+		roadmap *models.Roadmap
+	}
+	var changes []change
+	result := &RenameResult{Dependents: []string{}}
+	for _, rm := range all {
+		roadmap, rewritten := rewriteDependencyName(&rm.Roadmap, id, oldName, newName)
+		if rm.ID == id {
+			roadmap.Name = newName
+			// The renamed roadmap goes first so it is never left behind
+			changes = append([]change{{before: rm, roadmap: roadmap}}, changes...)
+			continue
+		}
+		if rewritten {
+			changes = append(changes, change{before: rm, roadmap: roadmap})
+			result.Dependents = append(result.Dependents, rm.ID)
+		}
+	}
+	if len(changes) == 0 || changes[0].before.ID != id {
+		return nil, ErrNotFound
+	}
+
+	var applied []*models.StoredRoadmap
+	for i, c := range changes {
+		updated, err := s.Update(c.before.ID, c.roadmap, author, c.before.CurrentRevision())
+		if err != nil {
+			err = fmt.Errorf("failed to update roadmap %s: %w", c.before.ID, err)
+			for j, done := range applied {
+				previous := changes[j].before
+				if _, rerr := s.Update(done.ID, &previous.Roadmap, author, done.CurrentRevision()); rerr != nil {
+					err = fmt.Errorf("%w; failed to revert roadmap %s: %v", err, done.ID, rerr)
+				}
+			}
+			return nil, err
+		}
+		if i == 0 {
+			result.Roadmap = updated
+		}
+		applied = append(applied, updated)
+	}
+
+	return result, nil
+}
+
+// rewriteDependencyName returns a copy of the roadmap with external
+// dependencies on the renamed roadmap pointed at its new name, and whether
+// any were changed. Dependencies that name a different roadmap_id are left alone.
+func rewriteDependencyName(roadmap *models.Roadmap, id, oldName, newName string) (*models.Roadmap, bool) {
+	rewritten := *roadmap
+	rewritten.Items = make([]models.RoadmapItem, len(roadmap.Items))
+	changed := false
+
+	for i, item := range roadmap.Items {
+		if len(item.ExternalDependencies) > 0 {
+			deps := make([]models.ExternalDependency, len(item.ExternalDependencies))
+			copy(deps, item.ExternalDependencies)
+			for j := range deps {
+				dep := &deps[j]
+				if dep.RoadmapName == oldName && (dep.RoadmapID == "" || dep.RoadmapID == id) {
+					dep.RoadmapName = newName
+					changed = true
+				}
+			}
+			item.ExternalDependencies = deps
+		}
+		rewritten.Items[i] = item
+	}
+
+	return &rewritten, changed
+}
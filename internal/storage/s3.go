@@ -0,0 +1,841 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"roadmap-visualizer/internal/models"
+	"roadmap-visualizer/internal/parser"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// S3Config holds connection settings for S3Storage
+type S3Config struct {
+	Endpoint        string
+	Region          string
+	Bucket          string
+	Prefix          string
+	AccessKeyID     string
+	SecretAccessKey string
+	UseSSL          bool
+	PathStyle       bool // Required by most MinIO deployments
+}
+
+// S3Storage implements storage for roadmaps in an S3-compatible bucket.
+// Objects use the same yaml/ and meta/ layout as FileStorage, plus an
+// index.json object holding every meta record so List is a single read.
+type S3Storage struct {
+	client *minio.Client
+	bucket string
+	prefix string
+	mu     sync.Mutex
+
+	// Cached copy of the index object, refreshed when its ETag changes
+	index     []*models.StoredRoadmap
+	indexETag string
+}
+
+// NewS3Storage creates a new S3 storage instance and verifies the bucket exists
+func NewS3Storage(config S3Config) (*S3Storage, error) {
+	if config.Endpoint == "" {
+		return nil, fmt.Errorf("S3 endpoint is required")
+	}
+	if config.Bucket == "" {
+		return nil, fmt.Errorf("S3 bucket is required")
+	}
+
+	// Fall back to the standard AWS credential chain when no keys are given
+	var creds *credentials.Credentials
+	if config.AccessKeyID != "" {
+		creds = credentials.NewStaticV4(config.AccessKeyID, config.SecretAccessKey, "")
+	} else {
+		creds = credentials.NewChainCredentials([]credentials.Provider{
+			&credentials.EnvAWS{},
+			&credentials.FileAWSCredentials{},
+			&credentials.IAM{},
+		})
+	}
+
+	lookup := minio.BucketLookupAuto
+	if config.PathStyle {
+		lookup = minio.BucketLookupPath
+	}
+
+	client, err := minio.New(config.Endpoint, &minio.Options{
+		Creds:        creds,
+		Secure:       config.UseSSL,
+		Region:       config.Region,
+		BucketLookup: lookup,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create S3 client: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	exists, err := client.BucketExists(ctx, config.Bucket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check bucket: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("bucket %s does not exist", config.Bucket)
+	}
+
+	prefix := config.Prefix
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+
+	return &S3Storage{
+		client: client,
+		bucket: config.Bucket,
+		prefix: prefix,
+	}, nil
+}
+
+// Create stores a new roadmap
+func (s *S3Storage) Create(roadmap *models.Roadmap, originalFileName, author string) (*models.StoredRoadmap, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ctx := context.Background()
+	now := time.Now()
+
+	index, err := s.loadIndex(ctx)
+	if err != nil {
+		return nil, err
+	}
+	live := make(map[string]bool, len(index))
+	for _, rm := range index {
+		live[rm.ID] = true
+	}
+
+	id, err := newID(roadmap.Name, func(candidate string) (bool, error) {
+		if live[candidate] {
+			return true, nil
+		}
+		_, err := s.client.StatObject(ctx, s.bucket, s.trashMetaKey(candidate), minio.StatObjectOptions{})
+		if err == nil {
+			return true, nil
+		}
+		if isNoSuchKey(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check trash: %w", err)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stored := &models.StoredRoadmap{
+		ID:        id,
+		Roadmap:   *roadmap,
+		CreatedAt: now,
+		UpdatedAt: now,
+		FileName:  originalFileName,
+		Revision:  1,
+		CreatedBy: author,
+		UpdatedBy: author,
+	}
+
+	if err := s.putRoadmap(ctx, stored); err != nil {
+		return nil, err
+	}
+	if err := s.putRevision(ctx, stored); err != nil {
+		return nil, err
+	}
+
+	index = append(index, stored)
+	if err := s.saveIndex(ctx, index); err != nil {
+		return nil, err
+	}
+
+	return stored, nil
+}
+
+// Get retrieves a roadmap by ID
+func (s *S3Storage) Get(id string) (*models.StoredRoadmap, error) {
+	return s.getMeta(context.Background(), id)
+}
+
+// List returns the stored roadmaps that match the filter from the index object
+func (s *S3Storage) List(filter ListFilter) ([]*models.StoredRoadmap, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	index, err := s.loadIndex(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	// Hand out copies so callers can't mutate the cache
+	roadmaps := make([]*models.StoredRoadmap, 0, len(index))
+	for _, rm := range index {
+		if !filter.Matches(rm) {
+			continue
+		}
+		copied := *rm
+		roadmaps = append(roadmaps, &copied)
+	}
+
+	return roadmaps, nil
+}
+
+// Update replaces the roadmap content for an existing ID
+func (s *S3Storage) Update(id string, roadmap *models.Roadmap, author string, ifRevision int) (*models.StoredRoadmap, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ctx := context.Background()
+
+	stored, err := s.getMeta(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkRevision(stored, ifRevision); err != nil {
+		return nil, err
+	}
+
+	// Roadmaps stored before revisions existed get their current content
+	// recorded as revision 1 so it isn't lost from the history
+	if stored.Revision == 0 {
+		stored.Revision = 1
+		if err := s.putRevision(ctx, stored); err != nil {
+			return nil, err
+		}
+	}
+
+	stored.Roadmap = *roadmap
+	stored.UpdatedAt = time.Now()
+	stored.Revision++
+	stored.UpdatedBy = author
+
+	if err := s.putRoadmap(ctx, stored); err != nil {
+		return nil, err
+	}
+	if err := s.putRevision(ctx, stored); err != nil {
+		return nil, err
+	}
+
+	index, err := s.loadIndex(ctx)
+	if err != nil {
+		return nil, err
+	}
+	found := false
+	for i, rm := range index {
+		if rm.ID == id {
+			index[i] = stored
+			found = true
+			break
+		}
+	}
+	if !found {
+		index = append(index, stored)
+	}
+	if err := s.saveIndex(ctx, index); err != nil {
+		return nil, err
+	}
+
+	return stored, nil
+}
+
+// Delete removes a roadmap by ID
+func (s *S3Storage) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ctx := context.Background()
+
+	// Check if metadata exists
+	if _, err := s.getMeta(ctx, id); err != nil {
+		return err
+	}
+
+	if err := s.client.RemoveObject(ctx, s.bucket, s.yamlKey(id), minio.RemoveObjectOptions{}); err != nil {
+		return fmt.Errorf("failed to delete yaml object: %w", err)
+	}
+	if err := s.client.RemoveObject(ctx, s.bucket, s.metaKey(id), minio.RemoveObjectOptions{}); err != nil {
+		return fmt.Errorf("failed to delete metadata object: %w", err)
+	}
+
+	if err := s.removeRevisions(ctx, id); err != nil {
+		return err
+	}
+	if err := s.removeComments(ctx, id); err != nil {
+		return err
+	}
+
+	return s.removeFromIndex(ctx, id)
+}
+
+// Trash moves a roadmap's objects under the trash/ prefix
+func (s *S3Storage) Trash(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ctx := context.Background()
+
+	stored, err := s.getMeta(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	stored.DeletedAt = &now
+
+	metaData, err := json.Marshal(stored)
+	if err != nil {
+		return fmt.Errorf("failed to serialize metadata: %w", err)
+	}
+
+	// Write the trashed metadata first so a failure can never lose the roadmap
+	if err := s.putObject(ctx, s.trashMetaKey(id), metaData, "application/json"); err != nil {
+		return fmt.Errorf("failed to write trash metadata object: %w", err)
+	}
+	if err := s.moveObject(ctx, s.yamlKey(id), s.trashYAMLKey(id)); err != nil {
+		return fmt.Errorf("failed to move yaml object to trash: %w", err)
+	}
+	if err := s.client.RemoveObject(ctx, s.bucket, s.metaKey(id), minio.RemoveObjectOptions{}); err != nil {
+		return fmt.Errorf("failed to delete metadata object: %w", err)
+	}
+
+	return s.removeFromIndex(ctx, id)
+}
+
+// ListTrash returns all roadmaps under the trash/ prefix
+func (s *S3Storage) ListTrash() ([]*models.StoredRoadmap, error) {
+	ctx := context.Background()
+
+	var roadmaps []*models.StoredRoadmap
+	for object := range s.client.ListObjects(ctx, s.bucket, minio.ListObjectsOptions{
+		Prefix:    s.prefix + "trash/meta/",
+		Recursive: true,
+	}) {
+		if object.Err != nil {
+			return nil, fmt.Errorf("failed to list trash objects: %w", object.Err)
+		}
+
+		data, err := s.getObject(ctx, object.Key)
+		if err != nil {
+			continue // Skip objects we can't read
+		}
+
+		var stored models.StoredRoadmap
+		if err := json.Unmarshal(data, &stored); err != nil {
+			continue // Skip objects we can't parse
+		}
+
+		roadmaps = append(roadmaps, &stored)
+	}
+
+	return roadmaps, nil
+}
+
+// Restore moves a roadmap's objects back out of the trash/ prefix
+func (s *S3Storage) Restore(id string) (*models.StoredRoadmap, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ctx := context.Background()
+
+	data, err := s.getObject(ctx, s.trashMetaKey(id))
+	if err != nil {
+		if isNoSuchKey(err) {
+			return nil, ErrNotInTrash
+		}
+		return nil, fmt.Errorf("failed to read trash metadata: %w", err)
+	}
+
+	var stored models.StoredRoadmap
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, fmt.Errorf("failed to parse trash metadata: %w", err)
+	}
+	stored.DeletedAt = nil
+
+	if err := s.moveObject(ctx, s.trashYAMLKey(id), s.yamlKey(id)); err != nil {
+		return nil, fmt.Errorf("failed to move yaml object out of trash: %w", err)
+	}
+
+	metaData, err := json.Marshal(&stored)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize metadata: %w", err)
+	}
+	if err := s.putObject(ctx, s.metaKey(id), metaData, "application/json"); err != nil {
+		return nil, fmt.Errorf("failed to write metadata object: %w", err)
+	}
+	if err := s.client.RemoveObject(ctx, s.bucket, s.trashMetaKey(id), minio.RemoveObjectOptions{}); err != nil {
+		return nil, fmt.Errorf("failed to delete trash metadata object: %w", err)
+	}
+
+	index, err := s.loadIndex(ctx)
+	if err != nil {
+		return nil, err
+	}
+	index = append(index, &stored)
+	if err := s.saveIndex(ctx, index); err != nil {
+		return nil, err
+	}
+
+	return &stored, nil
+}
+
+// Purge permanently removes a trashed roadmap and its revisions
+func (s *S3Storage) Purge(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ctx := context.Background()
+
+	if _, err := s.client.StatObject(ctx, s.bucket, s.trashMetaKey(id), minio.StatObjectOptions{}); err != nil {
+		if isNoSuchKey(err) {
+			return ErrNotInTrash
+		}
+		return fmt.Errorf("failed to stat trash metadata: %w", err)
+	}
+
+	if err := s.client.RemoveObject(ctx, s.bucket, s.trashYAMLKey(id), minio.RemoveObjectOptions{}); err != nil {
+		return fmt.Errorf("failed to delete trash yaml object: %w", err)
+	}
+	if err := s.client.RemoveObject(ctx, s.bucket, s.trashMetaKey(id), minio.RemoveObjectOptions{}); err != nil {
+		return fmt.Errorf("failed to delete trash metadata object: %w", err)
+	}
+
+	if err := s.removeRevisions(ctx, id); err != nil {
+		return err
+	}
+	return s.removeComments(ctx, id)
+}
+
+// Import stores a roadmap and its revision history under its existing ID
+func (s *S3Storage) Import(stored *models.StoredRoadmap, revisions []*models.Revision) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ctx := context.Background()
+
+	imported := *stored
+	imported.DeletedAt = nil
+
+	// Drop any trashed copy and its old history before writing the new one
+	for _, key := range []string{s.trashYAMLKey(imported.ID), s.trashMetaKey(imported.ID)} {
+		if err := s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{}); err != nil {
+			return fmt.Errorf("failed to delete trash object: %w", err)
+		}
+	}
+	if err := s.removeRevisions(ctx, imported.ID); err != nil {
+		return err
+	}
+	if err := s.removeComments(ctx, imported.ID); err != nil {
+		return err
+	}
+
+	if err := s.putRoadmap(ctx, &imported); err != nil {
+		return err
+	}
+	for _, rev := range revisions {
+		data, err := json.Marshal(rev)
+		if err != nil {
+			return fmt.Errorf("failed to serialize revision: %w", err)
+		}
+		if err := s.putObject(ctx, s.revisionKey(imported.ID, rev.Number), data, "application/json"); err != nil {
+			return fmt.Errorf("failed to write revision object: %w", err)
+		}
+	}
+
+	index, err := s.loadIndex(ctx)
+	if err != nil {
+		return err
+	}
+	replaced := make([]*models.StoredRoadmap, 0, len(index)+1)
+	for _, rm := range index {
+		if rm.ID != imported.ID {
+			replaced = append(replaced, rm)
+		}
+	}
+	replaced = append(replaced, &imported)
+
+	return s.saveIndex(ctx, replaced)
+}
+
+// ListRevisions returns the revision history of a roadmap, oldest first
+func (s *S3Storage) ListRevisions(id string) ([]*models.Revision, error) {
+	ctx := context.Background()
+
+	if _, err := s.getMeta(ctx, id); err != nil {
+		return nil, err
+	}
+
+	var revisions []*models.Revision
+	for object := range s.client.ListObjects(ctx, s.bucket, minio.ListObjectsOptions{
+		Prefix:    s.revisionPrefix(id),
+		Recursive: true,
+	}) {
+		if object.Err != nil {
+			return nil, fmt.Errorf("failed to list revision objects: %w", object.Err)
+		}
+
+		data, err := s.getObject(ctx, object.Key)
+		if err != nil {
+			continue // Skip objects we can't read
+		}
+
+		var revision models.Revision
+		if err := json.Unmarshal(data, &revision); err != nil {
+			continue // Skip objects we can't parse
+		}
+
+		revisions = append(revisions, &revision)
+	}
+
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Number < revisions[j].Number
+	})
+
+	return revisions, nil
+}
+
+// GetRevision returns a single revision of a roadmap
+func (s *S3Storage) GetRevision(id string, revision int) (*models.Revision, error) {
+	data, err := s.getObject(context.Background(), s.revisionKey(id, revision))
+	if err != nil {
+		if isNoSuchKey(err) {
+			return nil, ErrRevisionNotFound
+		}
+		return nil, fmt.Errorf("failed to read revision: %w", err)
+	}
+
+	var rev models.Revision
+	if err := json.Unmarshal(data, &rev); err != nil {
+		return nil, fmt.Errorf("failed to parse revision: %w", err)
+	}
+
+	return &rev, nil
+}
+
+// removeRevisions deletes every revision object for a roadmap
+func (s *S3Storage) removeRevisions(ctx context.Context, id string) error {
+	for object := range s.client.ListObjects(ctx, s.bucket, minio.ListObjectsOptions{
+		Prefix:    s.revisionPrefix(id),
+		Recursive: true,
+	}) {
+		if object.Err != nil {
+			return fmt.Errorf("failed to list revision objects: %w", object.Err)
+		}
+		if err := s.client.RemoveObject(ctx, s.bucket, object.Key, minio.RemoveObjectOptions{}); err != nil {
+			return fmt.Errorf("failed to delete revision object: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// AddComment stores a comment on a live roadmap as an object under
+// comments/{id}/
+func (s *S3Storage) AddComment(id string, comment *models.Comment) (*models.Comment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ctx := context.Background()
+
+	if _, err := s.getMeta(ctx, id); err != nil {
+		return nil, err
+	}
+
+	created := newComment(comment)
+	data, err := json.Marshal(created)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize comment: %w", err)
+	}
+	if err := s.putObject(ctx, s.commentPrefix(id)+created.ID+".json", data, "application/json"); err != nil {
+		return nil, fmt.Errorf("failed to write comment object: %w", err)
+	}
+
+	return created, nil
+}
+
+// ListComments returns the comments on a live roadmap, oldest first
+func (s *S3Storage) ListComments(id string) ([]*models.Comment, error) {
+	ctx := context.Background()
+
+	if _, err := s.getMeta(ctx, id); err != nil {
+		return nil, err
+	}
+
+	comments := []*models.Comment{}
+	for object := range s.client.ListObjects(ctx, s.bucket, minio.ListObjectsOptions{
+		Prefix:    s.commentPrefix(id),
+		Recursive: true,
+	}) {
+		if object.Err != nil {
+			return nil, fmt.Errorf("failed to list comment objects: %w", object.Err)
+		}
+
+		data, err := s.getObject(ctx, object.Key)
+		if err != nil {
+			continue // Skip objects we can't read
+		}
+
+		var comment models.Comment
+		if err := json.Unmarshal(data, &comment); err != nil {
+			continue // Skip objects we can't parse
+		}
+
+		comments = append(comments, &comment)
+	}
+
+	sortComments(comments)
+	return comments, nil
+}
+
+// removeComments deletes every comment object for a roadmap
+func (s *S3Storage) removeComments(ctx context.Context, id string) error {
+	for object := range s.client.ListObjects(ctx, s.bucket, minio.ListObjectsOptions{
+		Prefix:    s.commentPrefix(id),
+		Recursive: true,
+	}) {
+		if object.Err != nil {
+			return fmt.Errorf("failed to list comment objects: %w", object.Err)
+		}
+		if err := s.client.RemoveObject(ctx, s.bucket, object.Key, minio.RemoveObjectOptions{}); err != nil {
+			return fmt.Errorf("failed to delete comment object: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// removeFromIndex drops a roadmap from the index object
+func (s *S3Storage) removeFromIndex(ctx context.Context, id string) error {
+	index, err := s.loadIndex(ctx)
+	if err != nil {
+		return err
+	}
+
+	remaining := make([]*models.StoredRoadmap, 0, len(index))
+	for _, rm := range index {
+		if rm.ID != id {
+			remaining = append(remaining, rm)
+		}
+	}
+
+	return s.saveIndex(ctx, remaining)
+}
+
+// putRevision records the current content of a stored roadmap as a revision
+func (s *S3Storage) putRevision(ctx context.Context, stored *models.StoredRoadmap) error {
+	data, err := json.Marshal(revisionOf(stored))
+	if err != nil {
+		return fmt.Errorf("failed to serialize revision: %w", err)
+	}
+
+	if err := s.putObject(ctx, s.revisionKey(stored.ID, stored.Revision), data, "application/json"); err != nil {
+		return fmt.Errorf("failed to write revision object: %w", err)
+	}
+
+	return nil
+}
+
+// putRoadmap writes the yaml and meta objects for a roadmap
+func (s *S3Storage) putRoadmap(ctx context.Context, stored *models.StoredRoadmap) error {
+	yamlData, err := parser.SerializeRoadmap(&stored.Roadmap)
+	if err != nil {
+		return fmt.Errorf("failed to serialize roadmap: %w", err)
+	}
+
+	metaData, err := json.Marshal(stored)
+	if err != nil {
+		return fmt.Errorf("failed to serialize metadata: %w", err)
+	}
+
+	if err := s.putObject(ctx, s.yamlKey(stored.ID), yamlData, "application/x-yaml"); err != nil {
+		return fmt.Errorf("failed to write yaml object: %w", err)
+	}
+	if err := s.putObject(ctx, s.metaKey(stored.ID), metaData, "application/json"); err != nil {
+		// Clean up YAML object if metadata write fails
+		s.client.RemoveObject(ctx, s.bucket, s.yamlKey(stored.ID), minio.RemoveObjectOptions{})
+		return fmt.Errorf("failed to write metadata object: %w", err)
+	}
+
+	return nil
+}
+
+// getMeta reads and decodes the meta object for a roadmap
+func (s *S3Storage) getMeta(ctx context.Context, id string) (*models.StoredRoadmap, error) {
+	data, err := s.getObject(ctx, s.metaKey(id))
+	if err != nil {
+		if isNoSuchKey(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to read metadata: %w", err)
+	}
+
+	var stored models.StoredRoadmap
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, fmt.Errorf("failed to parse metadata: %w", err)
+	}
+
+	return &stored, nil
+}
+
+// loadIndex returns the index, re-reading it only when the object's ETag has
+// changed. A missing index is rebuilt from the meta objects.
+func (s *S3Storage) loadIndex(ctx context.Context) ([]*models.StoredRoadmap, error) {
+	info, err := s.client.StatObject(ctx, s.bucket, s.indexKey(), minio.StatObjectOptions{})
+	if err != nil {
+		if isNoSuchKey(err) {
+			return s.rebuildIndex(ctx)
+		}
+		return nil, fmt.Errorf("failed to stat index: %w", err)
+	}
+
+	if info.ETag == s.indexETag && s.index != nil {
+		return s.index, nil
+	}
+
+	data, err := s.getObject(ctx, s.indexKey())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read index: %w", err)
+	}
+
+	var index []*models.StoredRoadmap
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("failed to parse index: %w", err)
+	}
+
+	s.index = index
+	s.indexETag = info.ETag
+
+	return index, nil
+}
+
+// rebuildIndex lists every meta object and writes a fresh index
+func (s *S3Storage) rebuildIndex(ctx context.Context) ([]*models.StoredRoadmap, error) {
+	index := []*models.StoredRoadmap{}
+
+	for object := range s.client.ListObjects(ctx, s.bucket, minio.ListObjectsOptions{
+		Prefix:    s.prefix + "meta/",
+		Recursive: true,
+	}) {
+		if object.Err != nil {
+			return nil, fmt.Errorf("failed to list metadata objects: %w", object.Err)
+		}
+		if !strings.HasSuffix(object.Key, ".json") {
+			continue
+		}
+
+		data, err := s.getObject(ctx, object.Key)
+		if err != nil {
+			continue // Skip objects we can't read
+		}
+
+		var stored models.StoredRoadmap
+		if err := json.Unmarshal(data, &stored); err != nil {
+			continue // Skip objects we can't parse
+		}
+
+		index = append(index, &stored)
+	}
+
+	if err := s.saveIndex(ctx, index); err != nil {
+		return nil, err
+	}
+
+	return index, nil
+}
+
+// saveIndex writes the index object and refreshes the cache
+func (s *S3Storage) saveIndex(ctx context.Context, index []*models.StoredRoadmap) error {
+	data, err := json.Marshal(index)
+	if err != nil {
+		return fmt.Errorf("failed to serialize index: %w", err)
+	}
+
+	info, err := s.client.PutObject(ctx, s.bucket, s.indexKey(), bytes.NewReader(data), int64(len(data)),
+		minio.PutObjectOptions{ContentType: "application/json"})
+	if err != nil {
+		return fmt.Errorf("failed to write index: %w", err)
+	}
+
+	s.index = index
+	s.indexETag = info.ETag
+
+	return nil
+}
+
+// putObject uploads a byte slice
+func (s *S3Storage) putObject(ctx context.Context, key string, data []byte, contentType string) error {
+	_, err := s.client.PutObject(ctx, s.bucket, key, bytes.NewReader(data), int64(len(data)),
+		minio.PutObjectOptions{ContentType: contentType})
+	return err
+}
+
+// moveObject copies an object to a new key and removes the original
+func (s *S3Storage) moveObject(ctx context.Context, from, to string) error {
+	_, err := s.client.CopyObject(ctx,
+		minio.CopyDestOptions{Bucket: s.bucket, Object: to},
+		minio.CopySrcOptions{Bucket: s.bucket, Object: from},
+	)
+	if err != nil {
+		return err
+	}
+
+	return s.client.RemoveObject(ctx, s.bucket, from, minio.RemoveObjectOptions{})
+}
+
+// getObject downloads an object into memory
+func (s *S3Storage) getObject(ctx context.Context, key string) ([]byte, error) {
+	object, err := s.client.GetObject(ctx, s.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer object.Close()
+
+	return io.ReadAll(object)
+}
+
+func (s *S3Storage) yamlKey(id string) string {
+	return fmt.Sprintf("%syaml/%s.yaml", s.prefix, id)
+}
+
+func (s *S3Storage) metaKey(id string) string {
+	return fmt.Sprintf("%smeta/%s.json", s.prefix, id)
+}
+
+func (s *S3Storage) trashYAMLKey(id string) string {
+	return fmt.Sprintf("%strash/yaml/%s.yaml", s.prefix, id)
+}
+
+func (s *S3Storage) trashMetaKey(id string) string {
+	return fmt.Sprintf("%strash/meta/%s.json", s.prefix, id)
+}
+
+func (s *S3Storage) revisionPrefix(id string) string {
+	return fmt.Sprintf("%srevisions/%s/", s.prefix, id)
+}
+
+func (s *S3Storage) revisionKey(id string, revision int) string {
+	return fmt.Sprintf("%s%d.json", s.revisionPrefix(id), revision)
+}
+
+func (s *S3Storage) commentPrefix(id string) string {
+	return fmt.Sprintf("%scomments/%s/", s.prefix, id)
+}
+
+func (s *S3Storage) indexKey() string {
+	return s.prefix + "index.json"
+}
+
+// isNoSuchKey reports whether err is an S3 missing-object error
+func isNoSuchKey(err error) bool {
+	code := minio.ToErrorResponse(err).Code
+	return code == "NoSuchKey" || code == "NotFound"
+}
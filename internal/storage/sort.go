@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"fmt"
+	"roadmap-visualizer/internal/models"
+	"sort"
+	"strings"
+)
+
+// Fields accepted by SortRoadmaps
+const (
+	SortByName        = "name"
+	SortByCreatedAt   = "created_at"
+	SortByUpdatedAt   = "updated_at"
+	SortByServiceLine = "service_line"
+)
+
+// roadmapCompare compares two roadmaps by a single field
+var roadmapCompare = map[string]func(a, b *models.StoredRoadmap) int{
+	SortByName: func(a, b *models.StoredRoadmap) int {
+		return strings.Compare(strings.ToLower(a.Roadmap.Name), strings.ToLower(b.Roadmap.Name))
+	},
+	SortByCreatedAt: func(a, b *models.StoredRoadmap) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	},
+	SortByUpdatedAt: func(a, b *models.StoredRoadmap) int {
+		return a.UpdatedAt.Compare(b.UpdatedAt)
+	},
+	SortByServiceLine: func(a, b *models.StoredRoadmap) int {
+		return strings.Compare(strings.ToLower(a.Roadmap.ServiceLine), strings.ToLower(b.Roadmap.ServiceLine))
+	},
+}
+
+// SortRoadmaps orders roadmaps in place by field, ascending unless desc is
+// set. Ties are broken by ID so the order is stable across requests.
+func SortRoadmaps(roadmaps []*models.StoredRoadmap, field string, desc bool) error {
+	compare, ok := roadmapCompare[field]
+	if !ok {
+		return fmt.Errorf("invalid sort field: %s (must be name, created_at, updated_at, or service_line)", field)
+	}
+
+	sort.SliceStable(roadmaps, func(i, j int) bool {
+		c := compare(roadmaps[i], roadmaps[j])
+		if c == 0 {
+			c = strings.Compare(roadmaps[i].ID, roadmaps[j].ID)
+		}
+		if desc {
+			return c > 0
+		}
+		return c < 0
+	})
+
+	return nil
+}
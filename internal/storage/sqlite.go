@@ -0,0 +1,659 @@
+package storage
+
+import (
+	"database/sql"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"roadmap-visualizer/internal/models"
+	"sort"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// The full roadmap document is kept on the roadmaps row so it round-trips
+// exactly; items and external dependencies are normalized into their own
+// tables for querying.
+//
+//go:embed migrations/sqlite/*.sql
+var sqliteMigrations embed.FS
+
+// sqliteRoadmapColumns is the column list read by scanRoadmap
+const sqliteRoadmapColumns = `id, file_name, document, created_at, updated_at, revision, created_by, updated_by, deleted_at`
+
+// SQLiteStorage implements storage for roadmaps in a SQLite database
+type SQLiteStorage struct {
+	db *sql.DB
+}
+
+// NewSQLiteStorage opens (or creates) the SQLite database at path
+func NewSQLiteStorage(path string) (*SQLiteStorage, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create database directory: %w", err)
+	}
+
+	dsn := fmt.Sprintf("file:%s?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_txlock=immediate", path)
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	// SQLite only supports a single writer
+	db.SetMaxOpenConns(1)
+
+	if err := migrateSQLite(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &SQLiteStorage{
+		db: db,
+	}, nil
+}
+
+// migrateSQLite applies embedded migrations that haven't been recorded yet
+func migrateSQLite(db *sql.DB) error {
+	_, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    TEXT PRIMARY KEY,
+		applied_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`)
+	if err != nil {
+		return fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+
+	names, err := fs.Glob(sqliteMigrations, "migrations/sqlite/*.sql")
+	if err != nil {
+		return fmt.Errorf("failed to list migrations: %w", err)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		version := name[len("migrations/sqlite/") : len(name)-len(".sql")]
+
+		var applied bool
+		err := db.QueryRow(
+			`SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = ?)`, version).Scan(&applied)
+		if err != nil {
+			return fmt.Errorf("failed to check migration %s: %w", version, err)
+		}
+		if applied {
+			continue
+		}
+
+		script, err := sqliteMigrations.ReadFile(name)
+		if err != nil {
+			return fmt.Errorf("failed to read migration %s: %w", version, err)
+		}
+
+		tx, err := db.Begin()
+		if err != nil {
+			return fmt.Errorf("failed to begin migration %s: %w", version, err)
+		}
+		if _, err := tx.Exec(string(script)); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to apply migration %s: %w", version, err)
+		}
+		if _, err := tx.Exec(`INSERT INTO schema_migrations (version) VALUES (?)`, version); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to record migration %s: %w", version, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("failed to commit migration %s: %w", version, err)
+		}
+	}
+
+	return nil
+}
+
+// Close closes the underlying database
+func (s *SQLiteStorage) Close() error {
+	return s.db.Close()
+}
+
+// Create stores a new roadmap
+func (s *SQLiteStorage) Create(roadmap *models.Roadmap, originalFileName, author string) (*models.StoredRoadmap, error) {
+	now := time.Now()
+	stored := &models.StoredRoadmap{
+		Roadmap:   *roadmap,
+		CreatedAt: now,
+		UpdatedAt: now,
+		FileName:  originalFileName,
+		Revision:  1,
+		CreatedBy: author,
+		UpdatedBy: author,
+	}
+
+	document, err := json.Marshal(roadmap)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize roadmap: %w", err)
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Deleted (trashed) rows still hold their ID, so they count as taken
+	stored.ID, err = newID(roadmap.Name, func(candidate string) (bool, error) {
+		var count int
+		err := tx.QueryRow(`SELECT COUNT(*) FROM roadmaps WHERE id = ?`, candidate).Scan(&count)
+		return count > 0, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to allocate roadmap id: %w", err)
+	}
+
+	_, err = tx.Exec(
+		`INSERT INTO roadmaps (id, name, service_line, owner, file_name, document, created_at, updated_at, revision, created_by, updated_by)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		stored.ID, roadmap.Name, roadmap.ServiceLine, roadmap.Owner, originalFileName,
+		string(document), formatTime(now), formatTime(now), stored.Revision, author, author,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert roadmap: %w", err)
+	}
+
+	if err := insertItems(tx, stored.ID, roadmap); err != nil {
+		return nil, err
+	}
+	if err := insertSQLiteRevision(tx, stored.ID, stored.Revision, document, author, now); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit roadmap: %w", err)
+	}
+
+	return stored, nil
+}
+
+// Get retrieves a roadmap by ID
+func (s *SQLiteStorage) Get(id string) (*models.StoredRoadmap, error) {
+	row := s.db.QueryRow(`SELECT `+sqliteRoadmapColumns+` FROM roadmaps WHERE id = ? AND deleted_at IS NULL`, id)
+
+	stored, err := scanRoadmap(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return stored, nil
+}
+
+// List returns the stored roadmaps that match the filter. Service line and
+// status are matched in SQL; the date range is checked on the decoded
+// roadmaps because item dates are free-form strings, and so are owner, tags,
+// and confidence, since the items table doesn't hold those item fields.
+func (s *SQLiteStorage) List(filter ListFilter) ([]*models.StoredRoadmap, error) {
+	query := `SELECT ` + sqliteRoadmapColumns + ` FROM roadmaps WHERE deleted_at IS NULL`
+	var args []interface{}
+	if filter.ServiceLine != "" {
+		query += ` AND service_line = ?`
+		args = append(args, filter.ServiceLine)
+	}
+	if filter.Status != "" {
+		query += ` AND EXISTS (SELECT 1 FROM items WHERE items.roadmap_id = roadmaps.id AND items.status = ?)`
+		args = append(args, string(filter.Status))
+	}
+	query += ` ORDER BY created_at`
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query roadmaps: %w", err)
+	}
+	defer rows.Close()
+
+	var roadmaps []*models.StoredRoadmap
+	for rows.Next() {
+		stored, err := scanRoadmap(rows)
+		if err != nil {
+			continue // Skip rows we can't parse
+		}
+		roadmaps = append(roadmaps, stored)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read roadmaps: %w", err)
+	}
+
+	return filterRoadmaps(roadmaps, filter), nil
+}
+
+// Update replaces the roadmap content for an existing ID
+func (s *SQLiteStorage) Update(id string, roadmap *models.Roadmap, author string, ifRevision int) (*models.StoredRoadmap, error) {
+	document, err := json.Marshal(roadmap)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize roadmap: %w", err)
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	current, err := scanRoadmap(tx.QueryRow(`SELECT `+sqliteRoadmapColumns+` FROM roadmaps WHERE id = ? AND deleted_at IS NULL`, id))
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := checkRevision(current, ifRevision); err != nil {
+		return nil, err
+	}
+
+	// Roadmaps stored before revisions existed get their current content
+	// recorded as revision 1 so it isn't lost from the history
+	if current.Revision == 0 {
+		currentDoc, err := json.Marshal(current.Roadmap)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize roadmap: %w", err)
+		}
+		current.Revision = 1
+		if err := insertSQLiteRevision(tx, id, 1, currentDoc, current.UpdatedBy, current.UpdatedAt); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	revision := current.Revision + 1
+	_, err = tx.Exec(
+		`UPDATE roadmaps SET name = ?, service_line = ?, owner = ?, document = ?, updated_at = ?, revision = ?, updated_by = ?
+		 WHERE id = ?`,
+		roadmap.Name, roadmap.ServiceLine, roadmap.Owner, string(document), formatTime(now), revision, author, id,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update roadmap: %w", err)
+	}
+
+	// Replace the normalized rows
+	if _, err := tx.Exec(`DELETE FROM external_dependencies WHERE roadmap_id = ?`, id); err != nil {
+		return nil, fmt.Errorf("failed to clear external dependencies: %w", err)
+	}
+	if _, err := tx.Exec(`DELETE FROM items WHERE roadmap_id = ?`, id); err != nil {
+		return nil, fmt.Errorf("failed to clear items: %w", err)
+	}
+	if err := insertItems(tx, id, roadmap); err != nil {
+		return nil, err
+	}
+	if err := insertSQLiteRevision(tx, id, revision, document, author, now); err != nil {
+		return nil, err
+	}
+
+	stored, err := scanRoadmap(tx.QueryRow(`SELECT `+sqliteRoadmapColumns+` FROM roadmaps WHERE id = ? AND deleted_at IS NULL`, id))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit roadmap: %w", err)
+	}
+
+	return stored, nil
+}
+
+// Delete removes a roadmap by ID
+func (s *SQLiteStorage) Delete(id string) error {
+	result, err := s.db.Exec(`DELETE FROM roadmaps WHERE id = ? AND deleted_at IS NULL`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete roadmap: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// Trash soft-deletes a roadmap by setting deleted_at
+func (s *SQLiteStorage) Trash(id string) error {
+	result, err := s.db.Exec(
+		`UPDATE roadmaps SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL`, formatTime(time.Now()), id)
+	if err != nil {
+		return fmt.Errorf("failed to trash roadmap: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// ListTrash returns all soft-deleted roadmaps
+func (s *SQLiteStorage) ListTrash() ([]*models.StoredRoadmap, error) {
+	rows, err := s.db.Query(
+		`SELECT ` + sqliteRoadmapColumns + ` FROM roadmaps WHERE deleted_at IS NOT NULL ORDER BY deleted_at`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query trash: %w", err)
+	}
+	defer rows.Close()
+
+	var roadmaps []*models.StoredRoadmap
+	for rows.Next() {
+		stored, err := scanRoadmap(rows)
+		if err != nil {
+			continue // Skip rows we can't parse
+		}
+		roadmaps = append(roadmaps, stored)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read trash: %w", err)
+	}
+
+	return roadmaps, nil
+}
+
+// Restore clears deleted_at on a soft-deleted roadmap
+func (s *SQLiteStorage) Restore(id string) (*models.StoredRoadmap, error) {
+	result, err := s.db.Exec(`UPDATE roadmaps SET deleted_at = NULL WHERE id = ? AND deleted_at IS NOT NULL`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore roadmap: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return nil, ErrNotInTrash
+	}
+
+	return s.Get(id)
+}
+
+// Purge permanently removes a soft-deleted roadmap; revisions and items cascade
+func (s *SQLiteStorage) Purge(id string) error {
+	result, err := s.db.Exec(`DELETE FROM roadmaps WHERE id = ? AND deleted_at IS NOT NULL`, id)
+	if err != nil {
+		return fmt.Errorf("failed to purge roadmap: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ErrNotInTrash
+	}
+
+	return nil
+}
+
+// Import stores a roadmap and its revision history under its existing ID
+func (s *SQLiteStorage) Import(stored *models.StoredRoadmap, revisions []*models.Revision) error {
+	document, err := json.Marshal(&stored.Roadmap)
+	if err != nil {
+		return fmt.Errorf("failed to serialize roadmap: %w", err)
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Items, external dependencies, and revisions cascade with the roadmap row
+	if _, err := tx.Exec(`DELETE FROM roadmaps WHERE id = ?`, stored.ID); err != nil {
+		return fmt.Errorf("failed to replace roadmap: %w", err)
+	}
+
+	roadmap := &stored.Roadmap
+	_, err = tx.Exec(
+		`INSERT INTO roadmaps (id, name, service_line, owner, file_name, document, created_at, updated_at, revision, created_by, updated_by)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		stored.ID, roadmap.Name, roadmap.ServiceLine, roadmap.Owner, stored.FileName,
+		string(document), formatTime(stored.CreatedAt), formatTime(stored.UpdatedAt), stored.Revision, stored.CreatedBy, stored.UpdatedBy,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert roadmap: %w", err)
+	}
+
+	if err := insertItems(tx, stored.ID, roadmap); err != nil {
+		return err
+	}
+	for _, rev := range revisions {
+		revDoc, err := json.Marshal(&rev.Roadmap)
+		if err != nil {
+			return fmt.Errorf("failed to serialize revision: %w", err)
+		}
+		if err := insertSQLiteRevision(tx, stored.ID, rev.Number, revDoc, rev.Author, rev.CreatedAt); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit roadmap: %w", err)
+	}
+
+	return nil
+}
+
+// ListRevisions returns the revision history of a roadmap, oldest first
+func (s *SQLiteStorage) ListRevisions(id string) ([]*models.Revision, error) {
+	if _, err := s.Get(id); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(
+		`SELECT revision, document, author, created_at FROM roadmap_revisions
+		 WHERE roadmap_id = ? ORDER BY revision`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query revisions: %w", err)
+	}
+	defer rows.Close()
+
+	var revisions []*models.Revision
+	for rows.Next() {
+		revision, err := scanSQLiteRevision(rows)
+		if err != nil {
+			continue // Skip rows we can't parse
+		}
+		revisions = append(revisions, revision)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read revisions: %w", err)
+	}
+
+	return revisions, nil
+}
+
+// GetRevision returns a single revision of a roadmap
+func (s *SQLiteStorage) GetRevision(id string, revision int) (*models.Revision, error) {
+	row := s.db.QueryRow(
+		`SELECT revision, document, author, created_at FROM roadmap_revisions
+		 WHERE roadmap_id = ? AND revision = ?`, id, revision)
+
+	rev, err := scanSQLiteRevision(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrRevisionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return rev, nil
+}
+
+// AddComment stores a comment on a live roadmap
+func (s *SQLiteStorage) AddComment(id string, comment *models.Comment) (*models.Comment, error) {
+	created := newComment(comment)
+	result, err := s.db.Exec(
+		`INSERT INTO roadmap_comments (id, roadmap_id, item_id, author, body, created_at)
+		 SELECT ?, id, ?, ?, ?, ? FROM roadmaps WHERE id = ? AND deleted_at IS NULL`,
+		created.ID, created.ItemID, created.Author, created.Body, formatTime(created.CreatedAt), id,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert comment: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return nil, ErrNotFound
+	}
+
+	return created, nil
+}
+
+// ListComments returns the comments on a live roadmap, oldest first
+func (s *SQLiteStorage) ListComments(id string) ([]*models.Comment, error) {
+	if _, err := s.Get(id); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(
+		`SELECT id, item_id, author, body, created_at FROM roadmap_comments
+		 WHERE roadmap_id = ? ORDER BY created_at, id`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query comments: %w", err)
+	}
+	defer rows.Close()
+
+	comments := []*models.Comment{}
+	for rows.Next() {
+		var comment models.Comment
+		var createdAt string
+		if err := rows.Scan(&comment.ID, &comment.ItemID, &comment.Author, &comment.Body, &createdAt); err != nil {
+			return nil, fmt.Errorf("failed to read comment: %w", err)
+		}
+		if comment.CreatedAt, err = time.Parse(sqlTimeFormat, createdAt); err != nil {
+			continue // Skip rows we can't parse
+		}
+		comments = append(comments, &comment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read comments: %w", err)
+	}
+
+	return comments, nil
+}
+
+// Dependents returns the items of other live roadmaps with an external
+// dependency on the roadmap, by its ID or current name, from the
+// external_dependencies table. It returns nothing for a roadmap that isn't
+// live.
+func (s *SQLiteStorage) Dependents(roadmapID string) ([]models.ExternalDependent, error) {
+	var name string
+	err := s.db.QueryRow(`SELECT name FROM roadmaps WHERE id = ? AND deleted_at IS NULL`, roadmapID).Scan(&name)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get roadmap: %w", err)
+	}
+
+	rows, err := s.db.Query(`SELECT `+dependentColumns+` FROM `+dependentTables+`
+		 WHERE d.roadmap_id <> ? AND (d.target_roadmap_id = ? OR d.target_roadmap = ?)
+		 ORDER BY d.roadmap_id, i.position, d.position`, roadmapID, roadmapID, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query dependents: %w", err)
+	}
+	return readDependents(rows)
+}
+
+// insertItems writes the normalized item and external dependency rows
+func insertItems(tx *sql.Tx, roadmapID string, roadmap *models.Roadmap) error {
+	for i, item := range roadmap.Items {
+		_, err := tx.Exec(
+			`INSERT INTO items (roadmap_id, item_id, position, name, start_date, end_date, status, description)
+			 VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			roadmapID, item.ID, i, item.Name, item.Start, item.End, string(item.Status), item.Description,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to insert item %s: %w", item.ID, err)
+		}
+
+		for j, extDep := range item.ExternalDependencies {
+			_, err := tx.Exec(
+				`INSERT INTO external_dependencies
+				 (roadmap_id, item_id, position, target_roadmap, target_roadmap_id, target_item, target_item_name, target_milestone, target_completion, reason, criticality)
+				 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+				roadmapID, item.ID, j, extDep.RoadmapName, extDep.RoadmapID, extDep.ItemID, extDep.ItemName, extDep.Milestone, extDep.Completion, extDep.Reason, extDep.Criticality,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to insert external dependency for item %s: %w", item.ID, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// insertSQLiteRevision records a roadmap document as a numbered revision
+func insertSQLiteRevision(tx *sql.Tx, roadmapID string, revision int, document []byte, author string, at time.Time) error {
+	_, err := tx.Exec(
+		`INSERT INTO roadmap_revisions (roadmap_id, revision, document, author, created_at) VALUES (?, ?, ?, ?, ?)`,
+		roadmapID, revision, string(document), author, formatTime(at),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert revision %d: %w", revision, err)
+	}
+
+	return nil
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanRoadmap reads a roadmaps row into a StoredRoadmap
+func scanRoadmap(row rowScanner) (*models.StoredRoadmap, error) {
+	var stored models.StoredRoadmap
+	var document, createdAt, updatedAt string
+	var deletedAt sql.NullString
+
+	err := row.Scan(&stored.ID, &stored.FileName, &document, &createdAt, &updatedAt, &stored.Revision, &stored.CreatedBy, &stored.UpdatedBy, &deletedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to read roadmap: %w", err)
+	}
+
+	if err := json.Unmarshal([]byte(document), &stored.Roadmap); err != nil {
+		return nil, fmt.Errorf("failed to parse roadmap document: %w", err)
+	}
+
+	if stored.CreatedAt, err = time.Parse(sqlTimeFormat, createdAt); err != nil {
+		return nil, fmt.Errorf("failed to parse created_at: %w", err)
+	}
+	if stored.UpdatedAt, err = time.Parse(sqlTimeFormat, updatedAt); err != nil {
+		return nil, fmt.Errorf("failed to parse updated_at: %w", err)
+	}
+	if deletedAt.Valid {
+		t, err := time.Parse(sqlTimeFormat, deletedAt.String)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse deleted_at: %w", err)
+		}
+		stored.DeletedAt = &t
+	}
+
+	return &stored, nil
+}
+
+// scanSQLiteRevision reads a roadmap_revisions row into a Revision
+func scanSQLiteRevision(row rowScanner) (*models.Revision, error) {
+	var revision models.Revision
+	var document, createdAt string
+
+	if err := row.Scan(&revision.Number, &document, &revision.Author, &createdAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to read revision: %w", err)
+	}
+
+	if err := json.Unmarshal([]byte(document), &revision.Roadmap); err != nil {
+		return nil, fmt.Errorf("failed to parse revision document: %w", err)
+	}
+
+	var err error
+	if revision.CreatedAt, err = time.Parse(sqlTimeFormat, createdAt); err != nil {
+		return nil, fmt.Errorf("failed to parse created_at: %w", err)
+	}
+
+	return &revision, nil
+}
+
+// sqlTimeFormat is fixed-width so TEXT timestamps sort chronologically
+const sqlTimeFormat = "2006-01-02T15:04:05.000000000Z07:00"
+
+// formatTime formats a timestamp for storage in a TEXT column
+func formatTime(t time.Time) string {
+	return t.UTC().Format(sqlTimeFormat)
+}
@@ -0,0 +1,162 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"roadmap-visualizer/internal/models"
+	"time"
+)
+
+// Storage is the persistence interface used by the HTTP handlers.
+// FileStorage is the default implementation; other backends (SQL, object
+// stores) can be added by implementing the same methods.
+type Storage interface {
+	// Create stores a new roadmap, assigns it an ID, and records revision 1
+	Create(roadmap *models.Roadmap, originalFileName, author string) (*models.StoredRoadmap, error)
+	// Get retrieves a roadmap by ID
+	Get(id string) (*models.StoredRoadmap, error)
+	// List returns the stored roadmaps that match the filter
+	List(filter ListFilter) ([]*models.StoredRoadmap, error)
+	// Update replaces the roadmap content for an existing ID and records a new
+	// revision. If ifRevision is non-zero the update only succeeds while the
+	// roadmap is still at that revision; otherwise ErrRevisionMismatch is returned.
+	Update(id string, roadmap *models.Roadmap, author string, ifRevision int) (*models.StoredRoadmap, error)
+	// Delete removes a roadmap and its history by ID
+	Delete(id string) error
+	// ListRevisions returns the revision history of a roadmap, oldest first
+	ListRevisions(id string) ([]*models.Revision, error)
+	// GetRevision returns a single revision of a roadmap
+	GetRevision(id string, revision int) (*models.Revision, error)
+	// Trash soft-deletes a roadmap; it can be restored until it is purged
+	Trash(id string) error
+	// ListTrash returns all soft-deleted roadmaps
+	ListTrash() ([]*models.StoredRoadmap, error)
+	// Restore moves a soft-deleted roadmap back into the live set
+	Restore(id string) (*models.StoredRoadmap, error)
+	// Purge permanently removes a soft-deleted roadmap and its history
+	Purge(id string) error
+	// Import stores a roadmap under its existing ID with the given revision
+	// history, replacing any live or trashed roadmap with the same ID along
+	// with its comments
+	Import(stored *models.StoredRoadmap, revisions []*models.Revision) error
+	// AddComment stores a comment on a live roadmap, assigning its ID and
+	// creation time. Comments are kept while the roadmap is in the trash and
+	// removed with it.
+	AddComment(id string, comment *models.Comment) (*models.Comment, error)
+	// ListComments returns the comments on a live roadmap, oldest first
+	ListComments(id string) ([]*models.Comment, error)
+}
+
+// ErrNotFound is returned when no live roadmap has the requested ID
+var ErrNotFound = errors.New("roadmap not found")
+
+// ErrNotInTrash is returned by Restore and Purge when the trash holds no
+// roadmap with the requested ID
+var ErrNotInTrash = errors.New("roadmap not found in trash")
+
+// ErrRevisionNotFound is returned by GetRevision for a revision number the
+// roadmap does not have
+var ErrRevisionNotFound = errors.New("revision not found")
+
+// ErrRevisionMismatch is returned by Update when the roadmap has moved past
+// the revision the caller expected
+var ErrRevisionMismatch = errors.New("roadmap was modified by another update")
+
+// ErrReindexNotSupported is returned by Reindex on wrappers whose underlying
+// backend is not a Reindexer
+var ErrReindexNotSupported = errors.New("reindex is not supported by this storage driver")
+
+// checkRevision returns ErrRevisionMismatch unless ifRevision is zero or
+// matches the stored roadmap's current revision
+func checkRevision(stored *models.StoredRoadmap, ifRevision int) error {
+	if ifRevision != 0 && stored.CurrentRevision() != ifRevision {
+		return ErrRevisionMismatch
+	}
+	return nil
+}
+
+// Reindexer is implemented by backends whose data can be changed outside the
+// API, such as FileStorage where YAML files may be dropped into the data
+// directory by hand or by a git sync
+type Reindexer interface {
+	// Reindex brings stored metadata in line with the roadmap files:
+	// new files are registered, changed files recorded as a new revision,
+	// and roadmaps whose file was removed are deleted
+	Reindex() (*ReindexResult, error)
+}
+
+// DependentsFinder is implemented by backends that keep external dependencies
+// in their own tables, updated in the same transaction as the roadmap, so
+// dependents are found with a query and every process sharing the backend
+// gets the same answer
+type DependentsFinder interface {
+	// Dependents returns the items of other live roadmaps with an external
+	// dependency on the roadmap, by its ID or current name, ordered by
+	// roadmap ID and then as listed
+	Dependents(roadmapID string) ([]models.ExternalDependent, error)
+}
+
+// ReindexResult reports what a reindex changed
+type ReindexResult struct {
+	Added   []string       `json:"added"`
+	Updated []string       `json:"updated"`
+	Removed []string       `json:"removed"`
+	Errors  []ReindexError `json:"errors"`
+}
+
+// Changed reports whether the reindex added, updated, or removed anything
+func (r *ReindexResult) Changed() bool {
+	return len(r.Added) > 0 || len(r.Updated) > 0 || len(r.Removed) > 0
+}
+
+// ReindexError describes a file that could not be registered
+type ReindexError struct {
+	File  string `json:"file"`
+	Error string `json:"error"`
+}
+
+// PurgeTrash permanently removes roadmaps that were trashed before the cutoff
+// and returns how many were purged
+func PurgeTrash(s Storage, before time.Time) (int, error) {
+	trashed, err := s.ListTrash()
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, rm := range trashed {
+		if rm.DeletedAt == nil || !rm.DeletedAt.Before(before) {
+			continue
+		}
+		if err := s.Purge(rm.ID); err != nil {
+			return purged, fmt.Errorf("failed to purge roadmap %s: %w", rm.ID, err)
+		}
+		purged++
+	}
+
+	return purged, nil
+}
+
+// revisionOf builds a revision record from a stored roadmap
+func revisionOf(stored *models.StoredRoadmap) *models.Revision {
+	return &models.Revision{
+		Number:    stored.Revision,
+		Roadmap:   stored.Roadmap,
+		CreatedAt: stored.UpdatedAt,
+		Author:    stored.UpdatedBy,
+	}
+}
+
+// Ensure the backends satisfy the Storage interface
+var (
+	_ Storage = (*FileStorage)(nil)
+	_ Storage = (*MemoryStorage)(nil)
+	_ Storage = (*SQLiteStorage)(nil)
+	_ Storage = (*PostgresStorage)(nil)
+	_ Storage = (*S3Storage)(nil)
+
+	_ Reindexer = (*FileStorage)(nil)
+
+	_ DependentsFinder = (*SQLiteStorage)(nil)
+	_ DependentsFinder = (*PostgresStorage)(nil)
+)
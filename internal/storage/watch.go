@@ -0,0 +1,57 @@
+package storage
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Watch reindexes the yaml directory whenever files in it change, so edits
+// made outside the API (for example by a git-sync sidecar) show up without a
+// restart. Bursts of events are coalesced until the directory has been quiet
+// for the debounce interval. onSync is called with the result of every
+// reindex. Call the returned function to stop watching.
+func (fs *FileStorage) Watch(debounce time.Duration, onSync func(*ReindexResult, error)) (func() error, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create watcher: %w", err)
+	}
+
+	yamlDir := filepath.Join(fs.dataDir, "yaml")
+	if err := watcher.Add(yamlDir); err != nil {
+		watcher.Close()
+		return nil, fmt.Errorf("failed to watch %s: %w", yamlDir, err)
+	}
+
+	go func() {
+		timer := time.NewTimer(debounce)
+		timer.Stop()
+
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					timer.Stop()
+					return
+				}
+				name := filepath.Base(event.Name)
+				if filepath.Ext(name) != ".yaml" || isTempFile(name) {
+					continue
+				}
+				timer.Reset(debounce)
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					timer.Stop()
+					return
+				}
+				onSync(nil, fmt.Errorf("watcher error: %w", err))
+			case <-timer.C:
+				onSync(fs.Reindex())
+			}
+		}
+	}()
+
+	return watcher.Close, nil
+}
@@ -0,0 +1,208 @@
+package webhooks
+
+import (
+	"errors"
+	"log"
+	"reflect"
+	"roadmap-visualizer/internal/models"
+	"roadmap-visualizer/internal/storage"
+)
+
+// ItemValues are the fields of an item other roadmaps' plans rely on. For a
+// milestone or the roadmap's completion, Status is rolled up from the items
+// delivering it and End is its date.
+type ItemValues struct {
+	Status models.RoadmapStatus `json:"status"`
+	Start  string               `json:"start,omitempty"`
+	End    string               `json:"end"`
+}
+
+// ChangedItem is something depended on by other roadmaps whose status or
+// dates changed: an item, a milestone, or the roadmap's completion
+type ChangedItem struct {
+	// Target is what changed, as in depends_on: an item ID, "milestone:"
+	// and the milestone ID, or "completion"
+	Target   string     `json:"target"`
+	ItemID   string     `json:"item_id,omitempty"`
+	ItemName string     `json:"item_name,omitempty"`
+	Old      ItemValues `json:"old"`
+	New      ItemValues `json:"new"`
+}
+
+// AffectedItem is an item with an external dependency on a changed item,
+// milestone, or completion
+type AffectedItem struct {
+	ItemID      string `json:"item_id"`
+	ItemName    string `json:"item_name"`
+	DependsOn   string `json:"depends_on"`
+	Criticality string `json:"criticality,omitempty"`
+}
+
+// AffectedRoadmap is a roadmap with items depending on the changed items,
+// with its owners so the event can be routed to them
+type AffectedRoadmap struct {
+	RoadmapID   string           `json:"roadmap_id"`
+	RoadmapName string           `json:"roadmap_name"`
+	Owner       string           `json:"owner,omitempty"`
+	Owners      []models.Contact `json:"owners,omitempty"`
+	Items       []AffectedItem   `json:"items"`
+}
+
+// DependencyChangeEvent is the data of dependency.changed events, sent when
+// items, milestones, or the completion of a roadmap that other roadmaps
+// depend on change status or dates
+type DependencyChangeEvent struct {
+	RoadmapID   string            `json:"roadmap_id"`
+	RoadmapName string            `json:"roadmap_name"`
+	Changes     []ChangedItem     `json:"changes"`
+	Affected    []AffectedRoadmap `json:"affected"`
+}
+
+// publishDependencyChanges compares the roadmap before and after an update
+// and publishes dependency.changed if items, milestones, or the completion
+// other roadmaps depend on changed status or dates
+func (n *Notifier) publishDependencyChanges(before *models.Roadmap, after *models.StoredRoadmap) {
+	previous := make(map[string]ItemValues, len(before.Items))
+	for _, item := range before.Items {
+		previous[item.ID] = itemValues(&item)
+	}
+	itemsChanged := len(before.Items) != len(after.Roadmap.Items)
+	for i := range after.Roadmap.Items {
+		if old, ok := previous[after.Roadmap.Items[i].ID]; !ok || old != itemValues(&after.Roadmap.Items[i]) {
+			itemsChanged = true
+		}
+	}
+	// Milestones and completion roll up the items, so nothing anyone depends
+	// on changed unless an item or milestone did
+	if !itemsChanged && reflect.DeepEqual(before.Milestones, after.Roadmap.Milestones) {
+		return
+	}
+
+	roadmaps, err := n.dependentRoadmaps(after)
+	if err != nil {
+		log.Printf("Failed to find dependents for webhooks: %v", err)
+		return
+	}
+
+	event := DependencyChangeEvent{RoadmapID: after.ID, RoadmapName: after.Roadmap.Name}
+	changed := make(map[string]ChangedItem)
+	for _, rm := range roadmaps {
+		if rm.ID == after.ID {
+			continue
+		}
+		affected := AffectedRoadmap{RoadmapID: rm.ID, RoadmapName: rm.Roadmap.Name, Owner: rm.Roadmap.Owner, Owners: rm.Roadmap.Owners}
+		for _, item := range rm.Roadmap.Items {
+			for _, extDep := range item.ExternalDependencies {
+				if extDep.RoadmapID != after.ID && (extDep.RoadmapID != "" || extDep.RoadmapName != after.Roadmap.Name) {
+					continue
+				}
+				change, ok := targetChange(extDep, before, &after.Roadmap, previous)
+				if !ok {
+					continue
+				}
+				changed[change.Target] = change
+				affected.Items = append(affected.Items, AffectedItem{
+					ItemID:      item.ID,
+					ItemName:    item.Name,
+					DependsOn:   change.Target,
+					Criticality: extDep.Criticality,
+				})
+			}
+		}
+		if len(affected.Items) > 0 {
+			event.Affected = append(event.Affected, affected)
+		}
+	}
+	if len(event.Affected) == 0 {
+		return
+	}
+
+	// Only the changes someone depends on are news to other teams, listed
+	// items first, then milestones, then completion
+	var targets []string
+	for _, item := range after.Roadmap.Items {
+		targets = append(targets, item.ID)
+	}
+	for _, milestone := range after.Roadmap.Milestones {
+		targets = append(targets, "milestone:"+milestone.ID)
+	}
+	for _, target := range append(targets, "completion") {
+		if change, ok := changed[target]; ok {
+			event.Changes = append(event.Changes, change)
+		}
+	}
+	n.dispatcher.Publish(EventDependencyChanged, event)
+}
+
+// dependentRoadmaps loads the roadmaps that may depend on the roadmap: those
+// the reverse index names when the storage keeps one, otherwise all of them
+func (n *Notifier) dependentRoadmaps(target *models.StoredRoadmap) ([]*models.StoredRoadmap, error) {
+	index, ok := n.Storage.(*storage.DependentsIndex)
+	if !ok {
+		return n.Storage.List(storage.ListFilter{})
+	}
+	dependents, err := index.Dependents(target.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var roadmaps []*models.StoredRoadmap
+	loaded := make(map[string]bool)
+	for _, dependent := range dependents {
+		if loaded[dependent.RoadmapID] {
+			continue
+		}
+		loaded[dependent.RoadmapID] = true
+		rm, err := n.Storage.Get(dependent.RoadmapID)
+		if errors.Is(err, storage.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		roadmaps = append(roadmaps, rm)
+	}
+	return roadmaps, nil
+}
+
+// targetChange returns how what the dependency points at changed between
+// the two versions of its roadmap, or false if it didn't or doesn't resolve.
+// Items are matched by the ID the dependency resolves to now.
+func targetChange(dep models.ExternalDependency, before, after *models.Roadmap, previous map[string]ItemValues) (ChangedItem, bool) {
+	if dep.Milestone == "" && !dep.Completion {
+		item := dep.TargetItem(after)
+		if item == nil {
+			return ChangedItem{}, false
+		}
+		old, ok := previous[item.ID]
+		current := itemValues(item)
+		if !ok || old == current {
+			return ChangedItem{}, false
+		}
+		return ChangedItem{Target: item.ID, ItemID: item.ID, ItemName: item.Name, Old: old, New: current}, true
+	}
+
+	if dep.Milestone != "" && (!hasMilestone(before, dep.Milestone) || !hasMilestone(after, dep.Milestone)) {
+		return ChangedItem{}, false
+	}
+	var old, current ItemValues
+	old.Status, old.End = dep.Progress(before)
+	current.Status, current.End = dep.Progress(after)
+	if old == current {
+		return ChangedItem{}, false
+	}
+	return ChangedItem{Target: dep.Target(), Old: old, New: current}, true
+}
+
+func itemValues(item *models.RoadmapItem) ItemValues {
+	return ItemValues{Status: item.Status, Start: item.Start, End: item.End}
+}
+
+func hasMilestone(roadmap *models.Roadmap, id string) bool {
+	for _, milestone := range roadmap.Milestones {
+		if milestone.ID == id {
+			return true
+		}
+	}
+	return false
+}
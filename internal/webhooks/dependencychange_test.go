@@ -0,0 +1,94 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"roadmap-visualizer/internal/models"
+	"roadmap-visualizer/internal/storage"
+	"testing"
+	"time"
+)
+
+// receive registers a webhook for the event type and returns the data of
+// each event delivered to it
+func receive(t *testing.T, d *Dispatcher, eventType string) <-chan json.RawMessage {
+	t.Helper()
+	events := make(chan json.RawMessage, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var event struct {
+			Data json.RawMessage `json:"data"`
+		}
+		if err := json.Unmarshal(body, &event); err == nil {
+			events <- event.Data
+		}
+	}))
+	t.Cleanup(server.Close)
+	if _, err := d.Register(server.URL, "", []string{eventType}); err != nil {
+		t.Fatal(err)
+	}
+	return events
+}
+
+func TestDependencyChangedOnMilestone(t *testing.T) {
+	index, err := storage.NewDependentsIndex(storage.NewMemoryStorage())
+	if err != nil {
+		t.Fatal(err)
+	}
+	dispatcher, err := NewDispatcher(Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	notifier := NewNotifier(index, dispatcher)
+
+	platform := &models.Roadmap{
+		Name:        "Platform",
+		ServiceLine: "Infra",
+		Items: []models.RoadmapItem{
+			{ID: "auth", Name: "Auth", Start: "2026-01", End: "2026-03", Status: "planned", Milestone: "beta"},
+		},
+		Milestones: []models.Milestone{{ID: "beta", Name: "Beta", Date: "2026-03-31"}},
+	}
+	stored, err := notifier.Create(platform, "platform.yaml", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	payments := &models.Roadmap{
+		Name:        "Payments",
+		ServiceLine: "Commerce",
+		Items: []models.RoadmapItem{
+			{ID: "checkout", Name: "Checkout", Start: "2026-04", End: "2026-06", Status: "planned",
+				ExternalDependencies: []models.ExternalDependency{{RoadmapName: "Platform", Milestone: "beta"}}},
+		},
+	}
+	if _, err := notifier.Create(payments, "payments.yaml", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	events := receive(t, dispatcher, EventDependencyChanged)
+	slipped := stored.Roadmap
+	slipped.Milestones = []models.Milestone{{ID: "beta", Name: "Beta", Date: "2026-05-15"}}
+	if _, err := notifier.Update(stored.ID, &slipped, "", 0); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case data := <-events:
+		var event DependencyChangeEvent
+		if err := json.Unmarshal(data, &event); err != nil {
+			t.Fatal(err)
+		}
+		if len(event.Changes) != 1 || event.Changes[0].Target != "milestone:beta" ||
+			event.Changes[0].Old.End != "2026-03-31" || event.Changes[0].New.End != "2026-05-15" {
+			t.Errorf("changes = %+v, want milestone:beta moving from 2026-03-31 to 2026-05-15", event.Changes)
+		}
+		if len(event.Affected) != 1 || len(event.Affected[0].Items) != 1 ||
+			event.Affected[0].Items[0].ItemID != "checkout" || event.Affected[0].Items[0].DependsOn != "milestone:beta" {
+			t.Errorf("affected = %+v, want checkout depending on milestone:beta", event.Affected)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no dependency.changed event")
+	}
+}
@@ -0,0 +1,365 @@
+package webhooks
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Headers sent with every delivery
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// Config holds delivery settings for a Dispatcher
+type Config struct {
+	// Path is the JSON file webhook registrations are saved to; empty keeps
+	// them in memory only
+	Path string
+	// MaxAttempts is how many times a delivery is tried before it is marked failed
+	MaxAttempts int
+	// InitialBackoff is the wait before the first retry; it doubles after each attempt
+	InitialBackoff time.Duration
+	// Timeout bounds each HTTP request
+	Timeout time.Duration
+	// LogSize is how many deliveries are kept per webhook
+	LogSize int
+}
+
+// Dispatcher keeps the registered webhooks and delivers events to them
+type Dispatcher struct {
+	config Config
+	client *http.Client
+
+	mu         sync.RWMutex
+	webhooks   map[string]*Webhook
+	deliveries map[string][]*Delivery // per webhook, oldest first
+}
+
+// savedWebhook is the on-disk form of a webhook, which unlike the API
+// response includes the secret
+type savedWebhook struct {
+	Webhook
+	Secret string `json:"secret,omitempty"`
+}
+
+// NewDispatcher creates a dispatcher, loading saved webhooks from config.Path
+func NewDispatcher(config Config) (*Dispatcher, error) {
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = 5
+	}
+	if config.InitialBackoff <= 0 {
+		config.InitialBackoff = time.Second
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 10 * time.Second
+	}
+	if config.LogSize <= 0 {
+		config.LogSize = 100
+	}
+
+	d := &Dispatcher{
+		config:     config,
+		client:     &http.Client{Timeout: config.Timeout},
+		webhooks:   make(map[string]*Webhook),
+		deliveries: make(map[string][]*Delivery),
+	}
+	if err := d.load(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// load reads saved webhooks; a missing file means none are registered
+func (d *Dispatcher) load() error {
+	if d.config.Path == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(d.config.Path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read webhooks: %w", err)
+	}
+
+	var saved []savedWebhook
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", d.config.Path, err)
+	}
+	for _, s := range saved {
+		hook := s.Webhook
+		hook.Secret = s.Secret
+		d.webhooks[hook.ID] = &hook
+	}
+	return nil
+}
+
+// save writes the registered webhooks atomically. Callers hold d.mu.
+func (d *Dispatcher) save() error {
+	if d.config.Path == "" {
+		return nil
+	}
+
+	saved := make([]savedWebhook, 0, len(d.webhooks))
+	for _, hook := range d.sortedWebhooks() {
+		saved = append(saved, savedWebhook{Webhook: *hook, Secret: hook.Secret})
+	}
+	data, err := json.MarshalIndent(saved, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(d.config.Path), 0755); err != nil {
+		return fmt.Errorf("failed to create webhook directory: %w", err)
+	}
+	tmp := d.config.Path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write webhooks: %w", err)
+	}
+	if err := os.Rename(tmp, d.config.Path); err != nil {
+		return fmt.Errorf("failed to write webhooks: %w", err)
+	}
+	return nil
+}
+
+// sortedWebhooks returns the webhooks oldest first. Callers hold d.mu.
+func (d *Dispatcher) sortedWebhooks() []*Webhook {
+	hooks := make([]*Webhook, 0, len(d.webhooks))
+	for _, hook := range d.webhooks {
+		hooks = append(hooks, hook)
+	}
+	sort.Slice(hooks, func(i, j int) bool {
+		if !hooks[i].CreatedAt.Equal(hooks[j].CreatedAt) {
+			return hooks[i].CreatedAt.Before(hooks[j].CreatedAt)
+		}
+		return hooks[i].ID < hooks[j].ID
+	})
+	return hooks
+}
+
+// Register validates and saves a new webhook
+func (d *Dispatcher) Register(url, secret string, events []string) (*Webhook, error) {
+	if events == nil {
+		events = []string{}
+	}
+	hook := &Webhook{
+		ID:        uuid.NewString(),
+		URL:       url,
+		Secret:    secret,
+		Events:    events,
+		Signed:    secret != "",
+		CreatedAt: time.Now(),
+	}
+	if err := hook.Validate(); err != nil {
+		return nil, err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.webhooks[hook.ID] = hook
+	if err := d.save(); err != nil {
+		delete(d.webhooks, hook.ID)
+		return nil, err
+	}
+	copied := *hook
+	return &copied, nil
+}
+
+// List returns every registered webhook, oldest first
+func (d *Dispatcher) List() []*Webhook {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	hooks := d.sortedWebhooks()
+	for i, hook := range hooks {
+		copied := *hook
+		hooks[i] = &copied
+	}
+	return hooks
+}
+
+// Get returns one webhook
+func (d *Dispatcher) Get(id string) (*Webhook, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	hook, ok := d.webhooks[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	copied := *hook
+	return &copied, nil
+}
+
+// Delete removes a webhook and its delivery log. Deliveries already in
+// flight finish their current attempt and are not retried.
+func (d *Dispatcher) Delete(id string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	hook, ok := d.webhooks[id]
+	if !ok {
+		return ErrNotFound
+	}
+	delete(d.webhooks, id)
+	if err := d.save(); err != nil {
+		d.webhooks[id] = hook
+		return err
+	}
+	delete(d.deliveries, id)
+	return nil
+}
+
+// Deliveries returns the delivery log of a webhook, newest first
+func (d *Dispatcher) Deliveries(id string) ([]*Delivery, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if _, ok := d.webhooks[id]; !ok {
+		return nil, ErrNotFound
+	}
+	entries := d.deliveries[id]
+	deliveries := make([]*Delivery, len(entries))
+	for i, delivery := range entries {
+		copied := *delivery
+		copied.Attempts = append([]Attempt(nil), delivery.Attempts...)
+		deliveries[len(entries)-1-i] = &copied
+	}
+	return deliveries, nil
+}
+
+// Publish sends an event to every webhook subscribed to its type. Delivery
+// happens in the background.
+func (d *Dispatcher) Publish(eventType string, data interface{}) {
+	event := Event{
+		ID:        uuid.NewString(),
+		Type:      eventType,
+		CreatedAt: time.Now().UTC(),
+		Data:      data,
+	}
+	body, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("Failed to encode %s webhook event: %v", eventType, err)
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, hook := range d.webhooks {
+		if !hook.Wants(eventType) {
+			continue
+		}
+		delivery := &Delivery{
+			ID:        uuid.NewString(),
+			WebhookID: hook.ID,
+			EventID:   event.ID,
+			EventType: eventType,
+			Status:    DeliveryPending,
+			Attempts:  []Attempt{},
+			CreatedAt: time.Now(),
+		}
+		entries := append(d.deliveries[hook.ID], delivery)
+		if len(entries) > d.config.LogSize {
+			entries = entries[len(entries)-d.config.LogSize:]
+		}
+		d.deliveries[hook.ID] = entries
+
+		go d.deliver(*hook, delivery, body)
+	}
+}
+
+// deliver POSTs the event, retrying with exponential backoff until it is
+// accepted, attempts run out, or the webhook is deleted
+func (d *Dispatcher) deliver(hook Webhook, delivery *Delivery, body []byte) {
+	backoff := d.config.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		result := d.post(hook, delivery, body)
+
+		d.mu.Lock()
+		delivery.Attempts = append(delivery.Attempts, result)
+		_, registered := d.webhooks[hook.ID]
+		done := result.Error == "" && result.StatusCode < 300
+		switch {
+		case done:
+			delivery.Status = DeliverySucceeded
+		case attempt >= d.config.MaxAttempts || !registered:
+			delivery.Status = DeliveryFailed
+			done = true
+		}
+		d.mu.Unlock()
+
+		if done {
+			if delivery.Status == DeliveryFailed {
+				log.Printf("Webhook %s: giving up on %s delivery %s after %d attempt(s)", hook.ID, delivery.EventType, delivery.ID, attempt)
+			}
+			return
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// post makes a single delivery attempt
+func (d *Dispatcher) post(hook Webhook, delivery *Delivery, body []byte) Attempt {
+	start := time.Now()
+	attempt := Attempt{At: start}
+
+	req, err := http.NewRequest(http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+	timestamp := strconv.FormatInt(start.Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "roadmap-visualizer-webhooks")
+	req.Header.Set(HeaderEvent, delivery.EventType)
+	req.Header.Set(HeaderDelivery, delivery.ID)
+	req.Header.Set(HeaderTimestamp, timestamp)
+	if hook.Secret != "" {
+		req.Header.Set(HeaderSignature, "sha256="+Sign(hook.Secret, timestamp, body))
+	}
+
+	resp, err := d.client.Do(req)
+	attempt.DurationMS = time.Since(start).Milliseconds()
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+	resp.Body.Close()
+
+	attempt.StatusCode = resp.StatusCode
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		attempt.Error = fmt.Sprintf("unexpected status %s", resp.Status)
+	}
+	return attempt
+}
+
+// Sign returns the hex HMAC-SHA256 of "{timestamp}.{body}" keyed by secret.
+// Receivers recompute it to check a delivery came from this server.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
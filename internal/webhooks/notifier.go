@@ -0,0 +1,228 @@
+package webhooks
+
+import (
+	"log"
+	"roadmap-visualizer/internal/models"
+	"roadmap-visualizer/internal/storage"
+	"sort"
+)
+
+// RoadmapEvent is the data of roadmap.created, roadmap.updated, and
+// roadmap.deleted events
+type RoadmapEvent struct {
+	RoadmapID string                `json:"roadmap_id"`
+	Roadmap   *models.StoredRoadmap `json:"roadmap,omitempty"`
+	// Trashed is set when a deleted roadmap was moved to the trash and can
+	// still be restored
+	Trashed bool `json:"trashed,omitempty"`
+}
+
+// DependencyEvent is the data of dependency.invalid events, listing the
+// external dependencies that stopped resolving since the last check
+type DependencyEvent struct {
+	Validations []models.ExternalDependencyValidation `json:"validations"`
+}
+
+// Notifier wraps a Storage and publishes webhook events for every change
+// made through it. After each change the external dependencies are checked
+// in the background, and any that newly fail validation are published.
+type Notifier struct {
+	storage.Storage
+	dispatcher *Dispatcher
+
+	checks  chan struct{}
+	invalid map[string]bool // only touched by checkDependencies after construction
+}
+
+// NewNotifier wraps store. Dependencies that are already invalid are
+// recorded without publishing, so only new failures are reported.
+func NewNotifier(store storage.Storage, dispatcher *Dispatcher) *Notifier {
+	n := &Notifier{
+		Storage:    store,
+		dispatcher: dispatcher,
+		checks:     make(chan struct{}, 1),
+	}
+	invalid, err := n.invalidDependencies()
+	if err != nil {
+		log.Printf("Failed to validate dependencies for webhooks: %v", err)
+	}
+	n.invalid = keys(invalid)
+	go n.checkDependencies()
+	return n
+}
+
+// Create stores a roadmap and publishes roadmap.created
+func (n *Notifier) Create(roadmap *models.Roadmap, originalFileName, author string) (*models.StoredRoadmap, error) {
+	stored, err := n.Storage.Create(roadmap, originalFileName, author)
+	if err == nil {
+		n.publish(EventRoadmapCreated, RoadmapEvent{RoadmapID: stored.ID, Roadmap: stored})
+	}
+	return stored, err
+}
+
+// Update stores a new revision and publishes roadmap.updated, and
+// dependency.changed if items other roadmaps depend on changed
+func (n *Notifier) Update(id string, roadmap *models.Roadmap, author string, ifRevision int) (*models.StoredRoadmap, error) {
+	before, getErr := n.Storage.Get(id)
+	stored, err := n.Storage.Update(id, roadmap, author, ifRevision)
+	if err == nil {
+		n.publish(EventRoadmapUpdated, RoadmapEvent{RoadmapID: stored.ID, Roadmap: stored})
+		if getErr == nil {
+			n.publishDependencyChanges(&before.Roadmap, stored)
+		}
+	}
+	return stored, err
+}
+
+// Delete removes a roadmap and publishes roadmap.deleted
+func (n *Notifier) Delete(id string) error {
+	err := n.Storage.Delete(id)
+	if err == nil {
+		n.publish(EventRoadmapDeleted, RoadmapEvent{RoadmapID: id})
+	}
+	return err
+}
+
+// Trash soft-deletes a roadmap and publishes roadmap.deleted
+func (n *Notifier) Trash(id string) error {
+	err := n.Storage.Trash(id)
+	if err == nil {
+		n.publish(EventRoadmapDeleted, RoadmapEvent{RoadmapID: id, Trashed: true})
+	}
+	return err
+}
+
+// Restore brings a roadmap back from the trash and publishes roadmap.created
+func (n *Notifier) Restore(id string) (*models.StoredRoadmap, error) {
+	stored, err := n.Storage.Restore(id)
+	if err == nil {
+		n.publish(EventRoadmapCreated, RoadmapEvent{RoadmapID: stored.ID, Roadmap: stored})
+	}
+	return stored, err
+}
+
+// Import stores a roadmap under its own ID and publishes roadmap.created, or
+// roadmap.updated when it replaced a live roadmap
+func (n *Notifier) Import(stored *models.StoredRoadmap, revisions []*models.Revision) error {
+	_, getErr := n.Storage.Get(stored.ID)
+	if err := n.Storage.Import(stored, revisions); err != nil {
+		return err
+	}
+
+	eventType := EventRoadmapCreated
+	if getErr == nil {
+		eventType = EventRoadmapUpdated
+	}
+	n.publish(eventType, RoadmapEvent{RoadmapID: stored.ID, Roadmap: stored})
+	return nil
+}
+
+// Reindex syncs a backend that supports it and publishes its changes
+func (n *Notifier) Reindex() (*storage.ReindexResult, error) {
+	reindexer, ok := n.Storage.(storage.Reindexer)
+	if !ok {
+		return nil, storage.ErrReindexNotSupported
+	}
+	result, err := reindexer.Reindex()
+	if err != nil {
+		return nil, err
+	}
+	n.Reindexed(result)
+	return result, nil
+}
+
+// Reindexed publishes the changes found by a reindex that ran on the
+// wrapped storage directly, such as the data directory watcher
+func (n *Notifier) Reindexed(result *storage.ReindexResult) {
+	if result == nil || !result.Changed() {
+		return
+	}
+	for _, id := range result.Added {
+		if stored, err := n.Storage.Get(id); err == nil {
+			n.dispatcher.Publish(EventRoadmapCreated, RoadmapEvent{RoadmapID: id, Roadmap: stored})
+		}
+	}
+	for _, id := range result.Updated {
+		if stored, err := n.Storage.Get(id); err == nil {
+			n.dispatcher.Publish(EventRoadmapUpdated, RoadmapEvent{RoadmapID: id, Roadmap: stored})
+			if previous, err := n.Storage.GetRevision(id, stored.CurrentRevision()-1); err == nil {
+				n.publishDependencyChanges(&previous.Roadmap, stored)
+			}
+		}
+	}
+	for _, id := range result.Removed {
+		n.dispatcher.Publish(EventRoadmapDeleted, RoadmapEvent{RoadmapID: id})
+	}
+	n.scheduleDependencyCheck()
+}
+
+// publish sends a roadmap event and schedules a dependency check
+func (n *Notifier) publish(eventType string, data RoadmapEvent) {
+	n.dispatcher.Publish(eventType, data)
+	n.scheduleDependencyCheck()
+}
+
+// scheduleDependencyCheck asks the background checker to run. Requests made
+// while a check is pending are coalesced.
+func (n *Notifier) scheduleDependencyCheck() {
+	select {
+	case n.checks <- struct{}{}:
+	default:
+	}
+}
+
+// checkDependencies publishes dependency.invalid for dependencies that have
+// started failing validation since the previous check
+func (n *Notifier) checkDependencies() {
+	for range n.checks {
+		invalid, err := n.invalidDependencies()
+		if err != nil {
+			log.Printf("Failed to validate dependencies for webhooks: %v", err)
+			continue
+		}
+
+		var newKeys []string
+		for key := range invalid {
+			if !n.invalid[key] {
+				newKeys = append(newKeys, key)
+			}
+		}
+		n.invalid = keys(invalid)
+
+		sort.Strings(newKeys)
+		failed := make([]models.ExternalDependencyValidation, len(newKeys))
+		for i, key := range newKeys {
+			failed[i] = invalid[key]
+		}
+
+		if len(failed) > 0 {
+			n.dispatcher.Publish(EventDependencyInvalid, DependencyEvent{Validations: failed})
+		}
+	}
+}
+
+// invalidDependencies validates every external dependency and returns the
+// failures keyed by dependent item and target
+func (n *Notifier) invalidDependencies() (map[string]models.ExternalDependencyValidation, error) {
+	roadmaps, err := n.Storage.List(storage.ListFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	invalid := make(map[string]models.ExternalDependencyValidation)
+	for _, v := range storage.ValidateExternalDependencies(roadmaps) {
+		if !v.Valid {
+			invalid[v.RoadmapItemID+" -> "+v.DependencyDesc] = v
+		}
+	}
+	return invalid, nil
+}
+
+// keys returns the set of keys of a validation map
+func keys(validations map[string]models.ExternalDependencyValidation) map[string]bool {
+	set := make(map[string]bool, len(validations))
+	for key := range validations {
+		set[key] = true
+	}
+	return set
+}
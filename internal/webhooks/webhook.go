@@ -0,0 +1,117 @@
+// Package webhooks delivers signed HTTP callbacks when roadmaps change,
+// external dependencies stop resolving, or items other roadmaps depend on
+// change.
+package webhooks
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// Event types a webhook can subscribe to
+const (
+	EventRoadmapCreated    = "roadmap.created"
+	EventRoadmapUpdated    = "roadmap.updated"
+	EventRoadmapDeleted    = "roadmap.deleted"
+	EventDependencyInvalid = "dependency.invalid"
+	EventDependencyChanged = "dependency.changed"
+)
+
+// EventTypes lists every event type in a stable order
+var EventTypes = []string{
+	EventRoadmapCreated,
+	EventRoadmapUpdated,
+	EventRoadmapDeleted,
+	EventDependencyInvalid,
+	EventDependencyChanged,
+}
+
+// ErrNotFound is returned for an unknown webhook ID
+var ErrNotFound = errors.New("webhook not found")
+
+// Webhook is a registered callback URL
+type Webhook struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+	// Secret signs every delivery; it is never returned by the API
+	Secret string `json:"-"`
+	// Events filters which event types are delivered; empty means all
+	Events    []string  `json:"events"`
+	Signed    bool      `json:"signed"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Validate checks the URL and event filter
+func (w *Webhook) Validate() error {
+	u, err := url.Parse(w.URL)
+	if err != nil {
+		return fmt.Errorf("invalid url: %v", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("url must be an absolute http or https URL")
+	}
+	for _, event := range w.Events {
+		if !knownEvent(event) {
+			return fmt.Errorf("unknown event %q", event)
+		}
+	}
+	return nil
+}
+
+// Wants reports whether the webhook subscribes to the event type
+func (w *Webhook) Wants(eventType string) bool {
+	if len(w.Events) == 0 {
+		return true
+	}
+	for _, event := range w.Events {
+		if event == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+func knownEvent(eventType string) bool {
+	for _, known := range EventTypes {
+		if known == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Event is the JSON body POSTed to webhooks
+type Event struct {
+	ID        string      `json:"id"`
+	Type      string      `json:"type"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+// Delivery statuses
+const (
+	DeliveryPending   = "pending"
+	DeliverySucceeded = "succeeded"
+	DeliveryFailed    = "failed"
+)
+
+// Delivery records the attempts to deliver one event to one webhook
+type Delivery struct {
+	ID        string    `json:"id"`
+	WebhookID string    `json:"webhook_id"`
+	EventID   string    `json:"event_id"`
+	EventType string    `json:"event_type"`
+	Status    string    `json:"status"`
+	Attempts  []Attempt `json:"attempts"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Attempt is a single HTTP request made for a delivery
+type Attempt struct {
+	At         time.Time `json:"at"`
+	StatusCode int       `json:"status_code,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	DurationMS int64     `json:"duration_ms"`
+}
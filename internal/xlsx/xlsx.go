@@ -0,0 +1,220 @@
+// Package xlsx writes Excel workbooks of plain text cells, bold or filled
+// with a color, without depending on a spreadsheet library.
+package xlsx
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Cell is a text cell. Fill is a color such as #a5d6a7, or empty for none.
+type Cell struct {
+	Text string
+	Bold bool
+	Fill string
+}
+
+// Sheet is a worksheet of rows of cells
+type Sheet struct {
+	// Name is shown on the sheet's tab; Excel allows at most 31 characters
+	Name string
+	Rows [][]Cell
+	// Widths are the widths of the first columns, in characters
+	Widths []float64
+	// FrozenRows and FrozenColumns stay in view when scrolling
+	FrozenRows    int
+	FrozenColumns int
+}
+
+const (
+	mainNS = "http://schemas.openxmlformats.org/spreadsheetml/2006/main"
+	relsNS = "http://schemas.openxmlformats.org/package/2006/relationships"
+	docRel = "http://schemas.openxmlformats.org/officeDocument/2006/relationships"
+)
+
+// style is a combination of cell formatting, numbered in styles.xml
+type style struct {
+	bold bool
+	fill string
+}
+
+// part is a file of the workbook's zip package
+type part struct {
+	name    string
+	content string
+}
+
+// Write writes the sheets to w as an .xlsx workbook
+func Write(w io.Writer, sheets []Sheet) error {
+	// Style 0 is the default; the rest are numbered as first used
+	styles := []style{{}}
+	index := map[style]int{{}: 0}
+	for _, sheet := range sheets {
+		for _, row := range sheet.Rows {
+			for _, cell := range row {
+				s := style{cell.Bold, cell.Fill}
+				if _, ok := index[s]; !ok {
+					index[s] = len(styles)
+					styles = append(styles, s)
+				}
+			}
+		}
+	}
+
+	zw := zip.NewWriter(w)
+	parts := []part{
+		{"[Content_Types].xml", contentTypes(len(sheets))},
+		{"_rels/.rels", `<Relationships xmlns="` + relsNS + `"><Relationship Id="rId1" Type="` + docRel + `/officeDocument" Target="xl/workbook.xml"/></Relationships>`},
+		{"xl/workbook.xml", workbook(sheets)},
+		{"xl/_rels/workbook.xml.rels", workbookRels(len(sheets))},
+		{"xl/styles.xml", stylesheet(styles)},
+	}
+	for i, sheet := range sheets {
+		parts = append(parts, part{fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), worksheet(sheet, index)})
+	}
+
+	for _, part := range parts {
+		fw, err := zw.Create(part.name)
+		if err != nil {
+			return fmt.Errorf("failed to add %s: %w", part.name, err)
+		}
+		if _, err := io.WriteString(fw, xml.Header+part.content); err != nil {
+			return fmt.Errorf("failed to write %s: %w", part.name, err)
+		}
+	}
+	return zw.Close()
+}
+
+func contentTypes(sheets int) string {
+	var b strings.Builder
+	b.WriteString(`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`)
+	b.WriteString(`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>`)
+	b.WriteString(`<Default Extension="xml" ContentType="application/xml"/>`)
+	b.WriteString(`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>`)
+	b.WriteString(`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+	for i := 1; i <= sheets; i++ {
+		fmt.Fprintf(&b, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i)
+	}
+	b.WriteString(`</Types>`)
+	return b.String()
+}
+
+func workbook(sheets []Sheet) string {
+	var b strings.Builder
+	b.WriteString(`<workbook xmlns="` + mainNS + `" xmlns:r="` + docRel + `"><sheets>`)
+	for i, sheet := range sheets {
+		fmt.Fprintf(&b, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, escape(sheet.Name), i+1, i+1)
+	}
+	b.WriteString(`</sheets></workbook>`)
+	return b.String()
+}
+
+// workbookRels links the worksheets as rId1 to rIdN and the styles after them
+func workbookRels(sheets int) string {
+	var b strings.Builder
+	b.WriteString(`<Relationships xmlns="` + relsNS + `">`)
+	for i := 1; i <= sheets; i++ {
+		fmt.Fprintf(&b, `<Relationship Id="rId%d" Type="%s/worksheet" Target="worksheets/sheet%d.xml"/>`, i, docRel, i)
+	}
+	fmt.Fprintf(&b, `<Relationship Id="rId%d" Type="%s/styles" Target="styles.xml"/>`, sheets+1, docRel)
+	b.WriteString(`</Relationships>`)
+	return b.String()
+}
+
+// stylesheet declares a regular and a bold font, a solid fill for every
+// color used, and a cell format for every style. The first two fills are
+// reserved by Excel.
+func stylesheet(styles []style) string {
+	var fills []string
+	fillID := make(map[string]int)
+	for _, s := range styles {
+		if _, ok := fillID[s.fill]; s.fill != "" && !ok {
+			fillID[s.fill] = len(fills) + 2
+			fills = append(fills, s.fill)
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(`<styleSheet xmlns="` + mainNS + `">`)
+	b.WriteString(`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>`)
+	fmt.Fprintf(&b, `<fills count="%d"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill>`, len(fills)+2)
+	for _, fill := range fills {
+		fmt.Fprintf(&b, `<fill><patternFill patternType="solid"><fgColor rgb="FF%s"/><bgColor indexed="64"/></patternFill></fill>`,
+			strings.ToUpper(strings.TrimPrefix(fill, "#")))
+	}
+	b.WriteString(`</fills>`)
+	b.WriteString(`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>`)
+	b.WriteString(`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>`)
+	fmt.Fprintf(&b, `<cellXfs count="%d">`, len(styles))
+	for _, s := range styles {
+		font := 0
+		if s.bold {
+			font = 1
+		}
+		fmt.Fprintf(&b, `<xf numFmtId="0" fontId="%d" fillId="%d" borderId="0" xfId="0" applyFont="1" applyFill="1"/>`, font, fillID[s.fill])
+	}
+	b.WriteString(`</cellXfs>`)
+	b.WriteString(`<cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles>`)
+	b.WriteString(`</styleSheet>`)
+	return b.String()
+}
+
+func worksheet(sheet Sheet, styles map[style]int) string {
+	var b strings.Builder
+	b.WriteString(`<worksheet xmlns="` + mainNS + `">`)
+	if sheet.FrozenRows > 0 || sheet.FrozenColumns > 0 {
+		pane := "bottomRight"
+		switch {
+		case sheet.FrozenColumns == 0:
+			pane = "bottomLeft"
+		case sheet.FrozenRows == 0:
+			pane = "topRight"
+		}
+		fmt.Fprintf(&b, `<sheetViews><sheetView workbookViewId="0"><pane xSplit="%d" ySplit="%d" topLeftCell="%s" activePane="%s" state="frozen"/></sheetView></sheetViews>`,
+			sheet.FrozenColumns, sheet.FrozenRows, cellRef(sheet.FrozenRows, sheet.FrozenColumns), pane)
+	}
+	if len(sheet.Widths) > 0 {
+		b.WriteString(`<cols>`)
+		for i, width := range sheet.Widths {
+			fmt.Fprintf(&b, `<col min="%d" max="%d" width="%g" customWidth="1"/>`, i+1, i+1, width)
+		}
+		b.WriteString(`</cols>`)
+	}
+
+	b.WriteString(`<sheetData>`)
+	for i, row := range sheet.Rows {
+		fmt.Fprintf(&b, `<row r="%d">`, i+1)
+		for j, cell := range row {
+			s := styles[style{cell.Bold, cell.Fill}]
+			if cell.Text == "" {
+				if s != 0 {
+					fmt.Fprintf(&b, `<c r="%s" s="%d"/>`, cellRef(i, j), s)
+				}
+				continue
+			}
+			fmt.Fprintf(&b, `<c r="%s" s="%d" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, cellRef(i, j), s, escape(cell.Text))
+		}
+		b.WriteString(`</row>`)
+	}
+	b.WriteString(`</sheetData></worksheet>`)
+	return b.String()
+}
+
+// cellRef names the cell at a 0-based row and column, such as B3
+func cellRef(row, column int) string {
+	name := ""
+	for column++; column > 0; column = (column - 1) / 26 {
+		name = string(rune('A'+(column-1)%26)) + name
+	}
+	return fmt.Sprintf("%s%d", name, row+1)
+}
+
+// escape makes text safe in XML, replacing characters XML can't hold
+func escape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}